@@ -4,20 +4,48 @@ import (
 	"context"
 	"errors"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+
+	taskmanager "github.com/asp3cto/task-manager"
+	"github.com/asp3cto/task-manager/internal/adapters/blob"
+	"github.com/asp3cto/task-manager/internal/adapters/broker"
+	"github.com/asp3cto/task-manager/internal/adapters/clock"
 	httpAdapter "github.com/asp3cto/task-manager/internal/adapters/http"
+	"github.com/asp3cto/task-manager/internal/adapters/idempotency"
+	"github.com/asp3cto/task-manager/internal/adapters/idgen"
+	"github.com/asp3cto/task-manager/internal/adapters/metrics"
 	"github.com/asp3cto/task-manager/internal/adapters/repository"
+	"github.com/asp3cto/task-manager/internal/adapters/repository/bolt"
+	"github.com/asp3cto/task-manager/internal/adapters/repository/mongo"
+	"github.com/asp3cto/task-manager/internal/adapters/repository/postgres"
+	"github.com/asp3cto/task-manager/internal/adapters/repository/querylog"
+	"github.com/asp3cto/task-manager/internal/adapters/repository/redis"
+	"github.com/asp3cto/task-manager/internal/adapters/repository/sqlite"
+	"github.com/asp3cto/task-manager/internal/adapters/repository/sqlpool"
+	"github.com/asp3cto/task-manager/internal/adapters/statusconfig"
+	"github.com/asp3cto/task-manager/internal/adapters/tracing"
+	"github.com/asp3cto/task-manager/internal/adapters/webhook"
+	"github.com/asp3cto/task-manager/internal/auth"
+	"github.com/asp3cto/task-manager/internal/core/retention"
 	"github.com/asp3cto/task-manager/internal/core/service"
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/events"
 	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
 )
 
 // shutdownDelay defines the maximum time to wait for graceful shutdown.
-// The server will force shutdown if active connections don't close within this time.
+// The server will force shutdown if active connections don't close within this time,
+// including the drain delay during which readiness probes fail.
 const shutdownDelay = 30 * time.Second
 
 func main() {
@@ -29,12 +57,460 @@ func main() {
 		addr = ":8080"
 	}
 
+	// The logger outlives the signal context, so the entries logged while draining and
+	// shutting down are written; it is stopped by Close at the very end.
 	asyncLogger := logger.NewFromEnv(os.Stdout)
-	asyncLogger.Start(ctx)
+	asyncLogger.Start(context.Background())
+
+	// Libraries logging through slog share the async pipeline. The log package keeps
+	// writing to stderr synchronously, so messages of log.Fatalf are not lost on exit.
+	slog.SetDefault(slog.New(logger.NewHandler(asyncLogger)))
+	log.SetOutput(os.Stderr)
+	log.SetFlags(log.LstdFlags)
+
+	// SIGHUP reopens the log file after it was moved by an external tool such as logrotate
+	// and restores LOG_LEVEL after it was changed through /debug/loglevel.
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	go func() {
+		for range hangup {
+			asyncLogger.SetLevel(logger.LevelFromEnv())
+			if err := asyncLogger.Reopen(); err != nil {
+				log.Printf("failed to reopen log output: %v", err)
+			}
+		}
+	}()
+
+	// Tasks are kept by a single storage backend, so configuring several is a mistake
+	// that would leave all but one of them silently unused.
+	var backends []string
+	for _, name := range []string{"WAL_DIR", "DATABASE_URL", "SQLITE_PATH", "MONGODB_URI", "REDIS_URL", "BOLT_DIR"} {
+		if os.Getenv(name) != "" {
+			backends = append(backends, name)
+		}
+	}
+	if len(backends) > 1 {
+		log.Fatalf("only one storage backend can be configured, got %s", strings.Join(backends, ", "))
+	}
+
+	var repo ports.TaskRepository = repository.NewMemoryTaskRepository()
+
+	// The data files of the file-based backends are encrypted with their own keys, separate
+	// from the field encryption, which works with every backend.
+	storageKeyring, err := repository.NewStorageKeyringFromEnv()
+	if err != nil {
+		log.Fatalf("invalid storage encryption configuration: %v", err)
+	}
+	if storageKeyring != nil && os.Getenv("WAL_DIR") == "" && os.Getenv("BOLT_DIR") == "" {
+		log.Fatalf("STORAGE_ENCRYPTION_KEYS requires WAL_DIR or BOLT_DIR")
+	}
+
+	var (
+		durableOpts []repository.DurableOption
+		boltOpts    []bolt.Option
+	)
+	if storageKeyring != nil {
+		durableOpts = append(durableOpts, repository.WithFileEncryption(storageKeyring))
+		boltOpts = append(boltOpts, bolt.WithCipher(storageKeyring))
+	}
+
+	durableRepo, err := repository.NewDurableMemoryTaskRepositoryFromEnv(durableOpts...)
+	if err != nil {
+		log.Fatalf("failed to recover tasks from the write-ahead log: %v", err)
+	}
+
+	snapshotInterval, err := repository.SnapshotIntervalFromEnv()
+	if err != nil {
+		log.Fatalf("invalid snapshot configuration: %v", err)
+	}
+
+	if durableRepo != nil {
+		repo = durableRepo
+		go durableRepo.Run(ctx, snapshotInterval, asyncLogger)
+	}
+
+	slowQueryThreshold, err := querylog.SlowThresholdFromEnv()
+	if err != nil {
+		log.Fatalf("invalid query log configuration: %v", err)
+	}
+	queryLog := querylog.New(asyncLogger, slowQueryThreshold)
+
+	poolConfig, err := sqlpool.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("invalid connection pool configuration: %v", err)
+	}
+
+	pgRepo, err := postgres.NewFromEnv(ctx, postgres.WithQueryLog(queryLog), postgres.WithPoolConfig(poolConfig))
+	if err != nil {
+		log.Fatalf("failed to initialize PostgreSQL repository: %v", err)
+	}
+
+	if pgRepo != nil {
+		repo = pgRepo
+	}
+
+	sqliteRepo, err := sqlite.NewFromEnv(ctx, sqlite.WithQueryLog(queryLog), sqlite.WithPoolConfig(poolConfig))
+	if err != nil {
+		log.Fatalf("failed to initialize SQLite repository: %v", err)
+	}
+
+	if sqliteRepo != nil {
+		repo = sqliteRepo
+	}
+
+	mongoRepo, err := mongo.NewFromEnv(ctx)
+	if err != nil {
+		log.Fatalf("failed to initialize MongoDB repository: %v", err)
+	}
+
+	if mongoRepo != nil {
+		repo = mongoRepo
+	}
+
+	redisRepo, err := redis.NewFromEnv(ctx)
+	if err != nil {
+		log.Fatalf("failed to initialize Redis repository: %v", err)
+	}
+
+	if redisRepo != nil {
+		repo = redisRepo
+	}
+
+	boltRepo, err := bolt.NewFromEnv(boltOpts...)
+	if err != nil {
+		log.Fatalf("failed to initialize bbolt repository: %v", err)
+	}
+
+	if boltRepo != nil {
+		repo = boltRepo
+	}
+
+	// Comments are kept next to the tasks by stores that support it, and in memory otherwise.
+	var comments ports.CommentRepository = repository.NewMemoryCommentRepository()
+	if store, ok := repo.(ports.CommentStore); ok {
+		comments = store.Comments()
+	}
+
+	// Projects are likewise kept next to the tasks by stores that support it, and in memory otherwise.
+	var projects ports.ProjectRepository = repository.NewMemoryProjectRepository()
+	if store, ok := repo.(ports.ProjectStore); ok {
+		projects = store.Projects()
+	}
+
+	// Saved filters are likewise kept next to the tasks by stores that support it, and in memory otherwise.
+	var savedFilters ports.SavedFilterRepository = repository.NewMemorySavedFilterRepository()
+	if store, ok := repo.(ports.SavedFilterStore); ok {
+		savedFilters = store.SavedFilters()
+	}
+
+	// Webhooks are likewise kept next to the tasks by stores that support it, and in memory otherwise.
+	var webhooks ports.WebhookRepository = repository.NewMemoryWebhookRepository()
+	var deadLetters ports.WebhookDeadLetterRepository = repository.NewMemoryWebhookDeadLetterRepository()
+	if store, ok := repo.(ports.WebhookStore); ok {
+		webhooks = store.Webhooks()
+		deadLetters = store.WebhookDeadLetters()
+	}
+
+	// The history of tasks is likewise kept next to the tasks by stores that support it, and in memory otherwise.
+	var history ports.TaskHistoryRepository = repository.NewMemoryHistoryRepository()
+	if store, ok := repo.(ports.HistoryStore); ok {
+		history = store.History()
+	}
+
+	blobs, err := blob.NewFromEnv(ctx)
+	if err != nil {
+		log.Fatalf("failed to initialize attachment storage: %v", err)
+	}
+
+	retryOpts, err := repository.RetryOptionsFromEnv()
+	if err != nil {
+		log.Fatalf("invalid repository retry configuration: %v", err)
+	}
+
+	repo = repository.NewRetryingTaskRepository(repo, retryOpts...)
+
+	breakerOpts, err := repository.BreakerOptionsFromEnv()
+	if err != nil {
+		log.Fatalf("invalid repository circuit breaker configuration: %v", err)
+	}
+
+	repo = repository.NewCircuitBreakerTaskRepository(repo, asyncLogger, breakerOpts...)
+
+	tracingEnabled, err := tracing.OTLPEnabledFromEnv()
+	if err != nil {
+		log.Fatalf("invalid tracing configuration: %v", err)
+	}
+
+	var traceExporter *tracing.OTLPExporter
+	if tracingEnabled {
+		if traceExporter, err = tracing.NewOTLPExporter(ctx); err != nil {
+			log.Fatalf("failed to initialize OTLP trace export: %v", err)
+		}
+		repo = repository.NewTracingTaskRepository(repo, traceExporter.TracerProvider())
+	}
+
+	keyring, err := repository.NewKeyringFromEnv()
+	if err != nil {
+		log.Fatalf("invalid encryption configuration: %v", err)
+	}
+
+	var reencrypter ports.Reencrypter
+	if keyring != nil {
+		encryptedRepo := repository.NewEncryptedTaskRepository(repo, keyring)
+		encryptedHistory := repository.NewEncryptedHistoryRepository(history, keyring)
+		encryptedComments := repository.NewEncryptedCommentRepository(comments, keyring)
+		reencrypter = repository.NewReencrypter(encryptedRepo, encryptedHistory, encryptedComments)
+		repo, history, comments = encryptedRepo, encryptedHistory, encryptedComments
+	}
+
+	repo = repository.NewCoalescingTaskRepository(repo)
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+
+	repoConfig := map[string]any{"backend": "unknown", "encryption_key": "", "storage_encryption_key": ""}
+	if keyring != nil {
+		repoConfig["encryption_key"] = keyring.PrimaryID()
+	}
+	if storageKeyring != nil {
+		repoConfig["storage_encryption_key"] = storageKeyring.PrimaryID()
+	}
+
+	metrics.RegisterLoggerStats(registry, asyncLogger.Stats)
+	if pgRepo != nil || sqliteRepo != nil {
+		metrics.RegisterQueryStats(registry, queryLog.Stats)
+	}
+	if pgRepo != nil {
+		metrics.RegisterPoolStats(registry, pgRepo.PoolStats)
+	}
+	if sqliteRepo != nil {
+		metrics.RegisterPoolStats(registry, sqliteRepo.PoolStats)
+	}
+
+	taskMetrics := metrics.NewPrometheusTaskMetrics(registry)
+	if inspector, ok := repo.(ports.RepositoryInspector); ok {
+		stats, err := inspector.Inspect(ctx)
+		if err != nil {
+			log.Fatalf("failed to inspect repository: %v", err)
+		}
+		taskMetrics.SetTaskCounts(stats.TaskCounts)
+		repoConfig["backend"] = stats.Backend
+	}
+
+	otlpEnabled, err := metrics.OTLPEnabledFromEnv()
+	if err != nil {
+		log.Fatalf("invalid metrics configuration: %v", err)
+	}
+
+	var otlpExporter *metrics.OTLPExporter
+	if otlpEnabled {
+		if otlpExporter, err = metrics.NewOTLPExporter(ctx, registry); err != nil {
+			log.Fatalf("failed to initialize OTLP metrics export: %v", err)
+		}
+	}
+
+	workflow, err := statusconfig.WorkflowFromEnv()
+	if err != nil {
+		log.Fatalf("invalid status configuration: %v", err)
+	}
+	statuses := statusconfig.NewStaticConfig(workflow)
 
-	repo := repository.NewMemoryTaskRepository()
-	taskService := service.NewTaskService(repo, asyncLogger)
-	server := httpAdapter.NewServer(addr, taskService, asyncLogger)
+	limits, err := service.LimitsFromEnv()
+	if err != nil {
+		log.Fatalf("invalid task limits configuration: %v", err)
+	}
+
+	systemClock := clock.System{}
+
+	ids, idFormat, err := idgen.FromEnv()
+	if err != nil {
+		log.Fatalf("invalid ID format configuration: %v", err)
+	}
+
+	undoWindow, err := service.UndoWindowFromEnv()
+	if err != nil {
+		log.Fatalf("invalid undo window configuration: %v", err)
+	}
+
+	duplicates, err := service.DuplicatePolicyFromEnv()
+	if err != nil {
+		log.Fatalf("invalid duplicate titles configuration: %v", err)
+	}
+
+	quotas, err := service.QuotasFromEnv()
+	if err != nil {
+		log.Fatalf("invalid task quota configuration: %v", err)
+	}
+
+	cacheTTL, err := service.ReadCacheTTLFromEnv()
+	if err != nil {
+		log.Fatalf("invalid read cache configuration: %v", err)
+	}
+
+	// Task events are dispatched in process; features reacting to them subscribe to the bus.
+	bus := events.New(asyncLogger)
+	bus.Subscribe("log", func(ctx context.Context, event domain.Event) {
+		asyncLogger.Debug(
+			ctx, "task event",
+			slog.String("event_id", event.ID),
+			slog.String("event_type", string(event.Type)),
+			slog.String("task_id", event.Task.ID),
+		)
+	})
+
+	webhookOpts, err := webhook.OptionsFromEnv()
+	if err != nil {
+		log.Fatalf("invalid webhook configuration: %v", err)
+	}
+	webhookOpts = append(webhookOpts, webhook.WithDeadLetters(deadLetters, ids))
+	if traceExporter != nil {
+		webhookOpts = append(webhookOpts, webhook.WithTracerProvider(traceExporter.TracerProvider()))
+	}
+	dispatcher := webhook.NewDispatcher(webhooks, asyncLogger, webhookOpts...)
+	bus.Subscribe("webhooks", dispatcher.Handle)
+
+	kafkaPublisher := broker.KafkaFromEnv(asyncLogger)
+	if kafkaPublisher != nil {
+		bus.Subscribe("kafka", kafkaPublisher.Publish)
+	}
+
+	amqpPublisher := broker.AMQPFromEnv(asyncLogger)
+	if amqpPublisher != nil {
+		bus.Subscribe("amqp", amqpPublisher.Publish)
+	}
+
+	taskOpts := []service.Option{
+		service.WithMetrics(taskMetrics), service.WithComments(comments), service.WithProjects(projects),
+		service.WithStatusConfig(statuses), service.WithHistory(history), service.WithLimits(limits),
+		service.WithDuplicatePolicy(duplicates), service.WithIDGenerator(ids),
+		service.WithClock(systemClock), service.WithUndoWindow(undoWindow), service.WithQuotas(quotas),
+		service.WithEvents(bus),
+	}
+	if blobs != nil {
+		taskOpts = append(taskOpts, service.WithBlobStore(blobs))
+	}
+	if cacheTTL > 0 {
+		taskOpts = append(taskOpts, service.WithReadCache(cacheTTL))
+	}
+
+	taskService := service.NewTaskService(repo, asyncLogger, taskOpts...)
+	commentService := service.NewCommentService(comments, repo, ids, systemClock, asyncLogger)
+	projectService := service.NewProjectService(projects, repo, statuses, ids, systemClock, asyncLogger)
+	savedFilterService := service.NewSavedFilterService(savedFilters, repo, statuses, systemClock, asyncLogger)
+	webhookService := service.NewWebhookService(webhooks, deadLetters, dispatcher, ids, systemClock, asyncLogger)
+	validator, err := httpAdapter.NewSpecValidator(taskmanager.OpenAPISpec, asyncLogger)
+	if err != nil {
+		log.Fatalf("failed to initialize request validation: %v", err)
+	}
+
+	serverOpts := append(
+		httpAdapter.OptionsFromEnv(),
+		httpAdapter.WithHealthCheck("repository", repo),
+		httpAdapter.WithSpecValidation(validator),
+		httpAdapter.WithMetrics(registry),
+		httpAdapter.WithLogLevelControl(asyncLogger),
+		httpAdapter.WithComments(commentService),
+		httpAdapter.WithProjects(projectService),
+		httpAdapter.WithSavedFilters(savedFilterService),
+		httpAdapter.WithWebhooks(webhookService),
+	)
+	if blobs != nil {
+		serverOpts = append(
+			serverOpts,
+			httpAdapter.WithAttachments(service.NewAttachmentService(repo, blobs, history, ids, systemClock, asyncLogger)),
+			httpAdapter.WithHealthCheck("attachments", blobs),
+		)
+	}
+
+	issuer, err := auth.NewIssuerFromEnv()
+	if err != nil {
+		log.Fatalf("invalid auth configuration: %v", err)
+	}
+
+	if issuer != nil {
+		serverOpts = append(serverOpts, httpAdapter.WithTokenAuth(issuer))
+	}
+
+	idempotencyTTL, err := idempotency.TTLFromEnv()
+	if err != nil {
+		log.Fatalf("invalid idempotency configuration: %v", err)
+	}
+
+	serverOpts = append(serverOpts, httpAdapter.WithIdempotency(idempotency.NewMemoryStore(), idempotencyTTL))
+
+	if inspector, ok := repo.(ports.RepositoryInspector); ok {
+		serverOpts = append(serverOpts, httpAdapter.WithRepositoryInspector(inspector))
+	}
+
+	// Fields are re-encrypted first, as rewriting them writes to the data files.
+	if reencrypter != nil {
+		serverOpts = append(serverOpts, httpAdapter.WithReencrypter(reencrypter))
+	}
+	if storageKeyring != nil && durableRepo != nil {
+		serverOpts = append(serverOpts, httpAdapter.WithReencrypter(durableRepo))
+	}
+	if storageKeyring != nil && boltRepo != nil {
+		serverOpts = append(serverOpts, httpAdapter.WithReencrypter(boltRepo))
+	}
+
+	retentionRules, err := retention.RulesFromEnv(workflow)
+	if err != nil {
+		log.Fatalf("invalid retention configuration: %v", err)
+	}
+
+	retentionInterval, err := retention.IntervalFromEnv()
+	if err != nil {
+		log.Fatalf("invalid retention configuration: %v", err)
+	}
+
+	debugAddr := os.Getenv("DEBUG_ADDR")
+
+	ruleNames := make([]string, 0, len(retentionRules))
+	for _, rule := range retentionRules {
+		if rule.Action == retention.ActionArchive && blobs == nil {
+			log.Fatalf("invalid retention configuration: rule %s needs ATTACHMENTS_DIR or S3_BUCKET to archive to", rule)
+		}
+		ruleNames = append(ruleNames, rule.String())
+	}
+	retentionConfig := map[string]any{"rules": ruleNames, "interval": retentionInterval.String()}
+
+	serverOpts = append(
+		serverOpts,
+		httpAdapter.WithConfigSection("logger", func() any { return asyncLogger.Settings() }),
+		httpAdapter.WithConfigSection("repository", repoConfig),
+		httpAdapter.WithConfigSection("retention", retentionConfig),
+		httpAdapter.WithConfigSection("statuses", map[string]any{"statuses": workflow.Statuses()}),
+		httpAdapter.WithConfigSection("limits", map[string]any{
+			"title_max_length":       limits.MaxTitleLength,
+			"description_max_length": limits.MaxDescriptionLength,
+		}),
+		httpAdapter.WithConfigSection("ids", map[string]any{"format": idFormat}),
+		httpAdapter.WithConfigSection("undo", map[string]any{"window": undoWindow.String()}),
+		httpAdapter.WithConfigSection("duplicates", map[string]any{"policy": duplicates}),
+		httpAdapter.WithConfigSection("cache", map[string]any{"ttl": cacheTTL.String()}),
+		httpAdapter.WithConfigSection("quotas", map[string]any{
+			"max_tasks":      quotas.MaxTasks,
+			"max_per_status": quotas.MaxPerStatus,
+		}),
+		httpAdapter.WithConfigSection("metrics", map[string]any{"otlp": otlpEnabled}),
+		httpAdapter.WithConfigSection("tracing", map[string]any{"otlp": tracingEnabled}),
+		httpAdapter.WithConfigSection("debug", map[string]any{"addr": debugAddr}),
+	)
+
+	server := httpAdapter.NewServer(addr, taskService, asyncLogger, serverOpts...)
+
+	if len(retentionRules) > 0 {
+		retentionOpts := []retention.Option{retention.WithClock(systemClock)}
+		if blobs != nil {
+			retentionOpts = append(retentionOpts, retention.WithArchive(blobs))
+		}
+		if keyring != nil {
+			retentionOpts = append(retentionOpts, retention.WithArchiveEncryption(keyring))
+		}
+
+		engine := retention.NewEngine(repo, taskService, asyncLogger, retentionRules, retentionInterval, retentionOpts...)
+		go engine.Run(ctx)
+	}
 
 	go func() {
 		log.Printf("server starting on %s", server.Addr())
@@ -43,6 +519,17 @@ func main() {
 		}
 	}()
 
+	var debugServer *httpAdapter.DebugServer
+	if debugAddr != "" {
+		debugServer = httpAdapter.NewDebugServer(debugAddr)
+		go func() {
+			log.Printf("debug server starting on %s", debugServer.Addr())
+			if err := debugServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Fatalf("failed to start debug server: %v", err)
+			}
+		}()
+	}
+
 	<-ctx.Done()
 
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownDelay)
@@ -54,6 +541,74 @@ func main() {
 		log.Printf("server forced to shutdown: %v", err)
 	}
 
+	if debugServer != nil {
+		if err := debugServer.Shutdown(shutdownCtx); err != nil {
+			log.Printf("debug server forced to shutdown: %v", err)
+		}
+	}
+
+	if err := dispatcher.Shutdown(shutdownCtx); err != nil {
+		log.Printf("webhook deliveries left undelivered: %v", err)
+	}
+
+	if kafkaPublisher != nil {
+		if err := kafkaPublisher.Shutdown(shutdownCtx); err != nil {
+			log.Printf("kafka events left unpublished: %v", err)
+		}
+	}
+
+	if amqpPublisher != nil {
+		if err := amqpPublisher.Shutdown(shutdownCtx); err != nil {
+			log.Printf("amqp events left unpublished: %v", err)
+		}
+	}
+
+	if otlpExporter != nil {
+		if err := otlpExporter.Shutdown(shutdownCtx); err != nil {
+			log.Printf("failed to flush OTLP metrics: %v", err)
+		}
+	}
+
+	if traceExporter != nil {
+		if err := traceExporter.Shutdown(shutdownCtx); err != nil {
+			log.Printf("failed to flush OTLP traces: %v", err)
+		}
+	}
+
+	if durableRepo != nil {
+		if err := durableRepo.Close(); err != nil {
+			log.Printf("failed to write final snapshot: %v", err)
+		}
+	}
+
+	if pgRepo != nil {
+		pgRepo.Close()
+	}
+
+	if sqliteRepo != nil {
+		if err := sqliteRepo.Close(); err != nil {
+			log.Printf("failed to close SQLite database: %v", err)
+		}
+	}
+
+	if mongoRepo != nil {
+		if err := mongoRepo.Close(shutdownCtx); err != nil {
+			log.Printf("failed to disconnect from MongoDB: %v", err)
+		}
+	}
+
+	if redisRepo != nil {
+		if err := redisRepo.Close(); err != nil {
+			log.Printf("failed to close Redis connections: %v", err)
+		}
+	}
+
+	if boltRepo != nil {
+		if err := boltRepo.Close(); err != nil {
+			log.Printf("failed to close bbolt database: %v", err)
+		}
+	}
+
 	log.Println("server exited")
 
 	asyncLogger.Close()
@@ -0,0 +1,66 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// NewFromEnv creates the blob store selected by environment variables.
+//
+// Environment variables used:
+//   - ATTACHMENTS_DIR: Directory keeping attachments on the local filesystem
+//   - S3_BUCKET: Bucket keeping attachments in S3-compatible object storage
+//   - S3_PREFIX: Key prefix of the attachment objects in the bucket (default: none)
+//   - S3_ENDPOINT: URL of an S3-compatible service such as MinIO, addressed path-style (default: AWS)
+//
+// S3 credentials and region are read the standard AWS way, e.g. from AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY and AWS_REGION.
+// Returns nil if neither ATTACHMENTS_DIR nor S3_BUCKET is set, and an error if both are.
+func NewFromEnv(ctx context.Context) (ports.BlobStore, error) {
+	dir, bucket := os.Getenv("ATTACHMENTS_DIR"), os.Getenv("S3_BUCKET")
+
+	switch {
+	case dir != "" && bucket != "":
+		return nil, errors.New("ATTACHMENTS_DIR and S3_BUCKET are mutually exclusive")
+	case dir != "":
+		store, err := NewLocalStore(dir)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	case bucket != "":
+		store, err := newS3StoreFromEnv(ctx, bucket)
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	default:
+		return nil, nil
+	}
+}
+
+// newS3StoreFromEnv creates an S3 blob store for bucket from the AWS configuration
+// and the S3_PREFIX and S3_ENDPOINT environment variables.
+func newS3StoreFromEnv(ctx context.Context, bucket string) (*S3Store, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		if endpoint := os.Getenv("S3_ENDPOINT"); endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+			o.UsePathStyle = true
+		}
+	})
+
+	return NewS3Store(client, bucket, os.Getenv("S3_PREFIX")), nil
+}
@@ -0,0 +1,149 @@
+// Package blob provides implementations of the BlobStore port that keep the
+// content of task attachments on the local filesystem or in S3-compatible object storage.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var _ ports.BlobStore = (*LocalStore)(nil)
+
+// ErrInvalidKey is returned for keys that do not follow the key format of ports.BlobStore.
+var ErrInvalidKey = errors.New("invalid blob key")
+
+// LocalStore keeps blobs as files in a directory, one file per key.
+// The slashes in a key map to subdirectories.
+type LocalStore struct {
+	dir string
+}
+
+// NewLocalStore creates a blob store in dir, creating the directory if it does not exist.
+func NewLocalStore(dir string) (*LocalStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	return &LocalStore{dir: dir}, nil
+}
+
+// Put writes the content to a temporary file and renames it into place,
+// so readers never see a partially written blob.
+// Returns io.ErrUnexpectedEOF if content holds fewer than size bytes.
+func (s *LocalStore) Put(_ context.Context, key string, content io.Reader, size int64, _ string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create blob file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.CopyN(tmp, content, size); err != nil {
+		_ = tmp.Close()
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write blob: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to store blob: %w", err)
+	}
+
+	return nil
+}
+
+// Get opens the file of the blob.
+// Returns ports.ErrBlobNotFound if no blob is stored under key.
+func (s *LocalStore) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ports.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open blob: %w", err)
+	}
+
+	return file, nil
+}
+
+// Delete removes the file of the blob. Empty directories are left in place.
+func (s *LocalStore) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete blob: %w", err)
+	}
+
+	return nil
+}
+
+// Ping checks that the blob directory is still accessible.
+func (s *LocalStore) Ping(_ context.Context) error {
+	info, err := os.Stat(s.dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", s.dir)
+	}
+
+	return nil
+}
+
+// path returns the file path of the blob stored under key.
+// Returns ErrInvalidKey if key could escape the blob directory.
+func (s *LocalStore) path(key string) (string, error) {
+	if !validKey(key) {
+		return "", ErrInvalidKey
+	}
+
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// validKey reports whether key consists of non-empty segments of letters, digits,
+// hyphens and underscores separated by slashes.
+func validKey(key string) bool {
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" {
+			return false
+		}
+		for _, c := range segment {
+			switch {
+			case c >= '0' && c <= '9', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '-', c == '_':
+			default:
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,109 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var _ ports.BlobStore = (*S3Store)(nil)
+
+// S3Store keeps blobs as objects in an S3 bucket, optionally under a common key prefix.
+// It works with any S3-compatible object storage, such as MinIO.
+type S3Store struct {
+	client *s3.Client
+	bucket string
+	prefix string
+}
+
+// NewS3Store creates a blob store keeping objects in bucket under prefix.
+func NewS3Store(client *s3.Client, bucket, prefix string) *S3Store {
+	return &S3Store{client: client, bucket: bucket, prefix: prefix}
+}
+
+// Put uploads the content as a single object.
+// Plain HTTP endpoints require content to implement io.Seeker, so the payload can be signed.
+func (s *S3Store) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	objectKey, err := s.objectKey(key)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(objectKey),
+		Body:          content,
+		ContentLength: aws.Int64(size),
+		ContentType:   aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload blob: %w", err)
+	}
+
+	return nil
+}
+
+// Get starts downloading the object of the blob.
+// Returns ports.ErrBlobNotFound if no blob is stored under key.
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	objectKey, err := s.objectKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(objectKey),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, ports.ErrBlobNotFound
+		}
+		return nil, fmt.Errorf("failed to download blob: %w", err)
+	}
+
+	return out.Body, nil
+}
+
+// Delete removes the object of the blob. S3 does not report deleting a missing object.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	objectKey, err := s.objectKey(key)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(objectKey),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete blob: %w", err)
+	}
+
+	return nil
+}
+
+// Ping checks that the bucket exists and is accessible with the configured credentials.
+func (s *S3Store) Ping(ctx context.Context) error {
+	_, err := s.client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(s.bucket)})
+	return err
+}
+
+// objectKey returns the object key of the blob stored under key.
+// Returns ErrInvalidKey if key does not follow the key format.
+func (s *S3Store) objectKey(key string) (string, error) {
+	if !validKey(key) {
+		return "", ErrInvalidKey
+	}
+
+	return path.Join(s.prefix, key), nil
+}
@@ -0,0 +1,299 @@
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var _ ports.EventPublisher = (*AMQPPublisher)(nil)
+
+// Defaults of AMQPPublisher.
+const (
+	// amqpPublishAttempts is the number of attempts to publish an event, including the first one.
+	amqpPublishAttempts = 3
+	// amqpConfirmTimeout bounds the wait for the broker to confirm a message.
+	amqpConfirmTimeout = 10 * time.Second
+	// amqpReconnectBase is the delay before the first reconnection attempt, doubled with
+	// every further attempt up to amqpReconnectMax.
+	amqpReconnectBase = 500 * time.Millisecond
+	amqpReconnectMax  = 30 * time.Second
+	// amqpConnectionName identifies the connection in the management UI of the broker.
+	amqpConnectionName = "task-manager"
+)
+
+var (
+	// errNacked is returned when the broker rejects a published message.
+	errNacked = errors.New("message rejected by broker")
+	// errStopped is returned when reconnecting is abandoned at shutdown.
+	errStopped = errors.New("publisher stopped")
+)
+
+// AMQPPublisher publishes task events to a topic exchange of an AMQP 0-9-1 broker
+// such as RabbitMQ. The routing key of a message is the type of its event, e.g.
+// task.status_changed, so consumers bind queues to the events they need, or to task.#
+// for all of them. The body is the event encoded as JSON.
+//
+// Publish only queues the event; a goroutine publishes the queued events one at a time,
+// in order, and waits for the broker to confirm each of them. A rejected or unconfirmed
+// event is published again, up to three times. When the connection is lost it is
+// reestablished with exponential backoff and jitter, while the events wait in the queue.
+// When the queue is full, or the attempts of an event are used up, the event is dropped
+// and logged.
+type AMQPPublisher struct {
+	url      string
+	exchange string
+	logger   logger.Logger
+
+	// mu guards closed against Publish sending to a queue being closed
+	mu     sync.Mutex
+	closed bool
+	queue  chan amqpMessage
+	// stop is closed when Shutdown gives up waiting, to abandon reconnecting
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+
+	// conn and channel are only used by the publishing goroutine; nil while disconnected
+	conn    *amqp.Connection
+	channel *amqp.Channel
+}
+
+// amqpMessage is a queued event ready for publishing.
+type amqpMessage struct {
+	eventID    string
+	routingKey string
+	publishing amqp.Publishing
+}
+
+// NewAMQPPublisher creates a publisher of events to exchange on the broker at url and
+// starts publishing. The exchange is declared as a durable topic exchange on every
+// connection. Shutdown stops the publisher.
+func NewAMQPPublisher(url, exchange string, logger logger.Logger) *AMQPPublisher {
+	p := &AMQPPublisher{
+		url:      url,
+		exchange: exchange,
+		// The URL is not logged, as it carries the credentials.
+		logger: logger.With(slog.String("component", "amqp"), slog.String("exchange", exchange)),
+		queue:  make(chan amqpMessage, defaultQueueSize),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	go p.run()
+
+	return p
+}
+
+// Publish queues event for the exchange. It never waits for the broker.
+func (p *AMQPPublisher) Publish(ctx context.Context, event domain.Event) {
+	log := p.logger.With(slog.String("event_id", event.ID), slog.String("event_type", string(event.Type)))
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Error(ctx, "failed to encode event, event dropped", slog.String("error", err.Error()))
+		return
+	}
+
+	msg := amqpMessage{
+		eventID:    event.ID,
+		routingKey: string(event.Type),
+		publishing: amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			MessageId:    event.ID,
+			Type:         string(event.Type),
+			Timestamp:    event.OccurredAt,
+			Body:         body,
+		},
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		log.Warn(ctx, "amqp publisher stopped, event dropped")
+		return
+	}
+
+	select {
+	case p.queue <- msg:
+	default:
+		log.Error(ctx, "amqp queue full, event dropped")
+	}
+}
+
+// Shutdown stops accepting events and waits until the queued events are published
+// or ctx is done, whichever comes first. If ctx is done first, reconnecting is abandoned
+// and the events still queued are dropped.
+func (p *AMQPPublisher) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		p.stopOnce.Do(func() { close(p.stop) })
+		return ctx.Err()
+	}
+}
+
+// run publishes the queued events until the queue is closed, then closes the connection.
+func (p *AMQPPublisher) run() {
+	defer close(p.done)
+	defer p.disconnect()
+
+	for msg := range p.queue {
+		p.publish(msg)
+	}
+}
+
+// publish publishes msg, reconnecting as needed, until the broker confirms it or
+// its attempts are used up.
+func (p *AMQPPublisher) publish(msg amqpMessage) {
+	ctx := context.Background()
+	log := p.logger.With(slog.String("event_id", msg.eventID), slog.String("event_type", msg.routingKey))
+
+	for attempt := 1; ; attempt++ {
+		if err := p.connect(); err != nil {
+			log.Error(ctx, "amqp broker unavailable, event dropped", slog.String("error", err.Error()))
+			return
+		}
+
+		err := p.publishConfirmed(msg)
+		if err == nil {
+			log.Debug(ctx, "event published")
+			return
+		}
+
+		if attempt >= amqpPublishAttempts {
+			log.Error(
+				ctx,
+				"failed to publish event, event dropped",
+				slog.String("error", err.Error()), slog.Int("attempts", attempt),
+			)
+			return
+		}
+
+		log.Warn(ctx, "failed to publish event, will retry", slog.String("error", err.Error()))
+		if !errors.Is(err, errNacked) {
+			// The channel or connection is likely broken; start over with a new one.
+			p.disconnect()
+		}
+	}
+}
+
+// publishConfirmed publishes msg on the current channel and waits for the broker to confirm it.
+func (p *AMQPPublisher) publishConfirmed(msg amqpMessage) error {
+	ctx, cancel := context.WithTimeout(context.Background(), amqpConfirmTimeout)
+	defer cancel()
+
+	confirmation, err := p.channel.PublishWithDeferredConfirmWithContext(
+		ctx, p.exchange, msg.routingKey, false, false, msg.publishing,
+	)
+	if err != nil {
+		return err
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to wait for confirmation: %w", err)
+	}
+
+	if !acked {
+		return errNacked
+	}
+
+	return nil
+}
+
+// connect makes sure there is an open channel in confirm mode, dialing the broker with
+// exponential backoff and full jitter until it succeeds.
+// Returns errStopped if Shutdown abandons reconnecting first.
+func (p *AMQPPublisher) connect() error {
+	if p.channel != nil && !p.channel.IsClosed() {
+		return nil
+	}
+	p.disconnect()
+
+	ctx := context.Background()
+	delay := amqpReconnectBase
+	for attempt := 1; ; attempt++ {
+		err := p.dial()
+		if err == nil {
+			p.logger.Info(ctx, "connected to amqp broker", slog.Int("attempts", attempt))
+			return nil
+		}
+
+		wait := rand.N(delay + 1)
+		p.logger.Warn(
+			ctx,
+			"failed to connect to amqp broker, will retry",
+			slog.String("error", err.Error()), slog.Duration("delay", wait),
+		)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-p.stop:
+			timer.Stop()
+			return errStopped
+		}
+
+		delay = min(delay*2, amqpReconnectMax)
+	}
+}
+
+// dial opens a connection and a channel in confirm mode, and declares the exchange.
+func (p *AMQPPublisher) dial() error {
+	properties := amqp.NewConnectionProperties()
+	properties.SetClientConnectionName(amqpConnectionName)
+
+	conn, err := amqp.DialConfig(p.url, amqp.Config{Locale: "en_US", Properties: properties})
+	if err != nil {
+		return err
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to open channel: %w", err)
+	}
+
+	if err := channel.Confirm(false); err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+
+	if err := channel.ExchangeDeclare(p.exchange, amqp.ExchangeTopic, true, false, false, false, nil); err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to declare exchange: %w", err)
+	}
+
+	p.conn, p.channel = conn, channel
+	return nil
+}
+
+// disconnect closes the connection, if any.
+func (p *AMQPPublisher) disconnect() {
+	if p.conn != nil {
+		_ = p.conn.Close()
+	}
+	p.conn, p.channel = nil, nil
+}
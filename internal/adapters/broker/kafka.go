@@ -0,0 +1,193 @@
+// Package broker publishes task events to message brokers, so systems outside the
+// process can consume task changes without polling the API.
+package broker
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var _ ports.EventPublisher = (*KafkaPublisher)(nil)
+
+// Headers of a published message.
+const (
+	// HeaderEventType carries the type of the event.
+	HeaderEventType = "event-type"
+	// HeaderEventID carries the ID of the event, so consumers can ignore redeliveries.
+	HeaderEventID = "event-id"
+)
+
+// Defaults of KafkaPublisher.
+const (
+	// defaultQueueSize is the number of events waiting to be handed to the writer.
+	defaultQueueSize = 1000
+	// kafkaBatchTimeout bounds the time an event waits for its batch to fill;
+	// the default of the writer, a second, is too long for change notifications.
+	kafkaBatchTimeout = 50 * time.Millisecond
+)
+
+// KafkaPublisher publishes task events to a Kafka topic. The key of a message is the
+// ID of its task, so the events of a task land on the same partition in order.
+// The value is the event encoded as JSON.
+//
+// Publish only queues the event; a goroutine hands the queued events to a batching
+// writer, which retries failed writes and waits for all in-sync replicas. When the
+// queue is full, or the writes of an event fail for good, the event is dropped and logged.
+type KafkaPublisher struct {
+	writer *kafka.Writer
+	logger logger.Logger
+
+	// mu guards closed against Publish sending to a queue being closed
+	mu     sync.Mutex
+	closed bool
+	queue  chan kafka.Message
+	done   chan struct{}
+}
+
+// NewKafkaPublisher creates a publisher writing events to topic on the Kafka cluster
+// reachable through brokers, and starts handing events to it. Shutdown stops it.
+// The topic is not created; it must exist or be created by the brokers on first use.
+func NewKafkaPublisher(brokers []string, topic string, logger logger.Logger) *KafkaPublisher {
+	p := &KafkaPublisher{
+		logger: logger.With(slog.String("component", "kafka"), slog.String("topic", topic)),
+		queue:  make(chan kafka.Message, defaultQueueSize),
+		done:   make(chan struct{}),
+	}
+
+	p.writer = &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		BatchTimeout: kafkaBatchTimeout,
+		Async:        true,
+		Completion:   p.completed,
+	}
+
+	go p.run()
+
+	return p
+}
+
+// Publish queues event for the topic. It never waits for the brokers.
+func (p *KafkaPublisher) Publish(ctx context.Context, event domain.Event) {
+	log := p.logger.With(slog.String("event_id", event.ID), slog.String("event_type", string(event.Type)))
+
+	value, err := json.Marshal(event)
+	if err != nil {
+		log.Error(ctx, "failed to encode event, event dropped", slog.String("error", err.Error()))
+		return
+	}
+
+	msg := kafka.Message{
+		Key:   []byte(event.Task.ID),
+		Value: value,
+		Time:  event.OccurredAt,
+		Headers: []kafka.Header{
+			{Key: HeaderEventType, Value: []byte(event.Type)},
+			{Key: HeaderEventID, Value: []byte(event.ID)},
+		},
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		log.Warn(ctx, "kafka publisher stopped, event dropped")
+		return
+	}
+
+	select {
+	case p.queue <- msg:
+	default:
+		log.Error(ctx, "kafka queue full, event dropped")
+	}
+}
+
+// Shutdown stops accepting events and waits until the queued events are written
+// or ctx is done, whichever comes first.
+func (p *KafkaPublisher) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.queue)
+	}
+	p.mu.Unlock()
+
+	closed := make(chan error, 1)
+	go func() {
+		<-p.done
+		// Close flushes the pending batches and waits for their completion.
+		closed <- p.writer.Close()
+	}()
+
+	select {
+	case err := <-closed:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run hands the queued events to the writer, one at a time to keep their order,
+// until the queue is closed.
+func (p *KafkaPublisher) run() {
+	defer close(p.done)
+
+	for msg := range p.queue {
+		// An asynchronous writer only blocks to look up the partitions of the topic.
+		if err := p.writer.WriteMessages(context.Background(), msg); err != nil {
+			p.logger.Error(
+				context.Background(),
+				"failed to write event, event dropped",
+				slog.String("event_id", messageHeader(msg, HeaderEventID)),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+// completed logs the outcome of writing a batch of messages.
+func (p *KafkaPublisher) completed(messages []kafka.Message, err error) {
+	ctx := context.Background()
+
+	if err != nil {
+		for _, msg := range messages {
+			p.logger.Error(
+				ctx,
+				"failed to publish event, event dropped",
+				slog.String("event_id", messageHeader(msg, HeaderEventID)),
+				slog.String("error", err.Error()),
+			)
+		}
+		return
+	}
+
+	if len(messages) > 0 {
+		p.logger.Debug(
+			ctx,
+			"events published",
+			slog.Int("count", len(messages)), slog.Int("partition", messages[0].Partition),
+		)
+	}
+}
+
+// messageHeader returns the value of the header key of msg, or an empty string.
+func messageHeader(msg kafka.Message, key string) string {
+	for _, header := range msg.Headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,60 @@
+// Package clock provides the sources of the current time.
+package clock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.Clock = System{}
+	_ ports.Clock = (*Manual)(nil)
+)
+
+// System tells the time of the system clock in UTC.
+// Timestamps are kept in UTC so that they do not depend on the time zone of the
+// server, and daylight saving time changes never make them jump.
+type System struct{}
+
+// Now returns the current system time in UTC.
+func (System) Now() time.Time {
+	return time.Now().UTC()
+}
+
+// Manual tells a time that only changes when it is set or advanced.
+// It lets time-dependent behavior, such as retention rules, be run at a chosen time.
+type Manual struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewManual creates a clock showing now.
+func NewManual(now time.Time) *Manual {
+	return &Manual{now: now}
+}
+
+// Now returns the time the clock shows.
+func (c *Manual) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Set makes the clock show now.
+func (c *Manual) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now
+}
+
+// Advance moves the clock forward by d.
+func (c *Manual) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
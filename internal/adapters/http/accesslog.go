@@ -0,0 +1,54 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/logger"
+)
+
+// AccessLog writes one log entry per handled request.
+type AccessLog struct {
+	logger logger.Logger
+	// exclude holds the paths that are not logged, such as health checks
+	exclude map[string]struct{}
+}
+
+// NewAccessLog creates an access log writing to logger.
+// Requests to any of excludePaths are not logged.
+func NewAccessLog(logger logger.Logger, excludePaths ...string) *AccessLog {
+	exclude := make(map[string]struct{}, len(excludePaths))
+	for _, path := range excludePaths {
+		exclude[path] = struct{}{}
+	}
+
+	return &AccessLog{
+		logger:  logger,
+		exclude: exclude,
+	}
+}
+
+// Middleware logs the method, path, status code, response size and latency of every request.
+func (a *AccessLog) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := a.exclude[r.URL.Path]; ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		start := time.Now()
+		recorder := newResponseRecorder(w)
+		next.ServeHTTP(recorder, r)
+
+		a.logger.Info(
+			r.Context(),
+			"http request",
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", recorder.status),
+			slog.Int("bytes", recorder.bytes),
+			slog.Float64("latency_ms", float64(time.Since(start))/float64(time.Millisecond)),
+		)
+	})
+}
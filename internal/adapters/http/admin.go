@@ -0,0 +1,286 @@
+package http
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/auth"
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// Default and maximum page sizes for the raw repository dump.
+const (
+	defaultDumpLimit = 50
+	maxDumpLimit     = 1000
+)
+
+// Admin-specific error messages.
+var (
+	// ErrUnauthorized is returned when an admin endpoint is called without a valid token.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrInspectionUnsupported is returned when the repository cannot report its state.
+	ErrInspectionUnsupported = errors.New("repository inspection is not supported")
+	// ErrInvalidPagination is returned when offset or limit parameters are malformed.
+	ErrInvalidPagination = errors.New("invalid pagination parameters")
+	// ErrTokensDisabled is returned when minting tokens without a configured signing secret.
+	ErrTokensDisabled = errors.New("token authentication is not configured")
+	// ErrEncryptionDisabled is returned when re-encrypting without configured encryption keys.
+	ErrEncryptionDisabled = errors.New("encryption is not configured")
+)
+
+// MintTokenRequest represents the JSON payload for minting a scoped access token.
+type MintTokenRequest struct {
+	// Scopes lists the operations the token allows (tasks:read, tasks:write, webhooks:manage)
+	Scopes []auth.Scope `json:"scopes" xml:"scopes"`
+	// Resources optionally restricts the token to specific task IDs and "project:<id>" projects
+	Resources []string `json:"resources" xml:"resources"`
+	// TTL is the token lifetime as a Go duration string (e.g. "24h")
+	TTL string `json:"ttl" xml:"ttl"`
+}
+
+// MintTokenResponse represents the JSON response containing a newly minted token.
+type MintTokenResponse struct {
+	// Token is the encoded bearer token
+	Token string `json:"token"`
+	// Scopes lists the operations the token allows
+	Scopes []auth.Scope `json:"scopes"`
+	// Resources lists the task IDs and projects the token is restricted to
+	Resources []string `json:"resources,omitempty"`
+	// ExpiresAt is when the token stops being valid
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// RepositoryInspectionResponse represents the JSON format of GET /admin/repository.
+type RepositoryInspectionResponse struct {
+	ports.RepositoryStats
+	// Tasks contains the raw dump page if it was requested
+	Tasks []*domain.Task `json:"tasks,omitempty"`
+}
+
+// AdminHandler serves operator-only debugging endpoints.
+type AdminHandler struct {
+	capture      *RequestCapture
+	inspector    ports.RepositoryInspector
+	issuer       *auth.Issuer
+	reencrypters []ports.Reencrypter
+	logger       logger.Logger
+}
+
+// NewAdminHandler creates a handler for admin endpoints.
+// capture, inspector and issuer may be nil, and reencrypters empty, if the corresponding
+// features are disabled.
+func NewAdminHandler(
+	capture *RequestCapture,
+	inspector ports.RepositoryInspector,
+	issuer *auth.Issuer,
+	reencrypters []ports.Reencrypter,
+	logger logger.Logger,
+) *AdminHandler {
+	return &AdminHandler{
+		capture:      capture,
+		inspector:    inspector,
+		issuer:       issuer,
+		reencrypters: reencrypters,
+		logger:       logger,
+	}
+}
+
+// MintToken handles POST /admin/tokens requests.
+// Creates a short-lived token restricted to the requested scopes and resources.
+func (h *AdminHandler) MintToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.issuer == nil {
+		writeJSON(w, http.StatusNotImplemented, ErrorResponse{Error: ErrTokensDisabled.Error()})
+		return
+	}
+
+	var req MintTokenRequest
+	if err := decodeRequest(r, &req); err != nil {
+		h.logger.Warn(ctx, "invalid request format", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: ErrInvalidRequestFormat.Error()})
+		return
+	}
+
+	ttl, err := time.ParseDuration(req.TTL)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: auth.ErrInvalidTTL.Error()})
+		return
+	}
+
+	token, claims, err := h.issuer.Mint(req.Scopes, req.Resources, ttl)
+	if err != nil {
+		if errors.Is(err, auth.ErrInvalidScope) || errors.Is(err, auth.ErrInvalidTTL) ||
+			errors.Is(err, auth.ErrInvalidResource) {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		} else {
+			h.logger.Error(ctx, "failed to mint token", slog.String("error", err.Error()))
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: ErrInternalServerError.Error()})
+		}
+		return
+	}
+
+	h.logger.Info(
+		ctx,
+		"access token minted",
+		slog.String("token_id", claims.ID), slog.Time("expires_at", claims.ExpiresAt),
+	)
+
+	writeJSON(w, http.StatusCreated, MintTokenResponse{
+		Token:     token,
+		Scopes:    claims.Scopes,
+		Resources: claims.Resources,
+		ExpiresAt: claims.ExpiresAt,
+	})
+}
+
+// Repository handles GET /admin/repository requests.
+// Returns the backend type, task counts per status and a storage size estimate.
+// With ?dump=true it also returns a page of raw stored tasks (offset and limit
+// query parameters) if the backend supports it.
+func (h *AdminHandler) Repository(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.inspector == nil {
+		writeJSON(w, http.StatusNotImplemented, ErrorResponse{Error: ErrInspectionUnsupported.Error()})
+		return
+	}
+
+	stats, err := h.inspector.Inspect(ctx)
+	if err != nil {
+		h.logger.Error(ctx, "failed to inspect repository", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: ErrInternalServerError.Error()})
+		return
+	}
+
+	response := RepositoryInspectionResponse{RepositoryStats: stats}
+
+	if r.URL.Query().Get("dump") == "true" {
+		dumper, ok := h.inspector.(ports.RepositoryDumper)
+		if !ok {
+			writeJSON(w, http.StatusNotImplemented, ErrorResponse{Error: ErrInspectionUnsupported.Error()})
+			return
+		}
+
+		offset, limit, err := parseDumpPage(r)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		response.Tasks, err = dumper.Dump(ctx, offset, limit)
+		if err != nil {
+			h.logger.Error(ctx, "failed to dump repository", slog.String("error", err.Error()))
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: ErrInternalServerError.Error()})
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// parseDumpPage reads the offset and limit query parameters of the raw dump.
+func parseDumpPage(r *http.Request) (int, int, error) {
+	offset, limit := 0, defaultDumpLimit
+
+	if raw := r.URL.Query().Get("offset"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			return 0, 0, ErrInvalidPagination
+		}
+		offset = value
+	}
+
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value <= 0 || value > maxDumpLimit {
+			return 0, 0, ErrInvalidPagination
+		}
+		limit = value
+	}
+
+	return offset, limit, nil
+}
+
+// Requests handles GET /admin/requests requests.
+// Returns the most recent captured requests ordered from oldest to newest.
+func (h *AdminHandler) Requests(w http.ResponseWriter, _ *http.Request) {
+	entries := []CapturedRequest{}
+	if h.capture != nil {
+		entries = h.capture.Entries()
+	}
+
+	writeJSON(w, http.StatusOK, entries)
+}
+
+// Reencrypt handles POST /admin/encryption/reencrypt requests.
+// Rewrites the stored tasks, history and comments, and the encrypted data files, with the
+// primary encryption keys, so the keys used before a rotation can be removed, and returns
+// the number of rewritten records. The reencrypters run in order, and the rewrite runs to
+// completion even if the request is cancelled; it can be repeated safely.
+func (h *AdminHandler) Reencrypt(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if len(h.reencrypters) == 0 {
+		writeJSON(w, http.StatusNotImplemented, ErrorResponse{Error: ErrEncryptionDisabled.Error()})
+		return
+	}
+
+	var (
+		result ports.ReencryptionResult
+		err    error
+	)
+	for _, reencrypter := range h.reencrypters {
+		var rewritten ports.ReencryptionResult
+		rewritten, err = reencrypter.Reencrypt(context.WithoutCancel(ctx))
+		result.Tasks += rewritten.Tasks
+		result.HistoryEntries += rewritten.HistoryEntries
+		result.Comments += rewritten.Comments
+		result.StoredTasks += rewritten.StoredTasks
+		if err != nil {
+			break
+		}
+	}
+	if err != nil {
+		h.logger.Error(
+			ctx,
+			"failed to re-encrypt stored data",
+			slog.Int("tasks", result.Tasks), slog.Int("history_entries", result.HistoryEntries),
+			slog.Int("comments", result.Comments), slog.Int("stored_tasks", result.StoredTasks),
+			slog.String("error", err.Error()),
+		)
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: ErrInternalServerError.Error()})
+		return
+	}
+
+	h.logger.Info(
+		ctx,
+		"stored data re-encrypted",
+		slog.Bool("audit", true),
+		slog.Int("tasks", result.Tasks), slog.Int("history_entries", result.HistoryEntries),
+		slog.Int("comments", result.Comments), slog.Int("stored_tasks", result.StoredTasks),
+	)
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// requireAdmin rejects requests that don't carry the admin token as a Bearer credential.
+func requireAdmin(token string, log logger.Logger, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		provided, ok := bearerToken(r)
+		if !ok || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			log.Warn(r.Context(), "unauthorized admin request", slog.String("path", r.URL.Path))
+			writeJSON(w, http.StatusUnauthorized, ErrorResponse{Error: ErrUnauthorized.Error()})
+			return
+		}
+
+		next(w, r)
+	}
+}
@@ -0,0 +1,218 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// Attachment-specific error messages.
+var (
+	// ErrAttachmentNotFound is returned when a requested attachment does not exist on the task.
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	// ErrTooManyAttachments is returned when a task already has the maximum number of attachments.
+	ErrTooManyAttachments = errors.New("task has too many attachments")
+	// ErrFileRequired is returned when an upload has no file in its file form field.
+	ErrFileRequired = errors.New("multipart form field file is required")
+	// ErrAttachmentTooLarge is returned when an uploaded file exceeds the maximum attachment size.
+	ErrAttachmentTooLarge = errors.New("attachment is too large")
+)
+
+// attachmentFormMemory is how much of a multipart upload is buffered in memory;
+// larger files are spooled to temporary files.
+const attachmentFormMemory = 1 << 20
+
+// multipartOverhead is the allowance for the multipart boundaries and part headers
+// on top of the maximum attachment size.
+const multipartOverhead = 64 << 10
+
+// AttachmentHandler handles HTTP requests for the files attached to tasks.
+type AttachmentHandler struct {
+	service ports.AttachmentService
+	// maxSize is the largest accepted file in bytes
+	maxSize int64
+	logger  logger.Logger
+}
+
+// NewAttachmentHandler creates a new HTTP handler for attachment operations
+// accepting files of up to maxSize bytes.
+func NewAttachmentHandler(service ports.AttachmentService, maxSize int64, logger logger.Logger) *AttachmentHandler {
+	return &AttachmentHandler{
+		service: service,
+		maxSize: maxSize,
+		logger:  logger.With(slog.String("component", "http")),
+	}
+}
+
+// AddAttachment handles POST /tasks/{id}/attachments requests to attach a file to a task.
+// Expects a multipart/form-data body with the file in the file field, and an If-Match
+// header carrying the task ETag.
+// Returns the attachment metadata with the new task ETag, 412 if the task changed meanwhile,
+// 413 if the file is too large, or an error response.
+func (h *AttachmentHandler) AddAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID := r.PathValue("id")
+	log := h.logger.With(slog.String("task_id", taskID))
+	log.Info(ctx, "adding attachment")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		log.Warn(ctx, "precondition missing or invalid")
+		h.writePreconditionError(w, err)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxSize+multipartOverhead)
+	if err := r.ParseMultipartForm(attachmentFormMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			log.Warn(ctx, "attachment rejected: request too large")
+			writeJSON(w, http.StatusRequestEntityTooLarge, ErrorResponse{Error: ErrAttachmentTooLarge.Error()})
+			return
+		}
+
+		log.Warn(ctx, "invalid multipart form", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: ErrInvalidRequestFormat.Error()})
+		return
+	}
+	defer func() { _ = r.MultipartForm.RemoveAll() }()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		log.Warn(ctx, "attachment rejected: no file", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: ErrFileRequired.Error()})
+		return
+	}
+	defer file.Close()
+
+	if header.Size > h.maxSize {
+		log.Warn(ctx, "attachment rejected: file too large", slog.Int64("size", header.Size))
+		writeJSON(w, http.StatusRequestEntityTooLarge, ErrorResponse{Error: ErrAttachmentTooLarge.Error()})
+		return
+	}
+
+	contentType := header.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	task, attachment, err := h.service.AddAttachment(
+		ctx, taskID, header.Filename, contentType, file, header.Size, version,
+	)
+	if err != nil {
+		h.writeAttachmentError(w, r, log, err)
+		return
+	}
+
+	w.Header().Set("ETag", taskETag(task, nil))
+	writeJSON(w, http.StatusCreated, attachment)
+}
+
+// GetAttachment handles GET /tasks/{id}/attachments/{attachment_id} requests to download
+// an attached file. The file is sent with its original media type and file name.
+func (h *AttachmentHandler) GetAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, attachmentID := r.PathValue("id"), r.PathValue("attachment_id")
+	log := h.logger.With(slog.String("task_id", taskID), slog.String("attachment_id", attachmentID))
+	log.Info(ctx, "getting attachment")
+
+	attachment, content, err := h.service.OpenAttachment(ctx, taskID, attachmentID)
+	if err != nil {
+		h.writeAttachmentError(w, r, log, err)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": attachment.Filename,
+	}))
+	// Uploaded files are untrusted: browsers must not guess a more dangerous media type.
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, content); err != nil {
+		log.Warn(ctx, "failed to send attachment", slog.String("error", err.Error()))
+	}
+}
+
+// DeleteAttachment handles DELETE /tasks/{id}/attachments/{attachment_id} requests to remove
+// an attached file. Requires an If-Match header carrying the task ETag.
+// Returns 204 No Content with the new task ETag on success, 412 if the task changed
+// meanwhile, or a 404 error if the task or attachment doesn't exist.
+func (h *AttachmentHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, attachmentID := r.PathValue("id"), r.PathValue("attachment_id")
+	log := h.logger.With(slog.String("task_id", taskID), slog.String("attachment_id", attachmentID))
+	log.Info(ctx, "deleting attachment")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		log.Warn(ctx, "precondition missing or invalid")
+		h.writePreconditionError(w, err)
+		return
+	}
+
+	task, err := h.service.DeleteAttachment(ctx, taskID, attachmentID, version)
+	if err != nil {
+		h.writeAttachmentError(w, r, log, err)
+		return
+	}
+
+	w.Header().Set("ETag", taskETag(task, nil))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writePreconditionError maps an If-Match parsing error to its status code.
+func (h *AttachmentHandler) writePreconditionError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrPreconditionRequired) {
+		writeJSON(w, http.StatusPreconditionRequired, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusPreconditionFailed, ErrorResponse{Error: ErrPreconditionFailed.Error()})
+}
+
+// writeAttachmentError maps an error of an attachment operation to its response.
+func (h *AttachmentHandler) writeAttachmentError(w http.ResponseWriter, r *http.Request, log logger.Logger, err error) {
+	ctx := r.Context()
+
+	var (
+		status  int
+		message error
+	)
+	switch {
+	case errors.Is(err, domain.ErrTaskNotFound):
+		log.Warn(ctx, "task not found")
+		status, message = http.StatusNotFound, ErrTaskNotFound
+	case errors.Is(err, domain.ErrAttachmentNotFound):
+		log.Warn(ctx, "attachment not found")
+		status, message = http.StatusNotFound, ErrAttachmentNotFound
+	case errors.Is(err, domain.ErrVersionConflict):
+		log.Warn(ctx, "task version conflict")
+		status, message = http.StatusPreconditionFailed, ErrPreconditionFailed
+	case errors.Is(err, domain.ErrTooManyAttachments):
+		log.Warn(ctx, "attachment rejected: too many attachments")
+		status, message = http.StatusConflict, ErrTooManyAttachments
+	case errors.Is(err, ports.ErrRepositoryUnavailable):
+		log.Error(ctx, "task repository unavailable", slog.String("error", err.Error()))
+		writeProblem(w, r, http.StatusServiceUnavailable, ports.ErrRepositoryUnavailable.Error())
+		return
+	default:
+		log.Error(ctx, "failed to handle attachments", slog.String("error", err.Error()))
+		status, message = http.StatusInternalServerError, ErrInternalServerError
+	}
+
+	writeJSON(w, status, ErrorResponse{Error: message.Error()})
+}
@@ -0,0 +1,181 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxCapturedBody limits how many bytes of request and response bodies are kept per entry.
+const maxCapturedBody = 1024
+
+// redactedValue replaces sensitive values in captured bodies.
+const redactedValue = "[REDACTED]"
+
+// sensitiveKeys lists JSON keys whose values are never stored by the capture buffer.
+var sensitiveKeys = map[string]struct{}{
+	"authorization": {},
+	"password":      {},
+	"secret":        {},
+	"token":         {},
+	"api_key":       {},
+}
+
+// CapturedRequest represents a single request/response pair kept for debugging.
+type CapturedRequest struct {
+	// Time is when the request was received
+	Time time.Time `json:"time"`
+	// Method is the HTTP method of the request
+	Method string `json:"method"`
+	// Path is the request path without the query string
+	Path string `json:"path"`
+	// Status is the HTTP status code of the response
+	Status int `json:"status"`
+	// LatencyMS is how long the request took in milliseconds
+	LatencyMS float64 `json:"latency_ms"`
+	// RequestBody is the truncated request body with secrets redacted
+	RequestBody string `json:"request_body,omitempty"`
+	// ResponseBody is the truncated response body with secrets redacted
+	ResponseBody string `json:"response_body,omitempty"`
+}
+
+// RequestCapture keeps the last N requests in a fixed-size ring buffer.
+type RequestCapture struct {
+	// entries is the ring buffer storage
+	entries []CapturedRequest
+	// next is the index the next entry will be written to
+	next int
+	// full reports whether the buffer has wrapped around
+	full bool
+	// mu provides thread-safe access to the ring buffer
+	mu sync.Mutex
+}
+
+// NewRequestCapture creates a ring buffer holding up to size requests.
+func NewRequestCapture(size int) *RequestCapture {
+	return &RequestCapture{
+		entries: make([]CapturedRequest, size),
+	}
+}
+
+// Middleware records every request passing through next into the ring buffer.
+// Requests to admin endpoints are not captured.
+func (c *RequestCapture) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/admin/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		start := time.Now()
+
+		var reqBody []byte
+		if r.Body != nil {
+			reqBody, _ = io.ReadAll(r.Body)
+			r.Body = io.NopCloser(bytes.NewReader(reqBody))
+		}
+
+		recorder := &captureRecorder{responseRecorder: newResponseRecorder(w)}
+		next.ServeHTTP(recorder, r)
+
+		c.add(CapturedRequest{
+			Time:         start,
+			Method:       r.Method,
+			Path:         r.URL.Path,
+			Status:       recorder.status,
+			LatencyMS:    float64(time.Since(start)) / float64(time.Millisecond),
+			RequestBody:  sanitizeBody(reqBody),
+			ResponseBody: sanitizeBody(recorder.body.Bytes()),
+		})
+	})
+}
+
+// Entries returns the captured requests ordered from oldest to newest.
+func (c *RequestCapture) Entries() []CapturedRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.full {
+		return append([]CapturedRequest(nil), c.entries[:c.next]...)
+	}
+
+	result := make([]CapturedRequest, 0, len(c.entries))
+	result = append(result, c.entries[c.next:]...)
+	return append(result, c.entries[:c.next]...)
+}
+
+// add stores an entry, overwriting the oldest one when the buffer is full.
+func (c *RequestCapture) add(entry CapturedRequest) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) == 0 {
+		return
+	}
+
+	c.entries[c.next] = entry
+	c.next = (c.next + 1) % len(c.entries)
+	if c.next == 0 {
+		c.full = true
+	}
+}
+
+// captureRecorder additionally keeps the first bytes of the response body.
+type captureRecorder struct {
+	*responseRecorder
+	body bytes.Buffer
+}
+
+// Write copies up to maxCapturedBody bytes into the capture buffer and forwards the rest.
+func (r *captureRecorder) Write(b []byte) (int, error) {
+	if remaining := maxCapturedBody + 1 - r.body.Len(); remaining > 0 {
+		r.body.Write(b[:min(len(b), remaining)])
+	}
+
+	return r.responseRecorder.Write(b)
+}
+
+// sanitizeBody redacts sensitive JSON fields and truncates the body to maxCapturedBody bytes.
+// Non-JSON bodies are only truncated.
+func sanitizeBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var decoded any
+	if err := json.Unmarshal(body, &decoded); err == nil {
+		if redacted, err := json.Marshal(redact(decoded)); err == nil {
+			body = redacted
+		}
+	}
+
+	if len(body) > maxCapturedBody {
+		return string(body[:maxCapturedBody]) + "...(truncated)"
+	}
+
+	return string(body)
+}
+
+// redact walks a decoded JSON value and replaces values of sensitive keys.
+func redact(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if _, ok := sensitiveKeys[strings.ToLower(key)]; ok {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redact(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redact(item)
+		}
+	}
+
+	return value
+}
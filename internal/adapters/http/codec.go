@@ -0,0 +1,383 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Media types supported for request and response bodies.
+const (
+	mediaTypeJSON    = "application/json"
+	mediaTypeXML     = "application/xml"
+	mediaTypeMsgpack = "application/msgpack"
+)
+
+// ErrNotAcceptable is returned when the Accept header allows none of the supported media types.
+var ErrNotAcceptable = errors.New("none of the accepted media types is supported")
+
+// Codec converts request and response bodies between JSON and another media type.
+// Handlers always produce JSON; responses are transcoded after the handler has run.
+type Codec interface {
+	// MediaType returns the media type written in the Content-Type header.
+	MediaType() string
+	// Transcode re-encodes the JSON document src into dst.
+	Transcode(dst io.Writer, src []byte) error
+	// Decode decodes a request body into v.
+	Decode(body io.Reader, v any) error
+}
+
+// codecRegistry maps media types to the codecs handling them.
+type codecRegistry struct {
+	codecs map[string]Codec
+}
+
+// codecs is the registry of the media types supported by the API.
+var codecs = &codecRegistry{
+	codecs: map[string]Codec{
+		mediaTypeJSON:    jsonCodec{},
+		mediaTypeXML:     xmlCodec{},
+		"text/xml":       xmlCodec{},
+		mediaTypeMsgpack: msgpackCodec{},
+		// application/x-msgpack is the unregistered type still sent by many clients.
+		"application/x-msgpack": msgpackCodec{},
+	},
+}
+
+// lookup returns the codec for the media type of a Content-Type header.
+// An empty header selects JSON.
+func (c *codecRegistry) lookup(contentType string) (Codec, bool) {
+	if contentType == "" {
+		return jsonCodec{}, true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+
+	codec, ok := c.codecs[mediaType]
+	return codec, ok
+}
+
+// negotiate picks the codec preferred by an Accept header.
+// JSON is used when the header is empty or accepts any type, and wins ties.
+// Returns ErrNotAcceptable if no supported media type is acceptable.
+func (c *codecRegistry) negotiate(accept string) (Codec, error) {
+	if strings.TrimSpace(accept) == "" {
+		return jsonCodec{}, nil
+	}
+
+	var best Codec
+	bestQ := 0.0
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+
+		var codec Codec
+		switch mediaType {
+		case "*/*", "application/*":
+			codec = jsonCodec{}
+		default:
+			if codec = c.codecs[mediaType]; codec == nil {
+				continue
+			}
+		}
+
+		_, isJSON := codec.(jsonCodec)
+		if q > bestQ || (q == bestQ && q > 0 && isJSON) {
+			best, bestQ = codec, q
+		}
+	}
+
+	if best == nil {
+		return nil, ErrNotAcceptable
+	}
+
+	return best, nil
+}
+
+// decodeRequest decodes the request body into v using the codec for its Content-Type.
+func decodeRequest(r *http.Request, v any) error {
+	codec, ok := codecs.lookup(r.Header.Get("Content-Type"))
+	if !ok {
+		return ErrInvalidRequestFormat
+	}
+
+	return codec.Decode(r.Body, v)
+}
+
+// jsonCodec is the native JSON codec.
+type jsonCodec struct{}
+
+// MediaType returns application/json.
+func (jsonCodec) MediaType() string { return mediaTypeJSON }
+
+// Transcode copies src unchanged.
+func (jsonCodec) Transcode(dst io.Writer, src []byte) error {
+	_, err := dst.Write(src)
+	return err
+}
+
+// Decode decodes a JSON body into v.
+func (jsonCodec) Decode(body io.Reader, v any) error {
+	return json.NewDecoder(body).Decode(v)
+}
+
+// msgpackCodec encodes bodies as MessagePack maps keyed by the JSON field names.
+type msgpackCodec struct{}
+
+// MediaType returns application/msgpack.
+func (msgpackCodec) MediaType() string { return mediaTypeMsgpack }
+
+// Transcode re-encodes a JSON document as MessagePack.
+// Integral numbers become integers, other numbers become floats.
+func (msgpackCodec) Transcode(dst io.Writer, src []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(src))
+	dec.UseNumber()
+
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return err
+	}
+
+	enc := msgpack.NewEncoder(dst)
+	enc.SetSortMapKeys(true)
+	return enc.Encode(convertNumbers(value))
+}
+
+// Decode decodes a MessagePack body into v through its JSON representation,
+// so the JSON field names and types of the request structs apply.
+func (msgpackCodec) Decode(body io.Reader, v any) error {
+	var value any
+	if err := msgpack.NewDecoder(body).Decode(&value); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// convertNumbers replaces json.Number values with int64 or float64 in place.
+func convertNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for key, item := range v {
+			v[key] = convertNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = convertNumbers(item)
+		}
+	}
+
+	return value
+}
+
+// xmlCodec encodes bodies as XML documents with a <response> root.
+// Object fields become elements named after their JSON keys and array
+// items become <item> elements. Requests are decoded with the xml struct tags.
+type xmlCodec struct{}
+
+// MediaType returns application/xml.
+func (xmlCodec) MediaType() string { return mediaTypeXML }
+
+// Transcode re-encodes a JSON document as XML, preserving the field order.
+func (xmlCodec) Transcode(dst io.Writer, src []byte) error {
+	if _, err := io.WriteString(dst, xml.Header); err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(src))
+	dec.UseNumber()
+
+	enc := xml.NewEncoder(dst)
+	if err := writeXMLValue(enc, dec, "response"); err != nil {
+		return err
+	}
+
+	return enc.Flush()
+}
+
+// Decode decodes an XML body into v.
+func (xmlCodec) Decode(body io.Reader, v any) error {
+	return xml.NewDecoder(body).Decode(v)
+}
+
+// writeXMLValue converts the next JSON value from dec into an element named name.
+func writeXMLValue(enc *xml.Encoder, dec *json.Decoder, name string) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: xmlName(name)}}
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch t := token.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				if err := writeXMLValue(enc, dec, key.(string)); err != nil {
+					return err
+				}
+			}
+		case '[':
+			for dec.More() {
+				if err := writeXMLValue(enc, dec, "item"); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Consume the closing delimiter.
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		if err := enc.EncodeToken(xml.CharData(fmt.Sprint(t))); err != nil {
+			return err
+		}
+	}
+
+	return enc.EncodeToken(start.End())
+}
+
+// xmlName turns a JSON key into a valid XML element name by replacing
+// disallowed characters with underscores.
+func xmlName(key string) string {
+	var b strings.Builder
+	for i, r := range key {
+		valid := r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' ||
+			i > 0 && (r == '-' || r == '.' || r >= '0' && r <= '9')
+		if !valid {
+			if i == 0 && r >= '0' && r <= '9' {
+				b.WriteByte('_')
+				b.WriteRune(r)
+				continue
+			}
+			r = '_'
+		}
+		b.WriteRune(r)
+	}
+
+	if b.Len() == 0 {
+		return "_"
+	}
+
+	return b.String()
+}
+
+// negotiateContent transcodes JSON responses into the media type requested by
+// the Accept header. Requests accepting no supported type get 406 Not Acceptable:
+// unsafe methods are rejected before they run, while responses to safe methods are
+// replaced only if they are JSON, so that e.g. metrics are still served.
+func negotiateContent(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept")
+
+		// A nil codec means that the response can only be sent if it is not JSON.
+		codec, err := codecs.negotiate(r.Header.Get("Accept"))
+		if err != nil && r.Method != http.MethodGet && r.Method != http.MethodHead {
+			writeJSON(w, http.StatusNotAcceptable, ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		if _, ok := codec.(jsonCodec); ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		tw := &transcodeWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(tw, r)
+		tw.finish(codec)
+	})
+}
+
+// transcodeWriter buffers a response so its JSON body can be re-encoded.
+type transcodeWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+// WriteHeader records the status code until the body is transcoded.
+func (tw *transcodeWriter) WriteHeader(status int) {
+	if tw.wroteHeader {
+		return
+	}
+
+	tw.status = status
+	tw.wroteHeader = true
+}
+
+// Write buffers b.
+func (tw *transcodeWriter) Write(b []byte) (int, error) {
+	tw.wroteHeader = true
+	return tw.body.Write(b)
+}
+
+// finish writes the buffered response, transcoding JSON bodies with codec.
+// Bodies of other media types are sent unchanged.
+func (tw *transcodeWriter) finish(codec Codec) {
+	body := tw.body.Bytes()
+
+	mediaType, _, _ := mime.ParseMediaType(tw.Header().Get("Content-Type"))
+	isJSON := mediaType == mediaTypeJSON || mediaType == problemContentType
+	if isJSON && codec == nil {
+		tw.Header().Del("Content-Length")
+		writeJSON(tw.ResponseWriter, http.StatusNotAcceptable, ErrorResponse{Error: ErrNotAcceptable.Error()})
+		return
+	}
+
+	if len(body) > 0 && isJSON {
+		var transcoded bytes.Buffer
+		if err := codec.Transcode(&transcoded, body); err == nil {
+			tw.Header().Set("Content-Type", codec.MediaType())
+			tw.Header().Del("Content-Length")
+			_, subtype, _ := strings.Cut(codec.MediaType(), "/")
+			varyETag(tw.Header(), subtype)
+			body = transcoded.Bytes()
+		}
+	}
+
+	tw.ResponseWriter.WriteHeader(tw.status)
+	_, _ = tw.ResponseWriter.Write(body)
+}
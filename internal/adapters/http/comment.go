@@ -0,0 +1,136 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// Comment-specific error messages.
+var (
+	// ErrCommentNotFound is returned when a requested comment does not exist on the task.
+	ErrCommentNotFound = errors.New("comment not found")
+	// ErrCommentBodyRequired is returned when attempting to add a comment without a body.
+	ErrCommentBodyRequired = errors.New("comment body is required")
+	// ErrCommentAuthorRequired is returned when attempting to add a comment without an author.
+	ErrCommentAuthorRequired = errors.New("comment author is required")
+)
+
+// CommentHandler handles HTTP requests for the comments on tasks.
+type CommentHandler struct {
+	service ports.CommentService
+	logger  logger.Logger
+}
+
+// NewCommentHandler creates a new HTTP handler for comment operations.
+func NewCommentHandler(service ports.CommentService, logger logger.Logger) *CommentHandler {
+	return &CommentHandler{
+		service: service,
+		logger:  logger.With(slog.String("component", "http")),
+	}
+}
+
+// AddCommentRequest represents the JSON payload for adding a comment to a task.
+type AddCommentRequest struct {
+	// Author identifies who writes the comment
+	Author string `json:"author" xml:"author"`
+	// Body is the text of the comment
+	Body string `json:"body" xml:"body"`
+}
+
+// ListComments handles GET /tasks/{id}/comments requests to list the comments of a task, oldest first.
+func (h *CommentHandler) ListComments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID := r.PathValue("id")
+	log := h.logger.With(slog.String("task_id", taskID))
+	log.Info(ctx, "listing comments")
+
+	comments, err := h.service.ListComments(ctx, taskID)
+	if err != nil {
+		h.writeCommentError(w, r, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, comments)
+}
+
+// AddComment handles POST /tasks/{id}/comments requests to add a comment to a task.
+// Expects a JSON payload with the author and body of the comment.
+// Returns the created comment or an error response.
+func (h *CommentHandler) AddComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID := r.PathValue("id")
+	log := h.logger.With(slog.String("task_id", taskID))
+	log.Info(ctx, "adding comment")
+
+	var req AddCommentRequest
+	if err := decodeRequest(r, &req); err != nil {
+		log.Warn(ctx, "invalid request format", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: ErrInvalidRequestFormat.Error()})
+		return
+	}
+
+	comment, err := h.service.AddComment(ctx, taskID, req.Author, req.Body)
+	if err != nil {
+		h.writeCommentError(w, r, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, comment)
+}
+
+// DeleteComment handles DELETE /tasks/{id}/comments/{comment_id} requests to remove a comment.
+// Returns 204 No Content on success or a 404 error if the comment doesn't exist.
+func (h *CommentHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, commentID := r.PathValue("id"), r.PathValue("comment_id")
+	log := h.logger.With(slog.String("task_id", taskID), slog.String("comment_id", commentID))
+	log.Info(ctx, "deleting comment")
+
+	if err := h.service.DeleteComment(ctx, taskID, commentID); err != nil {
+		h.writeCommentError(w, r, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeCommentError maps an error of a comment operation to its response.
+func (h *CommentHandler) writeCommentError(w http.ResponseWriter, r *http.Request, log logger.Logger, err error) {
+	ctx := r.Context()
+
+	var (
+		status  int
+		message error
+	)
+	switch {
+	case errors.Is(err, domain.ErrTaskNotFound):
+		log.Warn(ctx, "task not found")
+		status, message = http.StatusNotFound, ErrTaskNotFound
+	case errors.Is(err, domain.ErrCommentNotFound):
+		log.Warn(ctx, "comment not found")
+		status, message = http.StatusNotFound, ErrCommentNotFound
+	case errors.Is(err, domain.ErrEmptyCommentBody):
+		log.Warn(ctx, "comment rejected: empty body")
+		status, message = http.StatusBadRequest, ErrCommentBodyRequired
+	case errors.Is(err, domain.ErrEmptyCommentAuthor):
+		log.Warn(ctx, "comment rejected: empty author")
+		status, message = http.StatusBadRequest, ErrCommentAuthorRequired
+	case errors.Is(err, ports.ErrRepositoryUnavailable):
+		log.Error(ctx, "comment repository unavailable", slog.String("error", err.Error()))
+		writeProblem(w, r, http.StatusServiceUnavailable, ports.ErrRepositoryUnavailable.Error())
+		return
+	default:
+		log.Error(ctx, "failed to handle comments", slog.String("error", err.Error()))
+		status, message = http.StatusInternalServerError, ErrInternalServerError
+	}
+
+	writeJSON(w, status, ErrorResponse{Error: message.Error()})
+}
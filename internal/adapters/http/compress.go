@@ -0,0 +1,234 @@
+package http
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Supported content codings.
+const (
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+// Compressor compresses API responses for clients that accept gzip or deflate.
+// Writers are pooled per coding to keep allocations low.
+type Compressor struct {
+	// minSize is the smallest response body, in bytes, that is compressed
+	minSize int
+	gzip    sync.Pool
+	deflate sync.Pool
+}
+
+// NewCompressor creates a Compressor that compresses response bodies of at least minSize bytes.
+func NewCompressor(minSize int) *Compressor {
+	return &Compressor{
+		minSize: minSize,
+		gzip: sync.Pool{New: func() any {
+			return gzip.NewWriter(io.Discard)
+		}},
+		deflate: sync.Pool{New: func() any {
+			return zlib.NewWriter(io.Discard)
+		}},
+	}
+}
+
+// pooledEncoder is a compressing writer that can be reused for another response.
+type pooledEncoder interface {
+	io.WriteCloser
+	Reset(w io.Writer)
+}
+
+// Middleware negotiates the content coding from Accept-Encoding and compresses
+// API responses once they reach the size threshold. Smaller responses are sent as is.
+func (c *Compressor) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, compressor: c, encoding: encoding, status: http.StatusOK}
+		defer cw.Close()
+
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// encoder takes a writer for encoding from its pool, writing to w.
+func (c *Compressor) encoder(encoding string, w io.Writer) pooledEncoder {
+	var enc pooledEncoder
+	if encoding == encodingGzip {
+		enc = c.gzip.Get().(*gzip.Writer)
+	} else {
+		enc = c.deflate.Get().(*zlib.Writer)
+	}
+
+	enc.Reset(w)
+	return enc
+}
+
+// release returns enc to the pool of its coding.
+func (c *Compressor) release(encoding string, enc pooledEncoder) {
+	enc.Reset(io.Discard)
+	if encoding == encodingGzip {
+		c.gzip.Put(enc)
+	} else {
+		c.deflate.Put(enc)
+	}
+}
+
+// negotiateEncoding picks the preferred supported coding from an Accept-Encoding header.
+// Returns an empty string if neither gzip nor deflate is acceptable.
+func negotiateEncoding(header string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		if name == "*" {
+			name = encodingGzip
+		}
+
+		if name != encodingGzip && name != encodingDeflate {
+			continue
+		}
+
+		// gzip wins ties as the more widely supported coding.
+		if q > bestQ || (q == bestQ && q > 0 && name == encodingGzip) {
+			best, bestQ = name, q
+		}
+	}
+
+	return best
+}
+
+// compressWriter buffers the start of a response to decide whether to compress it.
+type compressWriter struct {
+	http.ResponseWriter
+	compressor *Compressor
+	encoding   string
+	status     int
+	// buf holds body bytes written before the decision is made
+	buf []byte
+	// decided reports whether the headers have been sent
+	decided bool
+	// enc compresses the body, nil if the response is sent uncompressed
+	enc pooledEncoder
+}
+
+// WriteHeader defers the status until the body size is known.
+// Responses that cannot have a body are passed through immediately.
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.decided {
+		return
+	}
+
+	cw.status = status
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		cw.decide(false)
+	}
+}
+
+// Write buffers p until the threshold is reached, then compresses or passes the body through.
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if cw.decided {
+		if cw.enc != nil {
+			return cw.enc.Write(p)
+		}
+		return cw.ResponseWriter.Write(p)
+	}
+
+	cw.buf = append(cw.buf, p...)
+	if len(cw.buf) < cw.compressor.minSize {
+		return len(p), nil
+	}
+
+	if err := cw.flushBuffer(cw.compressible()); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Close sends a response that stayed below the threshold and finishes compression.
+func (cw *compressWriter) Close() {
+	if !cw.decided {
+		_ = cw.flushBuffer(false)
+	}
+
+	if cw.enc != nil {
+		_ = cw.enc.Close()
+		cw.compressor.release(cw.encoding, cw.enc)
+		cw.enc = nil
+	}
+}
+
+// Unwrap returns the wrapped writer so http.ResponseController can reach it.
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// compressible reports whether the response is in one of the API media types
+// and has not been encoded already.
+func (cw *compressWriter) compressible() bool {
+	header := cw.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	mediaType, _, _ := strings.Cut(header.Get("Content-Type"), ";")
+	mediaType = strings.TrimSpace(mediaType)
+	_, registered := codecs.codecs[mediaType]
+	return registered || strings.HasSuffix(mediaType, "+json")
+}
+
+// decide sends the headers, switching to compression if compress is set.
+func (cw *compressWriter) decide(compress bool) {
+	cw.decided = true
+	if compress {
+		cw.Header().Set("Content-Encoding", cw.encoding)
+		cw.Header().Del("Content-Length")
+		varyETag(cw.Header(), cw.encoding)
+		cw.enc = cw.compressor.encoder(cw.encoding, cw.ResponseWriter)
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.status)
+}
+
+// flushBuffer decides on compression and writes out the buffered body.
+func (cw *compressWriter) flushBuffer(compress bool) error {
+	cw.decide(compress)
+
+	buf := cw.buf
+	cw.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+
+	var err error
+	if cw.enc != nil {
+		_, err = cw.enc.Write(buf)
+	} else {
+		_, err = cw.ResponseWriter.Write(buf)
+	}
+
+	return err
+}
@@ -0,0 +1,172 @@
+package http
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OptionsFromEnv builds server options from environment variables.
+//
+// Environment variables used:
+//   - ADMIN_TOKEN: Bearer token enabling the /admin endpoints (disabled if empty)
+//   - REQUEST_CAPTURE_SIZE: Number of recent requests kept for GET /admin/requests (default: 0, disabled)
+//   - REQUEST_TIMEOUT: Maximum time to handle a request before answering 503 (default: 30s, 0 disables)
+//   - COMPRESSION_MIN_SIZE: Smallest JSON response in bytes that is compressed (default: 1024, -1 disables)
+//   - ACCESS_LOG: Whether every request is logged (default: true)
+//   - ACCESS_LOG_EXCLUDE: Comma-separated paths left out of the access log (default: /readyz,/healthz)
+//   - DRAIN_DELAY: Time to keep serving with failing readiness probes before shutdown (default: 0)
+//   - ATTACHMENT_MAX_SIZE: Largest file in bytes accepted as an attachment (default: 10485760)
+func OptionsFromEnv() []Option {
+	opts := []Option{WithAttachmentMaxSize(getAttachmentMaxSize())}
+
+	if delay := getDrainDelay(); delay > 0 {
+		opts = append(opts, WithDrainDelay(delay))
+	}
+
+	if timeout := getRequestTimeout(); timeout > 0 {
+		opts = append(opts, WithRequestTimeout(timeout))
+	}
+
+	if minSize := getCompressionMinSize(); minSize >= 0 {
+		opts = append(opts, WithCompression(minSize))
+	}
+
+	if getAccessLogEnabled() {
+		opts = append(opts, WithAccessLog(getAccessLogExclude()...))
+	}
+
+	if token := os.Getenv("ADMIN_TOKEN"); token != "" {
+		opts = append(opts, WithAdminToken(token))
+	}
+
+	if size := getRequestCaptureSize(); size > 0 {
+		opts = append(opts, WithRequestCapture(size))
+	}
+
+	return opts
+}
+
+// getRequestCaptureSize reads the REQUEST_CAPTURE_SIZE environment variable.
+// Returns 0 if the environment variable is not set.
+func getRequestCaptureSize() int {
+	sizeStr := os.Getenv("REQUEST_CAPTURE_SIZE")
+	if sizeStr == "" {
+		return 0
+	}
+
+	size, err := strconv.Atoi(sizeStr)
+	if err != nil || size < 0 {
+		panic("REQUEST_CAPTURE_SIZE must be a non-negative integer, got: " + sizeStr)
+	}
+
+	return size
+}
+
+// getAccessLogEnabled reads the ACCESS_LOG environment variable.
+// Returns true if the environment variable is not set.
+func getAccessLogEnabled() bool {
+	enabledStr := os.Getenv("ACCESS_LOG")
+	if enabledStr == "" {
+		return true
+	}
+
+	enabled, err := strconv.ParseBool(enabledStr)
+	if err != nil {
+		panic("ACCESS_LOG must be a boolean, got: " + enabledStr)
+	}
+
+	return enabled
+}
+
+// getAccessLogExclude reads the ACCESS_LOG_EXCLUDE environment variable.
+// Returns the health endpoints if the environment variable is not set.
+func getAccessLogExclude() []string {
+	excludeStr, ok := os.LookupEnv("ACCESS_LOG_EXCLUDE")
+	if !ok {
+		return []string{"/readyz", "/healthz"}
+	}
+
+	var paths []string
+	for _, path := range strings.Split(excludeStr, ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+// defaultCompressionMinSize is the default threshold for response compression.
+const defaultCompressionMinSize = 1024
+
+// getCompressionMinSize reads the COMPRESSION_MIN_SIZE environment variable.
+// Returns 1024 if the environment variable is not set and -1 if compression is disabled.
+func getCompressionMinSize() int {
+	sizeStr := os.Getenv("COMPRESSION_MIN_SIZE")
+	if sizeStr == "" {
+		return defaultCompressionMinSize
+	}
+
+	size, err := strconv.Atoi(sizeStr)
+	if err != nil || size < -1 {
+		panic("COMPRESSION_MIN_SIZE must be a non-negative integer or -1, got: " + sizeStr)
+	}
+
+	return size
+}
+
+// defaultRequestTimeout is the default limit on the handling time of a request.
+const defaultRequestTimeout = 30 * time.Second
+
+// getRequestTimeout reads the REQUEST_TIMEOUT environment variable.
+// Returns 30 seconds if the environment variable is not set.
+func getRequestTimeout() time.Duration {
+	timeoutStr := os.Getenv("REQUEST_TIMEOUT")
+	if timeoutStr == "" {
+		return defaultRequestTimeout
+	}
+
+	timeout, err := time.ParseDuration(timeoutStr)
+	if err != nil || timeout < 0 {
+		panic("REQUEST_TIMEOUT must be a non-negative duration, got: " + timeoutStr)
+	}
+
+	return timeout
+}
+
+// getDrainDelay reads the DRAIN_DELAY environment variable.
+// Returns 0 if the environment variable is not set.
+func getDrainDelay() time.Duration {
+	delayStr := os.Getenv("DRAIN_DELAY")
+	if delayStr == "" {
+		return 0
+	}
+
+	delay, err := time.ParseDuration(delayStr)
+	if err != nil || delay < 0 {
+		panic("DRAIN_DELAY must be a non-negative duration, got: " + delayStr)
+	}
+
+	return delay
+}
+
+// defaultAttachmentMaxSize is the default limit on the size of attached files, 10 MiB.
+const defaultAttachmentMaxSize = 10 << 20
+
+// getAttachmentMaxSize reads the ATTACHMENT_MAX_SIZE environment variable.
+// Returns 10 MiB if the environment variable is not set.
+func getAttachmentMaxSize() int64 {
+	sizeStr := os.Getenv("ATTACHMENT_MAX_SIZE")
+	if sizeStr == "" {
+		return defaultAttachmentMaxSize
+	}
+
+	size, err := strconv.ParseInt(sizeStr, 10, 64)
+	if err != nil || size <= 0 {
+		panic("ATTACHMENT_MAX_SIZE must be a positive integer, got: " + sizeStr)
+	}
+
+	return size
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/pprof"
+)
+
+// DebugServer serves the net/http/pprof profiling endpoints on a separate listener,
+// so they are never exposed on the public API address.
+type DebugServer struct {
+	http *http.Server
+}
+
+// NewDebugServer creates a debug server listening on addr.
+// It serves CPU, heap, goroutine and other runtime profiles under /debug/pprof/.
+func NewDebugServer(addr string) *DebugServer {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /debug/pprof/", pprof.Index)
+	mux.HandleFunc("GET /debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("GET /debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("GET /debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("POST /debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("GET /debug/pprof/trace", pprof.Trace)
+
+	return &DebugServer{
+		http: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+	}
+}
+
+// ListenAndServe starts the debug server. It blocks until the server is shut down.
+func (s *DebugServer) ListenAndServe() error {
+	return s.http.ListenAndServe()
+}
+
+// Shutdown gracefully shuts down the debug server.
+func (s *DebugServer) Shutdown(ctx context.Context) error {
+	return s.http.Shutdown(ctx)
+}
+
+// Addr returns the network address the debug server listens on.
+func (s *DebugServer) Addr() string {
+	return s.http.Addr
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"net/http"
+)
+
+// redacted replaces secret values in GET /debug/config.
+const redacted = "[redacted]"
+
+// ServerConfig describes the effective configuration of the HTTP server.
+type ServerConfig struct {
+	// Addr is the address the server listens on
+	Addr string `json:"addr"`
+	// AdminToken is redacted if set and empty otherwise
+	AdminToken string `json:"admin_token"`
+	// TokenAuth reports whether scoped access tokens are required
+	TokenAuth bool `json:"token_auth"`
+	// SpecValidation reports whether requests are validated against the OpenAPI spec
+	SpecValidation bool `json:"spec_validation"`
+	// RequestTimeout is the handling time limit, "0s" if disabled
+	RequestTimeout string `json:"request_timeout"`
+	// CompressionMinSize is the compression threshold in bytes, -1 if disabled
+	CompressionMinSize int `json:"compression_min_size"`
+	// AccessLog reports whether every request is logged
+	AccessLog bool `json:"access_log"`
+	// AccessLogExclude lists the paths left out of the access log
+	AccessLogExclude []string `json:"access_log_exclude"`
+	// RequestCaptureSize is the number of requests kept for GET /admin/requests
+	RequestCaptureSize int `json:"request_capture_size"`
+	// IdempotencyTTL is how long Idempotency-Key responses are replayed, empty if disabled
+	IdempotencyTTL string `json:"idempotency_ttl,omitempty"`
+	// AttachmentMaxSize is the largest accepted attachment in bytes, zero if attachments are disabled
+	AttachmentMaxSize int64 `json:"attachment_max_size"`
+	// Metrics reports whether GET /metrics is served
+	Metrics bool `json:"metrics"`
+	// DrainDelay is how long the server keeps serving after Shutdown is called
+	DrainDelay string `json:"drain_delay"`
+	// Middlewares is the number of registered custom middlewares
+	Middlewares int `json:"middlewares"`
+}
+
+// config returns the effective server configuration with secrets redacted.
+func (s *Server) config() ServerConfig {
+	config := ServerConfig{
+		Addr:               s.http.Addr,
+		TokenAuth:          s.issuer != nil,
+		SpecValidation:     s.validator != nil,
+		RequestTimeout:     s.requestTimeout.String(),
+		CompressionMinSize: -1,
+		AccessLog:          s.accessLog,
+		AccessLogExclude:   s.accessLogExclude,
+		Metrics:            s.metrics != nil,
+		DrainDelay:         s.drainDelay.String(),
+		Middlewares:        len(s.middlewares),
+	}
+
+	if s.adminToken != "" {
+		config.AdminToken = redacted
+	}
+
+	if s.compressor != nil {
+		config.CompressionMinSize = s.compressor.minSize
+	}
+
+	if s.capture != nil {
+		config.RequestCaptureSize = len(s.capture.entries)
+	}
+
+	if s.attachments != nil {
+		config.AttachmentMaxSize = s.attachmentMaxSize
+	}
+
+	if s.idempotencyStore != nil {
+		config.IdempotencyTTL = s.idempotencyTTL.String()
+	}
+
+	return config
+}
+
+// DebugConfig handles GET /debug/config requests.
+// Returns the server configuration under "server" together with the sections
+// registered with WithConfigSection.
+func (s *Server) DebugConfig(w http.ResponseWriter, _ *http.Request) {
+	response := make(map[string]any, len(s.configSections)+1)
+	for name, section := range s.configSections {
+		if current, ok := section.(func() any); ok {
+			section = current()
+		}
+		response[name] = section
+	}
+	response["server"] = s.config()
+
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,129 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+)
+
+// Precondition errors returned by mutating task handlers.
+var (
+	// ErrPreconditionRequired is returned when a mutating request has no If-Match header.
+	ErrPreconditionRequired = errors.New("missing If-Match header")
+	// ErrPreconditionFailed is returned when If-Match does not match the current task version.
+	ErrPreconditionFailed = errors.New("task was modified, fetch it again and retry")
+)
+
+// representationSeparator precedes the representation names that varyETag appends to an entity tag.
+const representationSeparator = "+"
+
+// taskETag returns a strong entity tag for the JSON representation of task.
+// The tag is the task version, suffixed with a hash of the selected fields for projections,
+// so it changes whenever the task is updated or a different set of fields is selected.
+// Middlewares re-encoding the response add their representation to it, see varyETag.
+func taskETag(task *domain.Task, fields projection) string {
+	tag := strconv.FormatInt(task.Version, 10)
+	if fields != nil {
+		sum := sha256.Sum256([]byte(strings.Join(fields, ",")))
+		tag += "-" + hex.EncodeToString(sum[:4])
+	}
+
+	return `"` + tag + `"`
+}
+
+// varyETag appends representation, such as a media type or content coding, to the strong
+// entity tag in header, if any, for a response whose body is re-encoded into it. A strong tag
+// must differ between representations, as the bodies differ byte for byte.
+func varyETag(header http.Header, representation string) {
+	etag := header.Get("ETag")
+	if len(etag) < 2 || etag[0] != '"' || etag[len(etag)-1] != '"' {
+		return
+	}
+
+	header.Set("ETag", etag[:len(etag)-1]+representationSeparator+representation+`"`)
+}
+
+// etagVersion extracts the task version from a strong entity tag produced by taskETag,
+// in any representation.
+func etagVersion(etag string) (int64, bool) {
+	if len(etag) < 2 || etag[0] != '"' || etag[len(etag)-1] != '"' {
+		return 0, false
+	}
+
+	tag, _, _ := strings.Cut(etag[1:len(etag)-1], representationSeparator)
+	tag, _, _ = strings.Cut(tag, "-")
+	version, err := strconv.ParseInt(tag, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+
+	return version, true
+}
+
+// notModified reports whether the If-None-Match header of r matches etag, as produced
+// by taskETag, and returns the matching tag to send with the 304 response.
+// Entity tags are compared weakly as required by RFC 9110, and regardless of their
+// representation, since every representation of the tag carries the same task;
+// the returned tag is the one of the representation the client holds.
+func notModified(r *http.Request, etag string) (string, bool) {
+	header := r.Header.Get("If-None-Match")
+	if header == "" {
+		return "", false
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return etag, true
+		}
+
+		opaque := strings.TrimPrefix(candidate, "W/")
+		if tag, _, found := strings.Cut(opaque, representationSeparator); found {
+			opaque = tag + `"`
+		}
+		if opaque == etag {
+			return candidate, true
+		}
+	}
+
+	return "", false
+}
+
+// ifMatchVersion returns the task version required by the If-Match header of r.
+// A wildcard yields zero, which matches any existing task. Weak tags never match.
+// Returns ErrPreconditionRequired if the header is missing and ErrPreconditionFailed
+// if it holds no usable entity tag.
+func ifMatchVersion(r *http.Request) (int64, error) {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		return 0, ErrPreconditionRequired
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return 0, nil
+		}
+
+		if version, ok := etagVersion(candidate); ok {
+			return version, nil
+		}
+	}
+
+	return 0, ErrPreconditionFailed
+}
+
+// writePreconditionError maps an If-Match parsing error to its status code.
+func (h *TaskHandler) writePreconditionError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrPreconditionRequired) {
+		h.writeError(w, err, http.StatusPreconditionRequired)
+		return
+	}
+
+	h.writeError(w, ErrPreconditionFailed, http.StatusPreconditionFailed)
+}
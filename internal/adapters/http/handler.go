@@ -3,8 +3,12 @@ package http
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/asp3cto/task-manager/internal/domain"
 	"github.com/asp3cto/task-manager/internal/logger"
@@ -22,22 +26,82 @@ type TaskHandler struct {
 func NewTaskHandler(service ports.TaskService, logger logger.Logger) *TaskHandler {
 	return &TaskHandler{
 		service: service,
-		logger:  logger,
+		logger:  logger.With(slog.String("component", "http")),
 	}
 }
 
 // CreateTaskRequest represents the JSON payload for creating a new task.
 type CreateTaskRequest struct {
+	// ID is the optional client-chosen ID of the task, which makes retrying the creation safe
+	ID string `json:"id" xml:"id"`
 	// Title is the short name or summary of the task
-	Title string `json:"title"`
+	Title string `json:"title" xml:"title"`
 	// Description provides detailed information about the task
-	Description string `json:"description"`
+	Description string `json:"description" xml:"description"`
+	// DueDate is the optional deadline of the task
+	DueDate *time.Time `json:"due_date" xml:"due_date"`
+	// Priority is one of low, medium, high, urgent; empty means medium
+	Priority domain.Priority `json:"priority" xml:"priority"`
+	// ProjectID is the optional project the task belongs to
+	ProjectID string `json:"project_id" xml:"project_id"`
+}
+
+// UpdateTaskRequest represents the JSON payload for partially updating a task.
+// Omitted fields are left unchanged.
+type UpdateTaskRequest struct {
+	// Title is the new short name or summary of the task
+	Title *string `json:"title" xml:"title"`
+	// Description is the new detailed information about the task
+	Description *string `json:"description" xml:"description"`
+	// DueDate is the new deadline of the task
+	DueDate *time.Time `json:"due_date" xml:"due_date"`
+	// Priority is the new priority of the task
+	Priority *domain.Priority `json:"priority" xml:"priority"`
+	// Estimate is the new estimated effort of the task in story points
+	Estimate *int `json:"estimate" xml:"estimate"`
+	// Progress is the new part of the task already done, in percent
+	Progress *int `json:"progress" xml:"progress"`
+	// Tags replaces the tags of the task; an empty list removes them
+	Tags []string `json:"tags" xml:"tags"`
+}
+
+// taskUpdate maps the request to the domain update.
+func (req UpdateTaskRequest) taskUpdate() domain.TaskUpdate {
+	return domain.TaskUpdate{
+		Title:       req.Title,
+		Description: req.Description,
+		DueDate:     req.DueDate,
+		Priority:    req.Priority,
+		Estimate:    req.Estimate,
+		Progress:    req.Progress,
+		Tags:        req.Tags,
+	}
 }
 
 // UpdateTaskStatusRequest represents the JSON payload for updating a task's status.
 type UpdateTaskStatusRequest struct {
 	// Status is the new status to set for the task
-	Status domain.TaskStatus `json:"status"`
+	Status domain.TaskStatus `json:"status" xml:"status"`
+}
+
+// AssignTaskRequest represents the JSON payload for setting a task's assignee.
+type AssignTaskRequest struct {
+	// Assignee is the person responsible for the task; empty unassigns it
+	Assignee string `json:"assignee" xml:"assignee"`
+}
+
+// MoveTaskToProjectRequest represents the JSON payload for moving a task to a project.
+type MoveTaskToProjectRequest struct {
+	// ProjectID is the project the task moves to; empty removes it from its project
+	ProjectID string `json:"project_id" xml:"project_id"`
+}
+
+// MoveTaskRequest represents the JSON payload for placing a task in the manual order.
+type MoveTaskRequest struct {
+	// After is the ID of the task to place the task right after; empty moves it to the start
+	After string `json:"after" xml:"after"`
+	// Before is the ID of the task to place the task right before; empty moves it to the end
+	Before string `json:"before" xml:"before"`
 }
 
 // ErrorResponse represents the JSON format for error responses.
@@ -46,55 +110,316 @@ type ErrorResponse struct {
 	Error string `json:"error"`
 }
 
+// DuplicateTaskResponse represents the JSON format for rejected duplicate tasks.
+type DuplicateTaskResponse struct {
+	// Error contains the error message to return to the client
+	Error string `json:"error"`
+	// ExistingTaskID is the ID of the unfinished task with the same title
+	ExistingTaskID string `json:"existing_task_id"`
+}
+
 // HTTP-specific error messages for consistent API responses.
 var (
 	// ErrInternalServerError is returned when an unexpected server error occurs.
 	ErrInternalServerError = errors.New("internal server error")
 	// ErrInvalidStatus is returned when an invalid status parameter is provided.
 	ErrInvalidStatus = errors.New("invalid status parameter")
+	// ErrInvalidTransition is returned when a task cannot move from its current status to the requested one.
+	ErrInvalidTransition = errors.New("task cannot move from its current status to the requested one")
 	// ErrTaskNotFound is returned when a requested task does not exist.
 	ErrTaskNotFound = errors.New("task not found")
 	// ErrInvalidRequestFormat is returned when the request JSON cannot be parsed.
 	ErrInvalidRequestFormat = errors.New("invalid request format")
 	// ErrTitleRequired is returned when attempting to create a task without a title.
 	ErrTitleRequired = errors.New("title is required")
+	// ErrInvalidDateFilter is returned when a date range parameter is not a valid RFC 3339 timestamp.
+	ErrInvalidDateFilter = errors.New("invalid date filter parameter")
+	// ErrInvalidPriority is returned when an invalid priority parameter or field is provided.
+	ErrInvalidPriority = errors.New("invalid priority parameter")
+	// ErrInvalidOverdue is returned when the overdue parameter is not a boolean.
+	ErrInvalidOverdue = errors.New("invalid overdue parameter")
+	// ErrInvalidIDs is returned when the ids parameter is empty or lists too many IDs.
+	ErrInvalidIDs = errors.New("invalid ids parameter")
+	// ErrInvalidLimit is returned when the page limit is not a number within the allowed range.
+	ErrInvalidLimit = errors.New("invalid limit parameter")
+	// ErrInvalidCursor is returned when the pagination cursor is malformed.
+	ErrInvalidCursor = errors.New("invalid cursor parameter")
+	// ErrInvalidMove is returned when a task cannot be placed between the given tasks.
+	ErrInvalidMove = errors.New("task cannot be placed between the given tasks")
+	// ErrMoveTargetNotFound is returned when a task to place the moved task next to does not exist.
+	ErrMoveTargetNotFound = errors.New("move target task not found")
+	// ErrInvalidEstimate is returned when a task estimate is negative.
+	ErrInvalidEstimate = errors.New("estimate cannot be negative")
+	// ErrInvalidProgress is returned when a task progress is not a percentage between 0 and 100.
+	ErrInvalidProgress = errors.New("progress must be between 0 and 100")
+	// ErrInvalidMinProgress is returned when the min_progress parameter is not a percentage between 0 and 100.
+	ErrInvalidMinProgress = errors.New("invalid min_progress parameter")
+	// ErrInvalidForce is returned when the force parameter is not a boolean.
+	ErrInvalidForce = errors.New("invalid force parameter")
+	// ErrTaskInProgress is returned when deleting a task in progress without force=true.
+	ErrTaskInProgress = errors.New("task in progress cannot be deleted without force=true")
+	// ErrInvalidTags is returned when a tag is malformed or a task gets too many tags.
+	ErrInvalidTags = errors.New("tags must be at most 20 non-empty labels of up to 32 characters without commas")
+	// ErrInvalidTaskID is returned when a client-supplied task ID is not a UUID, a ULID or 32 hexadecimal digits.
+	ErrInvalidTaskID = errors.New("task ID must be a lowercase UUID, a ULID or 32 lowercase hexadecimal digits")
+	// ErrTaskExists is returned when a different task already has the client-supplied ID.
+	ErrTaskExists = errors.New("a different task with this ID already exists")
+	// ErrNothingToUndo is returned when undoing a task that has not changed since it was created.
+	ErrNothingToUndo = errors.New("task has no change to undo")
+	// ErrUndoExpired is returned when the last change of a task is older than the undo window.
+	ErrUndoExpired = errors.New("last task change is too old to undo")
+	// ErrCannotUndo is returned when the last change of a task cannot be reverted.
+	ErrCannotUndo = errors.New("last task change cannot be undone")
+)
+
+// Page size bounds for task listings.
+const (
+	defaultPageLimit = 50
+	maxPageLimit     = 1000
 )
 
+// unassignedFilter is the value of the assignee filter that selects unassigned tasks.
+const unassignedFilter = "none"
+
+// maxBatchIDs limits how many tasks can be requested at once with the ids parameter.
+const maxBatchIDs = 100
+
 // GetTasks handles GET /tasks requests to retrieve all tasks.
-// Supports optional status query parameter for filtering tasks by status.
-// Returns a JSON array of tasks or an error response.
+// Supports optional status and priority query parameters (repeated or comma-separated) for filtering tasks,
+// assignee for tasks assigned to someone (or none for unassigned tasks), project_id for the tasks of a project,
+// q for a case-insensitive keyword search in title and description, and
+// created_after/created_before/updated_after/updated_before/due_after/due_before date ranges,
+// and overdue=true for unfinished tasks past their due date.
+// The sort and order query parameters select the order of the tasks, as in a search document.
+// With the ids query parameter it returns only the listed tasks instead.
+// If limit or cursor query parameters are given, the response is a page object
+// with a next_cursor; otherwise it is a plain JSON array of tasks.
+// The fields query parameter limits the serialized task fields.
 func (h *TaskHandler) GetTasks(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	status := r.URL.Query().Get("status")
-	h.logger.Info(ctx, "getting tasks", slog.String("status_filter", status))
+	fields, err := parseProjection(r)
+	if err != nil {
+		h.logger.Warn(ctx, "invalid fields parameter", slog.String("fields", r.URL.Query().Get("fields")))
+		h.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if r.URL.Query().Has("ids") {
+		h.getTasksByIDs(w, r, fields)
+		return
+	}
+
+	filter, err := parseListFilter(r)
+	if err != nil {
+		h.logger.Warn(ctx, "invalid filter parameters", slog.String("error", err.Error()))
+		h.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	h.logger.Info(ctx, "getting tasks", slog.Any("status_filter", filter.Statuses), slog.String("query", filter.Query))
 
-	if status != "" && !domain.IsValidStatus(status) {
-		h.logger.Warn(ctx, "invalid status parameter", slog.String("status", status))
-		h.writeError(w, ErrInvalidStatus, http.StatusBadRequest)
+	page, err := parsePageRequest(r)
+	if err != nil {
+		h.logger.Warn(ctx, "invalid pagination parameters", slog.String("error", err.Error()))
+		h.writeError(w, err, http.StatusBadRequest)
 		return
 	}
 
-	tasks, err := h.service.GetAllTasks(r.Context(), status)
+	sort, err := parseSort(r)
 	if err != nil {
+		h.logger.Warn(ctx, "invalid sort parameters", slog.String("error", err.Error()))
+		h.writeError(w, ErrInvalidSort, http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.GetAllTasks(r.Context(), ports.ListQuery{Filter: filter, Sort: sort, Page: page})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidStatus) {
+			h.logger.Warn(ctx, "invalid status filter", slog.Any("status_filter", filter.Statuses))
+			h.writeError(w, ErrInvalidStatus, http.StatusBadRequest)
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidCursor) {
+			h.logger.Warn(ctx, "invalid pagination cursor")
+			h.writeError(w, ErrInvalidCursor, http.StatusBadRequest)
+			return
+		}
+
 		h.logger.Error(ctx, "failed to get tasks", slog.String("error", err.Error()))
-		h.writeError(w, ErrInternalServerError, http.StatusInternalServerError)
+		h.writeServerError(w, r, err)
 		return
 	}
 
-	h.writeJSONResponse(w, http.StatusOK, tasks)
+	if page.IsZero() {
+		h.writeTasks(w, fields, result.Tasks)
+		return
+	}
+
+	h.writeTaskPage(w, fields, result)
+}
+
+// getTasksByIDs serves GET /tasks?ids=a,b,c requests.
+// Returns the requested tasks that exist, in the requested order.
+func (h *TaskHandler) getTasksByIDs(w http.ResponseWriter, r *http.Request, fields projection) {
+	ctx := r.Context()
+
+	var ids []string
+	for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	h.logger.Info(ctx, "getting tasks by IDs", slog.Int("requested", len(ids)))
+
+	if len(ids) == 0 || len(ids) > maxBatchIDs {
+		h.logger.Warn(ctx, "invalid ids parameter", slog.Int("requested", len(ids)))
+		h.writeError(w, ErrInvalidIDs, http.StatusBadRequest)
+		return
+	}
+
+	tasks, err := h.service.GetTasksByIDs(ctx, ids)
+	if err != nil {
+		h.logger.Error(ctx, "failed to get tasks by IDs", slog.String("error", err.Error()))
+		h.writeServerError(w, r, err)
+		return
+	}
+
+	h.writeTasks(w, fields, tasks)
+}
+
+// parseListFilter reads the status, priority, assignee, project_id, q, date range and min_progress query
+// parameters. Statuses may be repeated and/or comma-separated. Dates must be in RFC 3339 format.
+func parseListFilter(r *http.Request) (ports.ListFilter, error) {
+	query := r.URL.Query()
+	filter := ports.ListFilter{
+		Query:     query.Get("q"),
+		ProjectID: query.Get("project_id"),
+	}
+
+	if assignee := query.Get("assignee"); assignee == unassignedFilter {
+		filter.Unassigned = true
+	} else {
+		filter.Assignee = assignee
+	}
+
+	for _, value := range query["status"] {
+		for _, status := range strings.Split(value, ",") {
+			status = strings.TrimSpace(status)
+			if status == "" {
+				return ports.ListFilter{}, ErrInvalidStatus
+			}
+			filter.Statuses = append(filter.Statuses, domain.TaskStatus(status))
+		}
+	}
+
+	for _, value := range query["priority"] {
+		for _, priority := range strings.Split(value, ",") {
+			priority = strings.TrimSpace(priority)
+			if !domain.IsValidPriority(priority) {
+				return ports.ListFilter{}, ErrInvalidPriority
+			}
+			filter.Priorities = append(filter.Priorities, domain.Priority(priority))
+		}
+	}
+
+	bounds := []struct {
+		param  string
+		target *time.Time
+	}{
+		{"created_after", &filter.CreatedAfter},
+		{"created_before", &filter.CreatedBefore},
+		{"updated_after", &filter.UpdatedAfter},
+		{"updated_before", &filter.UpdatedBefore},
+		{"due_after", &filter.DueAfter},
+		{"due_before", &filter.DueBefore},
+	}
+
+	for _, bound := range bounds {
+		raw := query.Get(bound.param)
+		if raw == "" {
+			continue
+		}
+
+		value, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return ports.ListFilter{}, fmt.Errorf("%w: %s", ErrInvalidDateFilter, bound.param)
+		}
+		*bound.target = value
+	}
+
+	if raw := query.Get("overdue"); raw != "" {
+		overdue, err := strconv.ParseBool(raw)
+		if err != nil {
+			return ports.ListFilter{}, ErrInvalidOverdue
+		}
+		filter.Overdue = overdue
+	}
+
+	if raw := query.Get("min_progress"); raw != "" {
+		minProgress, err := strconv.Atoi(raw)
+		if err != nil || minProgress < 0 || minProgress > domain.MaxProgress {
+			return ports.ListFilter{}, ErrInvalidMinProgress
+		}
+		filter.MinProgress = minProgress
+	}
+
+	return filter, nil
+}
+
+// parseSort reads the sort and order query parameters.
+func parseSort(r *http.Request) (ports.Sort, error) {
+	sort := ports.Sort{
+		Field: ports.SortField(r.URL.Query().Get("sort")),
+		Order: ports.SortOrder(r.URL.Query().Get("order")),
+	}
+	if err := sort.Validate(); err != nil {
+		return ports.Sort{}, err
+	}
+
+	return sort, nil
+}
+
+// parsePageRequest reads the limit and cursor query parameters.
+// If only a cursor is given, the default page size is used.
+func parsePageRequest(r *http.Request) (ports.PageRequest, error) {
+	query := r.URL.Query()
+	page := ports.PageRequest{Cursor: query.Get("cursor")}
+
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 || limit > maxPageLimit {
+			return ports.PageRequest{}, ErrInvalidLimit
+		}
+		page.Limit = limit
+	} else if page.Cursor != "" {
+		page.Limit = defaultPageLimit
+	}
+
+	return page, nil
 }
 
 // GetTask handles GET /tasks/{id} requests to retrieve a specific task by ID.
 // Returns the task as JSON or a 404 error if the task doesn't exist.
+// The fields query parameter limits the serialized task fields.
 func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	fields, err := parseProjection(r)
+	if err != nil {
+		h.logger.Warn(ctx, "invalid fields parameter", slog.String("fields", r.URL.Query().Get("fields")))
+		h.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
 	taskID := r.PathValue("id")
-	h.logger.Info(ctx, "getting task by ID", slog.String("task_id", taskID))
+	log := h.logger.With(slog.String("task_id", taskID))
+	log.Info(ctx, "getting task by ID")
 
 	if taskID == "" {
-		h.logger.Warn(ctx, "empty task ID in request")
+		log.Warn(ctx, "empty task ID in request")
 		h.writeError(w, ErrTaskNotFound, http.StatusNotFound)
 		return
 	}
@@ -102,53 +427,436 @@ func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 	task, err := h.service.GetTaskByID(r.Context(), taskID)
 	if err != nil {
 		if errors.Is(err, domain.ErrTaskNotFound) {
-			h.logger.Warn(ctx, "task not found", slog.String("task_id", taskID))
+			log.Warn(ctx, "task not found")
 			h.writeError(w, ErrTaskNotFound, http.StatusNotFound)
 		} else {
-			h.logger.Error(ctx, "failed to get task", slog.String("task_id", taskID), slog.String("error", err.Error()))
-			h.writeError(w, ErrInternalServerError, http.StatusInternalServerError)
+			log.Error(ctx, "failed to get task", slog.String("error", err.Error()))
+			h.writeServerError(w, r, err)
 		}
 
 		return
 	}
 
-	h.writeJSONResponse(w, http.StatusOK, task)
+	etag := taskETag(task, fields)
+	w.Header().Set("ETag", etag)
+	if match, ok := notModified(r, etag); ok {
+		w.Header().Set("ETag", match)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	projected, err := fields.apply(task)
+	if err != nil {
+		log.Error(ctx, "failed to project task", slog.String("error", err.Error()))
+		h.writeError(w, ErrInternalServerError, http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, projected)
 }
 
 // CreateTask handles POST /tasks requests to create a new task.
-// Expects a JSON payload with title and description fields.
-// Returns the created task with a generated ID and pending status.
+// Expects a JSON payload with title, description and optional id, due_date, priority and project_id fields.
+// Returns the created task with pending status and the supplied or a generated ID, or with 200 the
+// existing task if the request retries the creation of a task with the supplied ID.
 func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	h.logger.Info(ctx, "creating new task")
 
 	var req CreateTaskRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	if err := decodeRequest(r, &req); err != nil {
 		h.logger.Warn(ctx, "invalid request format", slog.String("error", err.Error()))
 		h.writeError(w, ErrInvalidRequestFormat, http.StatusBadRequest)
 		return
 	}
 
 	h.logger.Debug(ctx, "parsed create task request", slog.String("title", req.Title))
-	task, err := h.service.CreateTask(r.Context(), req.Title, req.Description)
+	task, created, err := h.service.CreateTask(
+		r.Context(), req.ID, req.Title, req.Description, req.DueDate, req.Priority, req.ProjectID,
+	)
 	if err != nil {
-		if errors.Is(err, domain.ErrEmptyTitle) {
+		var validationErr *domain.ValidationError
+		var duplicateErr *domain.DuplicateTaskError
+		var quotaErr *domain.QuotaExceededError
+		if errors.As(err, &validationErr) {
+			h.logger.Warn(ctx, "task creation failed: invalid input", slog.Int("violations", len(validationErr.Fields)))
+			writeFieldErrors(w, validationErr)
+		} else if errors.As(err, &duplicateErr) {
+			h.logger.Warn(ctx, "task creation failed: duplicate title",
+				slog.String("existing_task_id", duplicateErr.ExistingID))
+			writeJSON(w, http.StatusConflict, DuplicateTaskResponse{
+				Error:          domain.ErrDuplicateTask.Error(),
+				ExistingTaskID: duplicateErr.ExistingID,
+			})
+		} else if errors.Is(err, domain.ErrEmptyTitle) {
 			h.logger.Warn(ctx, "task creation failed: empty title")
 			h.writeError(w, ErrTitleRequired, http.StatusBadRequest)
+		} else if errors.Is(err, domain.ErrInvalidPriority) {
+			h.logger.Warn(ctx, "task creation failed: invalid priority")
+			h.writeError(w, ErrInvalidPriority, http.StatusBadRequest)
+		} else if errors.Is(err, domain.ErrProjectNotFound) {
+			h.logger.Warn(ctx, "task creation failed: project not found")
+			h.writeError(w, ErrProjectNotFound, http.StatusBadRequest)
+		} else if errors.Is(err, domain.ErrInvalidTaskID) {
+			h.logger.Warn(ctx, "task creation failed: invalid ID")
+			h.writeError(w, ErrInvalidTaskID, http.StatusBadRequest)
+		} else if errors.Is(err, domain.ErrTaskExists) {
+			h.logger.Warn(ctx, "task creation failed: a different task has the ID")
+			h.writeError(w, ErrTaskExists, http.StatusConflict)
+		} else if errors.As(err, &quotaErr) {
+			h.logger.Warn(ctx, "task creation failed: quota exceeded", slog.String("status", string(quotaErr.Status)))
+			h.writeQuotaError(w, quotaErr)
 		} else {
 			h.logger.Error(ctx, "failed to create task", slog.String("error", err.Error()))
-			h.writeError(w, ErrInternalServerError, http.StatusInternalServerError)
+			h.writeServerError(w, r, err)
+		}
+		return
+	}
+
+	status := http.StatusCreated
+	if !created {
+		status = http.StatusOK
+	}
+
+	w.Header().Set("ETag", taskETag(task, nil))
+	h.writeJSONResponse(w, status, task)
+}
+
+// UpdateTask handles PATCH /tasks/{id} requests to edit a task's title, description, due date, priority,
+// estimate, progress and tags.
+// Expects a JSON payload with optional title, description, due_date, priority, estimate, progress and tags
+// fields and an If-Match header carrying the task ETag.
+// Returns the updated task, 412 if the task changed meanwhile, or an error response.
+func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID := r.PathValue("id")
+	log := h.logger.With(slog.String("task_id", taskID))
+	log.Info(ctx, "updating task")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		log.Warn(ctx, "precondition missing or invalid")
+		h.writePreconditionError(w, err)
+		return
+	}
+
+	var req UpdateTaskRequest
+	if err := decodeRequest(r, &req); err != nil {
+		log.Warn(ctx, "invalid request format", slog.String("error", err.Error()))
+		h.writeError(w, ErrInvalidRequestFormat, http.StatusBadRequest)
+		return
+	}
+
+	task, err := h.service.UpdateTask(ctx, taskID, req.taskUpdate(), version)
+	if err != nil {
+		var validationErr *domain.ValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			log.Warn(ctx, "task update failed: invalid input", slog.Int("violations", len(validationErr.Fields)))
+			writeFieldErrors(w, validationErr)
+		case errors.Is(err, domain.ErrTaskNotFound):
+			log.Warn(ctx, "task not found")
+			h.writeError(w, ErrTaskNotFound, http.StatusNotFound)
+		case errors.Is(err, domain.ErrVersionConflict):
+			log.Warn(ctx, "task version conflict")
+			h.writeError(w, ErrPreconditionFailed, http.StatusPreconditionFailed)
+		case errors.Is(err, domain.ErrEmptyTitle):
+			log.Warn(ctx, "task update failed: empty title")
+			h.writeError(w, ErrTitleRequired, http.StatusBadRequest)
+		case errors.Is(err, domain.ErrInvalidPriority):
+			log.Warn(ctx, "task update failed: invalid priority")
+			h.writeError(w, ErrInvalidPriority, http.StatusBadRequest)
+		case errors.Is(err, domain.ErrInvalidEstimate):
+			log.Warn(ctx, "task update failed: invalid estimate")
+			h.writeError(w, ErrInvalidEstimate, http.StatusBadRequest)
+		case errors.Is(err, domain.ErrInvalidProgress):
+			log.Warn(ctx, "task update failed: invalid progress")
+			h.writeError(w, ErrInvalidProgress, http.StatusBadRequest)
+		case errors.Is(err, domain.ErrInvalidTag), errors.Is(err, domain.ErrTooManyTags):
+			log.Warn(ctx, "task update failed: invalid tags")
+			h.writeError(w, ErrInvalidTags, http.StatusBadRequest)
+		default:
+			log.Error(ctx, "failed to update task", slog.String("error", err.Error()))
+			h.writeServerError(w, r, err)
+		}
+
+		return
+	}
+
+	w.Header().Set("ETag", taskETag(task, nil))
+	h.writeJSONResponse(w, http.StatusOK, task)
+}
+
+// UpdateTaskStatus handles PUT /tasks/{id}/status requests to change a task's status.
+// Expects a JSON payload with the new status and an If-Match header carrying the task ETag.
+// Returns the updated task, 409 if the task cannot move to the status, 412 if the task
+// changed meanwhile, or an error response.
+func (h *TaskHandler) UpdateTaskStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID := r.PathValue("id")
+	log := h.logger.With(slog.String("task_id", taskID))
+	log.Info(ctx, "updating task status")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		log.Warn(ctx, "precondition missing or invalid")
+		h.writePreconditionError(w, err)
+		return
+	}
+
+	var req UpdateTaskStatusRequest
+	if err := decodeRequest(r, &req); err != nil {
+		log.Warn(ctx, "invalid request format", slog.String("error", err.Error()))
+		h.writeError(w, ErrInvalidRequestFormat, http.StatusBadRequest)
+		return
+	}
+
+	task, err := h.service.UpdateTaskStatus(ctx, taskID, req.Status, version)
+	if err != nil {
+		var quotaErr *domain.QuotaExceededError
+		switch {
+		case errors.Is(err, domain.ErrTaskNotFound):
+			log.Warn(ctx, "task not found")
+			h.writeError(w, ErrTaskNotFound, http.StatusNotFound)
+		case errors.Is(err, domain.ErrInvalidStatus):
+			log.Warn(ctx, "invalid status value", slog.String("status", string(req.Status)))
+			h.writeError(w, ErrInvalidStatus, http.StatusBadRequest)
+		case errors.Is(err, domain.ErrInvalidTransition):
+			log.Warn(ctx, "invalid status transition", slog.String("status", string(req.Status)))
+			h.writeError(w, ErrInvalidTransition, http.StatusConflict)
+		case errors.As(err, &quotaErr):
+			log.Warn(ctx, "status quota exceeded", slog.String("status", string(req.Status)))
+			h.writeQuotaError(w, quotaErr)
+		case errors.Is(err, domain.ErrVersionConflict):
+			log.Warn(ctx, "task version conflict")
+			h.writeError(w, ErrPreconditionFailed, http.StatusPreconditionFailed)
+		default:
+			log.Error(ctx, "failed to update task status", slog.String("error", err.Error()))
+			h.writeServerError(w, r, err)
+		}
+
+		return
+	}
+
+	w.Header().Set("ETag", taskETag(task, nil))
+	h.writeJSONResponse(w, http.StatusOK, task)
+}
+
+// AssignTask handles PUT /tasks/{id}/assignee requests to change a task's assignee.
+// Expects a JSON payload with the assignee, empty to unassign the task, and an If-Match
+// header carrying the task ETag.
+// Returns the updated task, 412 if the task changed meanwhile, or an error response.
+func (h *TaskHandler) AssignTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID := r.PathValue("id")
+	log := h.logger.With(slog.String("task_id", taskID))
+	log.Info(ctx, "assigning task")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		log.Warn(ctx, "precondition missing or invalid")
+		h.writePreconditionError(w, err)
+		return
+	}
+
+	var req AssignTaskRequest
+	if err := decodeRequest(r, &req); err != nil {
+		log.Warn(ctx, "invalid request format", slog.String("error", err.Error()))
+		h.writeError(w, ErrInvalidRequestFormat, http.StatusBadRequest)
+		return
+	}
+
+	task, err := h.service.AssignTask(ctx, taskID, req.Assignee, version)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTaskNotFound):
+			log.Warn(ctx, "task not found")
+			h.writeError(w, ErrTaskNotFound, http.StatusNotFound)
+		case errors.Is(err, domain.ErrVersionConflict):
+			log.Warn(ctx, "task version conflict")
+			h.writeError(w, ErrPreconditionFailed, http.StatusPreconditionFailed)
+		default:
+			log.Error(ctx, "failed to assign task", slog.String("error", err.Error()))
+			h.writeServerError(w, r, err)
+		}
+
+		return
+	}
+
+	w.Header().Set("ETag", taskETag(task, nil))
+	h.writeJSONResponse(w, http.StatusOK, task)
+}
+
+// MoveTaskToProject handles PUT /tasks/{id}/project requests to move a task to another project.
+// Expects a JSON payload with the project_id, empty to remove the task from its project,
+// and an If-Match header carrying the task ETag.
+// Returns the updated task, 412 if the task changed meanwhile, or an error response.
+func (h *TaskHandler) MoveTaskToProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID := r.PathValue("id")
+	log := h.logger.With(slog.String("task_id", taskID))
+	log.Info(ctx, "moving task to project")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		log.Warn(ctx, "precondition missing or invalid")
+		h.writePreconditionError(w, err)
+		return
+	}
+
+	var req MoveTaskToProjectRequest
+	if err := decodeRequest(r, &req); err != nil {
+		log.Warn(ctx, "invalid request format", slog.String("error", err.Error()))
+		h.writeError(w, ErrInvalidRequestFormat, http.StatusBadRequest)
+		return
+	}
+
+	task, err := h.service.MoveTaskToProject(ctx, taskID, req.ProjectID, version)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTaskNotFound):
+			log.Warn(ctx, "task not found")
+			h.writeError(w, ErrTaskNotFound, http.StatusNotFound)
+		case errors.Is(err, domain.ErrProjectNotFound):
+			log.Warn(ctx, "project not found", slog.String("project_id", req.ProjectID))
+			h.writeError(w, ErrProjectNotFound, http.StatusBadRequest)
+		case errors.Is(err, domain.ErrVersionConflict):
+			log.Warn(ctx, "task version conflict")
+			h.writeError(w, ErrPreconditionFailed, http.StatusPreconditionFailed)
+		default:
+			log.Error(ctx, "failed to move task to project", slog.String("error", err.Error()))
+			h.writeServerError(w, r, err)
+		}
+
+		return
+	}
+
+	w.Header().Set("ETag", taskETag(task, nil))
+	h.writeJSONResponse(w, http.StatusOK, task)
+}
+
+// MoveTask handles POST /tasks/{id}/move requests to place a task in the manual order
+// between two other tasks, as when it is dragged on a board.
+// Expects a JSON payload with the after and/or before task IDs and an If-Match header
+// carrying the task ETag.
+// Returns the updated task with its new rank, 412 if the task changed meanwhile, or an error response.
+func (h *TaskHandler) MoveTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID := r.PathValue("id")
+	log := h.logger.With(slog.String("task_id", taskID))
+	log.Info(ctx, "moving task")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		log.Warn(ctx, "precondition missing or invalid")
+		h.writePreconditionError(w, err)
+		return
+	}
+
+	var req MoveTaskRequest
+	if err := decodeRequest(r, &req); err != nil {
+		log.Warn(ctx, "invalid request format", slog.String("error", err.Error()))
+		h.writeError(w, ErrInvalidRequestFormat, http.StatusBadRequest)
+		return
+	}
+
+	task, err := h.service.MoveTask(ctx, taskID, req.After, req.Before, version)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTaskNotFound):
+			log.Warn(ctx, "task not found")
+			h.writeError(w, ErrTaskNotFound, http.StatusNotFound)
+		case errors.Is(err, domain.ErrMoveTargetNotFound):
+			log.Warn(ctx, "move target task not found")
+			h.writeError(w, ErrMoveTargetNotFound, http.StatusBadRequest)
+		case errors.Is(err, domain.ErrInvalidMove):
+			log.Warn(ctx, "invalid move", slog.String("after", req.After), slog.String("before", req.Before))
+			h.writeError(w, ErrInvalidMove, http.StatusBadRequest)
+		case errors.Is(err, domain.ErrVersionConflict):
+			log.Warn(ctx, "task version conflict")
+			h.writeError(w, ErrPreconditionFailed, http.StatusPreconditionFailed)
+		default:
+			log.Error(ctx, "failed to move task", slog.String("error", err.Error()))
+			h.writeServerError(w, r, err)
 		}
+
 		return
 	}
 
-	h.writeJSONResponse(w, http.StatusCreated, task)
+	w.Header().Set("ETag", taskETag(task, nil))
+	h.writeJSONResponse(w, http.StatusOK, task)
+}
+
+// DeleteTask handles DELETE /tasks/{id} requests to remove a task.
+// Requires an If-Match header carrying the task ETag; a task in progress is deleted
+// only with the force=true query parameter.
+// Returns 204 No Content on success, 409 if the task is in progress, 412 if the task
+// changed meanwhile, or a 404 error if the task doesn't exist.
+func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID := r.PathValue("id")
+	log := h.logger.With(slog.String("task_id", taskID))
+	log.Info(ctx, "deleting task")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		log.Warn(ctx, "precondition missing or invalid")
+		h.writePreconditionError(w, err)
+		return
+	}
+
+	force := false
+	if raw := r.URL.Query().Get("force"); raw != "" {
+		if force, err = strconv.ParseBool(raw); err != nil {
+			log.Warn(ctx, "invalid force parameter", slog.String("force", raw))
+			h.writeError(w, ErrInvalidForce, http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := h.service.DeleteTask(ctx, taskID, force, version); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTaskNotFound):
+			log.Warn(ctx, "task not found")
+			h.writeError(w, ErrTaskNotFound, http.StatusNotFound)
+		case errors.Is(err, domain.ErrTaskInProgress):
+			log.Warn(ctx, "task deletion rejected: task in progress")
+			h.writeError(w, ErrTaskInProgress, http.StatusConflict)
+		case errors.Is(err, domain.ErrVersionConflict):
+			log.Warn(ctx, "task version conflict")
+			h.writeError(w, ErrPreconditionFailed, http.StatusPreconditionFailed)
+		default:
+			log.Error(ctx, "failed to delete task", slog.String("error", err.Error()))
+			h.writeServerError(w, r, err)
+		}
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeQuotaError responds to an exhausted task quota: with 429 if no more tasks are allowed
+// at all, or with 409 if the quota of a status, such as a work-in-progress limit, is reached.
+func (h *TaskHandler) writeQuotaError(w http.ResponseWriter, err *domain.QuotaExceededError) {
+	statusCode := http.StatusConflict
+	if err.Status == "" {
+		statusCode = http.StatusTooManyRequests
+	}
+	h.writeError(w, err, statusCode)
 }
 
 // writeError writes an error response in JSON format with the specified status code.
 // The err parameter can be a string, error, or any other type (converted to string).
 func (h *TaskHandler) writeError(w http.ResponseWriter, err any, statusCode int) {
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 
 	var errorMsg string
@@ -164,13 +872,28 @@ func (h *TaskHandler) writeError(w http.ResponseWriter, err any, statusCode int)
 	_ = json.NewEncoder(w).Encode(ErrorResponse{Error: errorMsg})
 }
 
+// writeServerError writes a 503 Service Unavailable problem document if the
+// repository is temporarily unavailable, and a 500 Internal Server Error otherwise.
+func (h *TaskHandler) writeServerError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, ports.ErrRepositoryUnavailable) {
+		writeProblem(w, r, http.StatusServiceUnavailable, ports.ErrRepositoryUnavailable.Error())
+		return
+	}
+
+	h.writeError(w, ErrInternalServerError, http.StatusInternalServerError)
+}
+
 // writeJSONResponse writes a successful JSON response with the specified status code.
 // Sets the appropriate Content-Type header and encodes the data as JSON.
 func (h *TaskHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	writeJSON(w, statusCode, data)
+}
+
+// writeJSON sets the JSON Content-Type header, writes the status code and encodes data.
+// It is shared by all handlers in the package.
+func writeJSON(w http.ResponseWriter, statusCode int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 
-	if err := json.NewEncoder(w).Encode(data); err != nil {
-		h.writeError(w, ErrInternalServerError, http.StatusInternalServerError)
-	}
+	_ = json.NewEncoder(w).Encode(data)
 }
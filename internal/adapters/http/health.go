@@ -0,0 +1,148 @@
+package http
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// healthCheckTimeout bounds how long a single dependency check may take.
+const healthCheckTimeout = 2 * time.Second
+
+// Health statuses reported by the health endpoints.
+const (
+	healthStatusOK          = "ok"
+	healthStatusUnavailable = "unavailable"
+	healthStatusDraining    = "draining"
+)
+
+// LivenessResponse represents the JSON format of the liveness endpoint.
+type LivenessResponse struct {
+	// Status is always "ok"
+	Status string `json:"status"`
+}
+
+// ReadinessResponse represents the JSON format of the readiness endpoint.
+type ReadinessResponse struct {
+	// Status is "ok" when every dependency is healthy, "draining" while the server
+	// is shutting down and "unavailable" otherwise
+	Status string `json:"status"`
+	// Checks contains the result of every registered dependency check
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// CheckResult represents the outcome of a single dependency check.
+type CheckResult struct {
+	// Status is "ok" or "unavailable"
+	Status string `json:"status"`
+	// LatencyMS is how long the check took in milliseconds
+	LatencyMS float64 `json:"latency_ms"`
+	// Error describes the failure if the dependency is unavailable
+	Error string `json:"error,omitempty"`
+}
+
+// HealthHandler serves health probe endpoints for orchestrators.
+type HealthHandler struct {
+	checkers map[string]ports.HealthChecker
+	logger   logger.Logger
+	// draining is set once the server starts shutting down
+	draining atomic.Bool
+}
+
+// NewHealthHandler creates a health handler aggregating the given dependency checkers.
+func NewHealthHandler(checkers map[string]ports.HealthChecker, logger logger.Logger) *HealthHandler {
+	return &HealthHandler{
+		checkers: checkers,
+		logger:   logger,
+	}
+}
+
+// SetDraining marks the instance as shutting down, so readiness probes fail
+// and load balancers stop routing new requests to it.
+func (h *HealthHandler) SetDraining() {
+	h.draining.Store(true)
+}
+
+// Live handles GET /healthz requests.
+// It always returns 200 while the process is able to serve requests.
+func (h *HealthHandler) Live(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, LivenessResponse{Status: healthStatusOK})
+}
+
+// Ready handles GET /readyz requests.
+// It pings every registered dependency concurrently and returns 200 if all of them
+// are healthy or 503 with per-dependency statuses and latencies otherwise.
+// While the server is draining it returns 503 without probing the dependencies.
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.draining.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, ReadinessResponse{
+			Status: healthStatusDraining,
+			Checks: map[string]CheckResult{},
+		})
+		return
+	}
+
+	response := ReadinessResponse{
+		Status: healthStatusOK,
+		Checks: make(map[string]CheckResult, len(h.checkers)),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	for name, checker := range h.checkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			result := runCheck(ctx, checker)
+
+			mu.Lock()
+			response.Checks[name] = result
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	statusCode := http.StatusOK
+	for name, result := range response.Checks {
+		if result.Status != healthStatusOK {
+			h.logger.Warn(ctx, "dependency is unavailable", slog.String("dependency", name), slog.String("error", result.Error))
+			response.Status = healthStatusUnavailable
+			statusCode = http.StatusServiceUnavailable
+		}
+	}
+
+	// The instance may be restarted after failing probes, so make sure the reason is written first.
+	if statusCode != http.StatusOK {
+		_ = h.logger.Flush(ctx)
+	}
+
+	writeJSON(w, statusCode, response)
+}
+
+// runCheck pings a single dependency and measures the latency.
+func runCheck(ctx context.Context, checker ports.HealthChecker) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := checker.Ping(ctx)
+	latency := float64(time.Since(start)) / float64(time.Millisecond)
+
+	if err != nil {
+		return CheckResult{Status: healthStatusUnavailable, LatencyMS: latency, Error: err.Error()}
+	}
+
+	return CheckResult{Status: healthStatusOK, LatencyMS: latency}
+}
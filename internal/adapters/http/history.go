@@ -0,0 +1,85 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+)
+
+// GetTaskHistory handles GET /tasks/{id}/history requests to list the changes of a task, oldest first.
+func (h *TaskHandler) GetTaskHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID := r.PathValue("id")
+	log := h.logger.With(slog.String("task_id", taskID))
+	log.Info(ctx, "getting task history")
+
+	entries, err := h.service.GetTaskHistory(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Warn(ctx, "task not found")
+			h.writeError(w, ErrTaskNotFound, http.StatusNotFound)
+		} else {
+			log.Error(ctx, "failed to get task history", slog.String("error", err.Error()))
+			h.writeServerError(w, r, err)
+		}
+
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, entries)
+}
+
+// UndoTask handles POST /tasks/{id}/undo requests to revert the most recent change of a task.
+// Expects an If-Match header carrying the task ETag.
+// Returns the restored task, 409 if the last change cannot be undone or the restored status
+// is full, 412 if the task changed meanwhile, or an error response.
+func (h *TaskHandler) UndoTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID := r.PathValue("id")
+	log := h.logger.With(slog.String("task_id", taskID))
+	log.Info(ctx, "undoing last task change")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		log.Warn(ctx, "precondition missing or invalid")
+		h.writePreconditionError(w, err)
+		return
+	}
+
+	task, err := h.service.UndoTask(ctx, taskID, version)
+	if err != nil {
+		var quotaErr *domain.QuotaExceededError
+		switch {
+		case errors.Is(err, domain.ErrTaskNotFound):
+			log.Warn(ctx, "task not found")
+			h.writeError(w, ErrTaskNotFound, http.StatusNotFound)
+		case errors.Is(err, domain.ErrNothingToUndo):
+			log.Warn(ctx, "task has no change to undo")
+			h.writeError(w, ErrNothingToUndo, http.StatusConflict)
+		case errors.Is(err, domain.ErrUndoExpired):
+			log.Warn(ctx, "last task change is too old to undo")
+			h.writeError(w, ErrUndoExpired, http.StatusConflict)
+		case errors.Is(err, domain.ErrCannotUndo):
+			log.Warn(ctx, "last task change cannot be undone")
+			h.writeError(w, ErrCannotUndo, http.StatusConflict)
+		case errors.As(err, &quotaErr):
+			log.Warn(ctx, "status quota exceeded", slog.String("status", string(quotaErr.Status)))
+			h.writeQuotaError(w, quotaErr)
+		case errors.Is(err, domain.ErrVersionConflict):
+			log.Warn(ctx, "task version conflict")
+			h.writeError(w, ErrPreconditionFailed, http.StatusPreconditionFailed)
+		default:
+			log.Error(ctx, "failed to undo task change", slog.String("error", err.Error()))
+			h.writeServerError(w, r, err)
+		}
+
+		return
+	}
+
+	w.Header().Set("ETag", taskETag(task, nil))
+	h.writeJSONResponse(w, http.StatusOK, task)
+}
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// maxIdempotencyKeyLength limits the size of the Idempotency-Key header.
+const maxIdempotencyKeyLength = 255
+
+// Idempotency errors returned to clients.
+var (
+	// ErrInvalidIdempotencyKey is returned when the Idempotency-Key header is too long.
+	ErrInvalidIdempotencyKey = errors.New("invalid Idempotency-Key header")
+	// ErrIdempotencyKeyInUse is returned when a request with the same key is still in progress.
+	ErrIdempotencyKeyInUse = errors.New("a request with this Idempotency-Key is in progress")
+	// ErrIdempotencyKeyReused is returned when a key is reused for a different request.
+	ErrIdempotencyKeyReused = errors.New("idempotency key was used for a different request")
+)
+
+// replayedHeaders are the response headers recorded for replay.
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// idempotency replays recorded responses for requests retried with the same Idempotency-Key.
+type idempotency struct {
+	// store keeps recorded responses, nil disables idempotency keys
+	store  ports.IdempotencyStore
+	ttl    time.Duration
+	logger logger.Logger
+}
+
+// wrap makes next idempotent for requests carrying an Idempotency-Key header.
+// Keys are scoped to the caller's bearer token. Responses with a 5xx status are not
+// recorded, so the request can be retried. If idempotency is disabled, next is returned unchanged.
+func (i *idempotency) wrap(next http.HandlerFunc) http.HandlerFunc {
+	if i.store == nil {
+		return next
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		key := r.Header.Get("Idempotency-Key")
+		if key == "" {
+			next(w, r)
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: ErrInvalidIdempotencyKey.Error()})
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: ErrInvalidRequestFormat.Error()})
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		token, _ := bearerToken(r)
+		scopedKey := hashParts(token, key)
+
+		recorded, err := i.store.Reserve(ctx, scopedKey, hashParts(r.Method, r.URL.Path, string(body)), i.ttl)
+		switch {
+		case errors.Is(err, ports.ErrIdempotencyKeyInUse):
+			writeJSON(w, http.StatusConflict, ErrorResponse{Error: ErrIdempotencyKeyInUse.Error()})
+			return
+		case errors.Is(err, ports.ErrIdempotencyKeyMismatch):
+			i.logger.Warn(ctx, "idempotency key reused with a different request", slog.String("path", r.URL.Path))
+			writeJSON(w, http.StatusUnprocessableEntity, ErrorResponse{Error: ErrIdempotencyKeyReused.Error()})
+			return
+		case err != nil:
+			i.logger.Error(ctx, "failed to reserve idempotency key", slog.String("error", err.Error()))
+			writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: ErrInternalServerError.Error()})
+			return
+		case recorded != nil:
+			i.logger.Info(ctx, "replaying idempotent response", slog.String("path", r.URL.Path))
+			replay(w, recorded)
+			return
+		}
+
+		rec := &bufferedResponse{responseRecorder: newResponseRecorder(w)}
+		next(rec, r)
+
+		if rec.status >= http.StatusInternalServerError {
+			err = i.store.Release(ctx, scopedKey)
+		} else {
+			err = i.store.Complete(ctx, scopedKey, rec.response())
+		}
+
+		if err != nil {
+			i.logger.Error(ctx, "failed to record idempotent response", slog.String("error", err.Error()))
+		}
+	}
+}
+
+// replay writes a recorded response, marking it with the Idempotent-Replayed header.
+func replay(w http.ResponseWriter, response *ports.IdempotentResponse) {
+	for name, values := range response.Header {
+		w.Header()[name] = values
+	}
+
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(response.StatusCode)
+	_, _ = w.Write(response.Body)
+}
+
+// bufferedResponse records the status and a copy of the body written by the downstream handler.
+type bufferedResponse struct {
+	*responseRecorder
+	body bytes.Buffer
+}
+
+// Write copies p into the buffer and forwards it to the client.
+func (b *bufferedResponse) Write(p []byte) (int, error) {
+	b.body.Write(p)
+	return b.responseRecorder.Write(p)
+}
+
+// response returns the recorded response for replay.
+func (b *bufferedResponse) response() ports.IdempotentResponse {
+	header := make(map[string][]string)
+	for _, name := range replayedHeaders {
+		if values := b.Header().Values(name); len(values) > 0 {
+			header[http.CanonicalHeaderKey(name)] = values
+		}
+	}
+
+	return ports.IdempotentResponse{
+		StatusCode: b.status,
+		Header:     header,
+		Body:       bytes.Clone(b.body.Bytes()),
+	}
+}
+
+// hashParts returns a hex SHA-256 digest of the length-prefixed parts.
+func hashParts(parts ...string) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		hash.Write(binary.BigEndian.AppendUint32(nil, uint32(len(part))))
+		hash.Write([]byte(part))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
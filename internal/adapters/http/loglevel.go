@@ -0,0 +1,75 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/asp3cto/task-manager/internal/logger"
+)
+
+// ErrInvalidLogLevel is returned when the requested log level is not supported.
+var ErrInvalidLogLevel = errors.New("level must be one of DEBUG, INFO, WARN, ERROR")
+
+// LogLevelRequest represents the JSON payload of PUT /debug/loglevel.
+type LogLevelRequest struct {
+	// Level is the new minimum log level: DEBUG, INFO, WARN or ERROR
+	Level string `json:"level" xml:"level"`
+}
+
+// LogLevelResponse represents the JSON format of the log level endpoints.
+type LogLevelResponse struct {
+	// Level is the current minimum log level
+	Level string `json:"level"`
+}
+
+// LogLevelHandler reads and changes the minimum log level at runtime.
+type LogLevelHandler struct {
+	controller logger.LevelController
+	logger     logger.Logger
+}
+
+// NewLogLevelHandler creates a handler changing the level of controller.
+func NewLogLevelHandler(controller logger.LevelController, logger logger.Logger) *LogLevelHandler {
+	return &LogLevelHandler{
+		controller: controller,
+		logger:     logger,
+	}
+}
+
+// Get handles GET /debug/loglevel requests.
+func (h *LogLevelHandler) Get(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, LogLevelResponse{Level: h.controller.Level().String()})
+}
+
+// Set handles PUT /debug/loglevel requests.
+// The change is not persisted: a restart or SIGHUP restores LOG_LEVEL.
+func (h *LogLevelHandler) Set(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req LogLevelRequest
+	if err := decodeRequest(r, &req); err != nil {
+		h.logger.Warn(ctx, "invalid request format", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: ErrInvalidRequestFormat.Error()})
+		return
+	}
+
+	level, ok := logger.ParseLevel(req.Level)
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: ErrInvalidLogLevel.Error()})
+		return
+	}
+
+	previous := h.controller.Level()
+	h.controller.SetLevel(level)
+
+	// Logged at WARN so the change is recorded at any level.
+	h.logger.Warn(
+		ctx,
+		"log level changed",
+		slog.Bool("audit", true),
+		slog.String("old_level", previous.String()), slog.String("new_level", level.String()),
+	)
+
+	writeJSON(w, http.StatusOK, LogLevelResponse{Level: level.String()})
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// unmatchedRoute labels requests that did not match any route.
+const unmatchedRoute = "unmatched"
+
+// Metrics records Prometheus metrics for every HTTP request.
+type Metrics struct {
+	gatherer prometheus.Gatherer
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+// NewMetrics registers the HTTP request metrics with registry.
+func NewMetrics(registry *prometheus.Registry) *Metrics {
+	m := &Metrics{
+		gatherer: registry,
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Number of HTTP requests by method, route and status code.",
+		}, []string{"method", "route", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Duration of HTTP requests by method and route.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+	}
+
+	registry.MustRegister(m.requests, m.duration)
+	return m
+}
+
+// Handler serves the registered metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.gatherer, promhttp.HandlerOpts{})
+}
+
+// Middleware counts requests and observes their duration, labelled by the
+// route pattern matched by the mux rather than the raw path.
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		route := &matchedRoute{}
+		recorder := newResponseRecorder(w)
+
+		next.ServeHTTP(recorder, r.WithContext(context.WithValue(r.Context(), matchedRouteKey{}, route)))
+
+		label := route.pattern
+		if label == "" {
+			label = unmatchedRoute
+		}
+
+		m.requests.WithLabelValues(r.Method, label, strconv.Itoa(recorder.status)).Inc()
+		m.duration.WithLabelValues(r.Method, label).Observe(time.Since(start).Seconds())
+	})
+}
+
+// matchedRouteKey is the context key of the *matchedRoute of a request.
+type matchedRouteKey struct{}
+
+// matchedRoute carries the route pattern matched by the mux back to outer middlewares.
+type matchedRoute struct {
+	pattern string
+}
+
+// recordRoute wraps mux so the path of the matched pattern is stored in the
+// request's matchedRoute, if any.
+func recordRoute(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.ServeHTTP(w, r)
+
+		if route, ok := r.Context().Value(matchedRouteKey{}).(*matchedRoute); ok && r.Pattern != "" {
+			_, path, found := strings.Cut(r.Pattern, " ")
+			if !found {
+				path = r.Pattern
+			}
+			route.pattern = path
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"net/http"
+)
+
+// Middleware wraps an http.Handler with cross-cutting behavior such as
+// recovery, logging, authentication or metrics.
+type Middleware func(http.Handler) http.Handler
+
+// Chain wraps h with middlewares. The first middleware is the outermost,
+// so it sees the request first and the response last.
+func Chain(h http.Handler, middlewares ...Middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		h = middlewares[i](h)
+	}
+
+	return h
+}
+
+// responseRecorder wraps http.ResponseWriter to remember the status code
+// and the number of body bytes written by the downstream handler.
+type responseRecorder struct {
+	http.ResponseWriter
+	// status is the HTTP status code sent to the client
+	status int
+	// bytes is the number of body bytes written
+	bytes int
+	// wroteHeader reports whether WriteHeader has been called
+	wroteHeader bool
+}
+
+// newResponseRecorder wraps w, defaulting the status to 200 OK.
+func newResponseRecorder(w http.ResponseWriter) *responseRecorder {
+	return &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+}
+
+// WriteHeader records the status code and forwards it to the wrapped writer.
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.wroteHeader {
+		return
+	}
+
+	r.status = status
+	r.wroteHeader = true
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write records the number of written bytes and forwards them to the wrapped writer.
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// Unwrap returns the wrapped writer so http.ResponseController can reach it.
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
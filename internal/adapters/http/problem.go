@@ -0,0 +1,37 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// problemContentType is the media type of RFC 9457 problem documents.
+const problemContentType = "application/problem+json"
+
+// Problem is an RFC 9457 problem details document.
+type Problem struct {
+	// Type is a URI reference identifying the problem type
+	Type string `json:"type"`
+	// Title is a short, human-readable summary of the problem type
+	Title string `json:"title"`
+	// Status is the HTTP status code of the response
+	Status int `json:"status"`
+	// Detail explains this occurrence of the problem
+	Detail string `json:"detail,omitempty"`
+	// Instance identifies the request that caused the problem
+	Instance string `json:"instance,omitempty"`
+}
+
+// writeProblem writes a problem document for the request path with the given status.
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, detail string) {
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+	})
+}
@@ -0,0 +1,266 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// Project-specific error messages.
+var (
+	// ErrProjectNotFound is returned when a requested or referenced project does not exist.
+	ErrProjectNotFound = errors.New("project not found")
+	// ErrProjectNameRequired is returned when attempting to create a project without a name.
+	ErrProjectNameRequired = errors.New("project name is required")
+	// ErrProjectNotEmpty is returned when attempting to delete a project that still has tasks.
+	ErrProjectNotEmpty = errors.New("project still has tasks")
+)
+
+// ProjectHandler handles HTTP requests for projects, the lists that group tasks.
+type ProjectHandler struct {
+	service ports.ProjectService
+	logger  logger.Logger
+}
+
+// NewProjectHandler creates a new HTTP handler for project operations.
+func NewProjectHandler(service ports.ProjectService, logger logger.Logger) *ProjectHandler {
+	return &ProjectHandler{
+		service: service,
+		logger:  logger.With(slog.String("component", "http")),
+	}
+}
+
+// CreateProjectRequest represents the JSON payload for creating a project.
+type CreateProjectRequest struct {
+	// Name is the short name of the project
+	Name string `json:"name" xml:"name"`
+	// Description provides detailed information about the project
+	Description string `json:"description" xml:"description"`
+}
+
+// UpdateProjectRequest represents the JSON payload for partially updating a project.
+// Omitted fields are left unchanged.
+type UpdateProjectRequest struct {
+	// Name is the new short name of the project
+	Name *string `json:"name" xml:"name"`
+	// Description is the new detailed information about the project
+	Description *string `json:"description" xml:"description"`
+}
+
+// ListProjects handles GET /projects requests to list all projects, oldest first.
+func (h *ProjectHandler) ListProjects(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.Info(ctx, "listing projects")
+
+	projects, err := h.service.ListProjects(ctx)
+	if err != nil {
+		h.writeProjectError(w, r, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, projects)
+}
+
+// CreateProject handles POST /projects requests to create a project.
+// Expects a JSON payload with the name and optional description of the project.
+// Returns the created project with a generated ID or an error response.
+func (h *ProjectHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.Info(ctx, "creating project")
+
+	var req CreateProjectRequest
+	if err := decodeRequest(r, &req); err != nil {
+		h.logger.Warn(ctx, "invalid request format", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: ErrInvalidRequestFormat.Error()})
+		return
+	}
+
+	project, err := h.service.CreateProject(ctx, req.Name, req.Description)
+	if err != nil {
+		h.writeProjectError(w, r, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, project)
+}
+
+// GetProject handles GET /projects/{id} requests to retrieve a project.
+// Returns the project or a 404 error if it doesn't exist.
+func (h *ProjectHandler) GetProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	projectID := r.PathValue("id")
+	log := h.logger.With(slog.String("project_id", projectID))
+	log.Info(ctx, "getting project")
+
+	project, err := h.service.GetProject(ctx, projectID)
+	if err != nil {
+		h.writeProjectError(w, r, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, project)
+}
+
+// UpdateProject handles PATCH /projects/{id} requests to change the name and/or description of a project.
+// Returns the updated project or an error response.
+func (h *ProjectHandler) UpdateProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	projectID := r.PathValue("id")
+	log := h.logger.With(slog.String("project_id", projectID))
+	log.Info(ctx, "updating project")
+
+	var req UpdateProjectRequest
+	if err := decodeRequest(r, &req); err != nil {
+		log.Warn(ctx, "invalid request format", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: ErrInvalidRequestFormat.Error()})
+		return
+	}
+
+	project, err := h.service.UpdateProject(ctx, projectID, req.Name, req.Description)
+	if err != nil {
+		h.writeProjectError(w, r, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, project)
+}
+
+// DeleteProject handles DELETE /projects/{id} requests to remove a project.
+// Returns 204 No Content on success, 409 if tasks still belong to the project,
+// or a 404 error if the project doesn't exist.
+func (h *ProjectHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	projectID := r.PathValue("id")
+	log := h.logger.With(slog.String("project_id", projectID))
+	log.Info(ctx, "deleting project")
+
+	if err := h.service.DeleteProject(ctx, projectID); err != nil {
+		h.writeProjectError(w, r, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetProjectProgress handles GET /projects/{id}/progress requests to roll up the estimates
+// and the progress of the tasks of a project.
+// Returns the rollup or a 404 error if the project doesn't exist.
+func (h *ProjectHandler) GetProjectProgress(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	projectID := r.PathValue("id")
+	log := h.logger.With(slog.String("project_id", projectID))
+	log.Info(ctx, "getting project progress")
+
+	progress, err := h.service.GetProjectProgress(ctx, projectID)
+	if err != nil {
+		h.writeProjectError(w, r, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, progress)
+}
+
+// ListProjectTasks handles GET /projects/{id}/tasks requests to list the tasks of a project.
+// Accepts the filter, sort, pagination and fields query parameters of GET /tasks;
+// project_id is always the project in the path.
+// If limit or cursor query parameters are given, the response is a page object
+// with a next_cursor; otherwise it is a plain JSON array of tasks.
+func (h *ProjectHandler) ListProjectTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	projectID := r.PathValue("id")
+	log := h.logger.With(slog.String("project_id", projectID))
+	log.Info(ctx, "listing project tasks")
+
+	fields, err := parseProjection(r)
+	if err != nil {
+		log.Warn(ctx, "invalid fields parameter", slog.String("fields", r.URL.Query().Get("fields")))
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	filter, err := parseListFilter(r)
+	if err != nil {
+		log.Warn(ctx, "invalid filter parameters", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	page, err := parsePageRequest(r)
+	if err != nil {
+		log.Warn(ctx, "invalid pagination parameters", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	sort, err := parseSort(r)
+	if err != nil {
+		log.Warn(ctx, "invalid sort parameters", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: ErrInvalidSort.Error()})
+		return
+	}
+
+	result, err := h.service.ListProjectTasks(ctx, projectID, ports.ListQuery{Filter: filter, Sort: sort, Page: page})
+	if err != nil {
+		h.writeProjectError(w, r, log, err)
+		return
+	}
+
+	projected, err := fields.applyAll(result.Tasks)
+	if err != nil {
+		log.Error(ctx, "failed to project tasks", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: ErrInternalServerError.Error()})
+		return
+	}
+
+	if page.IsZero() {
+		writeJSON(w, http.StatusOK, projected)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, projectedPage{Tasks: projected, NextCursor: result.NextCursor})
+}
+
+// writeProjectError maps an error of a project operation to its response.
+func (h *ProjectHandler) writeProjectError(w http.ResponseWriter, r *http.Request, log logger.Logger, err error) {
+	ctx := r.Context()
+
+	var (
+		status  int
+		message error
+	)
+	switch {
+	case errors.Is(err, domain.ErrProjectNotFound):
+		log.Warn(ctx, "project not found")
+		status, message = http.StatusNotFound, ErrProjectNotFound
+	case errors.Is(err, domain.ErrEmptyProjectName):
+		log.Warn(ctx, "project rejected: empty name")
+		status, message = http.StatusBadRequest, ErrProjectNameRequired
+	case errors.Is(err, domain.ErrProjectNotEmpty):
+		log.Warn(ctx, "project deletion rejected: project still has tasks")
+		status, message = http.StatusConflict, ErrProjectNotEmpty
+	case errors.Is(err, domain.ErrInvalidStatus):
+		log.Warn(ctx, "invalid status filter")
+		status, message = http.StatusBadRequest, ErrInvalidStatus
+	case errors.Is(err, domain.ErrInvalidCursor):
+		log.Warn(ctx, "invalid pagination cursor")
+		status, message = http.StatusBadRequest, ErrInvalidCursor
+	case errors.Is(err, ports.ErrRepositoryUnavailable):
+		log.Error(ctx, "project repository unavailable", slog.String("error", err.Error()))
+		writeProblem(w, r, http.StatusServiceUnavailable, ports.ErrRepositoryUnavailable.Error())
+		return
+	default:
+		log.Error(ctx, "failed to handle projects", slog.String("error", err.Error()))
+		status, message = http.StatusInternalServerError, ErrInternalServerError
+	}
+
+	writeJSON(w, status, ErrorResponse{Error: message.Error()})
+}
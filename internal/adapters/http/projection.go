@@ -0,0 +1,141 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// ErrInvalidFields is returned when the fields parameter names an unknown task field.
+var ErrInvalidFields = errors.New("invalid fields parameter")
+
+// taskFields is the set of JSON field names of domain.Task that can be selected,
+// including subtask_summary, which domain.Task.MarshalJSON derives from the subtasks.
+var taskFields = func() map[string]struct{} {
+	names := jsonFieldNames(reflect.TypeOf(domain.Task{}))
+	names["subtask_summary"] = struct{}{}
+	return names
+}()
+
+// projection selects which task fields are serialized in a response.
+// A nil projection serializes tasks unchanged.
+type projection []string
+
+// parseProjection reads the comma-separated fields query parameter.
+// Returns a nil projection if the parameter is absent.
+func parseProjection(r *http.Request) (projection, error) {
+	raw := r.URL.Query().Get("fields")
+	if raw == "" {
+		return nil, nil
+	}
+
+	var fields projection
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if _, ok := taskFields[field]; !ok {
+			return nil, ErrInvalidFields
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// apply returns a serializable representation of task limited to the selected fields.
+func (p projection) apply(task *domain.Task) (any, error) {
+	if p == nil {
+		return task, nil
+	}
+
+	encoded, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &all); err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]json.RawMessage, len(p))
+	for _, field := range p {
+		if value, ok := all[field]; ok {
+			selected[field] = value
+		}
+	}
+
+	return selected, nil
+}
+
+// applyAll projects every task in the list.
+func (p projection) applyAll(tasks []*domain.Task) (any, error) {
+	if p == nil {
+		return tasks, nil
+	}
+
+	result := make([]any, 0, len(tasks))
+	for _, task := range tasks {
+		projected, err := p.apply(task)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, projected)
+	}
+
+	return result, nil
+}
+
+// jsonFieldNames collects the JSON names of the exported fields of a struct type.
+func jsonFieldNames(t reflect.Type) map[string]struct{} {
+	names := make(map[string]struct{}, t.NumField())
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		names[name] = struct{}{}
+	}
+
+	return names
+}
+
+// projectedPage is a TaskPage whose tasks have been projected.
+type projectedPage struct {
+	Tasks      any    `json:"tasks"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// writeTasks writes a JSON array of tasks limited to the selected fields.
+func (h *TaskHandler) writeTasks(w http.ResponseWriter, fields projection, tasks []*domain.Task) {
+	projected, err := fields.applyAll(tasks)
+	if err != nil {
+		h.writeError(w, ErrInternalServerError, http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, projected)
+}
+
+// writeTaskPage writes a page of tasks limited to the selected fields.
+func (h *TaskHandler) writeTaskPage(w http.ResponseWriter, fields projection, page ports.TaskPage) {
+	projected, err := fields.applyAll(page.Tasks)
+	if err != nil {
+		h.writeError(w, ErrInternalServerError, http.StatusInternalServerError)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, projectedPage{Tasks: projected, NextCursor: page.NextCursor})
+}
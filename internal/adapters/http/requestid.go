@@ -0,0 +1,96 @@
+package http
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/asp3cto/task-manager/internal/logger"
+)
+
+// requestIDHeader carries the request ID between clients, proxies and the server.
+const requestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds the length of a request ID accepted from clients.
+const maxRequestIDLength = 128
+
+// requestID attaches a request ID and the W3C trace context to the request context,
+// so every entry logged while handling the request can be correlated and the spans
+// started while handling it join the trace of the caller.
+// The X-Request-ID header is reused if it is valid and generated otherwise,
+// and echoed in the response.
+func requestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+
+		w.Header().Set(requestIDHeader, id)
+		ctx := logger.WithRequestID(r.Context(), id)
+
+		if traceID, spanID, ok := parseTraceparent(r.Header.Get("traceparent")); ok {
+			ctx = logger.WithTrace(ctx, traceID, spanID)
+			ctx = propagation.TraceContext{}.Extract(ctx, propagation.HeaderCarrier(r.Header))
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// validRequestID reports whether id is a non-empty, bounded string of printable ASCII characters.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newRequestID generates a random 16-byte request ID encoded as hex.
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// parseTraceparent extracts the trace and parent span IDs from a W3C traceparent header
+// ("00-<32 hex trace ID>-<16 hex span ID>-<2 hex flags>").
+func parseTraceparent(header string) (string, string, bool) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return "", "", false
+	}
+
+	traceID, spanID := parts[1], parts[2]
+	if !isLowerHex(traceID, 32) || !isLowerHex(spanID, 16) ||
+		strings.Trim(traceID, "0") == "" || strings.Trim(spanID, "0") == "" {
+		return "", "", false
+	}
+
+	return traceID, spanID, true
+}
+
+// isLowerHex reports whether s consists of exactly n lowercase hex digits.
+func isLowerHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if !(s[i] >= '0' && s[i] <= '9' || s[i] >= 'a' && s[i] <= 'f') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,145 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/asp3cto/task-manager/internal/auth"
+)
+
+// apiV1Prefix is the path prefix of version 1 of the task API.
+const apiV1Prefix = "/api/v1"
+
+// route is a task API endpoint. Its path is relative to the API version prefix.
+type route struct {
+	method  string
+	path    string
+	scope   auth.Scope
+	handler http.HandlerFunc
+}
+
+// resource reports what the {id} path value of the route names, so tokens restricted
+// to specific tasks or projects are matched against it.
+func (rt route) resource() resourceKind {
+	switch {
+	case strings.HasPrefix(rt.path, "/tasks/{id}"):
+		return resourceTask
+	case strings.HasPrefix(rt.path, "/projects/{id}"):
+		return resourceProject
+	default:
+		return resourceNone
+	}
+}
+
+// apiVersion is a set of task routes served under a common path prefix.
+// Each version has its own handlers, so a new version with different DTOs
+// can be mounted next to the existing ones.
+type apiVersion struct {
+	prefix string
+	routes []route
+}
+
+// v1 returns version 1 of the task API.
+func (s *Server) v1(idem *idempotency) apiVersion {
+	v := apiVersion{
+		prefix: apiV1Prefix,
+		routes: []route{
+			{http.MethodGet, "/tasks", auth.ScopeTasksRead, s.handler.GetTasks},
+			{http.MethodGet, "/tasks/{id}", auth.ScopeTasksRead, s.handler.GetTask},
+			{http.MethodPost, "/tasks", auth.ScopeTasksWrite, idem.wrap(s.handler.CreateTask)},
+			{http.MethodPost, "/tasks/search", auth.ScopeTasksRead, s.handler.SearchTasks},
+			{http.MethodPatch, "/tasks/{id}", auth.ScopeTasksWrite, s.handler.UpdateTask},
+			{http.MethodPost, "/tasks/transition", auth.ScopeTasksWrite, s.handler.TransitionTasks},
+			{http.MethodPut, "/tasks/{id}/status", auth.ScopeTasksWrite, s.handler.UpdateTaskStatus},
+			{http.MethodPut, "/tasks/{id}/assignee", auth.ScopeTasksWrite, s.handler.AssignTask},
+			{http.MethodPut, "/tasks/{id}/project", auth.ScopeTasksWrite, s.handler.MoveTaskToProject},
+			{http.MethodPost, "/tasks/{id}/move", auth.ScopeTasksWrite, s.handler.MoveTask},
+			{http.MethodDelete, "/tasks/{id}", auth.ScopeTasksWrite, s.handler.DeleteTask},
+			{http.MethodGet, "/tasks/{id}/subtasks", auth.ScopeTasksRead, s.handler.GetSubtasks},
+			{http.MethodPost, "/tasks/{id}/subtasks", auth.ScopeTasksWrite, s.handler.AddSubtask},
+			{http.MethodPatch, "/tasks/{id}/subtasks/{subtask_id}", auth.ScopeTasksWrite, s.handler.UpdateSubtask},
+			{http.MethodDelete, "/tasks/{id}/subtasks/{subtask_id}", auth.ScopeTasksWrite, s.handler.DeleteSubtask},
+			{http.MethodGet, "/tasks/{id}/history", auth.ScopeTasksRead, s.handler.GetTaskHistory},
+			{http.MethodPost, "/tasks/{id}/undo", auth.ScopeTasksWrite, s.handler.UndoTask},
+			{http.MethodGet, "/statuses", auth.ScopeTasksRead, s.handler.GetStatuses},
+		},
+	}
+
+	if s.comments != nil {
+		v.routes = append(v.routes,
+			route{http.MethodGet, "/tasks/{id}/comments", auth.ScopeTasksRead, s.comments.ListComments},
+			route{http.MethodPost, "/tasks/{id}/comments", auth.ScopeTasksWrite, s.comments.AddComment},
+			route{http.MethodDelete, "/tasks/{id}/comments/{comment_id}", auth.ScopeTasksWrite, s.comments.DeleteComment},
+		)
+	}
+
+	if s.attachments != nil {
+		v.routes = append(v.routes,
+			route{http.MethodPost, "/tasks/{id}/attachments", auth.ScopeTasksWrite, s.attachments.AddAttachment},
+			route{http.MethodGet, "/tasks/{id}/attachments/{attachment_id}", auth.ScopeTasksRead, s.attachments.GetAttachment},
+			route{
+				http.MethodDelete, "/tasks/{id}/attachments/{attachment_id}",
+				auth.ScopeTasksWrite, s.attachments.DeleteAttachment,
+			},
+		)
+	}
+
+	if s.projects != nil {
+		v.routes = append(v.routes,
+			route{http.MethodGet, "/projects", auth.ScopeTasksRead, s.projects.ListProjects},
+			route{http.MethodPost, "/projects", auth.ScopeTasksWrite, s.projects.CreateProject},
+			route{http.MethodGet, "/projects/{id}", auth.ScopeTasksRead, s.projects.GetProject},
+			route{http.MethodPatch, "/projects/{id}", auth.ScopeTasksWrite, s.projects.UpdateProject},
+			route{http.MethodDelete, "/projects/{id}", auth.ScopeTasksWrite, s.projects.DeleteProject},
+			route{http.MethodGet, "/projects/{id}/tasks", auth.ScopeTasksRead, s.projects.ListProjectTasks},
+			route{http.MethodGet, "/projects/{id}/progress", auth.ScopeTasksRead, s.projects.GetProjectProgress},
+		)
+	}
+
+	if s.savedFilters != nil {
+		v.routes = append(v.routes,
+			route{http.MethodGet, "/views", auth.ScopeTasksRead, s.savedFilters.ListSavedFilters},
+			route{http.MethodPost, "/views", auth.ScopeTasksWrite, s.savedFilters.CreateSavedFilter},
+			route{http.MethodGet, "/views/{name}", auth.ScopeTasksRead, s.savedFilters.GetSavedFilter},
+			route{http.MethodPut, "/views/{name}", auth.ScopeTasksWrite, s.savedFilters.UpdateSavedFilter},
+			route{http.MethodDelete, "/views/{name}", auth.ScopeTasksWrite, s.savedFilters.DeleteSavedFilter},
+			route{http.MethodGet, "/views/{name}/tasks", auth.ScopeTasksRead, s.savedFilters.ListSavedFilterTasks},
+		)
+	}
+
+	if s.webhooks != nil {
+		v.routes = append(v.routes,
+			route{http.MethodGet, "/webhooks", auth.ScopeWebhooks, s.webhooks.ListWebhooks},
+			route{http.MethodPost, "/webhooks", auth.ScopeWebhooks, s.webhooks.CreateWebhook},
+			route{http.MethodGet, "/webhooks/{id}", auth.ScopeWebhooks, s.webhooks.GetWebhook},
+			route{http.MethodPatch, "/webhooks/{id}", auth.ScopeWebhooks, s.webhooks.UpdateWebhook},
+			route{http.MethodDelete, "/webhooks/{id}", auth.ScopeWebhooks, s.webhooks.DeleteWebhook},
+		)
+	}
+
+	return v
+}
+
+// mount registers the routes of v under its prefix, each guarded by its scope.
+func (v apiVersion) mount(mux *http.ServeMux, authz *authorizer) {
+	for _, rt := range v.routes {
+		mux.HandleFunc(rt.method+" "+v.prefix+rt.path, authz.require(rt.scope, rt.resource(), rt.handler))
+	}
+}
+
+// mountLegacy registers the routes of v without a prefix, for clients of the
+// unversioned API. Responses are marked deprecated and link to the versioned path.
+func (v apiVersion) mountLegacy(mux *http.ServeMux, authz *authorizer) {
+	for _, rt := range v.routes {
+		mux.HandleFunc(rt.method+" "+rt.path, deprecated(v.prefix, authz.require(rt.scope, rt.resource(), rt.handler)))
+	}
+}
+
+// deprecated adds the Deprecation header and a Link to the successor path under prefix.
+func deprecated(prefix string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", "<"+prefix+r.URL.EscapedPath()+`>; rel="successor-version"`)
+		next(w, r)
+	}
+}
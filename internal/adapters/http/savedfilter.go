@@ -0,0 +1,349 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// Saved filter-specific error messages.
+var (
+	// ErrSavedFilterNotFound is returned when a requested saved filter does not exist.
+	ErrSavedFilterNotFound = errors.New("saved filter not found")
+	// ErrSavedFilterExists is returned when saving a filter under a name that is already taken.
+	ErrSavedFilterExists = errors.New("saved filter already exists")
+	// ErrInvalidFilterName is returned when a saved filter name is not a lowercase identifier.
+	ErrInvalidFilterName = errors.New(
+		"invalid saved filter name: use 1 to 64 lowercase letters, digits, hyphens and underscores",
+	)
+)
+
+// SavedFilterHandler handles HTTP requests for saved filters, the named views over tasks.
+type SavedFilterHandler struct {
+	service ports.SavedFilterService
+	logger  logger.Logger
+}
+
+// NewSavedFilterHandler creates a new HTTP handler for saved filter operations.
+func NewSavedFilterHandler(service ports.SavedFilterService, logger logger.Logger) *SavedFilterHandler {
+	return &SavedFilterHandler{
+		service: service,
+		logger:  logger.With(slog.String("component", "http")),
+	}
+}
+
+// CreateSavedFilterRequest represents the JSON payload for saving a filter.
+type CreateSavedFilterRequest struct {
+	// Name is the unique name the filter is addressed by
+	Name string `json:"name" xml:"name"`
+	UpdateSavedFilterRequest
+}
+
+// UpdateSavedFilterRequest represents the JSON payload for replacing a saved filter.
+// Omitted fields are cleared.
+type UpdateSavedFilterRequest struct {
+	// Description explains what the filter selects
+	Description string `json:"description" xml:"description"`
+	// Filter selects the tasks
+	Filter TaskFilterRequest `json:"filter" xml:"filter"`
+	// Sort defines the order of the selected tasks
+	Sort SortRequest `json:"sort" xml:"sort"`
+}
+
+// SavedFilterResponse represents a saved filter in responses.
+// The filter and sort have the format of the requests, so a filter can be read,
+// changed and put back.
+type SavedFilterResponse struct {
+	// Name is the unique name the filter is addressed by
+	Name string `json:"name" xml:"name"`
+	// Description explains what the filter selects
+	Description string `json:"description" xml:"description"`
+	// Filter selects the tasks
+	Filter TaskFilterRequest `json:"filter" xml:"filter"`
+	// Sort defines the order of the selected tasks
+	Sort SortRequest `json:"sort" xml:"sort"`
+	// CreatedBy identifies who saved the filter; omitted if unknown
+	CreatedBy string `json:"created_by,omitempty" xml:"created_by,omitempty"`
+	// CreatedAt is the timestamp when the filter was saved
+	CreatedAt time.Time `json:"created_at" xml:"created_at"`
+	// UpdatedAt is the timestamp when the filter was last changed
+	UpdatedAt time.Time `json:"updated_at" xml:"updated_at"`
+}
+
+// ListSavedFilters handles GET /views requests to list all saved filters ordered by name.
+func (h *SavedFilterHandler) ListSavedFilters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.Info(ctx, "listing saved filters")
+
+	filters, err := h.service.ListSavedFilters(ctx)
+	if err != nil {
+		h.writeSavedFilterError(w, r, h.logger, err)
+		return
+	}
+
+	response := make([]SavedFilterResponse, len(filters))
+	for i, filter := range filters {
+		response[i] = toSavedFilterResponse(filter)
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// CreateSavedFilter handles POST /views requests to save a filter under a name.
+// Returns the saved filter, 409 if the name is taken, or an error response.
+func (h *SavedFilterHandler) CreateSavedFilter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.Info(ctx, "creating saved filter")
+
+	var req CreateSavedFilterRequest
+	if err := decodeRequest(r, &req); err != nil {
+		h.logger.Warn(ctx, "invalid request format", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: ErrInvalidRequestFormat.Error()})
+		return
+	}
+
+	log := h.logger.With(slog.String("filter_name", req.Name))
+	filter, sort, err := req.UpdateSavedFilterRequest.parse()
+	if err != nil {
+		log.Warn(ctx, "invalid saved filter", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	saved, err := h.service.CreateSavedFilter(ctx, req.Name, req.Description, filter, sort)
+	if err != nil {
+		h.writeSavedFilterError(w, r, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toSavedFilterResponse(saved))
+}
+
+// GetSavedFilter handles GET /views/{name} requests to retrieve a saved filter.
+// Returns the saved filter or a 404 error if it doesn't exist.
+func (h *SavedFilterHandler) GetSavedFilter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	name := r.PathValue("name")
+	log := h.logger.With(slog.String("filter_name", name))
+	log.Info(ctx, "getting saved filter")
+
+	saved, err := h.service.GetSavedFilter(ctx, name)
+	if err != nil {
+		h.writeSavedFilterError(w, r, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toSavedFilterResponse(saved))
+}
+
+// UpdateSavedFilter handles PUT /views/{name} requests to replace the description,
+// filter and sort of a saved filter.
+// Returns the updated saved filter or an error response.
+func (h *SavedFilterHandler) UpdateSavedFilter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	name := r.PathValue("name")
+	log := h.logger.With(slog.String("filter_name", name))
+	log.Info(ctx, "updating saved filter")
+
+	var req UpdateSavedFilterRequest
+	if err := decodeRequest(r, &req); err != nil {
+		log.Warn(ctx, "invalid request format", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: ErrInvalidRequestFormat.Error()})
+		return
+	}
+
+	filter, sort, err := req.parse()
+	if err != nil {
+		log.Warn(ctx, "invalid saved filter", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	saved, err := h.service.UpdateSavedFilter(ctx, name, req.Description, filter, sort)
+	if err != nil {
+		h.writeSavedFilterError(w, r, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toSavedFilterResponse(saved))
+}
+
+// DeleteSavedFilter handles DELETE /views/{name} requests to remove a saved filter.
+// Returns 204 No Content on success or a 404 error if the filter doesn't exist.
+func (h *SavedFilterHandler) DeleteSavedFilter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	name := r.PathValue("name")
+	log := h.logger.With(slog.String("filter_name", name))
+	log.Info(ctx, "deleting saved filter")
+
+	if err := h.service.DeleteSavedFilter(ctx, name); err != nil {
+		h.writeSavedFilterError(w, r, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListSavedFilterTasks handles GET /views/{name}/tasks requests to list the tasks
+// selected by a saved filter, in the order of its sort.
+// Accepts the pagination and fields query parameters of GET /tasks.
+// If limit or cursor query parameters are given, the response is a page object
+// with a next_cursor; otherwise it is a plain JSON array of tasks.
+func (h *SavedFilterHandler) ListSavedFilterTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	name := r.PathValue("name")
+	log := h.logger.With(slog.String("filter_name", name))
+	log.Info(ctx, "listing saved filter tasks")
+
+	fields, err := parseProjection(r)
+	if err != nil {
+		log.Warn(ctx, "invalid fields parameter", slog.String("fields", r.URL.Query().Get("fields")))
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	page, err := parsePageRequest(r)
+	if err != nil {
+		log.Warn(ctx, "invalid pagination parameters", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	result, err := h.service.ListSavedFilterTasks(ctx, name, page)
+	if err != nil {
+		h.writeSavedFilterError(w, r, log, err)
+		return
+	}
+
+	projected, err := fields.applyAll(result.Tasks)
+	if err != nil {
+		log.Error(ctx, "failed to project tasks", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusInternalServerError, ErrorResponse{Error: ErrInternalServerError.Error()})
+		return
+	}
+
+	if page.IsZero() {
+		writeJSON(w, http.StatusOK, projected)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, projectedPage{Tasks: projected, NextCursor: result.NextCursor})
+}
+
+// parse validates the filter and sort of the request and maps them to their service types.
+func (req UpdateSavedFilterRequest) parse() (domain.TaskFilter, ports.Sort, error) {
+	if err := req.Filter.validate(); err != nil {
+		return domain.TaskFilter{}, ports.Sort{}, err
+	}
+
+	sort := ports.Sort{Field: req.Sort.Field, Order: req.Sort.Order}
+	if err := sort.Validate(); err != nil {
+		return domain.TaskFilter{}, ports.Sort{}, ErrInvalidSort
+	}
+
+	filter := domain.TaskFilter{
+		Statuses:      req.Filter.Status,
+		Priorities:    req.Filter.Priority,
+		ProjectID:     req.Filter.ProjectID,
+		Query:         req.Filter.Query,
+		TitleContains: req.Filter.TitleContains,
+		CreatedAfter:  req.Filter.CreatedAfter,
+		CreatedBefore: req.Filter.CreatedBefore,
+		UpdatedAfter:  req.Filter.UpdatedAfter,
+		UpdatedBefore: req.Filter.UpdatedBefore,
+		DueAfter:      req.Filter.DueAfter,
+		DueBefore:     req.Filter.DueBefore,
+		Overdue:       req.Filter.Overdue,
+		MinProgress:   req.Filter.MinProgress,
+	}
+	if req.Filter.Assignee == unassignedFilter {
+		filter.Unassigned = true
+	} else {
+		filter.Assignee = req.Filter.Assignee
+	}
+
+	return filter, sort, nil
+}
+
+// toSavedFilterResponse maps a saved filter to its response.
+func toSavedFilterResponse(saved *domain.SavedFilter) SavedFilterResponse {
+	filter := TaskFilterRequest{
+		Status:        saved.Filter.Statuses,
+		Priority:      saved.Filter.Priorities,
+		Assignee:      saved.Filter.Assignee,
+		ProjectID:     saved.Filter.ProjectID,
+		Query:         saved.Filter.Query,
+		TitleContains: saved.Filter.TitleContains,
+		CreatedAfter:  saved.Filter.CreatedAfter,
+		CreatedBefore: saved.Filter.CreatedBefore,
+		UpdatedAfter:  saved.Filter.UpdatedAfter,
+		UpdatedBefore: saved.Filter.UpdatedBefore,
+		DueAfter:      saved.Filter.DueAfter,
+		DueBefore:     saved.Filter.DueBefore,
+		Overdue:       saved.Filter.Overdue,
+		MinProgress:   saved.Filter.MinProgress,
+	}
+	if saved.Filter.Unassigned {
+		filter.Assignee = unassignedFilter
+	}
+
+	return SavedFilterResponse{
+		Name:        saved.Name,
+		Description: saved.Description,
+		Filter:      filter,
+		Sort:        SortRequest{Field: ports.SortField(saved.SortField), Order: ports.SortOrder(saved.SortOrder)},
+		CreatedBy:   saved.CreatedBy,
+		CreatedAt:   saved.CreatedAt,
+		UpdatedAt:   saved.UpdatedAt,
+	}
+}
+
+// writeSavedFilterError maps an error of a saved filter operation to its response.
+func (h *SavedFilterHandler) writeSavedFilterError(
+	w http.ResponseWriter, r *http.Request, log logger.Logger, err error,
+) {
+	ctx := r.Context()
+
+	var (
+		status  int
+		message error
+	)
+	switch {
+	case errors.Is(err, domain.ErrSavedFilterNotFound):
+		log.Warn(ctx, "saved filter not found")
+		status, message = http.StatusNotFound, ErrSavedFilterNotFound
+	case errors.Is(err, domain.ErrSavedFilterExists):
+		log.Warn(ctx, "saved filter rejected: name already taken")
+		status, message = http.StatusConflict, ErrSavedFilterExists
+	case errors.Is(err, domain.ErrInvalidFilterName):
+		log.Warn(ctx, "saved filter rejected: invalid name")
+		status, message = http.StatusBadRequest, ErrInvalidFilterName
+	case errors.Is(err, domain.ErrInvalidFilter):
+		log.Warn(ctx, "saved filter rejected: invalid filter")
+		status, message = http.StatusBadRequest, domain.ErrInvalidFilter
+	case errors.Is(err, domain.ErrInvalidStatus):
+		log.Warn(ctx, "invalid status filter")
+		status, message = http.StatusBadRequest, ErrInvalidStatus
+	case errors.Is(err, domain.ErrInvalidCursor):
+		log.Warn(ctx, "invalid pagination cursor")
+		status, message = http.StatusBadRequest, ErrInvalidCursor
+	case errors.Is(err, ports.ErrRepositoryUnavailable):
+		log.Error(ctx, "saved filter repository unavailable", slog.String("error", err.Error()))
+		writeProblem(w, r, http.StatusServiceUnavailable, ports.ErrRepositoryUnavailable.Error())
+		return
+	default:
+		log.Error(ctx, "failed to handle saved filters", slog.String("error", err.Error()))
+		status, message = http.StatusInternalServerError, ErrInternalServerError
+	}
+
+	writeJSON(w, status, ErrorResponse{Error: message.Error()})
+}
@@ -0,0 +1,192 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// ErrInvalidSort is returned when the requested sort field or order is not supported.
+var ErrInvalidSort = errors.New("invalid sort parameter")
+
+// TaskFilterRequest represents the task filter of a search or transition document
+// or of a saved filter. All fields are optional; an empty filter matches all tasks.
+type TaskFilterRequest struct {
+	// Status restricts results to tasks with any of these statuses
+	Status []domain.TaskStatus `json:"status,omitempty" xml:"status,omitempty"`
+	// Priority restricts results to tasks with any of these priorities
+	Priority []domain.Priority `json:"priority,omitempty" xml:"priority,omitempty"`
+	// Assignee restricts results to tasks assigned to it; none selects unassigned tasks
+	Assignee string `json:"assignee,omitempty" xml:"assignee,omitempty"`
+	// ProjectID restricts results to the tasks of this project
+	ProjectID string `json:"project_id,omitempty" xml:"project_id,omitempty"`
+	// Query is a case-insensitive substring searched in title and description
+	Query string `json:"query,omitempty" xml:"query,omitempty"`
+	// TitleContains is a case-insensitive substring searched in the title only
+	TitleContains string `json:"title_contains,omitempty" xml:"title_contains,omitempty"`
+	// CreatedAfter restricts results to tasks created after this time
+	CreatedAfter *time.Time `json:"created_after,omitempty" xml:"created_after,omitempty"`
+	// CreatedBefore restricts results to tasks created before this time
+	CreatedBefore *time.Time `json:"created_before,omitempty" xml:"created_before,omitempty"`
+	// UpdatedAfter restricts results to tasks updated after this time
+	UpdatedAfter *time.Time `json:"updated_after,omitempty" xml:"updated_after,omitempty"`
+	// UpdatedBefore restricts results to tasks updated before this time
+	UpdatedBefore *time.Time `json:"updated_before,omitempty" xml:"updated_before,omitempty"`
+	// DueAfter restricts results to tasks due after this time
+	DueAfter *time.Time `json:"due_after,omitempty" xml:"due_after,omitempty"`
+	// DueBefore restricts results to tasks due before this time
+	DueBefore *time.Time `json:"due_before,omitempty" xml:"due_before,omitempty"`
+	// Overdue restricts results to unfinished tasks past their due date
+	Overdue bool `json:"overdue,omitempty" xml:"overdue,omitempty"`
+	// MinProgress restricts results to tasks at least this far done, in percent
+	MinProgress int `json:"min_progress,omitempty" xml:"min_progress,omitempty"`
+}
+
+// SearchTasksRequest represents the JSON filter document for POST /tasks/search.
+// All fields are optional; an empty document returns the first page of all tasks.
+type SearchTasksRequest struct {
+	TaskFilterRequest
+	// Sort defines the order of results
+	Sort SortRequest `json:"sort" xml:"sort"`
+	// Limit is the page size (default 50)
+	Limit int `json:"limit" xml:"limit"`
+	// Cursor is the next_cursor of the previous page
+	Cursor string `json:"cursor" xml:"cursor"`
+}
+
+// SortRequest represents the sort part of a search document.
+type SortRequest struct {
+	// Field is one of created_at, updated_at, title, status, priority, rank
+	Field ports.SortField `json:"field,omitempty" xml:"field,omitempty"`
+	// Order is asc or desc
+	Order ports.SortOrder `json:"order,omitempty" xml:"order,omitempty"`
+}
+
+// SearchTasks handles POST /tasks/search requests.
+// Accepts a JSON filter document and returns a page of matching tasks.
+func (h *TaskHandler) SearchTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	h.logger.Info(ctx, "searching tasks")
+
+	var req SearchTasksRequest
+	if err := decodeRequest(r, &req); err != nil {
+		h.logger.Warn(ctx, "invalid request format", slog.String("error", err.Error()))
+		h.writeError(w, ErrInvalidRequestFormat, http.StatusBadRequest)
+		return
+	}
+
+	query, err := req.toListQuery()
+	if err != nil {
+		h.logger.Warn(ctx, "invalid search request", slog.String("error", err.Error()))
+		h.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.GetAllTasks(ctx, query)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidStatus) {
+			h.logger.Warn(ctx, "invalid status filter", slog.Any("status_filter", query.Filter.Statuses))
+			h.writeError(w, ErrInvalidStatus, http.StatusBadRequest)
+			return
+		}
+
+		if errors.Is(err, domain.ErrInvalidCursor) {
+			h.logger.Warn(ctx, "invalid pagination cursor")
+			h.writeError(w, ErrInvalidCursor, http.StatusBadRequest)
+			return
+		}
+
+		h.logger.Error(ctx, "failed to search tasks", slog.String("error", err.Error()))
+		h.writeServerError(w, r, err)
+		return
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, result)
+}
+
+// toListQuery validates the search document and maps it to a service ListQuery.
+func (req SearchTasksRequest) toListQuery() (ports.ListQuery, error) {
+	filter, err := req.toListFilter()
+	if err != nil {
+		return ports.ListQuery{}, err
+	}
+
+	sort := ports.Sort{Field: req.Sort.Field, Order: req.Sort.Order}
+	if err := sort.Validate(); err != nil {
+		return ports.ListQuery{}, ErrInvalidSort
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultPageLimit
+	}
+
+	if limit < 0 || limit > maxPageLimit {
+		return ports.ListQuery{}, ErrInvalidLimit
+	}
+
+	return ports.ListQuery{
+		Filter: filter,
+		Sort:   sort.Normalize(),
+		Page:   ports.PageRequest{Cursor: req.Cursor, Limit: limit},
+	}, nil
+}
+
+// toListFilter validates the filter and maps it to a service ListFilter.
+func (req TaskFilterRequest) toListFilter() (ports.ListFilter, error) {
+	if err := req.validate(); err != nil {
+		return ports.ListFilter{}, err
+	}
+
+	filter := ports.ListFilter{
+		Statuses:      req.Status,
+		Priorities:    req.Priority,
+		ProjectID:     req.ProjectID,
+		Query:         req.Query,
+		TitleContains: req.TitleContains,
+		CreatedAfter:  timeOrZero(req.CreatedAfter),
+		CreatedBefore: timeOrZero(req.CreatedBefore),
+		UpdatedAfter:  timeOrZero(req.UpdatedAfter),
+		UpdatedBefore: timeOrZero(req.UpdatedBefore),
+		DueAfter:      timeOrZero(req.DueAfter),
+		DueBefore:     timeOrZero(req.DueBefore),
+		Overdue:       req.Overdue,
+		MinProgress:   req.MinProgress,
+	}
+
+	if req.Assignee == unassignedFilter {
+		filter.Unassigned = true
+	} else {
+		filter.Assignee = req.Assignee
+	}
+
+	return filter, nil
+}
+
+// validate checks the priorities and the minimum progress of the filter.
+func (req TaskFilterRequest) validate() error {
+	for _, priority := range req.Priority {
+		if !domain.IsValidPriority(string(priority)) {
+			return ErrInvalidPriority
+		}
+	}
+
+	if req.MinProgress < 0 || req.MinProgress > domain.MaxProgress {
+		return ErrInvalidMinProgress
+	}
+
+	return nil
+}
+
+// timeOrZero dereferences an optional timestamp.
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
@@ -8,6 +8,9 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/asp3cto/task-manager/internal/auth"
 	"github.com/asp3cto/task-manager/internal/logger"
 	"github.com/asp3cto/task-manager/internal/ports"
 )
@@ -17,31 +20,347 @@ type Server struct {
 	http *http.Server
 	// handler contains the HTTP request handlers for task operations
 	handler *TaskHandler
+	// commentService backs the comment endpoints, nil disables them
+	commentService ports.CommentService
+	// comments contains the HTTP request handlers for comment operations, nil if disabled
+	comments *CommentHandler
+	// attachmentService backs the attachment endpoints, nil disables them
+	attachmentService ports.AttachmentService
+	// attachmentMaxSize is the largest file accepted as an attachment, in bytes
+	attachmentMaxSize int64
+	// attachments contains the HTTP request handlers for attachment operations, nil if disabled
+	attachments *AttachmentHandler
+	// projectService backs the project endpoints, nil disables them
+	projectService ports.ProjectService
+	// projects contains the HTTP request handlers for project operations, nil if disabled
+	projects *ProjectHandler
+	// savedFilterService backs the saved filter endpoints, nil disables them
+	savedFilterService ports.SavedFilterService
+	// savedFilters contains the HTTP request handlers for saved filter operations, nil if disabled
+	savedFilters *SavedFilterHandler
+	// webhookService backs the webhook endpoints, nil disables them
+	webhookService ports.WebhookService
+	// webhooks contains the HTTP request handlers for webhook operations, nil if disabled
+	webhooks *WebhookHandler
+	// healthCheckers are the dependencies probed by the readiness endpoint
+	healthCheckers map[string]ports.HealthChecker
+	// adminToken enables the admin endpoints when non-empty
+	adminToken string
+	// capture keeps recent requests for GET /admin/requests, nil if disabled
+	capture *RequestCapture
+	// inspector reports repository state for GET /admin/repository, nil if unsupported
+	inspector ports.RepositoryInspector
+	// issuer mints and verifies scoped access tokens, nil disables token authentication
+	issuer *auth.Issuer
+	// reencrypters rewrite encrypted data for POST /admin/encryption/reencrypt, empty if encryption is disabled
+	reencrypters []ports.Reencrypter
+	// validator rejects requests violating the OpenAPI spec, nil disables validation
+	validator *SpecValidator
+	// idempotencyStore records responses to POST /tasks for replay, nil disables Idempotency-Key support
+	idempotencyStore ports.IdempotencyStore
+	// idempotencyTTL is how long a recorded response is replayed
+	idempotencyTTL time.Duration
+	// requestTimeout bounds the handling time of a request, zero disables the limit
+	requestTimeout time.Duration
+	// compressor compresses large JSON responses, nil disables compression
+	compressor *Compressor
+	// accessLog enables logging of every request
+	accessLog bool
+	// accessLogExclude lists the paths left out of the access log
+	accessLogExclude []string
+	// middlewares are applied to all routes, the first being the outermost
+	middlewares []Middleware
+	// metrics records request metrics and serves GET /metrics, nil disables metrics
+	metrics *Metrics
+	// health serves the liveness and readiness probes
+	health *HealthHandler
+	// drainDelay is how long Shutdown keeps serving with failing readiness probes
+	drainDelay time.Duration
+	// configSections are reported by GET /debug/config next to the server configuration
+	configSections map[string]any
+	// levelController enables the /debug/loglevel endpoints, nil if disabled
+	levelController logger.LevelController
+}
+
+// Option configures optional Server behavior.
+type Option func(*Server)
+
+// WithHealthCheck registers a dependency that the readiness endpoint will probe under the given name.
+func WithHealthCheck(name string, checker ports.HealthChecker) Option {
+	return func(s *Server) {
+		s.healthCheckers[name] = checker
+	}
+}
+
+// WithComments serves the comments on tasks under /tasks/{id}/comments, backed by service.
+func WithComments(service ports.CommentService) Option {
+	return func(s *Server) {
+		s.commentService = service
+	}
+}
+
+// WithAttachments serves the files attached to tasks under /tasks/{id}/attachments, backed by service.
+func WithAttachments(service ports.AttachmentService) Option {
+	return func(s *Server) {
+		s.attachmentService = service
+	}
+}
+
+// WithAttachmentMaxSize limits the size of attached files to maxSize bytes.
+func WithAttachmentMaxSize(maxSize int64) Option {
+	return func(s *Server) {
+		s.attachmentMaxSize = maxSize
+	}
+}
+
+// WithProjects serves the projects grouping tasks under /projects, backed by service.
+func WithProjects(service ports.ProjectService) Option {
+	return func(s *Server) {
+		s.projectService = service
+	}
+}
+
+// WithSavedFilters serves the saved filters, the named views over tasks, under /views, backed by service.
+func WithSavedFilters(service ports.SavedFilterService) Option {
+	return func(s *Server) {
+		s.savedFilterService = service
+	}
+}
+
+// WithWebhooks serves the webhook subscriptions to task events under /webhooks, backed by service.
+func WithWebhooks(service ports.WebhookService) Option {
+	return func(s *Server) {
+		s.webhookService = service
+	}
 }
 
 // readHeaderTimeout defines the maximum time allowed to read request headers.
 // This helps prevent Slowloris attacks by limiting the time spent reading headers.
 const readHeaderTimeout = 2 * time.Second
 
+// WithAdminToken enables the /admin endpoints, protected by the given Bearer token.
+func WithAdminToken(token string) Option {
+	return func(s *Server) {
+		s.adminToken = token
+	}
+}
+
+// WithRequestCapture keeps the last size requests in memory for GET /admin/requests.
+func WithRequestCapture(size int) Option {
+	return func(s *Server) {
+		s.capture = NewRequestCapture(size)
+	}
+}
+
+// WithRepositoryInspector exposes the repository state via GET /admin/repository.
+func WithRepositoryInspector(inspector ports.RepositoryInspector) Option {
+	return func(s *Server) {
+		s.inspector = inspector
+	}
+}
+
+// WithReencrypter exposes re-encryption of the stored data via POST /admin/encryption/reencrypt.
+// Several reencrypters run in the order they are given.
+func WithReencrypter(reencrypter ports.Reencrypter) Option {
+	return func(s *Server) {
+		s.reencrypters = append(s.reencrypters, reencrypter)
+	}
+}
+
+// WithTokenAuth requires scoped access tokens signed by issuer on all task endpoints.
+// Tokens are minted via POST /admin/tokens.
+func WithTokenAuth(issuer *auth.Issuer) Option {
+	return func(s *Server) {
+		s.issuer = issuer
+	}
+}
+
+// WithSpecValidation validates every request described in the OpenAPI spec before it reaches the handlers.
+func WithSpecValidation(validator *SpecValidator) Option {
+	return func(s *Server) {
+		s.validator = validator
+	}
+}
+
+// WithIdempotency honors the Idempotency-Key header on POST /tasks, replaying the
+// original response to retried requests for ttl.
+func WithIdempotency(store ports.IdempotencyStore, ttl time.Duration) Option {
+	return func(s *Server) {
+		s.idempotencyStore = store
+		s.idempotencyTTL = ttl
+	}
+}
+
+// WithRequestTimeout cancels requests that take longer than timeout and answers
+// them with 503 Service Unavailable.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(s *Server) {
+		s.requestTimeout = timeout
+	}
+}
+
+// WithCompression gzips or deflates JSON responses of at least minSize bytes
+// for clients that accept it.
+func WithCompression(minSize int) Option {
+	return func(s *Server) {
+		s.compressor = NewCompressor(minSize)
+	}
+}
+
+// WithAccessLog logs every request except those to excludePaths (e.g. health checks).
+func WithAccessLog(excludePaths ...string) Option {
+	return func(s *Server) {
+		s.accessLog = true
+		s.accessLogExclude = excludePaths
+	}
+}
+
+// WithMetrics records request metrics in registry and exposes it via GET /metrics.
+func WithMetrics(registry *prometheus.Registry) Option {
+	return func(s *Server) {
+		s.metrics = NewMetrics(registry)
+	}
+}
+
+// WithConfigSection reports the configuration of another component under name
+// in GET /debug/config. The section is encoded as JSON and must not contain secrets.
+// A func() any section is called on every request, for settings that change at runtime.
+func WithConfigSection(name string, section any) Option {
+	return func(s *Server) {
+		s.configSections[name] = section
+	}
+}
+
+// WithLogLevelControl enables GET and PUT /debug/loglevel, reading and changing
+// the minimum level of controller. The endpoints require the admin token.
+func WithLogLevelControl(controller logger.LevelController) Option {
+	return func(s *Server) {
+		s.levelController = controller
+	}
+}
+
+// WithDrainDelay keeps serving requests for delay after Shutdown is called,
+// while the readiness endpoint reports the server as draining.
+func WithDrainDelay(delay time.Duration) Option {
+	return func(s *Server) {
+		s.drainDelay = delay
+	}
+}
+
+// WithMiddleware appends middlewares to the chain applied to all routes.
+// Middlewares run in registration order, before the built-in ones
+// (metrics, access log, compression, content negotiation, request capture, validation, timeout).
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(s *Server) {
+		s.middlewares = append(s.middlewares, middlewares...)
+	}
+}
+
 // NewServer creates a new HTTP server instance with task management endpoints.
-func NewServer(addr string, service ports.TaskService, logger logger.Logger) *Server {
-	handler := NewTaskHandler(service, logger)
+func NewServer(addr string, service ports.TaskService, logger logger.Logger, opts ...Option) *Server {
+	s := &Server{
+		handler:           NewTaskHandler(service, logger),
+		healthCheckers:    make(map[string]ports.HealthChecker),
+		configSections:    make(map[string]any),
+		attachmentMaxSize: defaultAttachmentMaxSize,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.health = NewHealthHandler(s.healthCheckers, logger)
+	if s.commentService != nil {
+		s.comments = NewCommentHandler(s.commentService, logger)
+	}
+	if s.attachmentService != nil {
+		s.attachments = NewAttachmentHandler(s.attachmentService, s.attachmentMaxSize, logger)
+	}
+	if s.projectService != nil {
+		s.projects = NewProjectHandler(s.projectService, logger)
+	}
+	if s.savedFilterService != nil {
+		s.savedFilters = NewSavedFilterHandler(s.savedFilterService, logger)
+	}
+	if s.webhookService != nil {
+		s.webhooks = NewWebhookHandler(s.webhookService, logger)
+	}
+	authz := &authorizer{issuer: s.issuer, adminToken: s.adminToken, tasks: service, logger: logger}
+	idem := &idempotency{store: s.idempotencyStore, ttl: s.idempotencyTTL, logger: logger}
 
 	mux := http.NewServeMux()
-	mux.HandleFunc("GET /tasks", handler.GetTasks)
-	mux.HandleFunc("GET /tasks/{id}", handler.GetTask)
-	mux.HandleFunc("POST /tasks", handler.CreateTask)
+	v1 := s.v1(idem)
+	v1.mount(mux, authz)
+	v1.mountLegacy(mux, authz)
+	mux.HandleFunc("GET /healthz", s.health.Live)
+	mux.HandleFunc("GET /readyz", s.health.Ready)
+
+	if s.metrics != nil {
+		mux.Handle("GET /metrics", s.metrics.Handler())
+	}
+
+	if s.adminToken != "" {
+		admin := NewAdminHandler(s.capture, s.inspector, s.issuer, s.reencrypters, logger)
+		mux.HandleFunc("GET /admin/requests", requireAdmin(s.adminToken, logger, admin.Requests))
+		mux.HandleFunc("GET /admin/repository", requireAdmin(s.adminToken, logger, admin.Repository))
+		mux.HandleFunc("POST /admin/tokens", requireAdmin(s.adminToken, logger, admin.MintToken))
+		mux.HandleFunc("GET /debug/config", requireAdmin(s.adminToken, logger, s.DebugConfig))
+		mux.HandleFunc("POST /admin/encryption/reencrypt", requireAdmin(s.adminToken, logger, admin.Reencrypt))
+
+		if s.webhooks != nil {
+			mux.HandleFunc("GET /admin/webhooks/dead-letters",
+				requireAdmin(s.adminToken, logger, s.webhooks.ListDeadLetters))
+			mux.HandleFunc("GET /admin/webhooks/dead-letters/{id}",
+				requireAdmin(s.adminToken, logger, s.webhooks.GetDeadLetter))
+			mux.HandleFunc("POST /admin/webhooks/dead-letters/{id}/replay",
+				requireAdmin(s.adminToken, logger, s.webhooks.ReplayDeadLetter))
+			mux.HandleFunc("DELETE /admin/webhooks/dead-letters/{id}",
+				requireAdmin(s.adminToken, logger, s.webhooks.DeleteDeadLetter))
+		}
+
+		if s.levelController != nil {
+			logLevel := NewLogLevelHandler(s.levelController, logger)
+			mux.HandleFunc("GET /debug/loglevel", requireAdmin(s.adminToken, logger, logLevel.Get))
+			mux.HandleFunc("PUT /debug/loglevel", requireAdmin(s.adminToken, logger, logLevel.Set))
+		}
+	}
+
+	// The request ID is attached first so every middleware can log it,
+	// followed by the registered middlewares and the built-in ones.
+	middlewares := append([]Middleware{requestID}, s.middlewares...)
+	if s.metrics != nil {
+		middlewares = append(middlewares, s.metrics.Middleware)
+	}
+
+	if s.accessLog {
+		middlewares = append(middlewares, NewAccessLog(logger, s.accessLogExclude...).Middleware)
+	}
+
+	if s.compressor != nil {
+		middlewares = append(middlewares, s.compressor.Middleware)
+	}
 
-	httpServer := &http.Server{
+	middlewares = append(middlewares, negotiateContent)
+
+	if s.capture != nil {
+		middlewares = append(middlewares, s.capture.Middleware)
+	}
+
+	if s.validator != nil {
+		middlewares = append(middlewares, s.validator.Middleware)
+	}
+
+	if s.requestTimeout > 0 {
+		middlewares = append(middlewares, NewTimeout(s.requestTimeout, logger).Middleware)
+	}
+
+	s.http = &http.Server{
 		Addr:              addr,
-		Handler:           mux,
+		Handler:           Chain(recordRoute(mux), middlewares...),
 		ReadHeaderTimeout: readHeaderTimeout,
 	}
 
-	return &Server{
-		http:    httpServer,
-		handler: handler,
-	}
+	return s
 }
 
 // ListenAndServe starts the HTTP server and begins accepting connections.
@@ -51,8 +370,22 @@ func (s *Server) ListenAndServe() error {
 }
 
 // Shutdown gracefully shuts down the HTTP server without interrupting active connections.
-// It waits for active connections to finish or for the context to be cancelled.
+// The readiness endpoint starts failing immediately and the server keeps accepting
+// requests for the configured drain delay, so load balancers can take it out of rotation.
+// It then waits for active connections to finish or for the context to be cancelled.
 func (s *Server) Shutdown(ctx context.Context) error {
+	s.health.SetDraining()
+
+	if s.drainDelay > 0 {
+		timer := time.NewTimer(s.drainDelay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+	}
+
 	return s.http.Shutdown(ctx)
 }
 
@@ -0,0 +1,43 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+)
+
+// StatusResponse describes a configured task status and where tasks can move from it.
+type StatusResponse struct {
+	// Status is the name of the status
+	Status domain.TaskStatus `json:"status"`
+	// Transitions lists the statuses a task with this status can move to
+	Transitions []domain.TaskStatus `json:"transitions"`
+}
+
+// GetStatuses handles GET /statuses requests to list the configured task statuses,
+// the built-in ones first, with the transitions allowed from each of them.
+func (h *TaskHandler) GetStatuses(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	h.logger.Info(ctx, "getting statuses")
+
+	workflow, err := h.service.GetWorkflow(ctx)
+	if err != nil {
+		h.logger.Error(ctx, "failed to get statuses", slog.String("error", err.Error()))
+		h.writeServerError(w, r, err)
+		return
+	}
+
+	statuses := workflow.Statuses()
+	response := make([]StatusResponse, 0, len(statuses))
+	for _, status := range statuses {
+		transitions := workflow.Next(status)
+		if transitions == nil {
+			transitions = []domain.TaskStatus{}
+		}
+		response = append(response, StatusResponse{Status: status, Transitions: transitions})
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,201 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+)
+
+// Subtask-specific error messages.
+var (
+	// ErrSubtaskNotFound is returned when a requested subtask does not exist in the task.
+	ErrSubtaskNotFound = errors.New("subtask not found")
+	// ErrTooManySubtasks is returned when a task already has the maximum number of subtasks.
+	ErrTooManySubtasks = errors.New("task has too many subtasks")
+)
+
+// AddSubtaskRequest represents the JSON payload for adding a subtask to a task.
+type AddSubtaskRequest struct {
+	// Title is the short description of the subtask
+	Title string `json:"title" xml:"title"`
+	// Position is the zero-based place of the subtask in the checklist; omitted appends it
+	Position *int `json:"position" xml:"position"`
+}
+
+// UpdateSubtaskRequest represents the JSON payload for partially updating a subtask.
+// Omitted fields are left unchanged.
+type UpdateSubtaskRequest struct {
+	// Title is the new short description of the subtask
+	Title *string `json:"title" xml:"title"`
+	// Done marks the subtask as completed or not
+	Done *bool `json:"done" xml:"done"`
+	// Position is the new zero-based place of the subtask in the checklist
+	Position *int `json:"position" xml:"position"`
+}
+
+// GetSubtasks handles GET /tasks/{id}/subtasks requests to list the subtasks of a task in order.
+// The ETag is the one of the task, so it can be used to modify the subtasks.
+func (h *TaskHandler) GetSubtasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID := r.PathValue("id")
+	log := h.logger.With(slog.String("task_id", taskID))
+	log.Info(ctx, "getting subtasks")
+
+	task, err := h.service.GetTaskByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Warn(ctx, "task not found")
+			h.writeError(w, ErrTaskNotFound, http.StatusNotFound)
+		} else {
+			log.Error(ctx, "failed to get task", slog.String("error", err.Error()))
+			h.writeServerError(w, r, err)
+		}
+
+		return
+	}
+
+	etag := taskETag(task, nil)
+	w.Header().Set("ETag", etag)
+	if match, ok := notModified(r, etag); ok {
+		w.Header().Set("ETag", match)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	subtasks := task.Subtasks
+	if subtasks == nil {
+		subtasks = []domain.Subtask{}
+	}
+
+	h.writeJSONResponse(w, http.StatusOK, subtasks)
+}
+
+// AddSubtask handles POST /tasks/{id}/subtasks requests to add a subtask to a task.
+// Expects a JSON payload with the title and optional position, and an If-Match header
+// carrying the task ETag.
+// Returns the created subtask with the new task ETag, 412 if the task changed meanwhile,
+// or an error response.
+func (h *TaskHandler) AddSubtask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID := r.PathValue("id")
+	log := h.logger.With(slog.String("task_id", taskID))
+	log.Info(ctx, "adding subtask")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		log.Warn(ctx, "precondition missing or invalid")
+		h.writePreconditionError(w, err)
+		return
+	}
+
+	var req AddSubtaskRequest
+	if err := decodeRequest(r, &req); err != nil {
+		log.Warn(ctx, "invalid request format", slog.String("error", err.Error()))
+		h.writeError(w, ErrInvalidRequestFormat, http.StatusBadRequest)
+		return
+	}
+
+	task, subtask, err := h.service.AddSubtask(ctx, taskID, req.Title, req.Position, version)
+	if err != nil {
+		h.writeSubtaskError(w, r, log, err)
+		return
+	}
+
+	w.Header().Set("ETag", taskETag(task, nil))
+	h.writeJSONResponse(w, http.StatusCreated, subtask)
+}
+
+// UpdateSubtask handles PATCH /tasks/{id}/subtasks/{subtask_id} requests to change
+// the title, done flag and/or position of a subtask.
+// Expects a JSON payload with the fields to change and an If-Match header carrying the task ETag.
+// Returns the updated subtask with the new task ETag, 412 if the task changed meanwhile,
+// or an error response.
+func (h *TaskHandler) UpdateSubtask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, subtaskID := r.PathValue("id"), r.PathValue("subtask_id")
+	log := h.logger.With(slog.String("task_id", taskID), slog.String("subtask_id", subtaskID))
+	log.Info(ctx, "updating subtask")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		log.Warn(ctx, "precondition missing or invalid")
+		h.writePreconditionError(w, err)
+		return
+	}
+
+	var req UpdateSubtaskRequest
+	if err := decodeRequest(r, &req); err != nil {
+		log.Warn(ctx, "invalid request format", slog.String("error", err.Error()))
+		h.writeError(w, ErrInvalidRequestFormat, http.StatusBadRequest)
+		return
+	}
+
+	task, subtask, err := h.service.UpdateSubtask(ctx, taskID, subtaskID, req.Title, req.Done, req.Position, version)
+	if err != nil {
+		h.writeSubtaskError(w, r, log, err)
+		return
+	}
+
+	w.Header().Set("ETag", taskETag(task, nil))
+	h.writeJSONResponse(w, http.StatusOK, subtask)
+}
+
+// DeleteSubtask handles DELETE /tasks/{id}/subtasks/{subtask_id} requests to remove a subtask.
+// Requires an If-Match header carrying the task ETag.
+// Returns 204 No Content with the new task ETag on success, 412 if the task changed
+// meanwhile, or a 404 error if the task or subtask doesn't exist.
+func (h *TaskHandler) DeleteSubtask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	taskID, subtaskID := r.PathValue("id"), r.PathValue("subtask_id")
+	log := h.logger.With(slog.String("task_id", taskID), slog.String("subtask_id", subtaskID))
+	log.Info(ctx, "deleting subtask")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		log.Warn(ctx, "precondition missing or invalid")
+		h.writePreconditionError(w, err)
+		return
+	}
+
+	task, err := h.service.DeleteSubtask(ctx, taskID, subtaskID, version)
+	if err != nil {
+		h.writeSubtaskError(w, r, log, err)
+		return
+	}
+
+	w.Header().Set("ETag", taskETag(task, nil))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeSubtaskError maps an error of a subtask operation to its response.
+func (h *TaskHandler) writeSubtaskError(w http.ResponseWriter, r *http.Request, log logger.Logger, err error) {
+	ctx := r.Context()
+
+	switch {
+	case errors.Is(err, domain.ErrTaskNotFound):
+		log.Warn(ctx, "task not found")
+		h.writeError(w, ErrTaskNotFound, http.StatusNotFound)
+	case errors.Is(err, domain.ErrSubtaskNotFound):
+		log.Warn(ctx, "subtask not found")
+		h.writeError(w, ErrSubtaskNotFound, http.StatusNotFound)
+	case errors.Is(err, domain.ErrVersionConflict):
+		log.Warn(ctx, "task version conflict")
+		h.writeError(w, ErrPreconditionFailed, http.StatusPreconditionFailed)
+	case errors.Is(err, domain.ErrEmptyTitle):
+		log.Warn(ctx, "subtask rejected: empty title")
+		h.writeError(w, ErrTitleRequired, http.StatusBadRequest)
+	case errors.Is(err, domain.ErrTooManySubtasks):
+		log.Warn(ctx, "subtask rejected: too many subtasks")
+		h.writeError(w, ErrTooManySubtasks, http.StatusConflict)
+	default:
+		log.Error(ctx, "failed to modify subtasks", slog.String("error", err.Error()))
+		h.writeServerError(w, r, err)
+	}
+}
@@ -0,0 +1,124 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/logger"
+)
+
+// ErrRequestTimeout is reported when a request does not complete within the configured timeout.
+var ErrRequestTimeout = errors.New("request timed out")
+
+// Timeout bounds the time a handler may take to produce a response.
+type Timeout struct {
+	timeout time.Duration
+	logger  logger.Logger
+}
+
+// NewTimeout creates a Timeout that cancels requests running longer than timeout.
+func NewTimeout(timeout time.Duration, logger logger.Logger) *Timeout {
+	return &Timeout{
+		timeout: timeout,
+		logger:  logger,
+	}
+}
+
+// Middleware runs next with a request context that is cancelled after the timeout.
+// The response is buffered; if the timeout elapses first, the client receives
+// 503 Service Unavailable with a problem document and later writes by next are discarded.
+func (t *Timeout) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), t.timeout)
+		defer cancel()
+
+		tw := &timeoutWriter{header: make(http.Header), status: http.StatusOK}
+		done := make(chan struct{})
+		panicked := make(chan any, 1)
+
+		go func() {
+			defer func() {
+				if p := recover(); p != nil {
+					panicked <- p
+				}
+			}()
+
+			next.ServeHTTP(tw, r.WithContext(ctx))
+			close(done)
+		}()
+
+		select {
+		case p := <-panicked:
+			panic(p)
+		case <-done:
+			tw.mu.Lock()
+			defer tw.mu.Unlock()
+
+			for name, values := range tw.header {
+				w.Header()[name] = values
+			}
+			w.WriteHeader(tw.status)
+			_, _ = w.Write(tw.body.Bytes())
+		case <-ctx.Done():
+			tw.mu.Lock()
+			defer tw.mu.Unlock()
+
+			tw.timedOut = true
+			t.logger.Warn(
+				r.Context(),
+				"request timed out",
+				slog.String("method", r.Method), slog.String("path", r.URL.Path),
+				slog.Duration("timeout", t.timeout),
+			)
+			writeProblem(w, r, http.StatusServiceUnavailable, ErrRequestTimeout.Error())
+		}
+	})
+}
+
+// timeoutWriter buffers a response until the handler completes.
+type timeoutWriter struct {
+	mu     sync.Mutex
+	header http.Header
+	body   bytes.Buffer
+	status int
+	// wroteHeader reports whether WriteHeader has been called
+	wroteHeader bool
+	// timedOut is set once the timeout response has been sent
+	timedOut bool
+}
+
+// Header returns the buffered response headers.
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+// WriteHeader records the status code.
+func (tw *timeoutWriter) WriteHeader(status int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut || tw.wroteHeader {
+		return
+	}
+
+	tw.status = status
+	tw.wroteHeader = true
+}
+
+// Write buffers b, or returns http.ErrHandlerTimeout once the request has timed out.
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+
+	tw.wroteHeader = true
+	return tw.body.Write(b)
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+)
+
+// ErrTasksModified is returned when a task changed while a bulk transition was being applied.
+var ErrTasksModified = errors.New("tasks were modified during the transition, no task was changed")
+
+// TransitionTasksRequest represents the JSON body for POST /tasks/transition.
+type TransitionTasksRequest struct {
+	// Filter selects the tasks to move; an empty filter selects all tasks
+	Filter TaskFilterRequest `json:"filter" xml:"filter"`
+	// Status is the status to move the selected tasks to
+	Status domain.TaskStatus `json:"status" xml:"status"`
+}
+
+// TransitionTasksResponse reports the outcome of a bulk transition.
+type TransitionTasksResponse struct {
+	// Affected is the number of tasks moved to the status
+	Affected int `json:"affected"`
+}
+
+// TransitionTasks handles POST /tasks/transition requests to move all tasks matching
+// a filter to a status at once, such as cancelling all pending tasks created before a date.
+// Returns the number of changed tasks, 409 without changing any task if one of them cannot
+// move to the status or was modified meanwhile, or 500 if some tasks were left moved.
+func (h *TaskHandler) TransitionTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	h.logger.Info(ctx, "transitioning tasks")
+
+	var req TransitionTasksRequest
+	if err := decodeRequest(r, &req); err != nil {
+		h.logger.Warn(ctx, "invalid request format", slog.String("error", err.Error()))
+		h.writeError(w, ErrInvalidRequestFormat, http.StatusBadRequest)
+		return
+	}
+
+	filter, err := req.Filter.toListFilter()
+	if err != nil {
+		h.logger.Warn(ctx, "invalid transition filter", slog.String("error", err.Error()))
+		h.writeError(w, err, http.StatusBadRequest)
+		return
+	}
+
+	affected, err := h.service.TransitionTasks(ctx, filter, req.Status)
+	if err != nil {
+		var quotaErr *domain.QuotaExceededError
+		switch {
+		case errors.Is(err, domain.ErrInvalidStatus):
+			h.logger.Warn(ctx, "invalid status value", slog.String("status", string(req.Status)))
+			h.writeError(w, ErrInvalidStatus, http.StatusBadRequest)
+		case errors.Is(err, domain.ErrInvalidTransition):
+			h.logger.Warn(ctx, "invalid status transition", slog.String("status", string(req.Status)))
+			h.writeError(w, ErrInvalidTransition, http.StatusConflict)
+		case errors.As(err, &quotaErr):
+			h.logger.Warn(ctx, "status quota exceeded", slog.String("status", string(req.Status)))
+			h.writeQuotaError(w, quotaErr)
+		case errors.Is(err, domain.ErrVersionConflict):
+			h.logger.Warn(ctx, "tasks modified during transition")
+			h.writeError(w, ErrTasksModified, http.StatusConflict)
+		default:
+			h.logger.Error(ctx, "failed to transition tasks", slog.String("error", err.Error()))
+			h.writeServerError(w, r, err)
+		}
+		return
+	}
+
+	h.logger.Info(ctx, "tasks transitioned successfully", slog.Int("count", affected))
+	h.writeJSONResponse(w, http.StatusOK, TransitionTasksResponse{Affected: affected})
+}
@@ -0,0 +1,260 @@
+package http
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// Webhook-specific error messages.
+var (
+	// ErrWebhookNotFound is returned when a requested webhook does not exist.
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrDeadLetterNotFound is returned when a requested dead letter does not exist.
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+)
+
+// WebhookHandler handles HTTP requests for webhooks, the subscriptions of external endpoints to task events.
+type WebhookHandler struct {
+	service ports.WebhookService
+	logger  logger.Logger
+}
+
+// NewWebhookHandler creates a new HTTP handler for webhook operations.
+func NewWebhookHandler(service ports.WebhookService, logger logger.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		service: service,
+		logger:  logger.With(slog.String("component", "http")),
+	}
+}
+
+// CreateWebhookRequest represents the JSON payload for creating a webhook.
+type CreateWebhookRequest struct {
+	// URL is the endpoint the events are POSTed to
+	URL string `json:"url" xml:"url"`
+	// Events are the types of the events delivered to the URL
+	Events []domain.EventType `json:"events" xml:"events"`
+	// Secret is the key of the HMAC-SHA256 signature of the deliveries
+	Secret string `json:"secret" xml:"secret"`
+}
+
+// UpdateWebhookRequest represents the JSON payload for partially updating a webhook.
+// Omitted fields are left unchanged.
+type UpdateWebhookRequest struct {
+	// URL is the new endpoint the events are POSTed to
+	URL *string `json:"url" xml:"url"`
+	// Events are the new types of the events delivered to the URL
+	Events []domain.EventType `json:"events" xml:"events"`
+	// Secret is the new key of the HMAC-SHA256 signature of the deliveries
+	Secret *string `json:"secret" xml:"secret"`
+}
+
+// ListWebhooks handles GET /webhooks requests to list all webhooks, oldest first.
+// Secrets are never returned.
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.Info(ctx, "listing webhooks")
+
+	webhooks, err := h.service.ListWebhooks(ctx)
+	if err != nil {
+		h.writeWebhookError(w, r, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, webhooks)
+}
+
+// CreateWebhook handles POST /webhooks requests to subscribe an endpoint to task events.
+// Expects a JSON payload with the URL, the event types and the secret of the webhook.
+// Returns the created webhook with a generated ID or an error response.
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.Info(ctx, "creating webhook")
+
+	var req CreateWebhookRequest
+	if err := decodeRequest(r, &req); err != nil {
+		h.logger.Warn(ctx, "invalid request format", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: ErrInvalidRequestFormat.Error()})
+		return
+	}
+
+	webhook, err := h.service.CreateWebhook(ctx, req.URL, req.Events, req.Secret)
+	if err != nil {
+		h.writeWebhookError(w, r, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, webhook)
+}
+
+// GetWebhook handles GET /webhooks/{id} requests to retrieve a webhook.
+// Returns the webhook or a 404 error if it doesn't exist.
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	webhookID := r.PathValue("id")
+	log := h.logger.With(slog.String("webhook_id", webhookID))
+	log.Info(ctx, "getting webhook")
+
+	webhook, err := h.service.GetWebhook(ctx, webhookID)
+	if err != nil {
+		h.writeWebhookError(w, r, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, webhook)
+}
+
+// UpdateWebhook handles PATCH /webhooks/{id} requests to change the URL, event types
+// and/or secret of a webhook.
+// Returns the updated webhook or an error response.
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	webhookID := r.PathValue("id")
+	log := h.logger.With(slog.String("webhook_id", webhookID))
+	log.Info(ctx, "updating webhook")
+
+	var req UpdateWebhookRequest
+	if err := decodeRequest(r, &req); err != nil {
+		log.Warn(ctx, "invalid request format", slog.String("error", err.Error()))
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: ErrInvalidRequestFormat.Error()})
+		return
+	}
+
+	webhook, err := h.service.UpdateWebhook(ctx, webhookID, req.URL, req.Events, req.Secret)
+	if err != nil {
+		h.writeWebhookError(w, r, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, webhook)
+}
+
+// DeleteWebhook handles DELETE /webhooks/{id} requests to remove a webhook.
+// Returns 204 No Content on success or a 404 error if the webhook doesn't exist.
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	webhookID := r.PathValue("id")
+	log := h.logger.With(slog.String("webhook_id", webhookID))
+	log.Info(ctx, "deleting webhook")
+
+	if err := h.service.DeleteWebhook(ctx, webhookID); err != nil {
+		h.writeWebhookError(w, r, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeadLetters handles GET /admin/webhooks/dead-letters requests to list the
+// deliveries given up on, oldest first.
+func (h *WebhookHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	h.logger.Info(ctx, "listing dead letters")
+
+	letters, err := h.service.ListDeadLetters(ctx)
+	if err != nil {
+		h.writeWebhookError(w, r, h.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, letters)
+}
+
+// GetDeadLetter handles GET /admin/webhooks/dead-letters/{id} requests to retrieve
+// a delivery given up on, including its event.
+// Returns the dead letter or a 404 error if it doesn't exist.
+func (h *WebhookHandler) GetDeadLetter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	letterID := r.PathValue("id")
+	log := h.logger.With(slog.String("dead_letter_id", letterID))
+	log.Info(ctx, "getting dead letter")
+
+	letter, err := h.service.GetDeadLetter(ctx, letterID)
+	if err != nil {
+		h.writeWebhookError(w, r, log, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, letter)
+}
+
+// ReplayDeadLetter handles POST /admin/webhooks/dead-letters/{id}/replay requests to
+// deliver the event of a dead letter once more.
+// Returns 204 No Content and removes the dead letter if the delivery succeeds,
+// or 502 Bad Gateway with the failure if it fails again.
+func (h *WebhookHandler) ReplayDeadLetter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	letterID := r.PathValue("id")
+	log := h.logger.With(slog.String("dead_letter_id", letterID))
+	log.Info(ctx, "replaying dead letter")
+
+	if err := h.service.ReplayDeadLetter(ctx, letterID); err != nil {
+		h.writeWebhookError(w, r, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteDeadLetter handles DELETE /admin/webhooks/dead-letters/{id} requests to
+// discard a delivery given up on without replaying it.
+// Returns 204 No Content on success or a 404 error if the dead letter doesn't exist.
+func (h *WebhookHandler) DeleteDeadLetter(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	letterID := r.PathValue("id")
+	log := h.logger.With(slog.String("dead_letter_id", letterID))
+	log.Info(ctx, "deleting dead letter")
+
+	if err := h.service.DeleteDeadLetter(ctx, letterID); err != nil {
+		h.writeWebhookError(w, r, log, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeWebhookError maps an error of a webhook operation to its response.
+func (h *WebhookHandler) writeWebhookError(w http.ResponseWriter, r *http.Request, log logger.Logger, err error) {
+	ctx := r.Context()
+
+	var (
+		status  int
+		message error
+	)
+	switch {
+	case errors.Is(err, domain.ErrWebhookNotFound):
+		log.Warn(ctx, "webhook not found")
+		status, message = http.StatusNotFound, ErrWebhookNotFound
+	case errors.Is(err, domain.ErrDeadLetterNotFound):
+		log.Warn(ctx, "dead letter not found")
+		status, message = http.StatusNotFound, ErrDeadLetterNotFound
+	case errors.Is(err, domain.ErrWebhookDeliveryFailed):
+		status, message = http.StatusBadGateway, err
+	case errors.Is(err, domain.ErrInvalidWebhookURL),
+		errors.Is(err, domain.ErrWebhookURLNotAllowed),
+		errors.Is(err, domain.ErrNoWebhookEvents),
+		errors.Is(err, domain.ErrInvalidEventType),
+		errors.Is(err, domain.ErrEmptyWebhookSecret):
+		log.Warn(ctx, "webhook rejected", slog.String("error", err.Error()))
+		status, message = http.StatusBadRequest, err
+	case errors.Is(err, ports.ErrRepositoryUnavailable):
+		log.Error(ctx, "webhook repository unavailable", slog.String("error", err.Error()))
+		writeProblem(w, r, http.StatusServiceUnavailable, ports.ErrRepositoryUnavailable.Error())
+		return
+	default:
+		log.Error(ctx, "failed to handle webhooks", slog.String("error", err.Error()))
+		status, message = http.StatusInternalServerError, ErrInternalServerError
+	}
+
+	writeJSON(w, status, ErrorResponse{Error: message.Error()})
+}
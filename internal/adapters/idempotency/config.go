@@ -0,0 +1,25 @@
+package idempotency
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+const defaultTTL = 24 * time.Hour
+
+// TTLFromEnv reads the IDEMPOTENCY_TTL environment variable, the time a response
+// is kept for replay. Returns 24 hours if the variable is not set.
+func TTLFromEnv() (time.Duration, error) {
+	raw := os.Getenv("IDEMPOTENCY_TTL")
+	if raw == "" {
+		return defaultTTL, nil
+	}
+
+	ttl, err := time.ParseDuration(raw)
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("IDEMPOTENCY_TTL must be a positive duration, got: %s", raw)
+	}
+
+	return ttl, nil
+}
@@ -0,0 +1,103 @@
+// Package idempotency provides storage for responses to requests carrying an idempotency key.
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// sweepInterval is the minimum time between scans for expired entries.
+const sweepInterval = time.Minute
+
+// entry is a reserved or completed idempotency key.
+type entry struct {
+	fingerprint string
+	// response is nil while the original request is in progress
+	response  *ports.IdempotentResponse
+	expiresAt time.Time
+}
+
+// MemoryStore is an in-memory implementation of ports.IdempotencyStore.
+// Expired entries are removed lazily while reserving new keys.
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]*entry
+	lastSweep time.Time
+}
+
+var _ ports.IdempotencyStore = (*MemoryStore)(nil)
+
+// NewMemoryStore creates an empty in-memory idempotency store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]*entry),
+	}
+}
+
+// Reserve claims key for the request identified by fingerprint, or returns its recorded response.
+func (s *MemoryStore) Reserve(
+	_ context.Context, key, fingerprint string, ttl time.Duration,
+) (*ports.IdempotentResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+
+	if e, ok := s.entries[key]; ok && now.Before(e.expiresAt) {
+		if e.fingerprint != fingerprint {
+			return nil, ports.ErrIdempotencyKeyMismatch
+		}
+
+		if e.response == nil {
+			return nil, ports.ErrIdempotencyKeyInUse
+		}
+
+		response := *e.response
+		return &response, nil
+	}
+
+	s.entries[key] = &entry{fingerprint: fingerprint, expiresAt: now.Add(ttl)}
+	return nil, nil
+}
+
+// Complete records the response for a reserved key.
+// Keys that are no longer reserved are ignored.
+func (s *MemoryStore) Complete(_ context.Context, key string, response ports.IdempotentResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.entries[key]; ok {
+		e.response = &response
+	}
+
+	return nil
+}
+
+// Release frees a reserved key.
+func (s *MemoryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+// sweep removes expired entries at most once per sweepInterval.
+// Must be called with s.mu held.
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+
+	for key, e := range s.entries {
+		if !now.Before(e.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+
+	s.lastSweep = now
+}
@@ -0,0 +1,34 @@
+package idgen
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// ID formats selected by the TASK_ID_FORMAT environment variable.
+const (
+	// FormatUUIDv7 selects UUIDv7; it is the default.
+	FormatUUIDv7 = "uuidv7"
+	// FormatULID selects ULIDs.
+	FormatULID = "ulid"
+	// FormatRandom selects 32 random hexadecimal digits, the format of IDs before UUIDv7.
+	FormatRandom = "random"
+)
+
+// FromEnv creates the ID generator selected by the TASK_ID_FORMAT environment variable:
+// uuidv7, ulid or random. Returns the generator with its format; UUIDv7 if the variable is not set.
+// Existing IDs keep their format, so the setting can be changed at any time.
+func FromEnv() (ports.IDGenerator, string, error) {
+	switch format := os.Getenv("TASK_ID_FORMAT"); format {
+	case "", FormatUUIDv7:
+		return NewUUIDv7(), FormatUUIDv7, nil
+	case FormatULID:
+		return NewULID(), FormatULID, nil
+	case FormatRandom:
+		return Random{}, FormatRandom, nil
+	default:
+		return nil, "", fmt.Errorf("TASK_ID_FORMAT must be uuidv7, ulid or random, got: %s", format)
+	}
+}
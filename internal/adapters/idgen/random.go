@@ -0,0 +1,27 @@
+// Package idgen provides the generators of the IDs of new entities.
+package idgen
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var _ ports.IDGenerator = Random{}
+
+// randomLength is the number of random bytes of a Random ID.
+const randomLength = 16
+
+// Random generates IDs of 16 random bytes as 32 lowercase hexadecimal digits.
+// The IDs carry no order, so new rows land all over the indexes of the stores.
+type Random struct{}
+
+// NewID returns a new random ID.
+func (Random) NewID() (string, error) {
+	bytes := make([]byte, randomLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
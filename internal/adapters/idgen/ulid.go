@@ -0,0 +1,78 @@
+package idgen
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var _ ports.IDGenerator = (*ULID)(nil)
+
+// crockford is the Crockford base32 alphabet ULIDs are encoded with.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULID generates Universally Unique Lexicographically Sortable Identifiers:
+// 26 Crockford base32 characters, such as 01J9ZQ3T5R8W2X4Y6Z8A0B2C4D,
+// encoding a 48-bit Unix time in milliseconds followed by 80 random bits.
+// Within a millisecond the random part of the previous ID is incremented,
+// so the IDs of one generator are strictly increasing.
+type ULID struct {
+	// now returns the current time
+	now func() time.Time
+	// mu guards the last ID
+	mu sync.Mutex
+	// lastMillis is the timestamp of the last ID
+	lastMillis int64
+	// high and low are the upper 16 and lower 64 bits of the random part of the last ID
+	high uint16
+	low  uint64
+}
+
+// NewULID creates a generator of ULIDs.
+func NewULID() *ULID {
+	return &ULID{now: time.Now}
+}
+
+// NewID returns a new ULID.
+func (g *ULID) NewID() (string, error) {
+	var random [10]byte
+	if _, err := rand.Read(random[:]); err != nil {
+		return "", err
+	}
+
+	g.mu.Lock()
+	millis := g.now().UnixMilli()
+	if millis > g.lastMillis {
+		g.lastMillis = millis
+		g.high, g.low = binary.BigEndian.Uint16(random[:2]), binary.BigEndian.Uint64(random[2:])
+	} else if g.low++; g.low == 0 {
+		if g.high++; g.high == 0 {
+			// The random part is exhausted or the clock went back: borrow the next millisecond.
+			g.lastMillis++
+		}
+	}
+	millis, high, low := g.lastMillis, g.high, g.low
+	g.mu.Unlock()
+
+	var ulid [16]byte
+	binary.BigEndian.PutUint64(ulid[:8], uint64(millis)<<16|uint64(high))
+	binary.BigEndian.PutUint64(ulid[8:], low)
+	return encodeCrockford(ulid), nil
+}
+
+// encodeCrockford encodes the 128 bits of a ULID as 26 base32 characters, 5 bits each,
+// the first character holding only the 3 most significant bits.
+func encodeCrockford(ulid [16]byte) string {
+	high, low := binary.BigEndian.Uint64(ulid[:8]), binary.BigEndian.Uint64(ulid[8:])
+
+	var text [26]byte
+	for i := len(text) - 1; i >= 0; i-- {
+		text[i] = crockford[low&0x1f]
+		low = low>>5 | high<<59
+		high >>= 5
+	}
+	return string(text[:])
+}
@@ -0,0 +1,73 @@
+package idgen
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var _ ports.IDGenerator = (*UUIDv7)(nil)
+
+// UUIDv7 generates time-ordered UUIDs of version 7 (RFC 9562) in the canonical
+// lowercase form, such as 01928f3e-7a4b-7c3d-9e2f-1a2b3c4d5e6f.
+// The first 48 bits hold the Unix time in milliseconds, so IDs generated later sort
+// after earlier ones and new rows are appended to the end of the indexes.
+// Within a millisecond the 12-bit rand_a field is used as a counter seeded at random
+// (method 3 of RFC 9562), which keeps the IDs of one generator strictly increasing.
+type UUIDv7 struct {
+	// now returns the current time
+	now func() time.Time
+	// mu guards the state of the counter
+	mu sync.Mutex
+	// lastMillis is the timestamp of the last ID
+	lastMillis int64
+	// sequence is the rand_a field of the last ID
+	sequence uint16
+}
+
+// NewUUIDv7 creates a generator of UUIDv7 IDs.
+func NewUUIDv7() *UUIDv7 {
+	return &UUIDv7{now: time.Now}
+}
+
+// NewID returns a new UUIDv7.
+func (g *UUIDv7) NewID() (string, error) {
+	var random [10]byte
+	if _, err := rand.Read(random[:]); err != nil {
+		return "", err
+	}
+
+	g.mu.Lock()
+	millis := g.now().UnixMilli()
+	if millis > g.lastMillis {
+		// Seed the counter in the lower half of its range to leave room for increments.
+		g.lastMillis, g.sequence = millis, binary.BigEndian.Uint16(random[:2])&0x7ff
+	} else if g.sequence++; g.sequence > 0xfff {
+		// The counter is exhausted or the clock went back: borrow the next millisecond.
+		g.lastMillis, g.sequence = g.lastMillis+1, 0
+	}
+	millis, sequence := g.lastMillis, g.sequence
+	g.mu.Unlock()
+
+	var uuid [16]byte
+	binary.BigEndian.PutUint64(uuid[:8], uint64(millis)<<16|uint64(sequence))
+	uuid[6] |= 0x70 // version 7
+	copy(uuid[8:], random[2:])
+	uuid[8] = uuid[8]&0x3f | 0x80 // variant 10
+
+	var text [36]byte
+	hex.Encode(text[0:8], uuid[0:4])
+	text[8] = '-'
+	hex.Encode(text[9:13], uuid[4:6])
+	text[13] = '-'
+	hex.Encode(text[14:18], uuid[6:8])
+	text[18] = '-'
+	hex.Encode(text[19:23], uuid[8:10])
+	text[23] = '-'
+	hex.Encode(text[24:], uuid[10:])
+	return string(text[:]), nil
+}
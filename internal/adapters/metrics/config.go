@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"fmt"
+	"os"
+)
+
+// Metrics exporters selectable with OTEL_METRICS_EXPORTER.
+const (
+	exporterNone       = "none"
+	exporterPrometheus = "prometheus"
+	exporterOTLP       = "otlp"
+)
+
+// OTLPEnabledFromEnv reports whether metrics should also be pushed over OTLP.
+//
+// Environment variables used:
+//   - OTEL_METRICS_EXPORTER: "otlp" enables the OTLP exporter in addition to
+//     the /metrics endpoint; "prometheus" or "none" leave it disabled (default: disabled)
+//
+// Returns an error if the exporter name is not supported.
+func OTLPEnabledFromEnv() (bool, error) {
+	switch exporter := os.Getenv("OTEL_METRICS_EXPORTER"); exporter {
+	case "", exporterNone, exporterPrometheus:
+		return false, nil
+	case exporterOTLP:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unsupported OTEL_METRICS_EXPORTER %q, expected otlp, prometheus or none", exporter)
+	}
+}
@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/asp3cto/task-manager/internal/logger"
+)
+
+// RegisterLoggerStats exports the pipeline statistics of the async logger.
+// stats is called on every scrape.
+func RegisterLoggerStats(registry prometheus.Registerer, stats func() logger.Stats) {
+	counter := func(name, help string, value func(logger.Stats) uint64) prometheus.Collector {
+		return prometheus.NewCounterFunc(prometheus.CounterOpts{Name: name, Help: help}, func() float64 {
+			return float64(value(stats()))
+		})
+	}
+
+	gauge := func(name, help string, value func(logger.Stats) int) prometheus.Collector {
+		return prometheus.NewGaugeFunc(prometheus.GaugeOpts{Name: name, Help: help}, func() float64 {
+			return float64(value(stats()))
+		})
+	}
+
+	registry.MustRegister(
+		counter("log_entries_enqueued_total", "Number of log entries accepted into the queue.",
+			func(s logger.Stats) uint64 { return s.Enqueued }),
+		counter("log_entries_written_total", "Number of log entries written to the output.",
+			func(s logger.Stats) uint64 { return s.Written }),
+		counter("log_entries_dropped_total", "Number of log entries dropped because the queue was full or the context was done.",
+			func(s logger.Stats) uint64 { return s.Dropped }),
+		counter("log_entries_encode_failed_total", "Number of log entries that failed to encode.",
+			func(s logger.Stats) uint64 { return s.EncodeFailed }),
+		counter("log_entries_write_failed_total", "Number of log entries the output failed to write.",
+			func(s logger.Stats) uint64 { return s.WriteFailed }),
+		counter("log_entries_sampled_total", "Number of log entries discarded by sampling.",
+			func(s logger.Stats) uint64 { return s.Sampled }),
+		gauge("log_queue_depth", "Number of log entries waiting in the queue.",
+			func(s logger.Stats) int { return s.QueueDepth }),
+		gauge("log_queue_capacity", "Capacity of the log entry queue.",
+			func(s logger.Stats) int { return s.QueueCapacity }),
+	)
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	promBridge "go.opentelemetry.io/contrib/bridges/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// serviceName identifies the service in exported metrics unless OTEL_SERVICE_NAME overrides it.
+const serviceName = "task-manager"
+
+// OTLPExporter periodically pushes the metrics of a Prometheus registry
+// to an OpenTelemetry collector over OTLP/HTTP.
+type OTLPExporter struct {
+	provider *sdkmetric.MeterProvider
+}
+
+// NewOTLPExporter starts pushing every metric gathered from gatherer, including
+// the HTTP request and task metrics, to the OTLP endpoint.
+// The endpoint, headers, export interval and resource attributes are configured
+// with the standard OTEL_EXPORTER_OTLP_*, OTEL_METRIC_EXPORT_* and OTEL_RESOURCE_ATTRIBUTES variables.
+func NewOTLPExporter(ctx context.Context, gatherer prometheus.Gatherer) (*OTLPExporter, error) {
+	exporter, err := otlpmetrichttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	res, err := resource.New(
+		ctx,
+		resource.WithAttributes(attribute.String("service.name", serviceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP resource: %w", err)
+	}
+
+	reader := sdkmetric.NewPeriodicReader(
+		exporter,
+		sdkmetric.WithProducer(promBridge.NewMetricProducer(promBridge.WithGatherer(gatherer))),
+	)
+
+	return &OTLPExporter{
+		provider: sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithResource(res)),
+	}, nil
+}
+
+// Shutdown pushes the pending metrics and stops the exporter.
+func (e *OTLPExporter) Shutdown(ctx context.Context) error {
+	return e.provider.Shutdown(ctx)
+}
@@ -0,0 +1,79 @@
+// Package metrics exports business metrics of the task manager to Prometheus.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var _ ports.TaskMetrics = (*PrometheusTaskMetrics)(nil)
+
+// PrometheusTaskMetrics implements ports.TaskMetrics with Prometheus collectors.
+type PrometheusTaskMetrics struct {
+	tasks     *prometheus.GaugeVec
+	created   prometheus.Counter
+	completed prometheus.Counter
+	cancelled prometheus.Counter
+}
+
+// NewPrometheusTaskMetrics registers the task metrics with registry.
+func NewPrometheusTaskMetrics(registry prometheus.Registerer) *PrometheusTaskMetrics {
+	m := &PrometheusTaskMetrics{
+		tasks: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "tasks",
+			Help: "Number of tasks by status.",
+		}, []string{"status"}),
+		created: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "tasks_created_total",
+			Help: "Number of tasks created.",
+		}),
+		completed: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "tasks_completed_total",
+			Help: "Number of tasks moved to the completed status.",
+		}),
+		cancelled: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "tasks_cancelled_total",
+			Help: "Number of tasks moved to the cancelled status.",
+		}),
+	}
+
+	registry.MustRegister(m.tasks, m.created, m.completed, m.cancelled)
+	return m
+}
+
+// SetTaskCounts initializes the per-status gauge, e.g. from the repository at startup.
+func (m *PrometheusTaskMetrics) SetTaskCounts(counts map[domain.TaskStatus]int) {
+	for status, count := range counts {
+		m.tasks.WithLabelValues(string(status)).Set(float64(count))
+	}
+}
+
+// TaskCreated increments the created counter and the gauge of status.
+func (m *PrometheusTaskMetrics) TaskCreated(status domain.TaskStatus) {
+	m.created.Inc()
+	m.tasks.WithLabelValues(string(status)).Inc()
+}
+
+// TaskStatusChanged moves a task between status gauges and counts completions and cancellations.
+func (m *PrometheusTaskMetrics) TaskStatusChanged(from, to domain.TaskStatus) {
+	if from == to {
+		return
+	}
+
+	m.tasks.WithLabelValues(string(from)).Dec()
+	m.tasks.WithLabelValues(string(to)).Inc()
+
+	switch to {
+	case domain.StatusCompleted:
+		m.completed.Inc()
+	case domain.StatusCancelled:
+		m.cancelled.Inc()
+	}
+}
+
+// TaskDeleted decrements the gauge of status.
+func (m *PrometheusTaskMetrics) TaskDeleted(status domain.TaskStatus) {
+	m.tasks.WithLabelValues(string(status)).Dec()
+}
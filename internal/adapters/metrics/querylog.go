@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/asp3cto/task-manager/internal/adapters/repository/querylog"
+)
+
+// RegisterQueryStats exports the query statistics of a SQL repository.
+// stats is called on every scrape.
+func RegisterQueryStats(registry prometheus.Registerer, stats func() querylog.Stats) {
+	counter := func(name, help string, value func(querylog.Stats) uint64) prometheus.Collector {
+		return prometheus.NewCounterFunc(prometheus.CounterOpts{Name: name, Help: help}, func() float64 {
+			return float64(value(stats()))
+		})
+	}
+
+	registry.MustRegister(
+		counter("sql_queries_total", "Number of SQL queries executed by the repository.",
+			func(s querylog.Stats) uint64 { return s.Queries }),
+		counter("sql_slow_queries_total", "Number of SQL queries slower than SQL_SLOW_QUERY_THRESHOLD.",
+			func(s querylog.Stats) uint64 { return s.Slow }),
+		counter("sql_queries_failed_total", "Number of SQL queries that returned an error.",
+			func(s querylog.Stats) uint64 { return s.Failed }),
+	)
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/asp3cto/task-manager/internal/adapters/repository/sqlpool"
+)
+
+// RegisterPoolStats exports the connection pool utilization of a SQL repository.
+// stats is called on every scrape.
+func RegisterPoolStats(registry prometheus.Registerer, stats func() sqlpool.Stats) {
+	gauge := func(name, help string, value func(sqlpool.Stats) float64) prometheus.Collector {
+		return prometheus.NewGaugeFunc(prometheus.GaugeOpts{Name: name, Help: help}, func() float64 {
+			return value(stats())
+		})
+	}
+	counter := func(name, help string, value func(sqlpool.Stats) float64) prometheus.Collector {
+		return prometheus.NewCounterFunc(prometheus.CounterOpts{Name: name, Help: help}, func() float64 {
+			return value(stats())
+		})
+	}
+
+	registry.MustRegister(
+		gauge("sql_pool_max_open_connections", "Maximum number of open SQL connections, 0 if unlimited.",
+			func(s sqlpool.Stats) float64 { return float64(s.MaxOpen) }),
+		gauge("sql_pool_open_connections", "Number of open SQL connections.",
+			func(s sqlpool.Stats) float64 { return float64(s.Open) }),
+		gauge("sql_pool_in_use_connections", "Number of SQL connections in use.",
+			func(s sqlpool.Stats) float64 { return float64(s.InUse) }),
+		gauge("sql_pool_idle_connections", "Number of idle SQL connections.",
+			func(s sqlpool.Stats) float64 { return float64(s.Idle) }),
+		counter("sql_pool_waits_total", "Number of times a SQL connection was waited for.",
+			func(s sqlpool.Stats) float64 { return float64(s.Waits) }),
+		counter("sql_pool_wait_seconds_total", "Total time spent waiting for SQL connections.",
+			func(s sqlpool.Stats) float64 { return s.WaitDuration.Seconds() }),
+		gauge("sql_cached_statements", "Number of cached prepared SQL statements, 0 if the backend does not report it.",
+			func(s sqlpool.Stats) float64 { return float64(s.CachedStatements) }),
+	)
+}
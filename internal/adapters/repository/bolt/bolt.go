@@ -0,0 +1,637 @@
+// Package bolt provides a TaskRepository persisted to a bbolt database file in a local
+// data directory, so the service keeps its tasks across restarts without any external
+// dependency.
+//
+// Tasks are stored as JSON documents keyed by ID. A nested bucket per status indexes
+// the IDs of the tasks with that status, so listings filtered by status only read the
+// matching tasks. With a Cipher the documents are encrypted, while the IDs and statuses
+// in the keys stay readable.
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.TaskRepository      = (*TaskRepository)(nil)
+	_ ports.RepositoryInspector = (*TaskRepository)(nil)
+	_ ports.RepositoryDumper    = (*TaskRepository)(nil)
+	_ ports.Reencrypter         = (*TaskRepository)(nil)
+)
+
+// ErrEncrypted is returned when reading tasks that are encrypted without a Cipher.
+var ErrEncrypted = errors.New("stored tasks are encrypted, STORAGE_ENCRYPTION_KEYS is required")
+
+// fileName is the name of the database file in the data directory.
+const fileName = "tasks.db"
+
+// lockTimeout is how long Open waits for the file lock held by another process.
+const lockTimeout = time.Second
+
+// iterateBatch is the number of tasks Iterate reads per read transaction.
+const iterateBatch = 256
+
+// Names of the top-level buckets.
+var (
+	tasksBucket    = []byte("tasks")
+	statusesBucket = []byte("statuses")
+)
+
+// Cipher encrypts stored tasks, bound to their ID, such as a repository.Keyring.
+// Encrypted values must not start with "{", which marks plaintext tasks.
+type Cipher interface {
+	// Encrypt encrypts plaintext for the task with the ID owner.
+	Encrypt(plaintext, owner string) (string, error)
+	// Decrypt decrypts a value encrypted for the task with the ID owner.
+	Decrypt(value, owner string) (string, error)
+}
+
+// TaskRepository implements ports.TaskRepository on a bbolt database.
+// The database file is locked while it is open, so a data directory
+// can only be used by one process at a time.
+type TaskRepository struct {
+	// mu guards db, which Reencrypt replaces by a compacted copy
+	mu   sync.RWMutex
+	db   *bolt.DB
+	path string
+	// cipher encrypts the stored tasks, nil stores them in plaintext
+	cipher Cipher
+}
+
+// Option configures optional TaskRepository behavior.
+type Option func(*TaskRepository)
+
+// WithCipher encrypts the stored tasks with cipher. Tasks stored in plaintext are still
+// read; they are encrypted when they are next written, or by Reencrypt.
+func WithCipher(cipher Cipher) Option {
+	return func(r *TaskRepository) {
+		r.cipher = cipher
+	}
+}
+
+// Open opens or creates the database in the directory dir and creates the buckets if needed.
+func Open(dir string, opts ...Option) (*TaskRepository, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	path := filepath.Join(dir, fileName)
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: lockTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(tasksBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(statusesBucket)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create buckets: %w", err)
+	}
+
+	r := &TaskRepository{db: db, path: path}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r, nil
+}
+
+// NewFromEnv opens the database in the data directory in the BOLT_DIR environment variable,
+// e.g. /var/lib/task-manager.
+// Returns nil if the variable is not set.
+func NewFromEnv(opts ...Option) (*TaskRepository, error) {
+	dir := os.Getenv("BOLT_DIR")
+	if dir == "" {
+		return nil, nil
+	}
+
+	return Open(dir, opts...)
+}
+
+// Close closes the database and releases the file lock.
+func (r *TaskRepository) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.db.Close()
+}
+
+// view runs fn in a read transaction.
+func (r *TaskRepository) view(fn func(tx *bolt.Tx) error) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.db.View(fn)
+}
+
+// update runs fn in a write transaction.
+func (r *TaskRepository) update(fn func(tx *bolt.Tx) error) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.db.Update(fn)
+}
+
+// Create stores a new task and adds it to the index of its status.
+// Returns domain.ErrTaskExists if a task with the same ID already exists.
+func (r *TaskRepository) Create(_ context.Context, task *domain.Task) error {
+	return r.update(func(tx *bolt.Tx) error {
+		tasks := tx.Bucket(tasksBucket)
+		if tasks.Get([]byte(task.ID)) != nil {
+			return domain.ErrTaskExists
+		}
+
+		return r.putTask(tx, task)
+	})
+}
+
+// GetByID retrieves a task by its unique identifier.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (r *TaskRepository) GetByID(_ context.Context, id string) (*domain.Task, error) {
+	var task *domain.Task
+	err := r.view(func(tx *bolt.Tx) error {
+		var err error
+		task, err = r.getTask(tx, []byte(id))
+		return err
+	})
+
+	return task, err
+}
+
+// GetByIDs retrieves the tasks with the given identifiers in a single transaction.
+// Unknown IDs are skipped; tasks are returned in the order of ids.
+func (r *TaskRepository) GetByIDs(_ context.Context, ids []string) ([]*domain.Task, error) {
+	tasks := make([]*domain.Task, 0, len(ids))
+	err := r.view(func(tx *bolt.Tx) error {
+		for _, id := range ids {
+			task, err := r.getTask(tx, []byte(id))
+			if errors.Is(err, domain.ErrTaskNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			tasks = append(tasks, task)
+		}
+		return nil
+	})
+
+	return tasks, err
+}
+
+// GetAll retrieves the page of tasks described by the query. Status filters are
+// resolved with the status indexes; the remaining conditions are checked, and the
+// tasks ordered and paged, on the loaded tasks.
+func (r *TaskRepository) GetAll(_ context.Context, query ports.ListQuery) (ports.TaskPage, error) {
+	filter := query.Filter
+	tasks := make([]*domain.Task, 0)
+	collect := func(id, data []byte) error {
+		task, err := r.decodeTask(id, data)
+		if err != nil {
+			return err
+		}
+		if filter.Matches(task) {
+			tasks = append(tasks, task)
+		}
+		return nil
+	}
+
+	err := r.view(func(tx *bolt.Tx) error {
+		if len(filter.Statuses) == 0 {
+			return tx.Bucket(tasksBucket).ForEach(collect)
+		}
+
+		all := tx.Bucket(tasksBucket)
+		// Each status is read once, so a listed status does not duplicate its tasks.
+		for _, status := range slices.Compact(slices.Sorted(slices.Values(filter.Statuses))) {
+			index := tx.Bucket(statusesBucket).Bucket([]byte(status))
+			if index == nil {
+				continue
+			}
+
+			err := index.ForEach(func(id, _ []byte) error {
+				return collect(id, all.Get(id))
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return ports.TaskPage{}, err
+	}
+
+	return ports.Paginate(tasks, query)
+}
+
+// Iterate visits the tasks matching the filter, walking the tasks bucket or, if statuses
+// are filtered, the status indexes. Tasks are read in batches, each in its own read
+// transaction, and fn is called between transactions, so it may modify the repository.
+func (r *TaskRepository) Iterate(ctx context.Context, filter ports.ListFilter, fn func(*domain.Task) error) error {
+	if len(filter.Statuses) == 0 {
+		return r.iterateIndex(ctx, filter, fn, func(tx *bolt.Tx) *bolt.Bucket {
+			return tx.Bucket(tasksBucket)
+		})
+	}
+
+	// Each status is read once, so a listed status does not duplicate its tasks.
+	for _, status := range slices.Compact(slices.Sorted(slices.Values(filter.Statuses))) {
+		err := r.iterateIndex(ctx, filter, fn, func(tx *bolt.Tx) *bolt.Bucket {
+			return tx.Bucket(statusesBucket).Bucket([]byte(status))
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// iterateIndex calls fn for the tasks matching the filter whose IDs are the keys of
+// the bucket returned by index, which may be nil if it does not exist.
+func (r *TaskRepository) iterateIndex(
+	ctx context.Context, filter ports.ListFilter, fn func(*domain.Task) error,
+	index func(tx *bolt.Tx) *bolt.Bucket,
+) error {
+	var last []byte
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		batch := make([]*domain.Task, 0, iterateBatch)
+		done := true
+		err := r.view(func(tx *bolt.Tx) error {
+			bucket := index(tx)
+			if bucket == nil {
+				return nil
+			}
+
+			// The batch resumes after the last key of the previous one.
+			c := bucket.Cursor()
+			id, _ := c.First()
+			if last != nil {
+				if id, _ = c.Seek(last); bytes.Equal(id, last) {
+					id, _ = c.Next()
+				}
+			}
+
+			all := tx.Bucket(tasksBucket)
+			for ; id != nil; id, _ = c.Next() {
+				if len(batch) == iterateBatch {
+					done = false
+					break
+				}
+				last = bytes.Clone(id)
+
+				data := all.Get(id)
+				if data == nil {
+					continue
+				}
+
+				task, err := r.decodeTask(id, data)
+				if err != nil {
+					return err
+				}
+				batch = append(batch, task)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, task := range batch {
+			if !filter.Matches(task) {
+				continue
+			}
+
+			if err := fn(task); err != nil {
+				return err
+			}
+		}
+
+		if done {
+			return nil
+		}
+	}
+}
+
+// Update modifies an existing task if its stored version still equals task.Version,
+// increments the version and moves the task to the index of its new status.
+// Returns domain.ErrVersionConflict if the task was modified concurrently.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (r *TaskRepository) Update(_ context.Context, task *domain.Task) error {
+	return r.update(func(tx *bolt.Tx) error {
+		stored, err := r.getTask(tx, []byte(task.ID))
+		if err != nil {
+			return err
+		}
+
+		if stored.Version != task.Version {
+			return domain.ErrVersionConflict
+		}
+
+		if err := deleteTask(tx, stored); err != nil {
+			return err
+		}
+
+		updated := *task
+		updated.Version++
+		if err := r.putTask(tx, &updated); err != nil {
+			return err
+		}
+
+		task.Version = updated.Version
+		return nil
+	})
+}
+
+// Delete removes a task by its ID.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (r *TaskRepository) Delete(_ context.Context, id string) error {
+	return r.update(func(tx *bolt.Tx) error {
+		task, err := r.getTask(tx, []byte(id))
+		if err != nil {
+			return err
+		}
+
+		return deleteTask(tx, task)
+	})
+}
+
+// Ping checks that the database is open and readable.
+func (r *TaskRepository) Ping(_ context.Context) error {
+	return r.view(func(*bolt.Tx) error { return nil })
+}
+
+// Inspect reports task counts per status from the status indexes and the size of the database file.
+func (r *TaskRepository) Inspect(_ context.Context) (ports.RepositoryStats, error) {
+	stats := ports.RepositoryStats{
+		Backend:    "bolt",
+		TaskCounts: make(map[domain.TaskStatus]int),
+	}
+
+	err := r.view(func(tx *bolt.Tx) error {
+		statuses := tx.Bucket(statusesBucket)
+		err := statuses.ForEachBucket(func(status []byte) error {
+			if n := statuses.Bucket(status).Stats().KeyN; n > 0 {
+				stats.TaskCounts[domain.TaskStatus(status)] = n
+			}
+			return nil
+		})
+
+		stats.SizeBytes = tx.Size()
+		return err
+	})
+	if err != nil {
+		return ports.RepositoryStats{}, err
+	}
+
+	return stats, nil
+}
+
+// Dump returns a page of stored tasks ordered by ID.
+func (r *TaskRepository) Dump(_ context.Context, offset, limit int) ([]*domain.Task, error) {
+	tasks := make([]*domain.Task, 0, limit)
+	err := r.view(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(tasksBucket).Cursor()
+		skipped := 0
+		for id, data := cursor.First(); id != nil && len(tasks) < limit; id, data = cursor.Next() {
+			if skipped < offset {
+				skipped++
+				continue
+			}
+
+			task, err := r.decodeTask(id, data)
+			if err != nil {
+				return err
+			}
+			tasks = append(tasks, task)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// Reencrypt rewrites every stored task with the current key of the cipher, or in plaintext
+// without a cipher, so the keys used before a rotation can be removed. Tasks are rewritten
+// in batches, each in its own write transaction; their versions are kept, as their content
+// does not change. The database file is then compacted, as the pages freed by the rewrite
+// still hold the earlier values.
+// Returns the number of tasks rewritten as ReencryptionResult.StoredTasks.
+func (r *TaskRepository) Reencrypt(ctx context.Context) (ports.ReencryptionResult, error) {
+	var (
+		result ports.ReencryptionResult
+		last   []byte
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		n := 0
+		err := r.update(func(tx *bolt.Tx) error {
+			// The batch is read before it is written, as writes invalidate the cursor.
+			tasks := tx.Bucket(tasksBucket)
+			batch := make(map[string][]byte, iterateBatch)
+			c := tasks.Cursor()
+			id, data := c.First()
+			if last != nil {
+				if id, data = c.Seek(last); bytes.Equal(id, last) {
+					id, data = c.Next()
+				}
+			}
+
+			for ; id != nil && len(batch) < iterateBatch; id, data = c.Next() {
+				task, err := r.decodeTask(id, data)
+				if err != nil {
+					return fmt.Errorf("failed to read task %s: %w", id, err)
+				}
+
+				if batch[string(id)], err = r.encodeTask(task); err != nil {
+					return err
+				}
+				last = bytes.Clone(id)
+			}
+
+			for id, data := range batch {
+				if err := tasks.Put([]byte(id), data); err != nil {
+					return err
+				}
+			}
+
+			n = len(batch)
+			return nil
+		})
+		if err != nil {
+			return result, err
+		}
+
+		result.StoredTasks += n
+		if n < iterateBatch {
+			return result, r.compact()
+		}
+	}
+}
+
+// compact replaces the database file by a copy holding only the stored data.
+// Requests wait until the copy is in place.
+func (r *TaskRepository) compact() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tmp := r.path + ".compact"
+	_ = os.Remove(tmp)
+	dst, err := bolt.Open(tmp, 0o600, &bolt.Options{Timeout: lockTimeout})
+	if err != nil {
+		return fmt.Errorf("failed to create compacted database: %w", err)
+	}
+
+	if err := bolt.Compact(dst, r.db, 0); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to compact database: %w", err)
+	}
+
+	if err := dst.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to compact database: %w", err)
+	}
+
+	// The file is only replaced once the copy is complete, so a failure keeps the current one.
+	if err := r.db.Close(); err != nil {
+		return fmt.Errorf("failed to close database: %w", err)
+	}
+
+	renameErr := os.Rename(tmp, r.path)
+	db, err := bolt.Open(r.path, 0o600, &bolt.Options{Timeout: lockTimeout})
+	if err != nil {
+		return fmt.Errorf("failed to reopen database: %w", errors.Join(renameErr, err))
+	}
+	r.db = db
+
+	if renameErr != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to replace database by compacted copy: %w", renameErr)
+	}
+
+	return nil
+}
+
+// getTask loads the task with the given ID.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (r *TaskRepository) getTask(tx *bolt.Tx, id []byte) (*domain.Task, error) {
+	data := tx.Bucket(tasksBucket).Get(id)
+	if data == nil {
+		return nil, domain.ErrTaskNotFound
+	}
+
+	return r.decodeTask(id, data)
+}
+
+// putTask stores task and adds it to the index of its status.
+func (r *TaskRepository) putTask(tx *bolt.Tx, task *domain.Task) error {
+	data, err := r.encodeTask(task)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Bucket(tasksBucket).Put([]byte(task.ID), data); err != nil {
+		return err
+	}
+
+	index, err := tx.Bucket(statusesBucket).CreateBucketIfNotExists([]byte(task.Status))
+	if err != nil {
+		return err
+	}
+
+	return index.Put([]byte(task.ID), []byte{})
+}
+
+// deleteTask removes task and its entry in the index of its status.
+func deleteTask(tx *bolt.Tx, task *domain.Task) error {
+	if err := tx.Bucket(tasksBucket).Delete([]byte(task.ID)); err != nil {
+		return err
+	}
+
+	if index := tx.Bucket(statusesBucket).Bucket([]byte(task.Status)); index != nil {
+		return index.Delete([]byte(task.ID))
+	}
+
+	return nil
+}
+
+// encodeTask encodes task for storage, encrypted if a cipher is configured.
+func (r *TaskRepository) encodeTask(task *domain.Task) ([]byte, error) {
+	data, err := json.Marshal(task)
+	if err != nil || r.cipher == nil {
+		return data, err
+	}
+
+	sealed, err := r.cipher.Encrypt(string(data), task.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt task: %w", err)
+	}
+
+	return []byte(sealed), nil
+}
+
+// decodeTask decodes the stored task with the given ID. Decoding copies the data, which is
+// only valid during the transaction.
+// Tasks stored before priorities were introduced get the default priority, and
+// tasks stored before manual ordering the rank of their creation time.
+// Returns ErrEncrypted if the task is encrypted and no cipher is configured.
+func (r *TaskRepository) decodeTask(id, data []byte) (*domain.Task, error) {
+	// Plaintext tasks are JSON objects; anything else was encrypted.
+	if len(data) > 0 && data[0] != '{' {
+		if r.cipher == nil {
+			return nil, ErrEncrypted
+		}
+
+		plaintext, err := r.cipher.Decrypt(string(data), string(id))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt stored task: %w", err)
+		}
+		data = []byte(plaintext)
+	}
+
+	var task domain.Task
+	if err := json.Unmarshal(data, &task); err != nil {
+		return nil, fmt.Errorf("invalid stored task: %w", err)
+	}
+
+	if task.Priority == "" {
+		task.Priority = domain.PriorityMedium
+	}
+
+	if task.Rank == "" {
+		task.Rank = domain.InitialRank(task.CreatedAt)
+	}
+
+	return &task, nil
+}
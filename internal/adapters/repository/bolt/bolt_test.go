@@ -0,0 +1,39 @@
+package bolt_test
+
+import (
+	"testing"
+
+	"github.com/asp3cto/task-manager/internal/adapters/repository"
+	"github.com/asp3cto/task-manager/internal/adapters/repository/bolt"
+	"github.com/asp3cto/task-manager/internal/adapters/repository/repositorytest"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+func TestTaskRepository(t *testing.T) {
+	repositorytest.RunRepositoryTests(t, func(t *testing.T) ports.TaskRepository {
+		repo, err := bolt.Open(t.TempDir())
+		if err != nil {
+			t.Fatalf("failed to open repository: %v", err)
+		}
+		t.Cleanup(func() { _ = repo.Close() })
+
+		return repo
+	})
+}
+
+func TestEncryptedTaskRepository(t *testing.T) {
+	keyring, err := repository.NewKeyring("k1", map[string][]byte{"k1": make([]byte, 32)})
+	if err != nil {
+		t.Fatalf("failed to create keyring: %v", err)
+	}
+
+	repositorytest.RunRepositoryTests(t, func(t *testing.T) ports.TaskRepository {
+		repo, err := bolt.Open(t.TempDir(), bolt.WithCipher(keyring))
+		if err != nil {
+			t.Fatalf("failed to open repository: %v", err)
+		}
+		t.Cleanup(func() { _ = repo.Close() })
+
+		return repo
+	})
+}
@@ -0,0 +1,330 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.TaskRepository      = (*CircuitBreakerTaskRepository)(nil)
+	_ ports.RepositoryInspector = (*CircuitBreakerTaskRepository)(nil)
+	_ ports.RepositoryDumper    = (*CircuitBreakerTaskRepository)(nil)
+)
+
+// Defaults of CircuitBreakerTaskRepository.
+const (
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+// States of a circuit breaker.
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half_open"
+)
+
+// CircuitBreakerTaskRepository decorates a TaskRepository with a circuit breaker.
+// After a number of consecutive failures of the store the circuit opens, and operations
+// fail immediately with ports.ErrRepositoryUnavailable instead of waiting for the store.
+// Once the cooldown has passed the circuit is half-open: a single operation is let
+// through as a probe, and the circuit closes if it succeeds or opens again if it fails.
+// Domain errors and canceled operations are not failures of the store.
+type CircuitBreakerTaskRepository struct {
+	next      ports.TaskRepository
+	logger    logger.Logger
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// BreakerOption configures optional CircuitBreakerTaskRepository behavior.
+type BreakerOption func(*CircuitBreakerTaskRepository)
+
+// WithBreakerThreshold sets the number of consecutive failures opening the circuit.
+// The default is 5.
+func WithBreakerThreshold(threshold int) BreakerOption {
+	return func(r *CircuitBreakerTaskRepository) {
+		r.threshold = threshold
+	}
+}
+
+// WithBreakerCooldown sets how long the circuit stays open before a probe is let through.
+// The default is 30 seconds.
+func WithBreakerCooldown(cooldown time.Duration) BreakerOption {
+	return func(r *CircuitBreakerTaskRepository) {
+		r.cooldown = cooldown
+	}
+}
+
+// NewCircuitBreakerTaskRepository wraps next with a circuit breaker.
+// Transitions of the circuit are logged with logger.
+func NewCircuitBreakerTaskRepository(
+	next ports.TaskRepository, logger logger.Logger, opts ...BreakerOption,
+) *CircuitBreakerTaskRepository {
+	r := &CircuitBreakerTaskRepository{
+		next:      next,
+		logger:    logger.With(slog.String("component", "circuit_breaker")),
+		threshold: defaultBreakerThreshold,
+		cooldown:  defaultBreakerCooldown,
+		now:       time.Now,
+		state:     BreakerClosed,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// BreakerOptionsFromEnv returns the circuit breaker options from the
+// REPOSITORY_BREAKER_THRESHOLD and REPOSITORY_BREAKER_COOLDOWN environment variables.
+func BreakerOptionsFromEnv() ([]BreakerOption, error) {
+	var opts []BreakerOption
+	if raw := os.Getenv("REPOSITORY_BREAKER_THRESHOLD"); raw != "" {
+		threshold, err := strconv.Atoi(raw)
+		if err != nil || threshold < 1 {
+			return nil, fmt.Errorf("REPOSITORY_BREAKER_THRESHOLD must be a positive integer, got: %s", raw)
+		}
+		opts = append(opts, WithBreakerThreshold(threshold))
+	}
+
+	if raw := os.Getenv("REPOSITORY_BREAKER_COOLDOWN"); raw != "" {
+		cooldown, err := time.ParseDuration(raw)
+		if err != nil || cooldown <= 0 {
+			return nil, fmt.Errorf("REPOSITORY_BREAKER_COOLDOWN must be a positive duration, got: %s", raw)
+		}
+		opts = append(opts, WithBreakerCooldown(cooldown))
+	}
+
+	return opts, nil
+}
+
+// State returns the current state of the circuit: BreakerClosed, BreakerOpen or BreakerHalfOpen.
+func (r *CircuitBreakerTaskRepository) State() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.state
+}
+
+// Create stores a new task unless the circuit is open.
+func (r *CircuitBreakerTaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	return r.do(ctx, func() error {
+		return r.next.Create(ctx, task)
+	})
+}
+
+// GetByID retrieves a task by its ID unless the circuit is open.
+func (r *CircuitBreakerTaskRepository) GetByID(ctx context.Context, id string) (*domain.Task, error) {
+	var task *domain.Task
+	err := r.do(ctx, func() (err error) {
+		task, err = r.next.GetByID(ctx, id)
+		return err
+	})
+
+	return task, err
+}
+
+// GetByIDs retrieves tasks by their IDs unless the circuit is open.
+func (r *CircuitBreakerTaskRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+	err := r.do(ctx, func() (err error) {
+		tasks, err = r.next.GetByIDs(ctx, ids)
+		return err
+	})
+
+	return tasks, err
+}
+
+// GetAll retrieves a page of tasks matching the query unless the circuit is open.
+func (r *CircuitBreakerTaskRepository) GetAll(ctx context.Context, query ports.ListQuery) (ports.TaskPage, error) {
+	var page ports.TaskPage
+	err := r.do(ctx, func() (err error) {
+		page, err = r.next.GetAll(ctx, query)
+		return err
+	})
+
+	return page, err
+}
+
+// Iterate streams the tasks matching the filter unless the circuit is open.
+// Errors returned by fn are not failures of the store.
+func (r *CircuitBreakerTaskRepository) Iterate(
+	ctx context.Context, filter ports.ListFilter, fn func(*domain.Task) error,
+) error {
+	var fnErr error
+	err := r.do(ctx, func() error {
+		err := r.next.Iterate(ctx, filter, func(task *domain.Task) error {
+			fnErr = fn(task)
+			return fnErr
+		})
+		if fnErr != nil {
+			return nil
+		}
+		return err
+	})
+	if fnErr != nil {
+		return fnErr
+	}
+
+	return err
+}
+
+// Update persists a task unless the circuit is open.
+func (r *CircuitBreakerTaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	return r.do(ctx, func() error {
+		return r.next.Update(ctx, task)
+	})
+}
+
+// Delete removes a task unless the circuit is open.
+func (r *CircuitBreakerTaskRepository) Delete(ctx context.Context, id string) error {
+	return r.do(ctx, func() error {
+		return r.next.Delete(ctx, id)
+	})
+}
+
+// Ping checks the health of the underlying repository unless the circuit is open,
+// so readiness checks fail while the store is considered unavailable.
+func (r *CircuitBreakerTaskRepository) Ping(ctx context.Context) error {
+	return r.do(ctx, func() error {
+		return r.next.Ping(ctx)
+	})
+}
+
+// Inspect reports the state of the underlying repository unless the circuit is open.
+func (r *CircuitBreakerTaskRepository) Inspect(ctx context.Context) (ports.RepositoryStats, error) {
+	inspector, ok := r.next.(ports.RepositoryInspector)
+	if !ok {
+		return ports.RepositoryStats{}, ErrNotSupported
+	}
+
+	var stats ports.RepositoryStats
+	err := r.do(ctx, func() (err error) {
+		stats, err = inspector.Inspect(ctx)
+		return err
+	})
+
+	return stats, err
+}
+
+// Dump returns raw tasks from the underlying repository unless the circuit is open.
+func (r *CircuitBreakerTaskRepository) Dump(ctx context.Context, offset, limit int) ([]*domain.Task, error) {
+	dumper, ok := r.next.(ports.RepositoryDumper)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+
+	var tasks []*domain.Task
+	err := r.do(ctx, func() (err error) {
+		tasks, err = dumper.Dump(ctx, offset, limit)
+		return err
+	})
+
+	return tasks, err
+}
+
+// do calls op if the circuit lets it through and records its outcome.
+func (r *CircuitBreakerTaskRepository) do(ctx context.Context, op func() error) error {
+	if !r.allow(ctx) {
+		return fmt.Errorf("%w: circuit breaker is open", ports.ErrRepositoryUnavailable)
+	}
+
+	err := op()
+	r.record(ctx, err)
+	return err
+}
+
+// allow reports whether an operation may run, turning an open circuit whose
+// cooldown has passed into a half-open one with the operation as its probe.
+func (r *CircuitBreakerTaskRepository) allow(ctx context.Context) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch r.state {
+	case BreakerOpen:
+		if r.now().Sub(r.openedAt) < r.cooldown {
+			return false
+		}
+		r.transition(ctx, BreakerHalfOpen)
+		r.probing = true
+		return true
+	case BreakerHalfOpen:
+		if r.probing {
+			return false
+		}
+		r.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record updates the circuit with the outcome of an operation.
+func (r *CircuitBreakerTaskRepository) record(ctx context.Context, err error) {
+	failed := err != nil && !isDomainError(err) && !errors.Is(err, context.Canceled)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.state == BreakerHalfOpen {
+		r.probing = false
+		if failed {
+			r.open(ctx)
+		} else {
+			r.failures = 0
+			r.transition(ctx, BreakerClosed)
+		}
+		return
+	}
+
+	if !failed {
+		r.failures = 0
+		return
+	}
+
+	r.failures++
+	if r.state == BreakerClosed && r.failures >= r.threshold {
+		r.open(ctx)
+	}
+}
+
+// open opens the circuit for the cooldown.
+func (r *CircuitBreakerTaskRepository) open(ctx context.Context) {
+	r.openedAt = r.now()
+	r.transition(ctx, BreakerOpen)
+}
+
+// transition changes the state of the circuit and logs the change.
+func (r *CircuitBreakerTaskRepository) transition(ctx context.Context, state string) {
+	if r.state == state {
+		return
+	}
+
+	attrs := []slog.Attr{slog.String("from", r.state), slog.String("to", state)}
+	if state == BreakerOpen {
+		r.logger.Warn(ctx, "circuit breaker opened", append(attrs, slog.Int("failures", r.failures))...)
+	} else {
+		r.logger.Info(ctx, "circuit breaker state changed", attrs...)
+	}
+
+	r.state = state
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.TaskRepository      = (*CoalescingTaskRepository)(nil)
+	_ ports.RepositoryInspector = (*CoalescingTaskRepository)(nil)
+	_ ports.RepositoryDumper    = (*CoalescingTaskRepository)(nil)
+)
+
+// CoalescingTaskRepository decorates a TaskRepository so that concurrent reads of the same
+// task share one call to the store: while a GetByID for a task is in flight, further GetByID
+// calls for it wait for its result instead of reading the task again. This protects the store
+// from many clients polling a hot task. Every caller gets its own copy of the task.
+// A write of a task ends the sharing of reads started before it, so a read made after a write
+// returned always sees the write.
+type CoalescingTaskRepository struct {
+	next  ports.TaskRepository
+	reads singleflight.Group
+}
+
+// NewCoalescingTaskRepository wraps next with coalescing of concurrent reads.
+func NewCoalescingTaskRepository(next ports.TaskRepository) *CoalescingTaskRepository {
+	return &CoalescingTaskRepository{next: next}
+}
+
+// Create stores a new task in the underlying repository.
+func (r *CoalescingTaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	err := r.next.Create(ctx, task)
+	r.reads.Forget(task.ID)
+
+	return err
+}
+
+// GetByID retrieves a task by its ID, sharing the call to the underlying repository with
+// concurrent reads of the same task. The shared call runs with the context of the caller that
+// started it; if that caller goes away, the others read the task again with their own context.
+func (r *CoalescingTaskRepository) GetByID(ctx context.Context, id string) (*domain.Task, error) {
+	results := r.reads.DoChan(id, func() (any, error) {
+		return r.next.GetByID(ctx, id)
+	})
+
+	var result singleflight.Result
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result = <-results:
+	}
+
+	if result.Err != nil {
+		if result.Shared && isCanceled(result.Err) && ctx.Err() == nil {
+			return r.next.GetByID(ctx, id)
+		}
+		return nil, result.Err
+	}
+
+	taskCopy := *result.Val.(*domain.Task)
+	return &taskCopy, nil
+}
+
+// GetByIDs retrieves the tasks with the given IDs from the underlying repository.
+func (r *CoalescingTaskRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.Task, error) {
+	return r.next.GetByIDs(ctx, ids)
+}
+
+// GetAll retrieves a page of tasks from the underlying repository.
+func (r *CoalescingTaskRepository) GetAll(ctx context.Context, query ports.ListQuery) (ports.TaskPage, error) {
+	return r.next.GetAll(ctx, query)
+}
+
+// Iterate streams the tasks matching the filter from the underlying repository.
+func (r *CoalescingTaskRepository) Iterate(
+	ctx context.Context, filter ports.ListFilter, fn func(*domain.Task) error,
+) error {
+	return r.next.Iterate(ctx, filter, fn)
+}
+
+// Update modifies a task in the underlying repository.
+func (r *CoalescingTaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	err := r.next.Update(ctx, task)
+	r.reads.Forget(task.ID)
+
+	return err
+}
+
+// Delete removes a task from the underlying repository.
+func (r *CoalescingTaskRepository) Delete(ctx context.Context, id string) error {
+	err := r.next.Delete(ctx, id)
+	r.reads.Forget(id)
+
+	return err
+}
+
+// Ping checks the health of the underlying repository.
+func (r *CoalescingTaskRepository) Ping(ctx context.Context) error {
+	return r.next.Ping(ctx)
+}
+
+// Inspect reports the state of the underlying repository.
+func (r *CoalescingTaskRepository) Inspect(ctx context.Context) (ports.RepositoryStats, error) {
+	inspector, ok := r.next.(ports.RepositoryInspector)
+	if !ok {
+		return ports.RepositoryStats{}, ErrNotSupported
+	}
+
+	return inspector.Inspect(ctx)
+}
+
+// Dump returns raw tasks from the underlying repository.
+func (r *CoalescingTaskRepository) Dump(ctx context.Context, offset, limit int) ([]*domain.Task, error) {
+	dumper, ok := r.next.(ports.RepositoryDumper)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+
+	return dumper.Dump(ctx, offset, limit)
+}
+
+// isCanceled reports whether err is the result of a canceled or expired context.
+func isCanceled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
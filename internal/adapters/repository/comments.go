@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var _ ports.CommentRepository = (*MemoryCommentRepository)(nil)
+
+// MemoryCommentRepository provides an in-memory implementation of the CommentRepository interface.
+// Comments are grouped by task, in the order they were created.
+// Data is lost when the application restarts since it's stored only in memory.
+type MemoryCommentRepository struct {
+	// comments stores the comments of each task indexed by task ID
+	comments map[string][]*domain.Comment
+	// mu provides thread-safe access to the comments map
+	mu sync.RWMutex
+}
+
+// NewMemoryCommentRepository creates a new instance of the in-memory comment repository.
+func NewMemoryCommentRepository() *MemoryCommentRepository {
+	return &MemoryCommentRepository{
+		comments: make(map[string][]*domain.Comment),
+	}
+}
+
+// Create stores a new comment after the existing comments of its task.
+// The repository does not know about tasks, so it never returns domain.ErrTaskNotFound.
+func (r *MemoryCommentRepository) Create(_ context.Context, comment *domain.Comment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	commentCopy := *comment
+	r.comments[comment.TaskID] = append(r.comments[comment.TaskID], &commentCopy)
+	return nil
+}
+
+// ListByTask returns copies of the comments of a task, oldest first.
+func (r *MemoryCommentRepository) ListByTask(_ context.Context, taskID string) ([]*domain.Comment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	comments := make([]*domain.Comment, 0, len(r.comments[taskID]))
+	for _, comment := range r.comments[taskID] {
+		commentCopy := *comment
+		comments = append(comments, &commentCopy)
+	}
+
+	return comments, nil
+}
+
+// Update replaces the author, body and update time of an existing comment.
+// Returns domain.ErrCommentNotFound if the task has no comment with the ID of comment.
+func (r *MemoryCommentRepository) Update(_ context.Context, comment *domain.Comment) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	comments := r.comments[comment.TaskID]
+	i := slices.IndexFunc(comments, func(stored *domain.Comment) bool {
+		return stored.ID == comment.ID
+	})
+	if i < 0 {
+		return domain.ErrCommentNotFound
+	}
+
+	updated := *comments[i]
+	updated.Author = comment.Author
+	updated.Body = comment.Body
+	updated.UpdatedAt = comment.UpdatedAt
+	comments[i] = &updated
+	return nil
+}
+
+// Delete removes a comment from a task.
+// Returns domain.ErrCommentNotFound if the task has no comment with the given ID.
+func (r *MemoryCommentRepository) Delete(_ context.Context, taskID, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	comments := r.comments[taskID]
+	i := slices.IndexFunc(comments, func(comment *domain.Comment) bool {
+		return comment.ID == id
+	})
+	if i < 0 {
+		return domain.ErrCommentNotFound
+	}
+
+	if len(comments) == 1 {
+		delete(r.comments, taskID)
+		return nil
+	}
+
+	r.comments[taskID] = slices.Delete(comments, i, i+1)
+	return nil
+}
+
+// DeleteByTask removes all comments of a task.
+func (r *MemoryCommentRepository) DeleteByTask(_ context.Context, taskID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.comments, taskID)
+	return nil
+}
@@ -0,0 +1,451 @@
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.TaskRepository = (*DurableMemoryTaskRepository)(nil)
+	_ ports.Reencrypter    = (*DurableMemoryTaskRepository)(nil)
+)
+
+// ErrStorageEncrypted is returned when opening data files that are encrypted without a keyring.
+var ErrStorageEncrypted = errors.New("data files are encrypted, STORAGE_ENCRYPTION_KEYS is required")
+
+// Names of the files in the data directory of a DurableMemoryTaskRepository.
+const (
+	walFileName      = "wal.log"
+	snapshotFileName = "snapshot.json"
+)
+
+// defaultSnapshotInterval is used when SNAPSHOT_INTERVAL is not set.
+const defaultSnapshotInterval = 5 * time.Minute
+
+// Operations recorded in the write-ahead log.
+const (
+	walOpPut    = "put"
+	walOpDelete = "delete"
+)
+
+// walRecord is a line of the write-ahead log: a task stored by Create or Update,
+// or the ID of a deleted task.
+type walRecord struct {
+	Op   string       `json:"op"`
+	Task *domain.Task `json:"task,omitempty"`
+	ID   string       `json:"id,omitempty"`
+}
+
+// DurableMemoryTaskRepository is a MemoryTaskRepository that survives crashes.
+// Every mutation is appended to a write-ahead log and synced to disk before it is
+// applied in memory, and periodic snapshots of all tasks let the log be truncated.
+// On startup the latest snapshot is loaded and the log is replayed on top of it.
+// Reads are served from memory.
+type DurableMemoryTaskRepository struct {
+	*MemoryTaskRepository
+
+	dir string
+	// keyring encrypts the log records and the snapshot, nil keeps them in plaintext
+	keyring *Keyring
+	// mu serializes mutations, so the log records them in the order they are applied
+	mu  sync.Mutex
+	wal *os.File
+}
+
+// DurableOption configures optional DurableMemoryTaskRepository behavior.
+type DurableOption func(*DurableMemoryTaskRepository)
+
+// WithFileEncryption encrypts the records of the write-ahead log and the snapshot with the
+// primary key of keyring. Files written in plaintext or with an older key of the keyring are
+// still read; they are rewritten with the primary key by the next snapshot.
+func WithFileEncryption(keyring *Keyring) DurableOption {
+	return func(r *DurableMemoryTaskRepository) {
+		r.keyring = keyring
+	}
+}
+
+// NewDurableMemoryTaskRepository recovers the tasks stored in the directory dir,
+// creating it if needed, and returns a repository logging its mutations there.
+// A record torn by a crash during a write is discarded from the end of the log.
+// Returns ErrStorageEncrypted if the files are encrypted and no keyring is configured.
+func NewDurableMemoryTaskRepository(dir string, opts ...DurableOption) (*DurableMemoryTaskRepository, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	r := &DurableMemoryTaskRepository{MemoryTaskRepository: NewMemoryTaskRepository(), dir: dir}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if err := r.loadSnapshot(); err != nil {
+		return nil, err
+	}
+
+	wal, err := os.OpenFile(filepath.Join(dir, walFileName), os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open write-ahead log: %w", err)
+	}
+
+	if err := r.replay(wal); err != nil {
+		_ = wal.Close()
+		return nil, err
+	}
+
+	r.wal = wal
+	return r, nil
+}
+
+// NewDurableMemoryTaskRepositoryFromEnv recovers the tasks stored in the directory
+// in the WAL_DIR environment variable, e.g. /var/lib/task-manager.
+// Returns nil if the variable is not set, which keeps tasks in memory only.
+func NewDurableMemoryTaskRepositoryFromEnv(opts ...DurableOption) (*DurableMemoryTaskRepository, error) {
+	dir := os.Getenv("WAL_DIR")
+	if dir == "" {
+		return nil, nil
+	}
+
+	return NewDurableMemoryTaskRepository(dir, opts...)
+}
+
+// SnapshotIntervalFromEnv returns the interval between snapshots from the
+// SNAPSHOT_INTERVAL environment variable, or 5 minutes if it is not set.
+func SnapshotIntervalFromEnv() (time.Duration, error) {
+	raw := os.Getenv("SNAPSHOT_INTERVAL")
+	if raw == "" {
+		return defaultSnapshotInterval, nil
+	}
+
+	interval, err := time.ParseDuration(raw)
+	if err != nil || interval <= 0 {
+		return 0, fmt.Errorf("SNAPSHOT_INTERVAL must be a positive duration, got: %s", raw)
+	}
+
+	return interval, nil
+}
+
+// Create logs and stores a new task.
+// Returns domain.ErrTaskExists if a task with the same ID already exists.
+func (r *DurableMemoryTaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.MemoryTaskRepository.GetByID(ctx, task.ID); err == nil {
+		return domain.ErrTaskExists
+	}
+
+	taskCopy := *task
+	if err := r.append(walRecord{Op: walOpPut, Task: &taskCopy}); err != nil {
+		return err
+	}
+
+	return r.MemoryTaskRepository.Create(ctx, &taskCopy)
+}
+
+// Update logs and applies a modification of an existing task if its stored
+// version still equals task.Version, and increments the version.
+// Returns domain.ErrVersionConflict if the task was modified concurrently.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (r *DurableMemoryTaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, err := r.MemoryTaskRepository.GetByID(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+
+	if stored.Version != task.Version {
+		return domain.ErrVersionConflict
+	}
+
+	updated := *task
+	updated.Version++
+	if err := r.append(walRecord{Op: walOpPut, Task: &updated}); err != nil {
+		return err
+	}
+
+	return r.MemoryTaskRepository.Update(ctx, task)
+}
+
+// Delete logs and applies the removal of a task by its ID.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (r *DurableMemoryTaskRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, err := r.MemoryTaskRepository.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	if err := r.append(walRecord{Op: walOpDelete, ID: id}); err != nil {
+		return err
+	}
+
+	return r.MemoryTaskRepository.Delete(ctx, id)
+}
+
+// Snapshot writes all tasks to the snapshot file and truncates the write-ahead log.
+// Mutations wait until the snapshot is written.
+func (r *DurableMemoryTaskRepository) Snapshot() error {
+	_, err := r.snapshot()
+	return err
+}
+
+// Reencrypt rewrites the data files with the primary key of the keyring by taking a snapshot,
+// which replaces the write-ahead log, so the keys used before a rotation can be removed.
+// Returns the number of tasks written as ReencryptionResult.StoredTasks.
+func (r *DurableMemoryTaskRepository) Reencrypt(_ context.Context) (ports.ReencryptionResult, error) {
+	n, err := r.snapshot()
+	if err != nil {
+		return ports.ReencryptionResult{}, err
+	}
+
+	return ports.ReencryptionResult{StoredTasks: n}, nil
+}
+
+// snapshot implements Snapshot and returns the number of tasks written.
+func (r *DurableMemoryTaskRepository) snapshot() (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Mutations are serialized by mu, so the shards are visited in a consistent state.
+	tasks := make([]*domain.Task, 0)
+	r.each(func(task *domain.Task) {
+		tasks = append(tasks, task)
+	})
+
+	data, err := json.Marshal(tasks)
+	if err != nil {
+		return 0, err
+	}
+
+	if data, err = r.seal(data, snapshotFileName); err != nil {
+		return 0, err
+	}
+
+	// The snapshot replaces the previous one atomically, so a crash while it is
+	// written leaves the previous snapshot and the full log in place.
+	path := filepath.Join(r.dir, snapshotFileName)
+	if err := writeFileSync(path+".tmp", data); err != nil {
+		return 0, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return 0, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	if err := syncDir(r.dir); err != nil {
+		return 0, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	if err := r.wal.Truncate(0); err != nil {
+		return 0, fmt.Errorf("failed to truncate write-ahead log: %w", err)
+	}
+
+	return len(tasks), r.wal.Sync()
+}
+
+// Run takes a snapshot every interval until ctx is canceled.
+func (r *DurableMemoryTaskRepository) Run(ctx context.Context, interval time.Duration, log logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := r.Snapshot(); err != nil {
+				log.Error(ctx, "snapshot failed", slog.String("error", err.Error()))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Close takes a final snapshot and closes the write-ahead log.
+func (r *DurableMemoryTaskRepository) Close() error {
+	err := r.Snapshot()
+	return errors.Join(err, r.wal.Close())
+}
+
+// append writes record to the write-ahead log and syncs it to disk.
+func (r *DurableMemoryTaskRepository) append(record walRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if data, err = r.seal(data, walFileName); err != nil {
+		return err
+	}
+
+	if _, err := r.wal.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write to write-ahead log: %w", err)
+	}
+
+	if err := r.wal.Sync(); err != nil {
+		return fmt.Errorf("failed to sync write-ahead log: %w", err)
+	}
+
+	return nil
+}
+
+// loadSnapshot restores the tasks of the snapshot file, if there is one.
+func (r *DurableMemoryTaskRepository) loadSnapshot() error {
+	data, err := os.ReadFile(filepath.Join(r.dir, snapshotFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	if data, err = r.open(data, snapshotFileName); err != nil {
+		return fmt.Errorf("invalid snapshot: %w", err)
+	}
+
+	var tasks []*domain.Task
+	if err := json.Unmarshal(data, &tasks); err != nil {
+		return fmt.Errorf("invalid snapshot: %w", err)
+	}
+
+	for _, task := range tasks {
+		r.put(upgradeTask(task))
+	}
+
+	return nil
+}
+
+// replay applies the records of the write-ahead log on top of the snapshot.
+// A last line without a newline was torn by a crash and is cut off the log.
+func (r *DurableMemoryTaskRepository) replay(wal *os.File) error {
+	reader := bufio.NewReader(wal)
+	var offset int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(line) > 0 {
+				return wal.Truncate(offset)
+			}
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read write-ahead log: %w", err)
+		}
+
+		data, err := r.open(bytes.TrimSpace(line), walFileName)
+		if err != nil {
+			return fmt.Errorf("invalid write-ahead log record at offset %d: %w", offset, err)
+		}
+
+		var record walRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			return fmt.Errorf("invalid write-ahead log record at offset %d: %w", offset, err)
+		}
+
+		switch {
+		case record.Op == walOpPut && record.Task != nil:
+			r.put(upgradeTask(record.Task))
+		case record.Op == walOpDelete:
+			r.remove(record.ID)
+		default:
+			return fmt.Errorf("invalid write-ahead log record at offset %d: unknown operation %q", offset, record.Op)
+		}
+
+		offset += int64(len(line))
+	}
+}
+
+// seal encrypts the JSON data written to the file name with the keyring, if configured.
+// The result is a single line of text.
+func (r *DurableMemoryTaskRepository) seal(data []byte, name string) ([]byte, error) {
+	if r.keyring == nil {
+		return data, nil
+	}
+
+	sealed, err := r.keyring.Encrypt(string(data), name)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(sealed), nil
+}
+
+// open reverses seal for data read from the file name. Plaintext data, written before
+// encryption was configured, is returned unchanged.
+// Returns ErrStorageEncrypted if data is encrypted and no keyring is configured.
+func (r *DurableMemoryTaskRepository) open(data []byte, name string) ([]byte, error) {
+	if !bytes.HasPrefix(data, []byte(encryptedPrefix)) && !bytes.HasPrefix(data, []byte(legacyPrefix)) {
+		return data, nil
+	}
+
+	if r.keyring == nil {
+		return nil, ErrStorageEncrypted
+	}
+
+	plaintext, err := r.keyring.Decrypt(string(data), name)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(plaintext), nil
+}
+
+// upgradeTask fills in the fields missing from tasks logged by earlier versions:
+// tasks logged before priorities were introduced get the default priority, and
+// tasks logged before manual ordering the rank of their creation time.
+func upgradeTask(task *domain.Task) *domain.Task {
+	if task.Priority == "" {
+		task.Priority = domain.PriorityMedium
+	}
+	if task.Rank == "" {
+		task.Rank = domain.InitialRank(task.CreatedAt)
+	}
+	return task
+}
+
+// writeFileSync writes data to the file at path and syncs it to disk.
+func writeFileSync(path string, data []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// syncDir syncs the directory entries of dir, making a rename durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+
+	err = d.Sync()
+	return errors.Join(err, d.Close())
+}
@@ -0,0 +1,38 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/asp3cto/task-manager/internal/adapters/repository"
+	"github.com/asp3cto/task-manager/internal/adapters/repository/repositorytest"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+func TestDurableMemoryTaskRepository(t *testing.T) {
+	repositorytest.RunRepositoryTests(t, func(t *testing.T) ports.TaskRepository {
+		repo, err := repository.NewDurableMemoryTaskRepository(t.TempDir())
+		if err != nil {
+			t.Fatalf("failed to open repository: %v", err)
+		}
+		t.Cleanup(func() { _ = repo.Close() })
+
+		return repo
+	})
+}
+
+func TestEncryptedDurableMemoryTaskRepository(t *testing.T) {
+	keyring, err := repository.NewKeyring("k1", map[string][]byte{"k1": make([]byte, 32)})
+	if err != nil {
+		t.Fatalf("failed to create keyring: %v", err)
+	}
+
+	repositorytest.RunRepositoryTests(t, func(t *testing.T) ports.TaskRepository {
+		repo, err := repository.NewDurableMemoryTaskRepository(t.TempDir(), repository.WithFileEncryption(keyring))
+		if err != nil {
+			t.Fatalf("failed to open repository: %v", err)
+		}
+		t.Cleanup(func() { _ = repo.Close() })
+
+		return repo
+	})
+}
@@ -0,0 +1,698 @@
+package repository
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.TaskRepository        = (*EncryptedTaskRepository)(nil)
+	_ ports.RepositoryInspector   = (*EncryptedTaskRepository)(nil)
+	_ ports.RepositoryDumper      = (*EncryptedTaskRepository)(nil)
+	_ ports.TaskHistoryRepository = (*EncryptedHistoryRepository)(nil)
+	_ ports.CommentRepository     = (*EncryptedCommentRepository)(nil)
+	_ ports.Reencrypter           = (*Reencrypter)(nil)
+)
+
+// ErrNotSupported is returned when a decorated repository lacks an optional capability.
+var ErrNotSupported = errors.New("operation not supported by the underlying repository")
+
+// encryptedPrefix marks field values produced by the Keyring.
+// Values without the prefix are treated as legacy plaintext.
+const encryptedPrefix = "enc:v2:"
+
+// legacyPrefix marks field values encrypted before values were bound to their owner;
+// they are still decrypted, and rewritten with encryptedPrefix by re-encryption.
+const legacyPrefix = "enc:v1:"
+
+// aesKeySize is the required key length for AES-256-GCM.
+const aesKeySize = 32
+
+// ErrUnknownKey is returned when a value was encrypted with a key that is not in the keyring.
+var ErrUnknownKey = errors.New("unknown encryption key")
+
+// Keyring holds the AES-GCM keys used for field-level encryption.
+// New values are always encrypted with the primary key, while any key
+// in the ring can be used for decryption, which enables key rotation.
+type Keyring struct {
+	// primary is the ID of the key used for encryption
+	primary string
+	// keys maps key IDs to their AEAD ciphers
+	keys map[string]cipher.AEAD
+}
+
+// NewKeyring creates a keyring from raw 32-byte keys indexed by ID.
+// The primary key is used for all new encryptions and must be present in keys.
+func NewKeyring(primary string, keys map[string][]byte) (*Keyring, error) {
+	ring := &Keyring{
+		primary: primary,
+		keys:    make(map[string]cipher.AEAD, len(keys)),
+	}
+
+	for id, key := range keys {
+		if len(key) != aesKeySize {
+			return nil, fmt.Errorf("key %q must be %d bytes, got %d", id, aesKeySize, len(key))
+		}
+
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cipher for key %q: %w", id, err)
+		}
+
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GCM for key %q: %w", id, err)
+		}
+
+		ring.keys[id] = aead
+	}
+
+	if _, ok := ring.keys[primary]; !ok {
+		return nil, fmt.Errorf("primary key %q: %w", primary, ErrUnknownKey)
+	}
+
+	return ring, nil
+}
+
+// PrimaryID returns the ID of the key used for new encryptions.
+func (k *Keyring) PrimaryID() string {
+	return k.primary
+}
+
+// NewKeyringFromEnv creates a keyring from the TASK_ENCRYPTION_KEYS environment variable.
+//
+// The variable holds a comma-separated list of "id:base64key" pairs.
+// The first key is the primary one, the rest are kept for decrypting
+// values written before a rotation:
+//
+//	TASK_ENCRYPTION_KEYS=k2:<base64>,k1:<base64>
+//
+// Returns nil if the variable is not set, which disables encryption.
+func NewKeyringFromEnv() (*Keyring, error) {
+	return keyringFromEnv("TASK_ENCRYPTION_KEYS")
+}
+
+// NewStorageKeyringFromEnv creates the keyring encrypting the data files of the file-based
+// backends, WAL_DIR and BOLT_DIR, from the STORAGE_ENCRYPTION_KEYS environment variable.
+// The variable has the format of TASK_ENCRYPTION_KEYS.
+// Returns nil if the variable is not set, which keeps the data files in plaintext.
+func NewStorageKeyringFromEnv() (*Keyring, error) {
+	return keyringFromEnv("STORAGE_ENCRYPTION_KEYS")
+}
+
+// keyringFromEnv creates a keyring from the "id:base64key" pairs in the environment variable name.
+// Returns nil if the variable is not set.
+func keyringFromEnv(name string) (*Keyring, error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return nil, nil
+	}
+
+	var primary string
+	keys := make(map[string][]byte)
+	for _, part := range strings.Split(raw, ",") {
+		id, encoded, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("%s entry must be id:base64key, got: %q", name, part)
+		}
+
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("%s key %q is not valid base64: %w", name, id, err)
+		}
+
+		if primary == "" {
+			primary = id
+		}
+		keys[id] = key
+	}
+
+	return NewKeyring(primary, keys)
+}
+
+// Encrypt encrypts plaintext with the primary key, bound to owner, the ID of the record
+// the value belongs to, such as a task: decrypting it for another owner fails, so a value
+// copied to another record in the store is detected.
+// The result has the form "enc:v2:<key id>:<base64(nonce|ciphertext)>".
+// Empty strings are returned unchanged.
+func (k *Keyring) Encrypt(plaintext, owner string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	aead := k.keys[k.primary]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), additionalData(k.primary, owner))
+	return encryptedPrefix + k.primary + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt reverses Encrypt using whichever key the value was encrypted with.
+// The value must have been encrypted for owner, unless it predates the binding to owners.
+// Values without the encryption prefix are returned unchanged.
+func (k *Keyring) Decrypt(value, owner string) (string, error) {
+	rest, ok := strings.CutPrefix(value, encryptedPrefix)
+	if !ok {
+		if rest, ok = strings.CutPrefix(value, legacyPrefix); !ok {
+			return value, nil
+		}
+		owner = ""
+	}
+
+	id, encoded, ok := strings.Cut(rest, ":")
+	if !ok {
+		return "", errors.New("malformed encrypted value")
+	}
+
+	aead, ok := k.keys[id]
+	if !ok {
+		return "", fmt.Errorf("key %q: %w", id, ErrUnknownKey)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("malformed encrypted value: %w", err)
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return "", errors.New("malformed encrypted value: too short")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData(id, owner))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value: %w", err)
+	}
+
+	return string(plaintext), nil
+}
+
+// additionalData returns the data authenticated along with a value encrypted with the key
+// keyID for owner. Legacy values, with an empty owner, only authenticate the key ID.
+func additionalData(keyID, owner string) []byte {
+	if owner == "" {
+		return []byte(keyID)
+	}
+	return []byte(keyID + ":" + owner)
+}
+
+// EncryptedTaskRepository decorates a TaskRepository with field-level encryption.
+// Sensitive fields are encrypted before they reach the underlying store and
+// decrypted on the way out, so the store and its backups never see plaintext.
+type EncryptedTaskRepository struct {
+	next    ports.TaskRepository
+	keyring *Keyring
+}
+
+// NewEncryptedTaskRepository wraps next with field-level encryption using keyring.
+func NewEncryptedTaskRepository(next ports.TaskRepository, keyring *Keyring) *EncryptedTaskRepository {
+	return &EncryptedTaskRepository{
+		next:    next,
+		keyring: keyring,
+	}
+}
+
+// Create encrypts the task's sensitive fields and stores it.
+// The caller's task is left untouched.
+func (r *EncryptedTaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	encrypted, err := r.encrypt(task)
+	if err != nil {
+		return err
+	}
+
+	return r.next.Create(ctx, encrypted)
+}
+
+// GetByID retrieves a task and decrypts its sensitive fields.
+func (r *EncryptedTaskRepository) GetByID(ctx context.Context, id string) (*domain.Task, error) {
+	task, err := r.next.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.decrypt(task)
+}
+
+// GetByIDs retrieves tasks by their IDs and decrypts their sensitive fields.
+func (r *EncryptedTaskRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.Task, error) {
+	tasks, err := r.next.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, task := range tasks {
+		if tasks[i], err = r.decrypt(task); err != nil {
+			return nil, err
+		}
+	}
+
+	return tasks, nil
+}
+
+// GetAll retrieves the page of tasks described by the query and decrypts their
+// sensitive fields. The underlying store can't search encrypted content, so a text
+// query is applied here after decryption, and the page is then cut here as well
+// from the ordered tasks the store returns after the cursor.
+func (r *EncryptedTaskRepository) GetAll(ctx context.Context, query ports.ListQuery) (ports.TaskPage, error) {
+	storeQuery := query
+	if query.Filter.Query != "" {
+		storeQuery.Filter.Query = ""
+		storeQuery.Page.Limit = 0
+	}
+
+	page, err := r.next.GetAll(ctx, storeQuery)
+	if err != nil {
+		return ports.TaskPage{}, err
+	}
+
+	tasks := make([]*domain.Task, 0, len(page.Tasks))
+	for _, task := range page.Tasks {
+		decrypted, err := r.decrypt(task)
+		if err != nil {
+			return ports.TaskPage{}, err
+		}
+
+		if query.Filter.Matches(decrypted) {
+			tasks = append(tasks, decrypted)
+		}
+	}
+
+	if query.Filter.Query == "" {
+		page.Tasks = tasks
+		return page, nil
+	}
+
+	return ports.NewTaskPage(tasks, query), nil
+}
+
+// Iterate streams the tasks matching the filter with their sensitive fields decrypted.
+// As in GetAll, the text query is applied here after decryption.
+func (r *EncryptedTaskRepository) Iterate(
+	ctx context.Context, filter ports.ListFilter, fn func(*domain.Task) error,
+) error {
+	storeFilter := filter
+	storeFilter.Query = ""
+
+	return r.next.Iterate(ctx, storeFilter, func(task *domain.Task) error {
+		decrypted, err := r.decrypt(task)
+		if err != nil {
+			return err
+		}
+
+		if !filter.Matches(decrypted) {
+			return nil
+		}
+
+		return fn(decrypted)
+	})
+}
+
+// Update encrypts the task's sensitive fields and persists it.
+// The caller's task is left untouched apart from its new version.
+func (r *EncryptedTaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	encrypted, err := r.encrypt(task)
+	if err != nil {
+		return err
+	}
+
+	if err := r.next.Update(ctx, encrypted); err != nil {
+		return err
+	}
+
+	task.Version = encrypted.Version
+	return nil
+}
+
+// Delete removes a task from the underlying repository.
+func (r *EncryptedTaskRepository) Delete(ctx context.Context, id string) error {
+	return r.next.Delete(ctx, id)
+}
+
+// Ping checks the health of the underlying repository.
+func (r *EncryptedTaskRepository) Ping(ctx context.Context) error {
+	return r.next.Ping(ctx)
+}
+
+// Inspect reports the state of the underlying repository.
+// The backend name is suffixed with "+encrypted".
+func (r *EncryptedTaskRepository) Inspect(ctx context.Context) (ports.RepositoryStats, error) {
+	inspector, ok := r.next.(ports.RepositoryInspector)
+	if !ok {
+		return ports.RepositoryStats{}, ErrNotSupported
+	}
+
+	stats, err := inspector.Inspect(ctx)
+	if err != nil {
+		return ports.RepositoryStats{}, err
+	}
+
+	stats.Backend += "+encrypted"
+	return stats, nil
+}
+
+// Dump returns raw tasks from the underlying repository.
+// Sensitive fields are returned as stored, i.e. encrypted.
+func (r *EncryptedTaskRepository) Dump(ctx context.Context, offset, limit int) ([]*domain.Task, error) {
+	dumper, ok := r.next.(ports.RepositoryDumper)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+
+	return dumper.Dump(ctx, offset, limit)
+}
+
+// encrypt returns a copy of task with sensitive fields encrypted.
+func (r *EncryptedTaskRepository) encrypt(task *domain.Task) (*domain.Task, error) {
+	description, err := r.keyring.Encrypt(task.Description, task.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt task %s: %w", task.ID, err)
+	}
+
+	taskCopy := *task
+	taskCopy.Description = description
+	return &taskCopy, nil
+}
+
+// decrypt returns task with sensitive fields decrypted in place.
+func (r *EncryptedTaskRepository) decrypt(task *domain.Task) (*domain.Task, error) {
+	description, err := r.keyring.Decrypt(task.Description, task.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt task %s: %w", task.ID, err)
+	}
+
+	task.Description = description
+	return task, nil
+}
+
+// encryptedHistoryFields are the history fields recording changes of the task fields
+// encrypted by EncryptedTaskRepository.
+var encryptedHistoryFields = map[string]bool{"description": true}
+
+// EncryptedHistoryRepository decorates a TaskHistoryRepository so the old and new
+// values of encrypted task fields, such as the description, are encrypted in the
+// history as well. Without it every change would leave them in the history store as plaintext.
+type EncryptedHistoryRepository struct {
+	next    ports.TaskHistoryRepository
+	keyring *Keyring
+}
+
+// NewEncryptedHistoryRepository wraps next with encryption of sensitive values using keyring.
+func NewEncryptedHistoryRepository(next ports.TaskHistoryRepository, keyring *Keyring) *EncryptedHistoryRepository {
+	return &EncryptedHistoryRepository{
+		next:    next,
+		keyring: keyring,
+	}
+}
+
+// Append encrypts the values of sensitive fields and stores the entries.
+// The caller's entries are left untouched.
+func (r *EncryptedHistoryRepository) Append(ctx context.Context, entries []domain.HistoryEntry) error {
+	encrypted, err := r.encrypt(entries)
+	if err != nil {
+		return err
+	}
+
+	return r.next.Append(ctx, encrypted)
+}
+
+// ListByTask returns the history of a task with the values of sensitive fields decrypted.
+func (r *EncryptedHistoryRepository) ListByTask(ctx context.Context, taskID string) ([]domain.HistoryEntry, error) {
+	entries, err := r.next.ListByTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.decrypt(entries)
+}
+
+// RewriteByTask passes the history of a task to rewrite decrypted and stores the rewritten
+// entries encrypted, as a whole.
+func (r *EncryptedHistoryRepository) RewriteByTask(
+	ctx context.Context, taskID string, rewrite func([]domain.HistoryEntry) ([]domain.HistoryEntry, error),
+) error {
+	return r.next.RewriteByTask(ctx, taskID, func(stored []domain.HistoryEntry) ([]domain.HistoryEntry, error) {
+		entries, err := r.decrypt(stored)
+		if err != nil {
+			return nil, err
+		}
+
+		if entries, err = rewrite(entries); err != nil {
+			return nil, err
+		}
+
+		return r.encrypt(entries)
+	})
+}
+
+// DeleteByTask removes the history of a task from the underlying repository.
+func (r *EncryptedHistoryRepository) DeleteByTask(ctx context.Context, taskID string) error {
+	return r.next.DeleteByTask(ctx, taskID)
+}
+
+// encrypt returns copies of the entries with the values of sensitive fields encrypted
+// for their task.
+func (r *EncryptedHistoryRepository) encrypt(entries []domain.HistoryEntry) ([]domain.HistoryEntry, error) {
+	encrypted := make([]domain.HistoryEntry, len(entries))
+	for i, entry := range entries {
+		if encryptedHistoryFields[entry.Field] {
+			var err error
+			if entry.OldValue, err = r.keyring.Encrypt(entry.OldValue, entry.TaskID); err != nil {
+				return nil, fmt.Errorf("failed to encrypt history of task %s: %w", entry.TaskID, err)
+			}
+			if entry.NewValue, err = r.keyring.Encrypt(entry.NewValue, entry.TaskID); err != nil {
+				return nil, fmt.Errorf("failed to encrypt history of task %s: %w", entry.TaskID, err)
+			}
+		}
+		encrypted[i] = entry
+	}
+
+	return encrypted, nil
+}
+
+// decrypt decrypts the values of sensitive fields of the entries in place.
+func (r *EncryptedHistoryRepository) decrypt(entries []domain.HistoryEntry) ([]domain.HistoryEntry, error) {
+	for i := range entries {
+		if !encryptedHistoryFields[entries[i].Field] {
+			continue
+		}
+
+		var err error
+		if entries[i].OldValue, err = r.keyring.Decrypt(entries[i].OldValue, entries[i].TaskID); err != nil {
+			return nil, fmt.Errorf("failed to decrypt history of task %s: %w", entries[i].TaskID, err)
+		}
+		if entries[i].NewValue, err = r.keyring.Decrypt(entries[i].NewValue, entries[i].TaskID); err != nil {
+			return nil, fmt.Errorf("failed to decrypt history of task %s: %w", entries[i].TaskID, err)
+		}
+	}
+
+	return entries, nil
+}
+
+// EncryptedCommentRepository decorates a CommentRepository so comment bodies are
+// encrypted before they reach the underlying store, like task descriptions.
+type EncryptedCommentRepository struct {
+	next    ports.CommentRepository
+	keyring *Keyring
+}
+
+// NewEncryptedCommentRepository wraps next with encryption of comment bodies using keyring.
+func NewEncryptedCommentRepository(next ports.CommentRepository, keyring *Keyring) *EncryptedCommentRepository {
+	return &EncryptedCommentRepository{
+		next:    next,
+		keyring: keyring,
+	}
+}
+
+// Create encrypts the body of the comment and stores it.
+// The caller's comment is left untouched.
+func (r *EncryptedCommentRepository) Create(ctx context.Context, comment *domain.Comment) error {
+	body, err := r.keyring.Encrypt(comment.Body, comment.ID)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt comment %s: %w", comment.ID, err)
+	}
+
+	commentCopy := *comment
+	commentCopy.Body = body
+	return r.next.Create(ctx, &commentCopy)
+}
+
+// ListByTask returns the comments of a task with their bodies decrypted.
+func (r *EncryptedCommentRepository) ListByTask(ctx context.Context, taskID string) ([]*domain.Comment, error) {
+	comments, err := r.next.ListByTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	decrypted := make([]*domain.Comment, len(comments))
+	for i, comment := range comments {
+		body, err := r.keyring.Decrypt(comment.Body, comment.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt comment %s: %w", comment.ID, err)
+		}
+
+		commentCopy := *comment
+		commentCopy.Body = body
+		decrypted[i] = &commentCopy
+	}
+
+	return decrypted, nil
+}
+
+// Update encrypts the body of the comment and stores it.
+// The caller's comment is left untouched.
+func (r *EncryptedCommentRepository) Update(ctx context.Context, comment *domain.Comment) error {
+	body, err := r.keyring.Encrypt(comment.Body, comment.ID)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt comment %s: %w", comment.ID, err)
+	}
+
+	commentCopy := *comment
+	commentCopy.Body = body
+	return r.next.Update(ctx, &commentCopy)
+}
+
+// Delete removes a comment from the underlying repository.
+func (r *EncryptedCommentRepository) Delete(ctx context.Context, taskID, id string) error {
+	return r.next.Delete(ctx, taskID, id)
+}
+
+// DeleteByTask removes the comments of a task from the underlying repository.
+func (r *EncryptedCommentRepository) DeleteByTask(ctx context.Context, taskID string) error {
+	return r.next.DeleteByTask(ctx, taskID)
+}
+
+// Reencrypter rewrites the tasks, history and comments stored through the encrypted
+// repositories with the current primary key of their keyring. It is used after a key
+// rotation, so the old keys can be removed from TASK_ENCRYPTION_KEYS once it finished.
+// Values encrypted before they were bound to their owner are rewritten bound as well.
+type Reencrypter struct {
+	tasks    *EncryptedTaskRepository
+	history  *EncryptedHistoryRepository
+	comments *EncryptedCommentRepository
+}
+
+// NewReencrypter creates a reencrypter rewriting the tasks of tasks, together with their
+// history in history and their comments in comments. history and comments may be nil if
+// they are not encrypted.
+func NewReencrypter(
+	tasks *EncryptedTaskRepository, history *EncryptedHistoryRepository, comments *EncryptedCommentRepository,
+) *Reencrypter {
+	return &Reencrypter{
+		tasks:    tasks,
+		history:  history,
+		comments: comments,
+	}
+}
+
+// Reencrypt rewrites every stored task, its history and its comments with the primary key.
+// The IDs of the tasks are collected first, so the store is not read and written at the same
+// time, and every task is rewritten on its own: the service keeps running meanwhile. Tasks
+// deleted meanwhile are skipped, as are tasks changed meanwhile, since a change already stores
+// them with the primary key. A rewritten task gets a new version, like after any other update.
+func (r *Reencrypter) Reencrypt(ctx context.Context) (ports.ReencryptionResult, error) {
+	var (
+		result ports.ReencryptionResult
+		ids    []string
+	)
+	err := r.tasks.next.Iterate(ctx, ports.ListFilter{}, func(task *domain.Task) error {
+		ids = append(ids, task.ID)
+		return nil
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	for _, id := range ids {
+		rewritten, err := r.reencryptTask(ctx, id)
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+		if rewritten {
+			result.Tasks++
+		}
+
+		if r.history != nil {
+			err := r.history.RewriteByTask(ctx, id, func(entries []domain.HistoryEntry) ([]domain.HistoryEntry, error) {
+				result.HistoryEntries += len(entries)
+				return entries, nil
+			})
+			if err != nil && !errors.Is(err, domain.ErrTaskNotFound) {
+				return result, fmt.Errorf("failed to re-encrypt history of task %s: %w", id, err)
+			}
+		}
+
+		if r.comments != nil {
+			n, err := r.reencryptComments(ctx, id)
+			result.Comments += n
+			if err != nil {
+				return result, err
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// reencryptTask rewrites the task with the given ID. Returns false if the task was changed
+// since it was read, so it is already stored with the primary key.
+// Returns domain.ErrTaskNotFound if the task no longer exists.
+func (r *Reencrypter) reencryptTask(ctx context.Context, id string) (bool, error) {
+	task, err := r.tasks.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			return false, err
+		}
+		return false, fmt.Errorf("failed to read task %s: %w", id, err)
+	}
+
+	err = r.tasks.Update(ctx, task)
+	switch {
+	case errors.Is(err, domain.ErrVersionConflict):
+		return false, nil
+	case errors.Is(err, domain.ErrTaskNotFound):
+		return false, err
+	case err != nil:
+		return false, fmt.Errorf("failed to re-encrypt task %s: %w", id, err)
+	}
+
+	return true, nil
+}
+
+// reencryptComments rewrites the comments of the task with the given ID and returns their number.
+// Comments deleted meanwhile are skipped.
+func (r *Reencrypter) reencryptComments(ctx context.Context, taskID string) (int, error) {
+	comments, err := r.comments.ListByTask(ctx, taskID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read comments of task %s: %w", taskID, err)
+	}
+
+	rewritten := 0
+	for _, comment := range comments {
+		err := r.comments.Update(ctx, comment)
+		if errors.Is(err, domain.ErrCommentNotFound) {
+			continue
+		}
+		if err != nil {
+			return rewritten, fmt.Errorf("failed to re-encrypt comment %s: %w", comment.ID, err)
+		}
+		rewritten++
+	}
+
+	return rewritten, nil
+}
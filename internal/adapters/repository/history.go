@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var _ ports.TaskHistoryRepository = (*MemoryHistoryRepository)(nil)
+
+// MemoryHistoryRepository provides an in-memory implementation of the TaskHistoryRepository interface.
+// Entries are grouped by task, in the order they were appended.
+// Data is lost when the application restarts since it's stored only in memory.
+type MemoryHistoryRepository struct {
+	// entries stores the history of each task indexed by task ID
+	entries map[string][]domain.HistoryEntry
+	// mu provides thread-safe access to the entries map
+	mu sync.RWMutex
+}
+
+// NewMemoryHistoryRepository creates a new instance of the in-memory history repository.
+func NewMemoryHistoryRepository() *MemoryHistoryRepository {
+	return &MemoryHistoryRepository{
+		entries: make(map[string][]domain.HistoryEntry),
+	}
+}
+
+// Append stores entries after the existing entries of their tasks.
+func (r *MemoryHistoryRepository) Append(_ context.Context, entries []domain.HistoryEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, entry := range entries {
+		r.entries[entry.TaskID] = append(r.entries[entry.TaskID], entry)
+	}
+	return nil
+}
+
+// ListByTask returns a copy of the history of a task, oldest first.
+func (r *MemoryHistoryRepository) ListByTask(_ context.Context, taskID string) ([]domain.HistoryEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := slices.Clone(r.entries[taskID])
+	if entries == nil {
+		entries = make([]domain.HistoryEntry, 0)
+	}
+
+	return entries, nil
+}
+
+// RewriteByTask replaces the history of a task by the entries returned by rewrite.
+// The repository is locked meanwhile, so rewrite must not use it.
+// The repository does not know about tasks, so it never returns domain.ErrTaskNotFound.
+func (r *MemoryHistoryRepository) RewriteByTask(
+	_ context.Context, taskID string, rewrite func([]domain.HistoryEntry) ([]domain.HistoryEntry, error),
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries, err := rewrite(slices.Clone(r.entries[taskID]))
+	if err != nil {
+		return err
+	}
+
+	if len(entries) == 0 {
+		delete(r.entries, taskID)
+		return nil
+	}
+
+	r.entries[taskID] = slices.Clone(entries)
+	return nil
+}
+
+// DeleteByTask removes the history of a task.
+func (r *MemoryHistoryRepository) DeleteByTask(_ context.Context, taskID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.entries, taskID)
+	return nil
+}
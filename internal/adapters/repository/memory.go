@@ -4,42 +4,70 @@ package repository
 
 import (
 	"context"
+	"hash/maphash"
+	"slices"
+	"strings"
 	"sync"
+	"unsafe"
 
 	"github.com/asp3cto/task-manager/internal/domain"
 	"github.com/asp3cto/task-manager/internal/ports"
 )
 
-var _ ports.TaskRepository = (*MemoryTaskRepository)(nil)
+var (
+	_ ports.TaskRepository      = (*MemoryTaskRepository)(nil)
+	_ ports.RepositoryInspector = (*MemoryTaskRepository)(nil)
+	_ ports.RepositoryDumper    = (*MemoryTaskRepository)(nil)
+)
 
-// MemoryTaskRepository provides an in-memory implementation of the TaskRepository interface.
-// It stores tasks in a map with thread-safe access using read-write mutexes.
-// Data is lost when the application restarts since it's stored only in memory.
-type MemoryTaskRepository struct {
+// shardCount is the number of independently locked shards of a MemoryTaskRepository.
+const shardCount = 32
+
+// memoryShard holds the tasks whose IDs hash to it.
+type memoryShard struct {
 	// tasks stores the task data indexed by task ID
 	tasks map[string]*domain.Task
 	// mu provides thread-safe access to the tasks map
 	mu sync.RWMutex
 }
 
+// MemoryTaskRepository provides an in-memory implementation of the TaskRepository interface.
+// Tasks are spread over shards by the hash of their ID, each guarded by its own read-write
+// mutex, so operations on different tasks rarely wait for each other. Listings visit the
+// shards one after another and are therefore not an atomic snapshot of all tasks.
+// Data is lost when the application restarts since it's stored only in memory.
+type MemoryTaskRepository struct {
+	shards [shardCount]memoryShard
+	seed   maphash.Seed
+}
+
 // NewMemoryTaskRepository creates a new instance of the in-memory task repository.
 func NewMemoryTaskRepository() *MemoryTaskRepository {
-	return &MemoryTaskRepository{
-		tasks: make(map[string]*domain.Task),
+	r := &MemoryTaskRepository{seed: maphash.MakeSeed()}
+	for i := range r.shards {
+		r.shards[i].tasks = make(map[string]*domain.Task)
 	}
+
+	return r
+}
+
+// shard returns the shard holding the task with the given ID.
+func (r *MemoryTaskRepository) shard(id string) *memoryShard {
+	return &r.shards[maphash.String(r.seed, id)%shardCount]
 }
 
 // Create stores a new task in the in-memory repository.
 // Returns domain.ErrTaskExists if a task with the same ID already exists.
 func (r *MemoryTaskRepository) Create(_ context.Context, task *domain.Task) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	shard := r.shard(task.ID)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
 
-	if _, exists := r.tasks[task.ID]; exists {
+	if _, exists := shard.tasks[task.ID]; exists {
 		return domain.ErrTaskExists
 	}
 
-	r.tasks[task.ID] = task
+	shard.tasks[task.ID] = task
 	return nil
 }
 
@@ -47,10 +75,11 @@ func (r *MemoryTaskRepository) Create(_ context.Context, task *domain.Task) erro
 // Returns a copy of the task to prevent external modifications to the stored data.
 // Returns domain.ErrTaskNotFound if no task exists with the given ID.
 func (r *MemoryTaskRepository) GetByID(_ context.Context, id string) (*domain.Task, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	shard := r.shard(id)
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
 
-	task, exists := r.tasks[id]
+	task, exists := shard.tasks[id]
 	if !exists {
 		return nil, domain.ErrTaskNotFound
 	}
@@ -60,49 +89,171 @@ func (r *MemoryTaskRepository) GetByID(_ context.Context, id string) (*domain.Ta
 	return &taskCopy, nil
 }
 
-// GetAll retrieves all tasks from the in-memory repository, optionally filtered by status.
-// If status is empty, returns all tasks regardless of their status.
+// GetByIDs retrieves the tasks with the given identifiers from the in-memory repository.
+// Unknown IDs are skipped; tasks are returned in the order of ids.
 // Returns copies of tasks to prevent external modifications to the stored data.
-func (r *MemoryTaskRepository) GetAll(_ context.Context, status string) ([]*domain.Task, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+func (r *MemoryTaskRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.Task, error) {
+	tasks := make([]*domain.Task, 0, len(ids))
+	for _, id := range ids {
+		if task, err := r.GetByID(ctx, id); err == nil {
+			tasks = append(tasks, task)
+		}
+	}
 
+	return tasks, nil
+}
+
+// GetAll retrieves the page of tasks from the in-memory repository described by the query.
+// A zero filter matches all tasks, a zero page returns every matching task.
+// Returns copies of tasks to prevent external modifications to the stored data.
+func (r *MemoryTaskRepository) GetAll(_ context.Context, query ports.ListQuery) (ports.TaskPage, error) {
 	tasks := make([]*domain.Task, 0)
-	for _, task := range r.tasks {
-		if status == "" || string(task.Status) == status {
+	r.each(func(task *domain.Task) {
+		if query.Filter.Matches(task) {
 			// Create a copy to prevent external modifications
 			taskCopy := *task
 			tasks = append(tasks, &taskCopy)
 		}
+	})
+
+	return ports.Paginate(tasks, query)
+}
+
+// Iterate calls fn with a copy of every task matching the filter.
+// The matching tasks are collected under the read locks and fn is called after they
+// are released, so fn may modify the repository. Stored tasks are replaced rather
+// than modified in place, so the collected tasks stay consistent.
+func (r *MemoryTaskRepository) Iterate(
+	ctx context.Context, filter ports.ListFilter, fn func(*domain.Task) error,
+) error {
+	matched := make([]*domain.Task, 0)
+	r.each(func(task *domain.Task) {
+		if filter.Matches(task) {
+			matched = append(matched, task)
+		}
+	})
+
+	for _, task := range matched {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		taskCopy := *task
+		if err := fn(&taskCopy); err != nil {
+			return err
+		}
 	}
 
-	return tasks, nil
+	return nil
 }
 
-// Update modifies an existing task in the in-memory repository.
+// Update modifies an existing task in the in-memory repository if its stored
+// version still equals task.Version, and increments the version.
+// Returns domain.ErrVersionConflict if the task was modified concurrently.
 // Returns domain.ErrTaskNotFound if no task exists with the given ID.
 func (r *MemoryTaskRepository) Update(_ context.Context, task *domain.Task) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	shard := r.shard(task.ID)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
 
-	if _, exists := r.tasks[task.ID]; !exists {
+	stored, exists := shard.tasks[task.ID]
+	if !exists {
 		return domain.ErrTaskNotFound
 	}
 
-	r.tasks[task.ID] = task
+	if stored.Version != task.Version {
+		return domain.ErrVersionConflict
+	}
+
+	task.Version++
+	taskCopy := *task
+	shard.tasks[task.ID] = &taskCopy
 	return nil
 }
 
 // Delete removes a task from the in-memory repository by its ID.
 // Returns domain.ErrTaskNotFound if no task exists with the given ID.
 func (r *MemoryTaskRepository) Delete(_ context.Context, id string) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	shard := r.shard(id)
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
 
-	if _, exists := r.tasks[id]; !exists {
+	if _, exists := shard.tasks[id]; !exists {
 		return domain.ErrTaskNotFound
 	}
 
-	delete(r.tasks, id)
+	delete(shard.tasks, id)
+	return nil
+}
+
+// Ping reports the health of the in-memory repository.
+// The in-memory store is always available while the process is running.
+func (r *MemoryTaskRepository) Ping(_ context.Context) error {
 	return nil
 }
+
+// Inspect reports task counts per status and an estimate of the memory held by the tasks.
+// The estimate covers the task structs and their string contents, not the map overhead.
+func (r *MemoryTaskRepository) Inspect(_ context.Context) (ports.RepositoryStats, error) {
+	stats := ports.RepositoryStats{
+		Backend:    "memory",
+		TaskCounts: make(map[domain.TaskStatus]int),
+	}
+
+	r.each(func(task *domain.Task) {
+		stats.TaskCounts[task.Status]++
+		stats.SizeBytes += int64(unsafe.Sizeof(*task)) +
+			int64(len(task.ID)+len(task.Title)+len(task.Description)+len(task.Status))
+	})
+
+	return stats, nil
+}
+
+// Dump returns a page of stored tasks ordered by ID.
+// Returns copies of tasks to prevent external modifications to the stored data.
+func (r *MemoryTaskRepository) Dump(_ context.Context, offset, limit int) ([]*domain.Task, error) {
+	all := make([]*domain.Task, 0)
+	r.each(func(task *domain.Task) {
+		all = append(all, task)
+	})
+	slices.SortFunc(all, func(a, b *domain.Task) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	tasks := make([]*domain.Task, 0)
+	for i := offset; i < len(all) && len(tasks) < limit; i++ {
+		taskCopy := *all[i]
+		tasks = append(tasks, &taskCopy)
+	}
+
+	return tasks, nil
+}
+
+// each calls fn for every stored task, holding the read lock of its shard.
+// fn must not call back into the repository.
+func (r *MemoryTaskRepository) each(fn func(*domain.Task)) {
+	for i := range r.shards {
+		shard := &r.shards[i]
+		shard.mu.RLock()
+		for _, task := range shard.tasks {
+			fn(task)
+		}
+		shard.mu.RUnlock()
+	}
+}
+
+// put stores task, replacing any task with the same ID.
+func (r *MemoryTaskRepository) put(task *domain.Task) {
+	shard := r.shard(task.ID)
+	shard.mu.Lock()
+	shard.tasks[task.ID] = task
+	shard.mu.Unlock()
+}
+
+// remove deletes the task with the given ID, if there is one.
+func (r *MemoryTaskRepository) remove(id string) {
+	shard := r.shard(id)
+	shard.mu.Lock()
+	delete(shard.tasks, id)
+	shard.mu.Unlock()
+}
@@ -0,0 +1,108 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/adapters/repository"
+	"github.com/asp3cto/task-manager/internal/adapters/repository/repositorytest"
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// benchmarkTasks is the number of tasks stored before a benchmark starts.
+const benchmarkTasks = 1000
+
+func TestMemoryTaskRepository(t *testing.T) {
+	repositorytest.RunRepositoryTests(t, func(t *testing.T) ports.TaskRepository {
+		return repository.NewMemoryTaskRepository()
+	})
+}
+
+func BenchmarkMemoryRepository_Get(b *testing.B) {
+	repo := newBenchmarkRepository(b)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			if _, err := repo.GetByID(ctx, benchmarkTaskID(i%benchmarkTasks)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkMemoryRepository_Update(b *testing.B) {
+	repo := newBenchmarkRepository(b)
+	ctx := context.Background()
+
+	// Every goroutine updates tasks of its own, so updates never conflict.
+	var next atomic.Int64
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		id := benchmarkTaskID(int(next.Add(1)-1) % benchmarkTasks)
+		task, err := repo.GetByID(ctx, id)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		for pb.Next() {
+			task.Priority = domain.PriorityHigh
+			if err := repo.Update(ctx, task); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkMemoryRepository_List(b *testing.B) {
+	repo := newBenchmarkRepository(b)
+	ctx := context.Background()
+	query := ports.ListQuery{
+		Filter: ports.ListFilter{Statuses: []domain.TaskStatus{domain.StatusPending}},
+		Page:   ports.PageRequest{Limit: 50},
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := repo.GetAll(ctx, query); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// newBenchmarkRepository returns a memory repository holding benchmarkTasks pending tasks.
+func newBenchmarkRepository(b *testing.B) *repository.MemoryTaskRepository {
+	b.Helper()
+
+	repo := repository.NewMemoryTaskRepository()
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range benchmarkTasks {
+		task := &domain.Task{
+			ID:        benchmarkTaskID(i),
+			Title:     fmt.Sprintf("task %d", i),
+			Status:    domain.StatusPending,
+			CreatedAt: created,
+			UpdatedAt: created,
+			Version:   1,
+			Priority:  domain.PriorityMedium,
+			Rank:      domain.InitialRank(created.Add(time.Duration(i) * time.Second)),
+		}
+		if err := repo.Create(context.Background(), task); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	return repo
+}
+
+// benchmarkTaskID returns the ID of the i-th task of newBenchmarkRepository.
+func benchmarkTaskID(i int) string {
+	return fmt.Sprintf("task-%04d", i)
+}
@@ -0,0 +1,638 @@
+// Package mongo provides a TaskRepository storing tasks as documents of a MongoDB collection.
+// Every operation is bounded by a timeout, so an unreachable deployment fails requests
+// instead of blocking them.
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.TaskRepository      = (*TaskRepository)(nil)
+	_ ports.RepositoryInspector = (*TaskRepository)(nil)
+	_ ports.RepositoryDumper    = (*TaskRepository)(nil)
+)
+
+// collectionName is the collection tasks are stored in.
+const collectionName = "tasks"
+
+// defaultDatabase is the database used when MONGODB_DATABASE is not set.
+const defaultDatabase = "task_manager"
+
+// defaultTimeout bounds every operation unless the caller's context expires earlier.
+const defaultTimeout = 5 * time.Second
+
+// taskDocument is the stored form of a task. MongoDB keeps timestamps with millisecond precision.
+// The priority is stored as its rank, so it orders by urgency.
+type taskDocument struct {
+	ID          string               `bson:"_id"`
+	Title       string               `bson:"title"`
+	Description string               `bson:"description"`
+	Status      string               `bson:"status"`
+	CreatedAt   time.Time            `bson:"created_at"`
+	UpdatedAt   time.Time            `bson:"updated_at"`
+	Version     int64                `bson:"version"`
+	DueDate     *time.Time           `bson:"due_date,omitempty"`
+	Priority    int                  `bson:"priority"`
+	Assignee    string               `bson:"assignee,omitempty"`
+	Subtasks    []subtaskDocument    `bson:"subtasks,omitempty"`
+	Attachments []attachmentDocument `bson:"attachments,omitempty"`
+	ProjectID   string               `bson:"project_id,omitempty"`
+	Rank        string               `bson:"rank"`
+	Estimate    int                  `bson:"estimate,omitempty"`
+	Progress    int                  `bson:"progress,omitempty"`
+	Tags        []string             `bson:"tags,omitempty"`
+}
+
+// subtaskDocument is the stored form of a subtask, embedded in its task document.
+type subtaskDocument struct {
+	ID    string `bson:"id"`
+	Title string `bson:"title"`
+	Done  bool   `bson:"done"`
+}
+
+// newSubtaskDocuments converts subtasks into their stored form.
+func newSubtaskDocuments(subtasks []domain.Subtask) []subtaskDocument {
+	if subtasks == nil {
+		return nil
+	}
+
+	docs := make([]subtaskDocument, len(subtasks))
+	for i, subtask := range subtasks {
+		docs[i] = subtaskDocument(subtask)
+	}
+	return docs
+}
+
+// attachmentDocument is the stored form of the metadata of an attachment, embedded in its task document.
+type attachmentDocument struct {
+	ID          string    `bson:"id"`
+	Filename    string    `bson:"filename"`
+	ContentType string    `bson:"content_type"`
+	Size        int64     `bson:"size"`
+	Checksum    string    `bson:"checksum"`
+	CreatedAt   time.Time `bson:"created_at"`
+}
+
+// newAttachmentDocuments converts attachments into their stored form.
+func newAttachmentDocuments(attachments []domain.Attachment) []attachmentDocument {
+	if attachments == nil {
+		return nil
+	}
+
+	docs := make([]attachmentDocument, len(attachments))
+	for i, attachment := range attachments {
+		docs[i] = attachmentDocument(attachment)
+	}
+	return docs
+}
+
+// newTaskDocument converts a task into its stored form.
+func newTaskDocument(task *domain.Task) taskDocument {
+	return taskDocument{
+		ID:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		Status:      string(task.Status),
+		CreatedAt:   task.CreatedAt,
+		UpdatedAt:   task.UpdatedAt,
+		Version:     task.Version,
+		DueDate:     task.DueDate,
+		Priority:    task.Priority.Rank(),
+		Assignee:    task.Assignee,
+		Subtasks:    newSubtaskDocuments(task.Subtasks),
+		Attachments: newAttachmentDocuments(task.Attachments),
+		ProjectID:   task.ProjectID,
+		Rank:        task.Rank,
+		Estimate:    task.Estimate,
+		Progress:    task.Progress,
+		Tags:        task.Tags,
+	}
+}
+
+// task converts the document back into a domain task. Documents stored before
+// priorities were introduced have no rank and get the default priority; documents
+// stored before manual ordering get the rank of their creation time.
+func (d taskDocument) task() *domain.Task {
+	priority, ok := domain.PriorityOfRank(d.Priority)
+	if !ok {
+		priority = domain.PriorityMedium
+	}
+
+	rank := d.Rank
+	if rank == "" {
+		rank = domain.InitialRank(d.CreatedAt)
+	}
+
+	var subtasks []domain.Subtask
+	for _, subtask := range d.Subtasks {
+		subtasks = append(subtasks, domain.Subtask(subtask))
+	}
+
+	var attachments []domain.Attachment
+	for _, attachment := range d.Attachments {
+		attachments = append(attachments, domain.Attachment(attachment))
+	}
+
+	return &domain.Task{
+		ID:          d.ID,
+		Title:       d.Title,
+		Description: d.Description,
+		Status:      domain.TaskStatus(d.Status),
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+		Version:     d.Version,
+		DueDate:     d.DueDate,
+		Priority:    priority,
+		Assignee:    d.Assignee,
+		Subtasks:    subtasks,
+		Attachments: attachments,
+		ProjectID:   d.ProjectID,
+		Rank:        rank,
+		Estimate:    d.Estimate,
+		Progress:    d.Progress,
+		Tags:        d.Tags,
+	}
+}
+
+// TaskRepository implements ports.TaskRepository on a MongoDB collection.
+type TaskRepository struct {
+	client  *mongo.Client
+	tasks   *mongo.Collection
+	timeout time.Duration
+}
+
+// Option configures optional TaskRepository behavior.
+type Option func(*TaskRepository)
+
+// WithTimeout bounds the duration of every operation. The default is 5 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(r *TaskRepository) {
+		r.timeout = timeout
+	}
+}
+
+// New connects to the deployment at uri, creates the indexes of the tasks collection
+// in database if needed and returns a repository using it.
+func New(ctx context.Context, uri, database string, opts ...Option) (*TaskRepository, error) {
+	r := &TaskRepository{timeout: defaultTimeout}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	client, err := mongo.Connect(options.Client().ApplyURI(uri).SetTimeout(r.timeout))
+	if err != nil {
+		return nil, fmt.Errorf("invalid MongoDB configuration: %w", err)
+	}
+
+	r.client = client
+	r.tasks = client.Database(database).Collection(collectionName)
+
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	_, err = r.tasks.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "created_at", Value: 1}}},
+		{Keys: bson.D{{Key: "due_date", Value: 1}}},
+		{Keys: bson.D{{Key: "assignee", Value: 1}}},
+		{Keys: bson.D{{Key: "project_id", Value: 1}}},
+		{Keys: bson.D{{Key: "rank", Value: 1}, {Key: "_id", Value: 1}}},
+	})
+	if err != nil {
+		_ = client.Disconnect(context.Background())
+		return nil, fmt.Errorf("failed to create indexes: %w", err)
+	}
+
+	return r, nil
+}
+
+// NewFromEnv connects to the deployment in the MONGODB_URI environment variable,
+// e.g. mongodb://localhost:27017. MONGODB_DATABASE selects the database (task_manager
+// by default) and MONGODB_TIMEOUT the operation timeout (5s by default).
+// Returns nil if MONGODB_URI is not set.
+func NewFromEnv(ctx context.Context) (*TaskRepository, error) {
+	uri := os.Getenv("MONGODB_URI")
+	if uri == "" {
+		return nil, nil
+	}
+
+	database := os.Getenv("MONGODB_DATABASE")
+	if database == "" {
+		database = defaultDatabase
+	}
+
+	var opts []Option
+	if raw := os.Getenv("MONGODB_TIMEOUT"); raw != "" {
+		timeout, err := time.ParseDuration(raw)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("MONGODB_TIMEOUT must be a positive duration, got: %s", raw)
+		}
+		opts = append(opts, WithTimeout(timeout))
+	}
+
+	return New(ctx, uri, database, opts...)
+}
+
+// Close disconnects from the deployment.
+func (r *TaskRepository) Close(ctx context.Context) error {
+	return r.client.Disconnect(ctx)
+}
+
+// withTimeout derives a context bounded by the operation timeout.
+func (r *TaskRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, r.timeout)
+}
+
+// Create inserts a new task document.
+// Returns domain.ErrTaskExists if a task with the same ID already exists.
+func (r *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	_, err := r.tasks.InsertOne(ctx, newTaskDocument(task))
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrTaskExists
+	}
+
+	return err
+}
+
+// GetByID retrieves a task by its unique identifier.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (r *TaskRepository) GetByID(ctx context.Context, id string) (*domain.Task, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var doc taskDocument
+	err := r.tasks.FindOne(ctx, bson.D{{Key: "_id", Value: id}}).Decode(&doc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, domain.ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return doc.task(), nil
+}
+
+// GetByIDs retrieves the tasks with the given identifiers with a single query.
+// Unknown IDs are skipped; tasks are returned in the order of ids.
+func (r *TaskRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.Task, error) {
+	found, err := r.find(ctx, bson.D{{Key: "_id", Value: bson.D{{Key: "$in", Value: ids}}}}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*domain.Task, len(found))
+	for _, task := range found {
+		byID[task.ID] = task
+	}
+
+	tasks := make([]*domain.Task, 0, len(found))
+	for _, id := range ids {
+		if task, ok := byID[id]; ok {
+			tasks = append(tasks, task)
+		}
+	}
+
+	return tasks, nil
+}
+
+// GetAll retrieves the page of tasks described by the query. The filter and the cursor
+// are translated into a query document, with the text queries matched by case-insensitive
+// regular expressions, and the sort and page limit into find options.
+func (r *TaskRepository) GetAll(ctx context.Context, query ports.ListQuery) (ports.TaskPage, error) {
+	if err := query.Sort.Validate(); err != nil {
+		return ports.TaskPage{}, err
+	}
+
+	sort := query.Sort.Normalize()
+	direction, comparison := 1, "$gt"
+	if sort.Order == ports.SortDesc {
+		direction, comparison = -1, "$lt"
+	}
+
+	// The sort fields are named after the document fields. Strings are compared
+	// bytewise without a collation, so the order agrees with the cursor keys.
+	field := string(sort.Field)
+	filter := filterDocument(query.Filter)
+	if query.Page.Cursor != "" {
+		after, err := ports.DecodeCursor(sort, query.Page.Cursor)
+		if err != nil {
+			return ports.TaskPage{}, err
+		}
+
+		value, _ := after.Value()
+		filter = bson.D{{Key: "$and", Value: bson.A{filter, bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: field, Value: bson.D{{Key: comparison, Value: value}}}},
+			bson.D{{Key: field, Value: value}, {Key: "_id", Value: bson.D{{Key: comparison, Value: after.ID}}}},
+		}}}}}}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: field, Value: direction}, {Key: "_id", Value: direction}})
+	if query.Page.Limit > 0 {
+		// One task more than the page tells whether there is a next page.
+		opts.SetLimit(int64(query.Page.Limit) + 1)
+	}
+
+	tasks, err := r.find(ctx, filter, opts)
+	if err != nil {
+		return ports.TaskPage{}, err
+	}
+
+	return ports.NewTaskPage(tasks, query), nil
+}
+
+// Iterate streams the tasks matching the filter from a cursor, fetching them in
+// batches as fn consumes them. The timeout bounds each batch rather than the
+// whole iteration.
+func (r *TaskRepository) Iterate(ctx context.Context, filter ports.ListFilter, fn func(*domain.Task) error) error {
+	findCtx, cancel := r.withTimeout(ctx)
+	cursor, err := r.tasks.Find(findCtx, filterDocument(filter))
+	cancel()
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.WithoutCancel(ctx))
+
+	for {
+		nextCtx, cancel := r.withTimeout(ctx)
+		ok := cursor.Next(nextCtx)
+		cancel()
+		if !ok {
+			return cursor.Err()
+		}
+
+		var doc taskDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+
+		if err := fn(doc.task()); err != nil {
+			return err
+		}
+	}
+}
+
+// Update modifies an existing task if its stored version still equals task.Version,
+// and increments the version.
+// Returns domain.ErrVersionConflict if the task was modified concurrently.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	result, err := r.tasks.UpdateOne(
+		ctx,
+		bson.D{{Key: "_id", Value: task.ID}, {Key: "version", Value: task.Version}},
+		bson.D{
+			{Key: "$set", Value: bson.D{
+				{Key: "title", Value: task.Title},
+				{Key: "description", Value: task.Description},
+				{Key: "status", Value: string(task.Status)},
+				{Key: "updated_at", Value: task.UpdatedAt},
+				{Key: "due_date", Value: task.DueDate},
+				{Key: "priority", Value: task.Priority.Rank()},
+				{Key: "assignee", Value: task.Assignee},
+				{Key: "subtasks", Value: newSubtaskDocuments(task.Subtasks)},
+				{Key: "attachments", Value: newAttachmentDocuments(task.Attachments)},
+				{Key: "project_id", Value: task.ProjectID},
+				{Key: "rank", Value: task.Rank},
+				{Key: "estimate", Value: task.Estimate},
+				{Key: "progress", Value: task.Progress},
+				{Key: "tags", Value: task.Tags},
+			}},
+			{Key: "$inc", Value: bson.D{{Key: "version", Value: 1}}},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		count, err := r.tasks.CountDocuments(ctx, bson.D{{Key: "_id", Value: task.ID}})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return domain.ErrTaskNotFound
+		}
+		return domain.ErrVersionConflict
+	}
+
+	task.Version++
+	return nil
+}
+
+// Delete removes a task by its ID.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (r *TaskRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	result, err := r.tasks.DeleteOne(ctx, bson.D{{Key: "_id", Value: id}})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return domain.ErrTaskNotFound
+	}
+
+	return nil
+}
+
+// Ping checks that the primary of the deployment is reachable.
+func (r *TaskRepository) Ping(ctx context.Context) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	return r.client.Ping(ctx, readpref.Primary())
+}
+
+// Inspect reports task counts per status and the storage size of the collection.
+// The size is left at zero if the deployment does not report it.
+func (r *TaskRepository) Inspect(ctx context.Context) (ports.RepositoryStats, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	stats := ports.RepositoryStats{
+		Backend:    "mongo",
+		TaskCounts: make(map[domain.TaskStatus]int),
+	}
+
+	cursor, err := r.tasks.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$group", Value: bson.D{{Key: "_id", Value: "$status"}, {Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}}}}},
+	})
+	if err != nil {
+		return ports.RepositoryStats{}, err
+	}
+
+	var groups []struct {
+		Status string `bson:"_id"`
+		Count  int    `bson:"count"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return ports.RepositoryStats{}, err
+	}
+
+	for _, group := range groups {
+		stats.TaskCounts[domain.TaskStatus(group.Status)] = group.Count
+	}
+
+	var collStats struct {
+		StorageSize int64 `bson:"storageSize"`
+	}
+	err = r.tasks.Database().RunCommand(ctx, bson.D{{Key: "collStats", Value: collectionName}}).Decode(&collStats)
+	if err == nil {
+		stats.SizeBytes = collStats.StorageSize
+	}
+
+	return stats, nil
+}
+
+// Dump returns a page of stored tasks ordered by ID.
+func (r *TaskRepository) Dump(ctx context.Context, offset, limit int) ([]*domain.Task, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}).SetSkip(int64(offset)).SetLimit(int64(limit))
+	return r.find(ctx, bson.D{}, opts)
+}
+
+// find returns the tasks of the documents matching query.
+func (r *TaskRepository) find(
+	ctx context.Context, query bson.D, opts *options.FindOptionsBuilder,
+) ([]*domain.Task, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var findOpts []options.Lister[options.FindOptions]
+	if opts != nil {
+		findOpts = append(findOpts, opts)
+	}
+
+	cursor, err := r.tasks.Find(ctx, query, findOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var docs []taskDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*domain.Task, 0, len(docs))
+	for _, doc := range docs {
+		tasks = append(tasks, doc.task())
+	}
+
+	return tasks, nil
+}
+
+// filterDocument translates filter into a query document; a zero filter matches every task.
+func filterDocument(filter ports.ListFilter) bson.D {
+	query := bson.D{}
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			statuses[i] = string(status)
+		}
+		query = append(query, bson.E{Key: "status", Value: bson.D{{Key: "$in", Value: statuses}}})
+	}
+
+	if len(filter.Priorities) > 0 {
+		ranks := make([]int, len(filter.Priorities))
+		for i, priority := range filter.Priorities {
+			ranks[i] = priority.Rank()
+		}
+		query = append(query, bson.E{Key: "priority", Value: bson.D{{Key: "$in", Value: ranks}}})
+	}
+
+	if filter.Assignee != "" {
+		query = append(query, bson.E{Key: "assignee", Value: filter.Assignee})
+	}
+
+	// Documents of unassigned tasks have an empty assignee or none at all.
+	if filter.Unassigned {
+		query = append(query, bson.E{Key: "assignee", Value: bson.D{{Key: "$in", Value: bson.A{"", nil}}}})
+	}
+
+	if filter.ProjectID != "" {
+		query = append(query, bson.E{Key: "project_id", Value: filter.ProjectID})
+	}
+
+	if filter.MinProgress > 0 {
+		query = append(query, bson.E{Key: "progress", Value: bson.D{{Key: "$gte", Value: filter.MinProgress}}})
+	}
+
+	if filter.Query != "" {
+		pattern := containsPattern(filter.Query)
+		query = append(query, bson.E{Key: "$or", Value: bson.A{
+			bson.D{{Key: "title", Value: pattern}},
+			bson.D{{Key: "description", Value: pattern}},
+		}})
+	}
+
+	// $and keeps conditions on fields that other conditions of the query already match on.
+	var and bson.A
+	if filter.TitleContains != "" {
+		and = append(and, bson.D{{Key: "title", Value: containsPattern(filter.TitleContains)}})
+	}
+
+	if !filter.OverdueAt.IsZero() {
+		finished := []string{string(domain.StatusCompleted), string(domain.StatusCancelled)}
+		and = append(and,
+			bson.D{{Key: "due_date", Value: bson.D{{Key: "$lt", Value: filter.OverdueAt}}}},
+			bson.D{{Key: "status", Value: bson.D{{Key: "$nin", Value: finished}}}},
+		)
+	}
+
+	if len(and) > 0 {
+		query = append(query, bson.E{Key: "$and", Value: and})
+	}
+
+	if timeRange := rangeDocument(filter.CreatedAfter, filter.CreatedBefore); len(timeRange) > 0 {
+		query = append(query, bson.E{Key: "created_at", Value: timeRange})
+	}
+
+	if timeRange := rangeDocument(filter.UpdatedAfter, filter.UpdatedBefore); len(timeRange) > 0 {
+		query = append(query, bson.E{Key: "updated_at", Value: timeRange})
+	}
+
+	if timeRange := rangeDocument(filter.DueAfter, filter.DueBefore); len(timeRange) > 0 {
+		query = append(query, bson.E{Key: "due_date", Value: timeRange})
+	}
+
+	return query
+}
+
+// containsPattern returns a case-insensitive regular expression matching values containing s.
+func containsPattern(s string) bson.Regex {
+	return bson.Regex{Pattern: regexp.QuoteMeta(s), Options: "i"}
+}
+
+// rangeDocument returns the exclusive bounds between after and before; zero bounds are omitted.
+func rangeDocument(after, before time.Time) bson.D {
+	bounds := bson.D{}
+	if !after.IsZero() {
+		bounds = append(bounds, bson.E{Key: "$gt", Value: after})
+	}
+	if !before.IsZero() {
+		bounds = append(bounds, bson.E{Key: "$lt", Value: before})
+	}
+
+	return bounds
+}
@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.CommentRepository = (*CommentRepository)(nil)
+	_ ports.CommentStore      = (*TaskRepository)(nil)
+)
+
+// foreignKeyViolation is the SQLSTATE of a reference to a missing row.
+const foreignKeyViolation = "23503"
+
+// commentColumns lists the columns scanned by scanComment, in order.
+const commentColumns = "id, task_id, author, body, created_at, updated_at"
+
+// CommentRepository implements ports.CommentRepository on the connection pool of a
+// TaskRepository. Comments reference their task, so they are deleted together with it.
+type CommentRepository struct {
+	pool *pgxpool.Pool
+}
+
+// Comments returns the repository of task comments stored in the same database.
+func (r *TaskRepository) Comments() ports.CommentRepository {
+	return &CommentRepository{pool: r.pool}
+}
+
+// Create inserts a new comment.
+// Returns domain.ErrTaskNotFound if the task of the comment does not exist.
+func (r *CommentRepository) Create(ctx context.Context, comment *domain.Comment) error {
+	_, err := r.pool.Exec(
+		ctx,
+		`INSERT INTO comments (`+commentColumns+`) VALUES ($1, $2, $3, $4, $5, $6)`,
+		comment.ID, comment.TaskID, comment.Author, comment.Body, comment.CreatedAt, comment.UpdatedAt,
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return domain.ErrTaskNotFound
+	}
+
+	return err
+}
+
+// ListByTask returns the comments of a task, oldest first.
+func (r *CommentRepository) ListByTask(ctx context.Context, taskID string) ([]*domain.Comment, error) {
+	rows, err := r.pool.Query(
+		ctx,
+		`SELECT `+commentColumns+` FROM comments WHERE task_id = $1 ORDER BY created_at, id`,
+		taskID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.Comment, error) {
+		var comment domain.Comment
+		err := row.Scan(
+			&comment.ID, &comment.TaskID, &comment.Author, &comment.Body, &comment.CreatedAt, &comment.UpdatedAt,
+		)
+		return &comment, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if comments == nil {
+		comments = make([]*domain.Comment, 0)
+	}
+
+	return comments, nil
+}
+
+// Update replaces the author, body and update time of an existing comment.
+// Returns domain.ErrCommentNotFound if the task has no comment with the ID of comment.
+func (r *CommentRepository) Update(ctx context.Context, comment *domain.Comment) error {
+	tag, err := r.pool.Exec(
+		ctx,
+		`UPDATE comments SET author = $1, body = $2, updated_at = $3 WHERE id = $4 AND task_id = $5`,
+		comment.Author, comment.Body, comment.UpdatedAt, comment.ID, comment.TaskID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a comment from a task.
+// Returns domain.ErrCommentNotFound if the task has no comment with the given ID.
+func (r *CommentRepository) Delete(ctx context.Context, taskID, id string) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM comments WHERE id = $1 AND task_id = $2`, id, taskID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// DeleteByTask removes all comments of a task.
+func (r *CommentRepository) DeleteByTask(ctx context.Context, taskID string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM comments WHERE task_id = $1`, taskID)
+	return err
+}
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.TaskHistoryRepository = (*HistoryRepository)(nil)
+	_ ports.HistoryStore          = (*TaskRepository)(nil)
+)
+
+// historyColumns lists the columns written by Append and scanned by ListByTask, in order.
+var historyColumns = []string{"task_id", "version", "field", "old_value", "new_value", "actor", "changed_at"}
+
+// HistoryRepository implements ports.TaskHistoryRepository on the connection pool of a
+// TaskRepository. Entries reference their task, so they are deleted together with it;
+// the order of the entries is kept by a sequence column.
+type HistoryRepository struct {
+	pool *pgxpool.Pool
+}
+
+// History returns the repository of the task history stored in the same database.
+func (r *TaskRepository) History() ports.TaskHistoryRepository {
+	return &HistoryRepository{pool: r.pool}
+}
+
+// Append copies the entries into the history table in a single statement.
+// Returns domain.ErrTaskNotFound if the task of an entry does not exist.
+func (r *HistoryRepository) Append(ctx context.Context, entries []domain.HistoryEntry) error {
+	_, err := r.pool.CopyFrom(
+		ctx,
+		pgx.Identifier{"task_history"},
+		historyColumns,
+		historyRows(entries),
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+		return domain.ErrTaskNotFound
+	}
+
+	return err
+}
+
+// ListByTask returns the history of a task, oldest first.
+func (r *HistoryRepository) ListByTask(ctx context.Context, taskID string) ([]domain.HistoryEntry, error) {
+	rows, err := r.pool.Query(
+		ctx,
+		`SELECT task_id, version, field, old_value, new_value, actor, changed_at
+		FROM task_history WHERE task_id = $1 ORDER BY seq`,
+		taskID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := pgx.CollectRows(rows, scanHistoryEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	if entries == nil {
+		entries = make([]domain.HistoryEntry, 0)
+	}
+
+	return entries, nil
+}
+
+// RewriteByTask replaces the history of a task by the entries returned by rewrite in a
+// single transaction. The task row is locked first, which blocks concurrent appends, as
+// they reference the task.
+// Returns domain.ErrTaskNotFound if the task does not exist.
+func (r *HistoryRepository) RewriteByTask(
+	ctx context.Context, taskID string, rewrite func([]domain.HistoryEntry) ([]domain.HistoryEntry, error),
+) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	// Rolling back after a commit does nothing.
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var locked int
+	err = tx.QueryRow(ctx, `SELECT 1 FROM tasks WHERE id = $1 FOR UPDATE`, taskID).Scan(&locked)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrTaskNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(
+		ctx,
+		`SELECT task_id, version, field, old_value, new_value, actor, changed_at
+		FROM task_history WHERE task_id = $1 ORDER BY seq`,
+		taskID,
+	)
+	if err != nil {
+		return err
+	}
+
+	entries, err := pgx.CollectRows(rows, scanHistoryEntry)
+	if err != nil {
+		return err
+	}
+
+	if entries, err = rewrite(entries); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM task_history WHERE task_id = $1`, taskID); err != nil {
+		return err
+	}
+
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{"task_history"}, historyColumns, historyRows(entries)); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// DeleteByTask removes the history of a task.
+func (r *HistoryRepository) DeleteByTask(ctx context.Context, taskID string) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM task_history WHERE task_id = $1`, taskID)
+	return err
+}
+
+// historyRows returns the entries as the rows of historyColumns for CopyFrom.
+func historyRows(entries []domain.HistoryEntry) pgx.CopyFromSource {
+	return pgx.CopyFromSlice(len(entries), func(i int) ([]any, error) {
+		entry := entries[i]
+		return []any{
+			entry.TaskID, entry.Version, entry.Field, entry.OldValue, entry.NewValue, entry.Actor, entry.ChangedAt,
+		}, nil
+	})
+}
+
+// scanHistoryEntry scans a row of historyColumns into a history entry.
+func scanHistoryEntry(row pgx.CollectableRow) (domain.HistoryEntry, error) {
+	var entry domain.HistoryEntry
+	err := row.Scan(
+		&entry.TaskID, &entry.Version, &entry.Field, &entry.OldValue, &entry.NewValue, &entry.Actor,
+		&entry.ChangedAt,
+	)
+	return entry, err
+}
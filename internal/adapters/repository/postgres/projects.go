@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.ProjectRepository = (*ProjectRepository)(nil)
+	_ ports.ProjectStore      = (*TaskRepository)(nil)
+)
+
+// projectColumns lists the columns scanned by scanProject, in order.
+const projectColumns = "id, name, description, created_at, updated_at"
+
+// ProjectRepository implements ports.ProjectRepository on the connection pool of a TaskRepository.
+type ProjectRepository struct {
+	pool *pgxpool.Pool
+}
+
+// Projects returns the repository of projects stored in the same database.
+func (r *TaskRepository) Projects() ports.ProjectRepository {
+	return &ProjectRepository{pool: r.pool}
+}
+
+// Create inserts a new project.
+// Returns domain.ErrProjectExists if a project with the same ID already exists.
+func (r *ProjectRepository) Create(ctx context.Context, project *domain.Project) error {
+	_, err := r.pool.Exec(
+		ctx,
+		`INSERT INTO projects (`+projectColumns+`) VALUES ($1, $2, $3, $4, $5)`,
+		project.ID, project.Name, project.Description, project.CreatedAt, project.UpdatedAt,
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return domain.ErrProjectExists
+	}
+
+	return err
+}
+
+// GetByID returns the project with the given ID.
+// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+func (r *ProjectRepository) GetByID(ctx context.Context, id string) (*domain.Project, error) {
+	project, err := scanProject(
+		r.pool.QueryRow(ctx, `SELECT `+projectColumns+` FROM projects WHERE id = $1`, id),
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	return project, err
+}
+
+// GetAll returns all projects, oldest first.
+func (r *ProjectRepository) GetAll(ctx context.Context) ([]*domain.Project, error) {
+	rows, err := r.pool.Query(ctx, `SELECT `+projectColumns+` FROM projects ORDER BY created_at, id COLLATE "C"`)
+	if err != nil {
+		return nil, err
+	}
+
+	projects, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.Project, error) {
+		return scanProject(row)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if projects == nil {
+		projects = make([]*domain.Project, 0)
+	}
+
+	return projects, nil
+}
+
+// Update modifies an existing project.
+// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+func (r *ProjectRepository) Update(ctx context.Context, project *domain.Project) error {
+	tag, err := r.pool.Exec(
+		ctx,
+		`UPDATE projects SET name = $2, description = $3, updated_at = $4 WHERE id = $1`,
+		project.ID, project.Name, project.Description, project.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+// Delete removes the project with the given ID.
+// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+func (r *ProjectRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM projects WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+// scanProject reads a project from a row with the projectColumns.
+func scanProject(row pgx.Row) (*domain.Project, error) {
+	var project domain.Project
+	err := row.Scan(&project.ID, &project.Name, &project.Description, &project.CreatedAt, &project.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &project, nil
+}
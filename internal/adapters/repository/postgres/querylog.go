@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/asp3cto/task-manager/internal/adapters/repository/querylog"
+)
+
+var (
+	_ pgx.QueryTracer    = (*queryTracer)(nil)
+	_ pgx.CopyFromTracer = (*queryTracer)(nil)
+)
+
+// queryStartKey is the context key of the start of a traced query.
+type queryStartKey struct{}
+
+// queryStart is the traced query and when it started.
+type queryStart struct {
+	sql string
+	at  time.Time
+}
+
+// queryTracer records the queries and copies of the connections with a querylog.Recorder.
+// Prepared statements are recorded by their name.
+type queryTracer struct {
+	recorder *querylog.Recorder
+}
+
+// TraceQueryStart stores the query and its start time in the returned context.
+func (t *queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, queryStart{sql: data.SQL, at: time.Now()})
+}
+
+// TraceQueryEnd records the query started by TraceQueryStart.
+func (t *queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	t.end(ctx, data.Err)
+}
+
+// TraceCopyFromStart stores the copy and its start time in the returned context.
+func (t *queryTracer) TraceCopyFromStart(
+	ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromStartData,
+) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, queryStart{sql: "COPY " + data.TableName.Sanitize(), at: time.Now()})
+}
+
+// TraceCopyFromEnd records the copy started by TraceCopyFromStart.
+func (t *queryTracer) TraceCopyFromEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceCopyFromEndData) {
+	t.end(ctx, data.Err)
+}
+
+// end records the query stored in ctx, which failed with err, if not nil.
+func (t *queryTracer) end(ctx context.Context, err error) {
+	start, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+
+	t.recorder.Record(ctx, start.sql, time.Since(start.at), err)
+}
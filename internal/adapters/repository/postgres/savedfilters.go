@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.SavedFilterRepository = (*SavedFilterRepository)(nil)
+	_ ports.SavedFilterStore      = (*TaskRepository)(nil)
+)
+
+// savedFilterColumns lists the columns scanned by scanSavedFilter, in order.
+// The filter is stored as a JSONB object.
+const savedFilterColumns = "name, description, filter, sort_field, sort_order, created_by, created_at, updated_at"
+
+// SavedFilterRepository implements ports.SavedFilterRepository on the connection pool of a TaskRepository.
+type SavedFilterRepository struct {
+	pool *pgxpool.Pool
+}
+
+// SavedFilters returns the repository of saved filters stored in the same database.
+func (r *TaskRepository) SavedFilters() ports.SavedFilterRepository {
+	return &SavedFilterRepository{pool: r.pool}
+}
+
+// Create inserts a new saved filter.
+// Returns domain.ErrSavedFilterExists if a filter with the same name already exists.
+func (r *SavedFilterRepository) Create(ctx context.Context, filter *domain.SavedFilter) error {
+	_, err := r.pool.Exec(
+		ctx,
+		`INSERT INTO saved_filters (`+savedFilterColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		filter.Name, filter.Description, filter.Filter, filter.SortField, filter.SortOrder,
+		filter.CreatedBy, filter.CreatedAt, filter.UpdatedAt,
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return domain.ErrSavedFilterExists
+	}
+
+	return err
+}
+
+// GetByName returns the saved filter with the given name.
+// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+func (r *SavedFilterRepository) GetByName(ctx context.Context, name string) (*domain.SavedFilter, error) {
+	filter, err := scanSavedFilter(
+		r.pool.QueryRow(ctx, `SELECT `+savedFilterColumns+` FROM saved_filters WHERE name = $1`, name),
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrSavedFilterNotFound
+	}
+
+	return filter, err
+}
+
+// GetAll returns all saved filters ordered by name.
+func (r *SavedFilterRepository) GetAll(ctx context.Context) ([]*domain.SavedFilter, error) {
+	rows, err := r.pool.Query(ctx, `SELECT `+savedFilterColumns+` FROM saved_filters ORDER BY name COLLATE "C"`)
+	if err != nil {
+		return nil, err
+	}
+
+	filters, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.SavedFilter, error) {
+		return scanSavedFilter(row)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if filters == nil {
+		filters = make([]*domain.SavedFilter, 0)
+	}
+
+	return filters, nil
+}
+
+// Update replaces an existing saved filter.
+// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+func (r *SavedFilterRepository) Update(ctx context.Context, filter *domain.SavedFilter) error {
+	tag, err := r.pool.Exec(
+		ctx,
+		`UPDATE saved_filters SET description = $2, filter = $3, sort_field = $4, sort_order = $5, updated_at = $6
+		WHERE name = $1`,
+		filter.Name, filter.Description, filter.Filter, filter.SortField, filter.SortOrder, filter.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrSavedFilterNotFound
+	}
+
+	return nil
+}
+
+// Delete removes the saved filter with the given name.
+// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+func (r *SavedFilterRepository) Delete(ctx context.Context, name string) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM saved_filters WHERE name = $1`, name)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrSavedFilterNotFound
+	}
+
+	return nil
+}
+
+// scanSavedFilter reads a saved filter from a row with the savedFilterColumns.
+func scanSavedFilter(row pgx.Row) (*domain.SavedFilter, error) {
+	var filter domain.SavedFilter
+	err := row.Scan(
+		&filter.Name, &filter.Description, &filter.Filter, &filter.SortField, &filter.SortOrder,
+		&filter.CreatedBy, &filter.CreatedAt, &filter.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filter, nil
+}
@@ -0,0 +1,224 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.WebhookRepository           = (*WebhookRepository)(nil)
+	_ ports.WebhookDeadLetterRepository = (*WebhookDeadLetterRepository)(nil)
+	_ ports.WebhookStore                = (*TaskRepository)(nil)
+)
+
+// webhookColumns lists the columns scanned by scanWebhook, in order.
+// The event types are stored as a JSONB array.
+const webhookColumns = "id, url, events, secret, created_at, updated_at"
+
+// deadLetterColumns lists the columns scanned by scanDeadLetter, in order.
+// The event is stored as a JSONB object.
+const deadLetterColumns = "id, webhook_id, event, attempts, last_error, failed_at"
+
+// WebhookRepository implements ports.WebhookRepository on the connection pool of a TaskRepository.
+type WebhookRepository struct {
+	pool *pgxpool.Pool
+}
+
+// Webhooks returns the repository of webhooks stored in the same database.
+func (r *TaskRepository) Webhooks() ports.WebhookRepository {
+	return &WebhookRepository{pool: r.pool}
+}
+
+// WebhookDeadLetters returns the repository of webhook dead letters stored in the same database.
+func (r *TaskRepository) WebhookDeadLetters() ports.WebhookDeadLetterRepository {
+	return &WebhookDeadLetterRepository{pool: r.pool}
+}
+
+// Create inserts a new webhook.
+// Returns domain.ErrWebhookExists if a webhook with the same ID already exists.
+func (r *WebhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	_, err := r.pool.Exec(
+		ctx,
+		`INSERT INTO webhooks (`+webhookColumns+`) VALUES ($1, $2, $3, $4, $5, $6)`,
+		webhook.ID, webhook.URL, jsonArray(webhook.Events), webhook.Secret, webhook.CreatedAt, webhook.UpdatedAt,
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return domain.ErrWebhookExists
+	}
+
+	return err
+}
+
+// GetByID returns the webhook with the given ID.
+// Returns domain.ErrWebhookNotFound if no webhook exists with the given ID.
+func (r *WebhookRepository) GetByID(ctx context.Context, id string) (*domain.Webhook, error) {
+	webhook, err := scanWebhook(
+		r.pool.QueryRow(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE id = $1`, id),
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrWebhookNotFound
+	}
+
+	return webhook, err
+}
+
+// GetAll returns all webhooks, oldest first.
+func (r *WebhookRepository) GetAll(ctx context.Context) ([]*domain.Webhook, error) {
+	rows, err := r.pool.Query(ctx, `SELECT `+webhookColumns+` FROM webhooks ORDER BY created_at, id COLLATE "C"`)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.Webhook, error) {
+		return scanWebhook(row)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if webhooks == nil {
+		webhooks = make([]*domain.Webhook, 0)
+	}
+
+	return webhooks, nil
+}
+
+// Update modifies an existing webhook.
+// Returns domain.ErrWebhookNotFound if no webhook exists with the given ID.
+func (r *WebhookRepository) Update(ctx context.Context, webhook *domain.Webhook) error {
+	tag, err := r.pool.Exec(
+		ctx,
+		`UPDATE webhooks SET url = $2, events = $3, secret = $4, updated_at = $5 WHERE id = $1`,
+		webhook.ID, webhook.URL, jsonArray(webhook.Events), webhook.Secret, webhook.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// Delete removes the webhook with the given ID.
+// Returns domain.ErrWebhookNotFound if no webhook exists with the given ID.
+func (r *WebhookRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// scanWebhook reads a webhook from a row with the webhookColumns.
+func scanWebhook(row pgx.Row) (*domain.Webhook, error) {
+	var webhook domain.Webhook
+	err := row.Scan(&webhook.ID, &webhook.URL, &webhook.Events, &webhook.Secret, &webhook.CreatedAt, &webhook.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &webhook, nil
+}
+
+// WebhookDeadLetterRepository implements ports.WebhookDeadLetterRepository on the
+// connection pool of a TaskRepository.
+type WebhookDeadLetterRepository struct {
+	pool *pgxpool.Pool
+}
+
+// Create inserts a new dead letter.
+// Returns domain.ErrDeadLetterExists if a dead letter with the same ID already exists.
+func (r *WebhookDeadLetterRepository) Create(ctx context.Context, letter *domain.WebhookDeadLetter) error {
+	_, err := r.pool.Exec(
+		ctx,
+		`INSERT INTO webhook_dead_letters (`+deadLetterColumns+`) VALUES ($1, $2, $3, $4, $5, $6)`,
+		letter.ID, letter.WebhookID, letter.Event, letter.Attempts, letter.LastError, letter.FailedAt,
+	)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return domain.ErrDeadLetterExists
+	}
+
+	return err
+}
+
+// GetByID returns the dead letter with the given ID.
+// Returns domain.ErrDeadLetterNotFound if no dead letter exists with the given ID.
+func (r *WebhookDeadLetterRepository) GetByID(ctx context.Context, id string) (*domain.WebhookDeadLetter, error) {
+	letter, err := scanDeadLetter(
+		r.pool.QueryRow(ctx, `SELECT `+deadLetterColumns+` FROM webhook_dead_letters WHERE id = $1`, id),
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrDeadLetterNotFound
+	}
+
+	return letter, err
+}
+
+// GetAll returns all dead letters, oldest first.
+func (r *WebhookDeadLetterRepository) GetAll(ctx context.Context) ([]*domain.WebhookDeadLetter, error) {
+	rows, err := r.pool.Query(
+		ctx,
+		`SELECT `+deadLetterColumns+` FROM webhook_dead_letters ORDER BY failed_at, id COLLATE "C"`,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	letters, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.WebhookDeadLetter, error) {
+		return scanDeadLetter(row)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if letters == nil {
+		letters = make([]*domain.WebhookDeadLetter, 0)
+	}
+
+	return letters, nil
+}
+
+// Delete removes the dead letter with the given ID.
+// Returns domain.ErrDeadLetterNotFound if no dead letter exists with the given ID.
+func (r *WebhookDeadLetterRepository) Delete(ctx context.Context, id string) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM webhook_dead_letters WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrDeadLetterNotFound
+	}
+
+	return nil
+}
+
+// scanDeadLetter reads a dead letter from a row with the deadLetterColumns.
+func scanDeadLetter(row pgx.Row) (*domain.WebhookDeadLetter, error) {
+	var letter domain.WebhookDeadLetter
+	err := row.Scan(&letter.ID, &letter.WebhookID, &letter.Event, &letter.Attempts, &letter.LastError, &letter.FailedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &letter, nil
+}
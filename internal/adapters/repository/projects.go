@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var _ ports.ProjectRepository = (*MemoryProjectRepository)(nil)
+
+// MemoryProjectRepository provides an in-memory implementation of the ProjectRepository interface.
+// Data is lost when the application restarts since it's stored only in memory.
+type MemoryProjectRepository struct {
+	// projects stores all projects indexed by their ID
+	projects map[string]*domain.Project
+	// mu provides thread-safe access to the projects map
+	mu sync.RWMutex
+}
+
+// NewMemoryProjectRepository creates a new instance of the in-memory project repository.
+func NewMemoryProjectRepository() *MemoryProjectRepository {
+	return &MemoryProjectRepository{
+		projects: make(map[string]*domain.Project),
+	}
+}
+
+// Create stores a copy of a new project.
+// Returns domain.ErrProjectExists if a project with the same ID already exists.
+func (r *MemoryProjectRepository) Create(_ context.Context, project *domain.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.projects[project.ID]; exists {
+		return domain.ErrProjectExists
+	}
+
+	projectCopy := *project
+	r.projects[project.ID] = &projectCopy
+	return nil
+}
+
+// GetByID returns a copy of the project with the given ID.
+// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+func (r *MemoryProjectRepository) GetByID(_ context.Context, id string) (*domain.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	project, exists := r.projects[id]
+	if !exists {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	projectCopy := *project
+	return &projectCopy, nil
+}
+
+// GetAll returns copies of all projects, oldest first. Projects created at the
+// same instant are ordered by ID.
+func (r *MemoryProjectRepository) GetAll(_ context.Context) ([]*domain.Project, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	projects := make([]*domain.Project, 0, len(r.projects))
+	for _, project := range r.projects {
+		projectCopy := *project
+		projects = append(projects, &projectCopy)
+	}
+
+	slices.SortFunc(projects, func(a, b *domain.Project) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return projects, nil
+}
+
+// Update replaces the stored project with a copy of project.
+// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+func (r *MemoryProjectRepository) Update(_ context.Context, project *domain.Project) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.projects[project.ID]; !exists {
+		return domain.ErrProjectNotFound
+	}
+
+	projectCopy := *project
+	r.projects[project.ID] = &projectCopy
+	return nil
+}
+
+// Delete removes the project with the given ID.
+// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+func (r *MemoryProjectRepository) Delete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.projects[id]; !exists {
+		return domain.ErrProjectNotFound
+	}
+
+	delete(r.projects, id)
+	return nil
+}
@@ -0,0 +1,94 @@
+// Package querylog records the queries executed by the SQL repositories: every query is
+// logged with its duration at debug level, and queries slower than a threshold are logged
+// as warnings and counted, so storage regressions become visible.
+package querylog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/logger"
+)
+
+// DefaultSlowThreshold is the duration above which a query is slow by default.
+const DefaultSlowThreshold = 500 * time.Millisecond
+
+// Stats counts the recorded queries.
+type Stats struct {
+	// Queries is the number of executed queries
+	Queries uint64
+	// Slow is the number of queries that took longer than the slow threshold
+	Slow uint64
+	// Failed is the number of queries that returned an error
+	Failed uint64
+}
+
+// Recorder logs and counts the queries of a SQL repository.
+// Query arguments are never logged, as they hold task content.
+type Recorder struct {
+	logger        logger.Logger
+	slowThreshold time.Duration
+
+	queries atomic.Uint64
+	slow    atomic.Uint64
+	failed  atomic.Uint64
+}
+
+// New creates a recorder logging to logger and reporting queries slower than slowThreshold.
+func New(logger logger.Logger, slowThreshold time.Duration) *Recorder {
+	return &Recorder{
+		logger:        logger.With(slog.String("component", "sql")),
+		slowThreshold: slowThreshold,
+	}
+}
+
+// SlowThresholdFromEnv reads the SQL_SLOW_QUERY_THRESHOLD environment variable, a positive duration.
+// Returns DefaultSlowThreshold if the variable is not set.
+func SlowThresholdFromEnv() (time.Duration, error) {
+	raw := os.Getenv("SQL_SLOW_QUERY_THRESHOLD")
+	if raw == "" {
+		return DefaultSlowThreshold, nil
+	}
+
+	threshold, err := time.ParseDuration(raw)
+	if err != nil || threshold <= 0 {
+		return 0, fmt.Errorf("SQL_SLOW_QUERY_THRESHOLD must be a positive duration, got: %s", raw)
+	}
+	return threshold, nil
+}
+
+// Record logs the query sql, which took duration and failed with err, if not nil.
+func (r *Recorder) Record(ctx context.Context, sql string, duration time.Duration, err error) {
+	r.queries.Add(1)
+
+	attrs := []slog.Attr{
+		slog.String("query", strings.Join(strings.Fields(sql), " ")),
+		slog.Float64("duration_ms", float64(duration.Microseconds())/1000),
+	}
+	if err != nil {
+		r.failed.Add(1)
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	if duration > r.slowThreshold {
+		r.slow.Add(1)
+		r.logger.Warn(ctx, "slow sql query", attrs...)
+		return
+	}
+
+	r.logger.Debug(ctx, "sql query executed", attrs...)
+}
+
+// Stats returns the counts of the recorded queries.
+func (r *Recorder) Stats() Stats {
+	return Stats{
+		Queries: r.queries.Load(),
+		Slow:    r.slow.Load(),
+		Failed:  r.failed.Load(),
+	}
+}
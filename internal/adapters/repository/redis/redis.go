@@ -0,0 +1,628 @@
+// Package redis provides a TaskRepository stored in Redis, for deployments that want
+// a fast shared store without a relational database.
+//
+// Every task is a hash; an index hash maps task IDs to their statuses and a set per
+// status holds the IDs of the tasks with that status, so listings filtered by status
+// only read the matching tasks. Mutations run as Lua scripts that address keys derived
+// from the key prefix, so Redis Cluster is not supported.
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.TaskRepository      = (*TaskRepository)(nil)
+	_ ports.RepositoryInspector = (*TaskRepository)(nil)
+	_ ports.RepositoryDumper    = (*TaskRepository)(nil)
+)
+
+// defaultKeyPrefix is prepended to every key unless WithKeyPrefix is used.
+const defaultKeyPrefix = "task-manager:"
+
+// scanBatch is the number of IDs requested per scan by Iterate.
+const scanBatch = 500
+
+// createScript stores a task unless it exists and adds it to the indexes.
+// An index entry left behind by an expired task is moved to the new status.
+//
+// KEYS: task, index.
+// ARGV: prefix, id, title, description, status, created_at, updated_at, version, ttl in ms,
+// due_date, priority, assignee, subtasks, attachments, project_id, rank, estimate, progress, tags.
+var createScript = goredis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'title', ARGV[3], 'description', ARGV[4], 'status', ARGV[5],
+	'created_at', ARGV[6], 'updated_at', ARGV[7], 'version', ARGV[8], 'due_date', ARGV[10], 'priority', ARGV[11],
+	'assignee', ARGV[12], 'subtasks', ARGV[13], 'attachments', ARGV[14], 'project_id', ARGV[15],
+	'rank', ARGV[16], 'estimate', ARGV[17], 'progress', ARGV[18],
+	'tags', ARGV[19])
+if tonumber(ARGV[9]) > 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[9])
+end
+local stale = redis.call('HGET', KEYS[2], ARGV[2])
+if stale then
+	redis.call('SREM', ARGV[1] .. 'status:' .. stale, ARGV[2])
+end
+redis.call('HSET', KEYS[2], ARGV[2], ARGV[5])
+redis.call('SADD', ARGV[1] .. 'status:' .. ARGV[5], ARGV[2])
+return 1
+`)
+
+// updateScript modifies a task if its version matches, increments the version
+// and moves the task between status sets. Returns -1 if the task does not exist
+// and 0 on a version conflict.
+//
+// KEYS: task, index.
+// ARGV: prefix, id, title, description, status, updated_at, version, ttl in ms, due_date, priority, assignee,
+// subtasks, attachments, project_id, rank, estimate, progress, tags.
+var updateScript = goredis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return -1
+end
+if redis.call('HGET', KEYS[1], 'version') ~= ARGV[7] then
+	return 0
+end
+local old = redis.call('HGET', KEYS[1], 'status')
+redis.call('HSET', KEYS[1], 'title', ARGV[3], 'description', ARGV[4], 'status', ARGV[5], 'updated_at', ARGV[6],
+	'due_date', ARGV[9], 'priority', ARGV[10], 'assignee', ARGV[11], 'subtasks', ARGV[12],
+	'attachments', ARGV[13], 'project_id', ARGV[14], 'rank', ARGV[15], 'estimate', ARGV[16],
+	'progress', ARGV[17], 'tags', ARGV[18])
+redis.call('HINCRBY', KEYS[1], 'version', 1)
+if tonumber(ARGV[8]) > 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[8])
+end
+if old ~= ARGV[5] then
+	redis.call('SREM', ARGV[1] .. 'status:' .. old, ARGV[2])
+	redis.call('SADD', ARGV[1] .. 'status:' .. ARGV[5], ARGV[2])
+	redis.call('HSET', KEYS[2], ARGV[2], ARGV[5])
+end
+return 1
+`)
+
+// deleteScript removes a task and its index entries. Returns 0 if the task does not exist.
+//
+// KEYS: task, index. ARGV: prefix, id.
+var deleteScript = goredis.NewScript(`
+local status = redis.call('HGET', KEYS[1], 'status')
+if not status then
+	return 0
+end
+redis.call('DEL', KEYS[1])
+redis.call('HDEL', KEYS[2], ARGV[2])
+redis.call('SREM', ARGV[1] .. 'status:' .. status, ARGV[2])
+return 1
+`)
+
+// pruneScript removes the index entries of the given tasks that no longer exist,
+// i.e. that expired.
+//
+// KEYS: index. ARGV: prefix, ids...
+var pruneScript = goredis.NewScript(`
+for i = 2, #ARGV do
+	if redis.call('EXISTS', ARGV[1] .. 'task:' .. ARGV[i]) == 0 then
+		local status = redis.call('HGET', KEYS[1], ARGV[i])
+		if status then
+			redis.call('SREM', ARGV[1] .. 'status:' .. status, ARGV[i])
+		end
+		redis.call('HDEL', KEYS[1], ARGV[i])
+	end
+end
+return 0
+`)
+
+// TaskRepository implements ports.TaskRepository on a Redis server.
+type TaskRepository struct {
+	client *goredis.Client
+	prefix string
+	ttl    time.Duration
+}
+
+// Option configures optional TaskRepository behavior.
+type Option func(*TaskRepository)
+
+// WithKeyPrefix sets the prefix of all keys, so several services can share a database.
+func WithKeyPrefix(prefix string) Option {
+	return func(r *TaskRepository) {
+		r.prefix = prefix
+	}
+}
+
+// WithTTL makes tasks expire after ttl without changes. Expired tasks are removed
+// from the indexes when a listing or inspection comes across them.
+func WithTTL(ttl time.Duration) Option {
+	return func(r *TaskRepository) {
+		r.ttl = ttl
+	}
+}
+
+// New connects to the server at url, e.g. redis://localhost:6379/0,
+// and returns a repository using it.
+func New(ctx context.Context, url string, opts ...Option) (*TaskRepository, error) {
+	clientOpts, err := goredis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+
+	r := &TaskRepository{prefix: defaultKeyPrefix}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	r.client = goredis.NewClient(clientOpts)
+	if err := r.client.Ping(ctx).Err(); err != nil {
+		_ = r.client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return r, nil
+}
+
+// NewFromEnv connects to the server in the REDIS_URL environment variable.
+// REDIS_KEY_PREFIX overrides the key prefix and REDIS_TTL enables expiration of tasks.
+// Returns nil if REDIS_URL is not set.
+func NewFromEnv(ctx context.Context) (*TaskRepository, error) {
+	url := os.Getenv("REDIS_URL")
+	if url == "" {
+		return nil, nil
+	}
+
+	var opts []Option
+	if prefix, ok := os.LookupEnv("REDIS_KEY_PREFIX"); ok {
+		opts = append(opts, WithKeyPrefix(prefix))
+	}
+
+	if raw := os.Getenv("REDIS_TTL"); raw != "" {
+		ttl, err := time.ParseDuration(raw)
+		if err != nil || ttl <= 0 {
+			return nil, fmt.Errorf("REDIS_TTL must be a positive duration, got: %s", raw)
+		}
+		opts = append(opts, WithTTL(ttl))
+	}
+
+	return New(ctx, url, opts...)
+}
+
+// Close closes the connections to the server.
+func (r *TaskRepository) Close() error {
+	return r.client.Close()
+}
+
+// taskKey returns the key of the hash holding the task with the given ID.
+func (r *TaskRepository) taskKey(id string) string {
+	return r.prefix + "task:" + id
+}
+
+// indexKey returns the key of the hash mapping task IDs to their statuses.
+func (r *TaskRepository) indexKey() string {
+	return r.prefix + "index"
+}
+
+// statusKey returns the key of the set of tasks with the given status.
+func (r *TaskRepository) statusKey(status domain.TaskStatus) string {
+	return r.prefix + "status:" + string(status)
+}
+
+// Create stores a new task.
+// Returns domain.ErrTaskExists if a task with the same ID already exists.
+func (r *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	subtasks, err := encodeJSONArray("subtasks", task.Subtasks)
+	if err != nil {
+		return err
+	}
+
+	attachments, err := encodeJSONArray("attachments", task.Attachments)
+	if err != nil {
+		return err
+	}
+
+	tags, err := encodeJSONArray("tags", task.Tags)
+	if err != nil {
+		return err
+	}
+
+	created, err := createScript.Run(
+		ctx, r.client, []string{r.taskKey(task.ID), r.indexKey()},
+		r.prefix, task.ID, task.Title, task.Description, string(task.Status),
+		task.CreatedAt.UnixNano(), task.UpdatedAt.UnixNano(), task.Version, r.ttl.Milliseconds(),
+		encodeDueDate(task.DueDate), string(task.Priority), task.Assignee, subtasks, attachments,
+		task.ProjectID, task.Rank, task.Estimate, task.Progress, tags,
+	).Int()
+	if err != nil {
+		return err
+	}
+
+	if created == 0 {
+		return domain.ErrTaskExists
+	}
+
+	return nil
+}
+
+// GetByID retrieves a task by its unique identifier.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (r *TaskRepository) GetByID(ctx context.Context, id string) (*domain.Task, error) {
+	fields, err := r.client.HGetAll(ctx, r.taskKey(id)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields) == 0 {
+		return nil, domain.ErrTaskNotFound
+	}
+
+	return decodeTask(id, fields)
+}
+
+// GetByIDs retrieves the tasks with the given identifiers in a single round trip.
+// Unknown IDs are skipped; tasks are returned in the order of ids.
+func (r *TaskRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.Task, error) {
+	return r.fetch(ctx, ids)
+}
+
+// GetAll retrieves the page of tasks described by the query. Status filters are
+// resolved with the status sets; the remaining conditions are checked, and the
+// tasks ordered and paged, on the loaded tasks.
+func (r *TaskRepository) GetAll(ctx context.Context, query ports.ListQuery) (ports.TaskPage, error) {
+	filter := query.Filter
+	var ids []string
+	var err error
+	if len(filter.Statuses) > 0 {
+		keys := make([]string, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			keys[i] = r.statusKey(status)
+		}
+		ids, err = r.client.SUnion(ctx, keys...).Result()
+	} else {
+		ids, err = r.client.HKeys(ctx, r.indexKey()).Result()
+	}
+	if err != nil {
+		return ports.TaskPage{}, err
+	}
+
+	tasks, err := r.fetch(ctx, ids)
+	if err != nil {
+		return ports.TaskPage{}, err
+	}
+
+	tasks = slices.DeleteFunc(tasks, func(task *domain.Task) bool {
+		return !filter.Matches(task)
+	})
+	return ports.Paginate(tasks, query)
+}
+
+// Iterate visits the tasks matching the filter in batches, scanning the index hash
+// or, if statuses are filtered, the status sets, and loading each batch of tasks
+// with a pipeline.
+func (r *TaskRepository) Iterate(ctx context.Context, filter ports.ListFilter, fn func(*domain.Task) error) error {
+	if len(filter.Statuses) == 0 {
+		return r.iterateScan(ctx, filter, fn, func(cursor uint64) ([]string, uint64, error) {
+			pairs, next, err := r.client.HScan(ctx, r.indexKey(), cursor, "", scanBatch).Result()
+			ids := make([]string, 0, len(pairs)/2)
+			for i := 0; i < len(pairs); i += 2 {
+				ids = append(ids, pairs[i])
+			}
+			return ids, next, err
+		})
+	}
+
+	// Each status is scanned once, so a listed status does not duplicate its tasks.
+	for _, status := range slices.Compact(slices.Sorted(slices.Values(filter.Statuses))) {
+		err := r.iterateScan(ctx, filter, fn, func(cursor uint64) ([]string, uint64, error) {
+			return r.client.SScan(ctx, r.statusKey(status), cursor, "", scanBatch).Result()
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// iterateScan calls fn for the tasks matching the filter whose IDs are returned by
+// scan, a SCAN family command resumed from the cursor returned by its previous call.
+func (r *TaskRepository) iterateScan(
+	ctx context.Context, filter ports.ListFilter, fn func(*domain.Task) error,
+	scan func(cursor uint64) ([]string, uint64, error),
+) error {
+	var cursor uint64
+	for {
+		ids, next, err := scan(cursor)
+		if err != nil {
+			return err
+		}
+
+		tasks, err := r.fetch(ctx, ids)
+		if err != nil {
+			return err
+		}
+
+		for _, task := range tasks {
+			if !filter.Matches(task) {
+				continue
+			}
+
+			if err := fn(task); err != nil {
+				return err
+			}
+		}
+
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// Update modifies an existing task if its stored version still equals task.Version,
+// and increments the version.
+// Returns domain.ErrVersionConflict if the task was modified concurrently.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	subtasks, err := encodeJSONArray("subtasks", task.Subtasks)
+	if err != nil {
+		return err
+	}
+
+	attachments, err := encodeJSONArray("attachments", task.Attachments)
+	if err != nil {
+		return err
+	}
+
+	tags, err := encodeJSONArray("tags", task.Tags)
+	if err != nil {
+		return err
+	}
+
+	result, err := updateScript.Run(
+		ctx, r.client, []string{r.taskKey(task.ID), r.indexKey()},
+		r.prefix, task.ID, task.Title, task.Description, string(task.Status),
+		task.UpdatedAt.UnixNano(), task.Version, r.ttl.Milliseconds(), encodeDueDate(task.DueDate),
+		string(task.Priority), task.Assignee, subtasks, attachments, task.ProjectID, task.Rank,
+		task.Estimate, task.Progress, tags,
+	).Int()
+	if err != nil {
+		return err
+	}
+
+	switch result {
+	case -1:
+		return domain.ErrTaskNotFound
+	case 0:
+		return domain.ErrVersionConflict
+	}
+
+	task.Version++
+	return nil
+}
+
+// Delete removes a task by its ID.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (r *TaskRepository) Delete(ctx context.Context, id string) error {
+	deleted, err := deleteScript.Run(ctx, r.client, []string{r.taskKey(id), r.indexKey()}, r.prefix, id).Int()
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return domain.ErrTaskNotFound
+	}
+
+	return nil
+}
+
+// Ping checks that the server responds.
+func (r *TaskRepository) Ping(ctx context.Context) error {
+	return r.client.Ping(ctx).Err()
+}
+
+// Inspect reports task counts per status and the memory used by the task hashes.
+// The size is left at zero if the server does not report it.
+func (r *TaskRepository) Inspect(ctx context.Context) (ports.RepositoryStats, error) {
+	stats := ports.RepositoryStats{
+		Backend:    "redis",
+		TaskCounts: make(map[domain.TaskStatus]int),
+	}
+
+	ids, err := r.client.HKeys(ctx, r.indexKey()).Result()
+	if err != nil {
+		return ports.RepositoryStats{}, err
+	}
+
+	tasks, err := r.fetch(ctx, ids)
+	if err != nil {
+		return ports.RepositoryStats{}, err
+	}
+
+	for _, task := range tasks {
+		stats.TaskCounts[task.Status]++
+	}
+
+	pipe := r.client.Pipeline()
+	usages := make([]*goredis.IntCmd, len(tasks))
+	for i, task := range tasks {
+		usages[i] = pipe.MemoryUsage(ctx, r.taskKey(task.ID))
+	}
+
+	if _, err := pipe.Exec(ctx); err == nil {
+		for _, usage := range usages {
+			stats.SizeBytes += usage.Val()
+		}
+	}
+
+	return stats, nil
+}
+
+// Dump returns a page of stored tasks ordered by ID.
+func (r *TaskRepository) Dump(ctx context.Context, offset, limit int) ([]*domain.Task, error) {
+	ids, err := r.client.HKeys(ctx, r.indexKey()).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Sort(ids)
+	if offset >= len(ids) {
+		return []*domain.Task{}, nil
+	}
+
+	ids = ids[offset:min(offset+limit, len(ids))]
+	return r.fetch(ctx, ids)
+}
+
+// fetch loads the tasks with the given IDs in a single pipeline, in the order of ids.
+// Missing tasks are skipped, and the index entries of expired tasks are pruned.
+func (r *TaskRepository) fetch(ctx context.Context, ids []string) ([]*domain.Task, error) {
+	if len(ids) == 0 {
+		return []*domain.Task{}, nil
+	}
+
+	pipe := r.client.Pipeline()
+	cmds := make([]*goredis.MapStringStringCmd, len(ids))
+	for i, id := range ids {
+		cmds[i] = pipe.HGetAll(ctx, r.taskKey(id))
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	tasks := make([]*domain.Task, 0, len(ids))
+	var missing []any
+	for i, cmd := range cmds {
+		fields := cmd.Val()
+		if len(fields) == 0 {
+			missing = append(missing, ids[i])
+			continue
+		}
+
+		task, err := decodeTask(ids[i], fields)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	if len(missing) > 0 {
+		args := append([]any{r.prefix}, missing...)
+		if err := pruneScript.Run(ctx, r.client, []string{r.indexKey()}, args...).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return tasks, nil
+}
+
+// decodeTask builds a task from the fields of its hash.
+func decodeTask(id string, fields map[string]string) (*domain.Task, error) {
+	createdAt, err1 := strconv.ParseInt(fields["created_at"], 10, 64)
+	updatedAt, err2 := strconv.ParseInt(fields["updated_at"], 10, 64)
+	version, err3 := strconv.ParseInt(fields["version"], 10, 64)
+	if err := errors.Join(err1, err2, err3); err != nil {
+		return nil, fmt.Errorf("invalid stored task %s: %w", id, err)
+	}
+
+	task := &domain.Task{
+		ID:          id,
+		Title:       fields["title"],
+		Description: fields["description"],
+		Status:      domain.TaskStatus(fields["status"]),
+		CreatedAt:   time.Unix(0, createdAt),
+		UpdatedAt:   time.Unix(0, updatedAt),
+		Version:     version,
+		Priority:    domain.Priority(fields["priority"]),
+		Assignee:    fields["assignee"],
+		ProjectID:   fields["project_id"],
+		Rank:        fields["rank"],
+	}
+
+	// Tasks stored before priorities were introduced get the default priority.
+	if task.Priority == "" {
+		task.Priority = domain.PriorityMedium
+	}
+
+	// Tasks stored before manual ordering get the rank of their creation time.
+	if task.Rank == "" {
+		task.Rank = domain.InitialRank(task.CreatedAt)
+	}
+
+	// Tasks stored before estimates were introduced have neither an estimate nor progress.
+	for _, field := range []struct {
+		name   string
+		target *int
+	}{{"estimate", &task.Estimate}, {"progress", &task.Progress}} {
+		raw := fields[field.name]
+		if raw == "" {
+			continue
+		}
+
+		value, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stored task %s: %w", id, err)
+		}
+		*field.target = value
+	}
+
+	if raw := fields["due_date"]; raw != "" {
+		dueDate, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid stored task %s: %w", id, err)
+		}
+		due := time.Unix(0, dueDate)
+		task.DueDate = &due
+	}
+
+	if raw := fields["tags"]; raw != "" {
+		if err := json.Unmarshal([]byte(raw), &task.Tags); err != nil {
+			return nil, fmt.Errorf("invalid stored task %s: %w", id, err)
+		}
+	}
+
+	if raw := fields["subtasks"]; raw != "" {
+		if err := json.Unmarshal([]byte(raw), &task.Subtasks); err != nil {
+			return nil, fmt.Errorf("invalid stored task %s: %w", id, err)
+		}
+	}
+
+	if raw := fields["attachments"]; raw != "" {
+		if err := json.Unmarshal([]byte(raw), &task.Attachments); err != nil {
+			return nil, fmt.Errorf("invalid stored task %s: %w", id, err)
+		}
+	}
+
+	return task, nil
+}
+
+// encodeJSONArray returns the value of the named hash field holding items as a JSON array;
+// empty if there are none.
+func encodeJSONArray[T any](field string, items []T) (string, error) {
+	if len(items) == 0 {
+		return "", nil
+	}
+
+	encoded, err := json.Marshal(items)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode %s: %w", field, err)
+	}
+	return string(encoded), nil
+}
+
+// encodeDueDate returns the hash field value of a due date; empty if the task has none.
+func encodeDueDate(dueDate *time.Time) string {
+	if dueDate == nil {
+		return ""
+	}
+	return strconv.FormatInt(dueDate.UnixNano(), 10)
+}
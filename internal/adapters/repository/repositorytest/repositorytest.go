@@ -0,0 +1,595 @@
+// Package repositorytest provides a conformance suite for ports.TaskRepository
+// implementations, so every adapter is verified against the same semantics:
+// version checks, error mapping, filtering, ordering, paging, iteration and
+// behavior under concurrent use.
+//
+// An adapter test runs the suite with a factory returning an empty repository:
+//
+//	func TestRepository(t *testing.T) {
+//		repositorytest.RunRepositoryTests(t, func(t *testing.T) ports.TaskRepository {
+//			return repository.NewMemoryTaskRepository()
+//		})
+//	}
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// Factory returns an empty repository for a single test.
+// Resources of the repository should be released with t.Cleanup.
+type Factory func(t *testing.T) ports.TaskRepository
+
+// concurrency is the number of goroutines of the concurrency tests.
+const concurrency = 16
+
+// baseTime is the creation time of the tasks created by the suite. Timestamps are
+// kept at millisecond precision, which every supported store preserves.
+var baseTime = time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+// RunRepositoryTests runs the conformance suite against the repositories returned by factory.
+// Every subtest gets a repository of its own.
+func RunRepositoryTests(t *testing.T, factory Factory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		test func(t *testing.T, repo ports.TaskRepository)
+	}{
+		{"Ping", testPing},
+		{"CreateAndGet", testCreateAndGet},
+		{"CreateDuplicate", testCreateDuplicate},
+		{"GetMissing", testGetMissing},
+		{"GetByIDs", testGetByIDs},
+		{"GetAllFilter", testGetAllFilter},
+		{"GetAllSortAndPage", testGetAllSortAndPage},
+		{"GetAllInvalidCursor", testGetAllInvalidCursor},
+		{"Iterate", testIterate},
+		{"Update", testUpdate},
+		{"UpdateConflict", testUpdateConflict},
+		{"UpdateMissing", testUpdateMissing},
+		{"Delete", testDelete},
+		{"ConcurrentCreate", testConcurrentCreate},
+		{"ConcurrentUpdate", testConcurrentUpdate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, factory(t))
+		})
+	}
+}
+
+// newTask returns a pending task created n minutes after baseTime.
+func newTask(id, title string, n int) *domain.Task {
+	created := baseTime.Add(time.Duration(n) * time.Minute)
+	return &domain.Task{
+		ID:          id,
+		Title:       title,
+		Description: "description of " + title,
+		Status:      domain.StatusPending,
+		CreatedAt:   created,
+		UpdatedAt:   created,
+		Version:     1,
+		Priority:    domain.PriorityMedium,
+		Rank:        domain.InitialRank(created),
+	}
+}
+
+// withPriority sets the priority of task and returns it.
+func withPriority(task *domain.Task, priority domain.Priority) *domain.Task {
+	task.Priority = priority
+	return task
+}
+
+// withRank sets the rank of task and returns it.
+func withRank(task *domain.Task, rank string) *domain.Task {
+	task.Rank = rank
+	return task
+}
+
+// create stores the tasks, failing the test on error.
+func create(t *testing.T, repo ports.TaskRepository, tasks ...*domain.Task) {
+	t.Helper()
+
+	for _, task := range tasks {
+		if err := repo.Create(context.Background(), task); err != nil {
+			t.Fatalf("Create(%s) failed: %v", task.ID, err)
+		}
+	}
+}
+
+// ids returns the IDs of tasks in order.
+func ids(tasks []*domain.Task) []string {
+	result := make([]string, len(tasks))
+	for i, task := range tasks {
+		result[i] = task.ID
+	}
+
+	return result
+}
+
+// assertTask fails the test if got differs from want.
+func assertTask(t *testing.T, got, want *domain.Task) {
+	t.Helper()
+
+	if got.ID != want.ID || got.Title != want.Title || got.Description != want.Description ||
+		got.Status != want.Status || got.Version != want.Version || got.Priority != want.Priority ||
+		got.Assignee != want.Assignee || got.ProjectID != want.ProjectID || got.Rank != want.Rank ||
+		got.Estimate != want.Estimate || got.Progress != want.Progress || !slices.Equal(got.Tags, want.Tags) ||
+		!slices.Equal(got.Subtasks, want.Subtasks) ||
+		!slices.EqualFunc(got.Attachments, want.Attachments, equalAttachments) ||
+		!got.CreatedAt.Equal(want.CreatedAt) || !got.UpdatedAt.Equal(want.UpdatedAt) ||
+		!equalTimes(got.DueDate, want.DueDate) {
+		t.Fatalf("got task %+v, want %+v", got, want)
+	}
+}
+
+// equalAttachments reports whether two attachments have the same fields and creation instant.
+func equalAttachments(a, b domain.Attachment) bool {
+	createdAt := a.CreatedAt.Equal(b.CreatedAt)
+	a.CreatedAt, b.CreatedAt = time.Time{}, time.Time{}
+	return createdAt && a == b
+}
+
+// equalTimes reports whether two optional timestamps are both nil or the same instant.
+func equalTimes(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func testPing(t *testing.T, repo ports.TaskRepository) {
+	if err := repo.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+}
+
+func testCreateAndGet(t *testing.T, repo ports.TaskRepository) {
+	ctx := context.Background()
+	task := newTask("a", "Write report", 0)
+	create(t, repo, task)
+
+	got, err := repo.GetByID(ctx, "a")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	assertTask(t, got, newTask("a", "Write report", 0))
+
+	// The returned task is a copy: modifying it must not change the stored task.
+	got.Title = "changed"
+	again, err := repo.GetByID(ctx, "a")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if again.Title != "Write report" {
+		t.Fatalf("stored task was modified through a returned copy: %q", again.Title)
+	}
+}
+
+func testCreateDuplicate(t *testing.T, repo ports.TaskRepository) {
+	create(t, repo, newTask("a", "first", 0))
+
+	err := repo.Create(context.Background(), newTask("a", "second", 1))
+	if !errors.Is(err, domain.ErrTaskExists) {
+		t.Fatalf("Create of an existing ID returned %v, want %v", err, domain.ErrTaskExists)
+	}
+}
+
+func testGetMissing(t *testing.T, repo ports.TaskRepository) {
+	_, err := repo.GetByID(context.Background(), "missing")
+	if !errors.Is(err, domain.ErrTaskNotFound) {
+		t.Fatalf("GetByID of a missing ID returned %v, want %v", err, domain.ErrTaskNotFound)
+	}
+}
+
+func testGetByIDs(t *testing.T, repo ports.TaskRepository) {
+	create(t, repo, newTask("a", "a", 0), newTask("b", "b", 1), newTask("c", "c", 2))
+
+	tasks, err := repo.GetByIDs(context.Background(), []string{"c", "missing", "a"})
+	if err != nil {
+		t.Fatalf("GetByIDs failed: %v", err)
+	}
+
+	if got, want := ids(tasks), []string{"c", "a"}; !slices.Equal(got, want) {
+		t.Fatalf("GetByIDs returned %v, want %v", got, want)
+	}
+}
+
+func testGetAllFilter(t *testing.T, repo ports.TaskRepository) {
+	ctx := context.Background()
+	due, overdue := baseTime.Add(time.Hour), baseTime
+	report := newTask("a", "Write report", 0)
+	report.DueDate = &due
+	report.Assignee = "alice"
+	report.ProjectID = "p1"
+	report.Estimate = 3
+	report.Progress = 40
+	done := newTask("b", "Deploy service", 1)
+	done.Status = domain.StatusCompleted
+	done.Progress = domain.MaxProgress
+	done.DueDate = &overdue
+	done.Assignee = "bob"
+	create(t, repo, report, done, withPriority(newTask("c", "Review REPORT", 2), domain.PriorityUrgent))
+
+	tests := []struct {
+		name   string
+		filter ports.ListFilter
+		want   []string
+	}{
+		{"zero", ports.ListFilter{}, []string{"a", "b", "c"}},
+		{"status", ports.ListFilter{Statuses: []domain.TaskStatus{domain.StatusCompleted}}, []string{"b"}},
+		{"statuses", ports.ListFilter{
+			Statuses: []domain.TaskStatus{domain.StatusPending, domain.StatusCompleted},
+		}, []string{"a", "b", "c"}},
+		{"priority", ports.ListFilter{Priorities: []domain.Priority{domain.PriorityUrgent}}, []string{"c"}},
+		{"priorities", ports.ListFilter{
+			Priorities: []domain.Priority{domain.PriorityLow, domain.PriorityMedium},
+		}, []string{"a", "b"}},
+		{"assignee", ports.ListFilter{Assignee: "alice"}, []string{"a"}},
+		{"unassigned", ports.ListFilter{Unassigned: true}, []string{"c"}},
+		{"project", ports.ListFilter{ProjectID: "p1"}, []string{"a"}},
+		{"query", ports.ListFilter{Query: "report"}, []string{"a", "c"}},
+		{"query description", ports.ListFilter{Query: "OF DEPLOY"}, []string{"b"}},
+		{"title", ports.ListFilter{TitleContains: "review"}, []string{"c"}},
+		{"min progress", ports.ListFilter{MinProgress: 40}, []string{"a", "b"}},
+		{"min progress above", ports.ListFilter{MinProgress: 41}, []string{"b"}},
+		{"created after", ports.ListFilter{CreatedAfter: baseTime}, []string{"b", "c"}},
+		{"created before", ports.ListFilter{CreatedBefore: baseTime.Add(2 * time.Minute)}, []string{"a", "b"}},
+		{"due after", ports.ListFilter{DueAfter: baseTime}, []string{"a"}},
+		{"due before", ports.ListFilter{DueBefore: baseTime.Add(2 * time.Hour)}, []string{"a", "b"}},
+		// Completed tasks are never overdue.
+		{"overdue", ports.ListFilter{OverdueAt: baseTime.Add(2 * time.Hour)}, []string{"a"}},
+		{"combined", ports.ListFilter{
+			Statuses: []domain.TaskStatus{domain.StatusPending}, Query: "report", CreatedAfter: baseTime,
+		}, []string{"c"}},
+	}
+
+	for _, tt := range tests {
+		page, err := repo.GetAll(ctx, ports.ListQuery{Filter: tt.filter})
+		if err != nil {
+			t.Fatalf("%s: GetAll failed: %v", tt.name, err)
+		}
+
+		if got := ids(page.Tasks); !slices.Equal(got, tt.want) {
+			t.Fatalf("%s: GetAll returned %v, want %v", tt.name, got, tt.want)
+		}
+
+		if page.NextCursor != "" {
+			t.Fatalf("%s: GetAll without a limit returned a next cursor", tt.name)
+		}
+	}
+}
+
+func testGetAllSortAndPage(t *testing.T, repo ports.TaskRepository) {
+	// Tasks b and d share their creation time, and c and d their priority, so ties are broken by ID.
+	create(
+		t, repo,
+		withPriority(newTask("d", "banana", 1), domain.PriorityUrgent),
+		withPriority(newTask("a", "cherry", 0), domain.PriorityLow),
+		withRank(withPriority(newTask("e", "Apple", 3), domain.PriorityHigh), "0i"),
+		newTask("b", "apple", 1),
+		withPriority(newTask("c", "date", 2), domain.PriorityUrgent),
+	)
+
+	tests := []struct {
+		sort ports.Sort
+		want []string
+	}{
+		{ports.Sort{}, []string{"a", "b", "d", "c", "e"}},
+		{ports.Sort{Field: ports.SortByCreatedAt, Order: ports.SortDesc}, []string{"e", "c", "d", "b", "a"}},
+		// Titles are compared bytewise, so upper case sorts first.
+		{ports.Sort{Field: ports.SortByTitle}, []string{"e", "b", "d", "a", "c"}},
+		{ports.Sort{Field: ports.SortByTitle, Order: ports.SortDesc}, []string{"c", "a", "d", "b", "e"}},
+		// Priorities are ordered by urgency, not alphabetically.
+		{ports.Sort{Field: ports.SortByPriority}, []string{"a", "b", "e", "c", "d"}},
+		{ports.Sort{Field: ports.SortByPriority, Order: ports.SortDesc}, []string{"d", "c", "e", "b", "a"}},
+		// Ranks follow the creation times, with e moved to the start.
+		{ports.Sort{Field: ports.SortByRank}, []string{"e", "a", "b", "d", "c"}},
+		{ports.Sort{Field: ports.SortByRank, Order: ports.SortDesc}, []string{"c", "d", "b", "a", "e"}},
+	}
+
+	for _, tt := range tests {
+		for _, limit := range []int{0, 1, 2, 5, 10} {
+			var got []string
+			query := ports.ListQuery{Sort: tt.sort, Page: ports.PageRequest{Limit: limit}}
+			for pages := 0; ; pages++ {
+				if pages > len(tt.want) {
+					t.Fatalf("sort %v, limit %d: paging does not terminate", tt.sort, limit)
+				}
+
+				page, err := repo.GetAll(context.Background(), query)
+				if err != nil {
+					t.Fatalf("sort %v, limit %d: GetAll failed: %v", tt.sort, limit, err)
+				}
+
+				if limit > 0 && len(page.Tasks) > limit {
+					t.Fatalf("sort %v, limit %d: page has %d tasks", tt.sort, limit, len(page.Tasks))
+				}
+
+				got = append(got, ids(page.Tasks)...)
+				if page.NextCursor == "" {
+					break
+				}
+				query.Page.Cursor = page.NextCursor
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("sort %v, limit %d: got %v, want %v", tt.sort, limit, got, tt.want)
+			}
+		}
+	}
+}
+
+func testGetAllInvalidCursor(t *testing.T, repo ports.TaskRepository) {
+	create(t, repo, newTask("a", "a", 0), newTask("b", "b", 1))
+
+	ctx := context.Background()
+	for _, cursor := range []string{"not a cursor", "e30"} {
+		_, err := repo.GetAll(ctx, ports.ListQuery{Page: ports.PageRequest{Cursor: cursor, Limit: 1}})
+		if !errors.Is(err, domain.ErrInvalidCursor) {
+			t.Fatalf("GetAll with cursor %q returned %v, want %v", cursor, err, domain.ErrInvalidCursor)
+		}
+	}
+
+	// A cursor is only valid for the sort it was produced for.
+	page, err := repo.GetAll(ctx, ports.ListQuery{Page: ports.PageRequest{Limit: 1}})
+	if err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+
+	_, err = repo.GetAll(ctx, ports.ListQuery{
+		Sort: ports.Sort{Field: ports.SortByTitle},
+		Page: ports.PageRequest{Cursor: page.NextCursor, Limit: 1},
+	})
+	if !errors.Is(err, domain.ErrInvalidCursor) {
+		t.Fatalf("GetAll with a cursor of another sort returned %v, want %v", err, domain.ErrInvalidCursor)
+	}
+}
+
+func testIterate(t *testing.T, repo ports.TaskRepository) {
+	ctx := context.Background()
+	for i := range 50 {
+		task := newTask(fmt.Sprintf("task-%02d", i), fmt.Sprintf("title %d", i), i)
+		if i%2 == 1 {
+			task.Status = domain.StatusCompleted
+		}
+		create(t, repo, task)
+	}
+
+	var visited []string
+	err := repo.Iterate(ctx, ports.ListFilter{Statuses: []domain.TaskStatus{domain.StatusCompleted}},
+		func(task *domain.Task) error {
+			visited = append(visited, task.ID)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("Iterate failed: %v", err)
+	}
+
+	slices.Sort(visited)
+	var want []string
+	for i := 1; i < 50; i += 2 {
+		want = append(want, fmt.Sprintf("task-%02d", i))
+	}
+	if !slices.Equal(visited, want) {
+		t.Fatalf("Iterate visited %v, want %v", visited, want)
+	}
+
+	// An error returned by fn stops the iteration and is returned as is.
+	stop := errors.New("stop")
+	calls := 0
+	err = repo.Iterate(ctx, ports.ListFilter{}, func(*domain.Task) error {
+		calls++
+		return stop
+	})
+	if !errors.Is(err, stop) || calls != 1 {
+		t.Fatalf("Iterate returned %v after %d calls, want %v after 1 call", err, calls, stop)
+	}
+}
+
+func testUpdate(t *testing.T, repo ports.TaskRepository) {
+	ctx := context.Background()
+	create(t, repo, newTask("a", "Write report", 0))
+
+	task, err := repo.GetByID(ctx, "a")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+
+	due := baseTime.Add(24 * time.Hour)
+	task.Title = "Write final report"
+	task.Status = domain.StatusInProgress
+	task.UpdatedAt = baseTime.Add(time.Hour)
+	task.DueDate = &due
+	task.Assignee = "alice"
+	task.ProjectID = "p1"
+	task.Rank = "0i"
+	task.Estimate = 5
+	task.Progress = 60
+	task.Tags = []string{"backend", "q4"}
+	task.Subtasks = []domain.Subtask{{ID: "s1", Title: "Collect data", Done: true}, {ID: "s2", Title: "Draft"}}
+	task.Attachments = []domain.Attachment{{
+		ID: "f1", Filename: "data.csv", ContentType: "text/csv", Size: 42,
+		Checksum: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", CreatedAt: baseTime.Add(time.Hour),
+	}}
+	if err := repo.Update(ctx, task); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	if task.Version != 2 {
+		t.Fatalf("Update set version %d, want 2", task.Version)
+	}
+
+	got, err := repo.GetByID(ctx, "a")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	assertTask(t, got, task)
+
+	page, err := repo.GetAll(ctx, ports.ListQuery{
+		Filter: ports.ListFilter{Statuses: []domain.TaskStatus{domain.StatusPending}},
+	})
+	if err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+	if len(page.Tasks) != 0 {
+		t.Fatalf("task is still listed with its old status: %v", ids(page.Tasks))
+	}
+}
+
+func testUpdateConflict(t *testing.T, repo ports.TaskRepository) {
+	ctx := context.Background()
+	create(t, repo, newTask("a", "Write report", 0))
+
+	first, _ := repo.GetByID(ctx, "a")
+	second, _ := repo.GetByID(ctx, "a")
+
+	first.Title = "first"
+	if err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	second.Title = "second"
+	if err := repo.Update(ctx, second); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("Update of a stale version returned %v, want %v", err, domain.ErrVersionConflict)
+	}
+
+	got, err := repo.GetByID(ctx, "a")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if got.Title != "first" || got.Version != 2 {
+		t.Fatalf("stale update was applied: %+v", got)
+	}
+}
+
+func testUpdateMissing(t *testing.T, repo ports.TaskRepository) {
+	err := repo.Update(context.Background(), newTask("missing", "missing", 0))
+	if !errors.Is(err, domain.ErrTaskNotFound) {
+		t.Fatalf("Update of a missing task returned %v, want %v", err, domain.ErrTaskNotFound)
+	}
+}
+
+func testDelete(t *testing.T, repo ports.TaskRepository) {
+	ctx := context.Background()
+	create(t, repo, newTask("a", "a", 0), newTask("b", "b", 1))
+
+	if err := repo.Delete(ctx, "a"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	if _, err := repo.GetByID(ctx, "a"); !errors.Is(err, domain.ErrTaskNotFound) {
+		t.Fatalf("GetByID of a deleted task returned %v, want %v", err, domain.ErrTaskNotFound)
+	}
+
+	if err := repo.Delete(ctx, "a"); !errors.Is(err, domain.ErrTaskNotFound) {
+		t.Fatalf("Delete of a deleted task returned %v, want %v", err, domain.ErrTaskNotFound)
+	}
+
+	page, err := repo.GetAll(ctx, ports.ListQuery{})
+	if err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+	if got, want := ids(page.Tasks), []string{"b"}; !slices.Equal(got, want) {
+		t.Fatalf("GetAll after Delete returned %v, want %v", got, want)
+	}
+}
+
+func testConcurrentCreate(t *testing.T, repo ports.TaskRepository) {
+	ctx := context.Background()
+
+	// Every goroutine creates its own tasks and races the others for a shared ID.
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		winners int
+	)
+	for g := range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for i := range 10 {
+				task := newTask(fmt.Sprintf("g%02d-%d", g, i), "task", i)
+				if err := repo.Create(ctx, task); err != nil {
+					t.Errorf("Create(%s) failed: %v", task.ID, err)
+				}
+			}
+
+			err := repo.Create(ctx, newTask("shared", "shared", 0))
+			switch {
+			case err == nil:
+				mu.Lock()
+				winners++
+				mu.Unlock()
+			case !errors.Is(err, domain.ErrTaskExists):
+				t.Errorf("Create(shared) returned %v, want nil or %v", err, domain.ErrTaskExists)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if winners != 1 {
+		t.Fatalf("%d concurrent creates of the same ID succeeded, want 1", winners)
+	}
+
+	page, err := repo.GetAll(ctx, ports.ListQuery{})
+	if err != nil {
+		t.Fatalf("GetAll failed: %v", err)
+	}
+	if got, want := len(page.Tasks), concurrency*10+1; got != want {
+		t.Fatalf("GetAll returned %d tasks, want %d", got, want)
+	}
+}
+
+func testConcurrentUpdate(t *testing.T, repo ports.TaskRepository) {
+	ctx := context.Background()
+	create(t, repo, newTask("a", "counter", 0))
+
+	// Every goroutine retries its update on conflicts until it succeeds once,
+	// so the final version counts the successful updates.
+	var wg sync.WaitGroup
+	for range concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				task, err := repo.GetByID(ctx, "a")
+				if err != nil {
+					t.Errorf("GetByID failed: %v", err)
+					return
+				}
+
+				err = repo.Update(ctx, task)
+				if err == nil {
+					return
+				}
+				if !errors.Is(err, domain.ErrVersionConflict) {
+					t.Errorf("Update returned %v, want nil or %v", err, domain.ErrVersionConflict)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	task, err := repo.GetByID(ctx, "a")
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if want := int64(1 + concurrency); task.Version != want {
+		t.Fatalf("version after %d updates is %d, want %d", concurrency, task.Version, want)
+	}
+}
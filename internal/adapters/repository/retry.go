@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.TaskRepository      = (*RetryingTaskRepository)(nil)
+	_ ports.RepositoryInspector = (*RetryingTaskRepository)(nil)
+	_ ports.RepositoryDumper    = (*RetryingTaskRepository)(nil)
+)
+
+// Defaults of RetryingTaskRepository.
+const (
+	defaultRetryAttempts = 3
+	defaultRetryBase     = 50 * time.Millisecond
+	defaultRetryMax      = 2 * time.Second
+)
+
+// RetryingTaskRepository decorates a TaskRepository with retries of transient failures,
+// such as connection resets and deadlocks, with exponential backoff and full jitter.
+// Domain errors and other permanent failures are returned immediately.
+//
+// A write whose outcome is unknown, e.g. because the connection was reset after the
+// store committed it, is retried as well: a retried Create then reports
+// domain.ErrTaskExists and a retried Update domain.ErrVersionConflict.
+type RetryingTaskRepository struct {
+	next      ports.TaskRepository
+	attempts  int
+	base      time.Duration
+	max       time.Duration
+	retryable func(error) bool
+}
+
+// RetryOption configures optional RetryingTaskRepository behavior.
+type RetryOption func(*RetryingTaskRepository)
+
+// WithRetryAttempts sets the maximum number of attempts per operation, including the first one.
+// The default is 3.
+func WithRetryAttempts(attempts int) RetryOption {
+	return func(r *RetryingTaskRepository) {
+		r.attempts = attempts
+	}
+}
+
+// WithRetryBackoff sets the delay before the first retry, which doubles with every
+// further retry up to max. The defaults are 50ms and 2s.
+func WithRetryBackoff(base, max time.Duration) RetryOption {
+	return func(r *RetryingTaskRepository) {
+		r.base = base
+		r.max = max
+	}
+}
+
+// WithRetryClassifier replaces IsTransient as the test of whether a failure is retried.
+func WithRetryClassifier(retryable func(error) bool) RetryOption {
+	return func(r *RetryingTaskRepository) {
+		r.retryable = retryable
+	}
+}
+
+// NewRetryingTaskRepository wraps next with retries of transient failures.
+func NewRetryingTaskRepository(next ports.TaskRepository, opts ...RetryOption) *RetryingTaskRepository {
+	r := &RetryingTaskRepository{
+		next:      next,
+		attempts:  defaultRetryAttempts,
+		base:      defaultRetryBase,
+		max:       defaultRetryMax,
+		retryable: IsTransient,
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// RetryOptionsFromEnv returns the retry options from the REPOSITORY_RETRY_ATTEMPTS,
+// REPOSITORY_RETRY_BASE and REPOSITORY_RETRY_MAX environment variables.
+func RetryOptionsFromEnv() ([]RetryOption, error) {
+	var opts []RetryOption
+	if raw := os.Getenv("REPOSITORY_RETRY_ATTEMPTS"); raw != "" {
+		attempts, err := strconv.Atoi(raw)
+		if err != nil || attempts < 1 {
+			return nil, fmt.Errorf("REPOSITORY_RETRY_ATTEMPTS must be a positive integer, got: %s", raw)
+		}
+		opts = append(opts, WithRetryAttempts(attempts))
+	}
+
+	base, err := retryDurationFromEnv("REPOSITORY_RETRY_BASE", defaultRetryBase)
+	if err != nil {
+		return nil, err
+	}
+
+	max, err := retryDurationFromEnv("REPOSITORY_RETRY_MAX", defaultRetryMax)
+	if err != nil {
+		return nil, err
+	}
+
+	if base > max {
+		return nil, fmt.Errorf("REPOSITORY_RETRY_BASE must not exceed REPOSITORY_RETRY_MAX, got: %s > %s", base, max)
+	}
+
+	return append(opts, WithRetryBackoff(base, max)), nil
+}
+
+// retryDurationFromEnv parses the positive duration in the environment variable name,
+// or returns def if it is not set.
+func retryDurationFromEnv(name string, def time.Duration) (time.Duration, error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return def, nil
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%s must be a positive duration, got: %s", name, raw)
+	}
+
+	return d, nil
+}
+
+// IsTransient reports whether err is a failure that may succeed when retried:
+// a network timeout, a reset, refused or broken connection, an SQL error of class 08
+// (connection exception) or 40 (transaction rollback, e.g. a deadlock or serialization
+// failure), or an error labeled as retryable by the MongoDB driver.
+// Domain errors and context cancellation are never transient.
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if isDomainError(err) {
+		return false
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	var sqlErr interface{ SQLState() string }
+	if errors.As(err, &sqlErr) {
+		state := sqlErr.SQLState()
+		return strings.HasPrefix(state, "08") || strings.HasPrefix(state, "40")
+	}
+
+	var labeled interface{ HasErrorLabel(string) bool }
+	if errors.As(err, &labeled) {
+		return labeled.HasErrorLabel("RetryableWriteError") || labeled.HasErrorLabel("TransientTransactionError")
+	}
+
+	return false
+}
+
+// isDomainError reports whether err is an expected outcome of a repository operation
+// rather than a failure of the store.
+func isDomainError(err error) bool {
+	return errors.Is(err, domain.ErrTaskNotFound) || errors.Is(err, domain.ErrTaskExists) ||
+		errors.Is(err, domain.ErrVersionConflict) || errors.Is(err, domain.ErrInvalidCursor)
+}
+
+// Create stores a new task, retrying transient failures.
+func (r *RetryingTaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	return r.do(ctx, func() error {
+		return r.next.Create(ctx, task)
+	})
+}
+
+// GetByID retrieves a task by its ID, retrying transient failures.
+func (r *RetryingTaskRepository) GetByID(ctx context.Context, id string) (*domain.Task, error) {
+	var task *domain.Task
+	err := r.do(ctx, func() (err error) {
+		task, err = r.next.GetByID(ctx, id)
+		return err
+	})
+
+	return task, err
+}
+
+// GetByIDs retrieves tasks by their IDs, retrying transient failures.
+func (r *RetryingTaskRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.Task, error) {
+	var tasks []*domain.Task
+	err := r.do(ctx, func() (err error) {
+		tasks, err = r.next.GetByIDs(ctx, ids)
+		return err
+	})
+
+	return tasks, err
+}
+
+// GetAll retrieves a page of tasks matching the query, retrying transient failures.
+func (r *RetryingTaskRepository) GetAll(ctx context.Context, query ports.ListQuery) (ports.TaskPage, error) {
+	var page ports.TaskPage
+	err := r.do(ctx, func() (err error) {
+		page, err = r.next.GetAll(ctx, query)
+		return err
+	})
+
+	return page, err
+}
+
+// Iterate streams the tasks matching the filter from the underlying repository.
+// It is not retried, since fn may already have been called for some tasks.
+func (r *RetryingTaskRepository) Iterate(
+	ctx context.Context, filter ports.ListFilter, fn func(*domain.Task) error,
+) error {
+	return r.next.Iterate(ctx, filter, fn)
+}
+
+// Update persists a task, retrying transient failures.
+func (r *RetryingTaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	return r.do(ctx, func() error {
+		return r.next.Update(ctx, task)
+	})
+}
+
+// Delete removes a task, retrying transient failures.
+func (r *RetryingTaskRepository) Delete(ctx context.Context, id string) error {
+	return r.do(ctx, func() error {
+		return r.next.Delete(ctx, id)
+	})
+}
+
+// Ping checks the health of the underlying repository without retrying,
+// so health checks report failures as they happen.
+func (r *RetryingTaskRepository) Ping(ctx context.Context) error {
+	return r.next.Ping(ctx)
+}
+
+// Inspect reports the state of the underlying repository, retrying transient failures.
+func (r *RetryingTaskRepository) Inspect(ctx context.Context) (ports.RepositoryStats, error) {
+	inspector, ok := r.next.(ports.RepositoryInspector)
+	if !ok {
+		return ports.RepositoryStats{}, ErrNotSupported
+	}
+
+	var stats ports.RepositoryStats
+	err := r.do(ctx, func() (err error) {
+		stats, err = inspector.Inspect(ctx)
+		return err
+	})
+
+	return stats, err
+}
+
+// Dump returns raw tasks from the underlying repository, retrying transient failures.
+func (r *RetryingTaskRepository) Dump(ctx context.Context, offset, limit int) ([]*domain.Task, error) {
+	dumper, ok := r.next.(ports.RepositoryDumper)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+
+	var tasks []*domain.Task
+	err := r.do(ctx, func() (err error) {
+		tasks, err = dumper.Dump(ctx, offset, limit)
+		return err
+	})
+
+	return tasks, err
+}
+
+// do calls op until it succeeds, fails permanently, the attempts are exhausted
+// or ctx is done. The last error of op is returned.
+func (r *RetryingTaskRepository) do(ctx context.Context, op func() error) error {
+	delay := r.base
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if err == nil || attempt >= r.attempts || !r.retryable(err) {
+			return err
+		}
+
+		timer := time.NewTimer(rand.N(delay + 1))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		}
+
+		delay = min(delay*2, r.max)
+	}
+}
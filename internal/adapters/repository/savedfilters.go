@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var _ ports.SavedFilterRepository = (*MemorySavedFilterRepository)(nil)
+
+// MemorySavedFilterRepository provides an in-memory implementation of the SavedFilterRepository interface.
+// Data is lost when the application restarts since it's stored only in memory.
+type MemorySavedFilterRepository struct {
+	// filters stores all saved filters indexed by their name
+	filters map[string]*domain.SavedFilter
+	// mu provides thread-safe access to the filters map
+	mu sync.RWMutex
+}
+
+// NewMemorySavedFilterRepository creates a new instance of the in-memory saved filter repository.
+func NewMemorySavedFilterRepository() *MemorySavedFilterRepository {
+	return &MemorySavedFilterRepository{
+		filters: make(map[string]*domain.SavedFilter),
+	}
+}
+
+// Create stores a copy of a new saved filter.
+// Returns domain.ErrSavedFilterExists if a filter with the same name already exists.
+func (r *MemorySavedFilterRepository) Create(_ context.Context, filter *domain.SavedFilter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.filters[filter.Name]; exists {
+		return domain.ErrSavedFilterExists
+	}
+
+	filterCopy := *filter
+	r.filters[filter.Name] = &filterCopy
+	return nil
+}
+
+// GetByName returns a copy of the saved filter with the given name.
+// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+func (r *MemorySavedFilterRepository) GetByName(_ context.Context, name string) (*domain.SavedFilter, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	filter, exists := r.filters[name]
+	if !exists {
+		return nil, domain.ErrSavedFilterNotFound
+	}
+
+	filterCopy := *filter
+	return &filterCopy, nil
+}
+
+// GetAll returns copies of all saved filters ordered by name.
+func (r *MemorySavedFilterRepository) GetAll(_ context.Context) ([]*domain.SavedFilter, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	filters := make([]*domain.SavedFilter, 0, len(r.filters))
+	for _, filter := range r.filters {
+		filterCopy := *filter
+		filters = append(filters, &filterCopy)
+	}
+
+	slices.SortFunc(filters, func(a, b *domain.SavedFilter) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return filters, nil
+}
+
+// Update replaces the stored saved filter with a copy of filter.
+// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+func (r *MemorySavedFilterRepository) Update(_ context.Context, filter *domain.SavedFilter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.filters[filter.Name]; !exists {
+		return domain.ErrSavedFilterNotFound
+	}
+
+	filterCopy := *filter
+	r.filters[filter.Name] = &filterCopy
+	return nil
+}
+
+// Delete removes the saved filter with the given name.
+// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+func (r *MemorySavedFilterRepository) Delete(_ context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.filters[name]; !exists {
+		return domain.ErrSavedFilterNotFound
+	}
+
+	delete(r.filters, name)
+	return nil
+}
@@ -0,0 +1,135 @@
+//go:build sqlite
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sqlite "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.CommentRepository = (*CommentRepository)(nil)
+	_ ports.CommentStore      = (*TaskRepository)(nil)
+)
+
+// commentColumns lists the columns scanned by ListByTask, in order.
+const commentColumns = "id, task_id, author, body, created_at, updated_at"
+
+// CommentRepository implements ports.CommentRepository on the database of a TaskRepository.
+// Comments reference their task, so they are deleted together with it.
+type CommentRepository struct {
+	db *sql.DB
+}
+
+// Comments returns the repository of task comments stored in the same database.
+func (r *TaskRepository) Comments() ports.CommentRepository {
+	return &CommentRepository{db: r.db}
+}
+
+// Create inserts a new comment.
+// Returns domain.ErrTaskNotFound if the task of the comment does not exist.
+func (r *CommentRepository) Create(ctx context.Context, comment *domain.Comment) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO comments (`+commentColumns+`) VALUES (?, ?, ?, ?, ?, ?)`,
+		comment.ID, comment.TaskID, comment.Author, comment.Body,
+		comment.CreatedAt.UnixNano(), comment.UpdatedAt.UnixNano(),
+	)
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY {
+		return domain.ErrTaskNotFound
+	}
+
+	return err
+}
+
+// ListByTask returns the comments of a task, oldest first.
+func (r *CommentRepository) ListByTask(ctx context.Context, taskID string) ([]*domain.Comment, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT `+commentColumns+` FROM comments WHERE task_id = ? ORDER BY created_at, id`,
+		taskID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := make([]*domain.Comment, 0)
+	for rows.Next() {
+		var (
+			comment              domain.Comment
+			createdAt, updatedAt int64
+		)
+		if err := rows.Scan(
+			&comment.ID, &comment.TaskID, &comment.Author, &comment.Body, &createdAt, &updatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		comment.CreatedAt = time.Unix(0, createdAt)
+		comment.UpdatedAt = time.Unix(0, updatedAt)
+		comments = append(comments, &comment)
+	}
+
+	return comments, rows.Err()
+}
+
+// Update replaces the author, body and update time of an existing comment.
+// Returns domain.ErrCommentNotFound if the task has no comment with the ID of comment.
+func (r *CommentRepository) Update(ctx context.Context, comment *domain.Comment) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		`UPDATE comments SET author = ?, body = ?, updated_at = ? WHERE id = ? AND task_id = ?`,
+		comment.Author, comment.Body, comment.UpdatedAt.UnixNano(), comment.ID, comment.TaskID,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a comment from a task.
+// Returns domain.ErrCommentNotFound if the task has no comment with the given ID.
+func (r *CommentRepository) Delete(ctx context.Context, taskID, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM comments WHERE id = ? AND task_id = ?`, id, taskID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// DeleteByTask removes all comments of a task.
+func (r *CommentRepository) DeleteByTask(ctx context.Context, taskID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM comments WHERE task_id = ?`, taskID)
+	return err
+}
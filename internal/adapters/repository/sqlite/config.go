@@ -0,0 +1,63 @@
+// Package sqlite provides a TaskRepository and a CommentRepository stored in an embedded SQLite database,
+// giving persistence to single-binary deployments without a database server.
+// The driver is only compiled in with the sqlite build tag:
+//
+//	go build -tags sqlite -o task-manager ./cmd
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/asp3cto/task-manager/internal/adapters/repository/querylog"
+	"github.com/asp3cto/task-manager/internal/adapters/repository/sqlpool"
+	"github.com/asp3cto/task-manager/internal/migrations"
+)
+
+// ErrNotCompiled is returned when SQLITE_PATH is set in a binary built without the sqlite tag.
+var ErrNotCompiled = errors.New("SQLite support is not compiled in, rebuild with -tags sqlite")
+
+// Option configures optional TaskRepository behavior.
+type Option func(*TaskRepository)
+
+// WithAutoMigrate sets whether Open applies pending migrations. Enabled by default;
+// when disabled, Open fails if the schema is out of date.
+func WithAutoMigrate(enabled bool) Option {
+	return func(r *TaskRepository) {
+		r.autoMigrate = enabled
+	}
+}
+
+// WithQueryLog records every executed query with recorder.
+func WithQueryLog(recorder *querylog.Recorder) Option {
+	return func(r *TaskRepository) {
+		r.queries = recorder
+	}
+}
+
+// WithPoolConfig limits the connections of the database handle and the number of
+// prepared statements cached for the task queries.
+func WithPoolConfig(config sqlpool.Config) Option {
+	return func(r *TaskRepository) {
+		r.poolConfig = config
+	}
+}
+
+// NewFromEnv opens the database file in the SQLITE_PATH environment variable,
+// e.g. /var/lib/task-manager/tasks.db.
+// DB_AUTO_MIGRATE disables the migration on startup. opts are applied after it.
+// Returns nil if the variable is not set.
+func NewFromEnv(ctx context.Context, opts ...Option) (*TaskRepository, error) {
+	path := os.Getenv("SQLITE_PATH")
+	if path == "" {
+		return nil, nil
+	}
+
+	autoMigrate, err := migrations.AutoMigrateFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	return Open(ctx, path, append([]Option{WithAutoMigrate(autoMigrate)}, opts...)...)
+}
@@ -0,0 +1,66 @@
+//go:build !sqlite
+
+package sqlite
+
+import (
+	"context"
+
+	"github.com/asp3cto/task-manager/internal/adapters/repository/querylog"
+	"github.com/asp3cto/task-manager/internal/adapters/repository/sqlpool"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// TaskRepository is a placeholder in binaries built without the sqlite tag.
+// Open always fails, so no instance is ever used.
+type TaskRepository struct {
+	ports.TaskRepository
+
+	autoMigrate bool
+	queries     *querylog.Recorder
+	poolConfig  sqlpool.Config
+}
+
+// Open returns ErrNotCompiled.
+func Open(_ context.Context, _ string, _ ...Option) (*TaskRepository, error) {
+	return nil, ErrNotCompiled
+}
+
+// Close does nothing.
+func (r *TaskRepository) Close() error {
+	return nil
+}
+
+// PoolStats returns empty statistics.
+func (r *TaskRepository) PoolStats() sqlpool.Stats {
+	return sqlpool.Stats{}
+}
+
+// Comments returns nil.
+func (r *TaskRepository) Comments() ports.CommentRepository {
+	return nil
+}
+
+// Projects returns nil.
+func (r *TaskRepository) Projects() ports.ProjectRepository {
+	return nil
+}
+
+// History returns nil.
+func (r *TaskRepository) History() ports.TaskHistoryRepository {
+	return nil
+}
+
+// SavedFilters returns nil.
+func (r *TaskRepository) SavedFilters() ports.SavedFilterRepository {
+	return nil
+}
+
+// Webhooks returns nil.
+func (r *TaskRepository) Webhooks() ports.WebhookRepository {
+	return nil
+}
+
+// WebhookDeadLetters returns nil.
+func (r *TaskRepository) WebhookDeadLetters() ports.WebhookDeadLetterRepository {
+	return nil
+}
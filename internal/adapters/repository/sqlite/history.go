@@ -0,0 +1,187 @@
+//go:build sqlite
+
+package sqlite
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	sqlite "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.TaskHistoryRepository = (*HistoryRepository)(nil)
+	_ ports.HistoryStore          = (*TaskRepository)(nil)
+)
+
+// historyColumns lists the columns written by Append and scanned by ListByTask, in order.
+const historyColumns = "task_id, version, field, old_value, new_value, actor, changed_at"
+
+// HistoryRepository implements ports.TaskHistoryRepository on the database of a TaskRepository.
+// Entries reference their task, so they are deleted together with it; the order of
+// the entries is kept by a sequence column.
+type HistoryRepository struct {
+	db *sql.DB
+}
+
+// History returns the repository of the task history stored in the same database.
+func (r *TaskRepository) History() ports.TaskHistoryRepository {
+	return &HistoryRepository{db: r.db}
+}
+
+// Append inserts the entries in a single transaction.
+// Returns domain.ErrTaskNotFound if the task of an entry does not exist.
+func (r *HistoryRepository) Append(ctx context.Context, entries []domain.HistoryEntry) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	// Rolling back after a commit does nothing.
+	defer func() { _ = tx.Rollback() }()
+
+	if err := insertHistory(ctx, tx, entries); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// RewriteByTask replaces the history of a task by the entries returned by rewrite in a
+// single transaction.
+// Returns domain.ErrTaskNotFound if the task of an entry does not exist.
+func (r *HistoryRepository) RewriteByTask(
+	ctx context.Context, taskID string, rewrite func([]domain.HistoryEntry) ([]domain.HistoryEntry, error),
+) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	// Rolling back after a commit does nothing.
+	defer func() { _ = tx.Rollback() }()
+
+	// Deleting first takes the write lock of the database, so no entry can be appended
+	// between reading and replacing the history.
+	rows, err := tx.QueryContext(
+		ctx,
+		`DELETE FROM task_history WHERE task_id = ? RETURNING seq, `+historyColumns,
+		taskID,
+	)
+	if err != nil {
+		return err
+	}
+
+	type sequenced struct {
+		seq   int64
+		entry domain.HistoryEntry
+	}
+	var deleted []sequenced
+	for rows.Next() {
+		var (
+			row       sequenced
+			changedAt int64
+		)
+		if err := rows.Scan(
+			&row.seq, &row.entry.TaskID, &row.entry.Version, &row.entry.Field, &row.entry.OldValue,
+			&row.entry.NewValue, &row.entry.Actor, &changedAt,
+		); err != nil {
+			_ = rows.Close()
+			return err
+		}
+
+		row.entry.ChangedAt = time.Unix(0, changedAt)
+		deleted = append(deleted, row)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// RETURNING does not keep the order of the rows.
+	slices.SortFunc(deleted, func(a, b sequenced) int { return cmp.Compare(a.seq, b.seq) })
+	entries := make([]domain.HistoryEntry, len(deleted))
+	for i, row := range deleted {
+		entries[i] = row.entry
+	}
+
+	if entries, err = rewrite(entries); err != nil {
+		return err
+	}
+
+	if err := insertHistory(ctx, tx, entries); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// insertHistory inserts the entries in tx, in order.
+// Returns domain.ErrTaskNotFound if the task of an entry does not exist.
+func insertHistory(ctx context.Context, tx *sql.Tx, entries []domain.HistoryEntry) error {
+	for _, entry := range entries {
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO task_history (`+historyColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			entry.TaskID, entry.Version, entry.Field, entry.OldValue, entry.NewValue, entry.Actor,
+			entry.ChangedAt.UnixNano(),
+		)
+
+		var sqliteErr *sqlite.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY {
+			return domain.ErrTaskNotFound
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to insert history entry: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListByTask returns the history of a task, oldest first.
+func (r *HistoryRepository) ListByTask(ctx context.Context, taskID string) ([]domain.HistoryEntry, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT `+historyColumns+` FROM task_history WHERE task_id = ? ORDER BY seq`,
+		taskID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]domain.HistoryEntry, 0)
+	for rows.Next() {
+		var (
+			entry     domain.HistoryEntry
+			changedAt int64
+		)
+		if err := rows.Scan(
+			&entry.TaskID, &entry.Version, &entry.Field, &entry.OldValue, &entry.NewValue, &entry.Actor, &changedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		entry.ChangedAt = time.Unix(0, changedAt)
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// DeleteByTask removes the history of a task.
+func (r *HistoryRepository) DeleteByTask(ctx context.Context, taskID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM task_history WHERE task_id = ?`, taskID)
+	return err
+}
@@ -0,0 +1,141 @@
+//go:build sqlite
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sqlite "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.ProjectRepository = (*ProjectRepository)(nil)
+	_ ports.ProjectStore      = (*TaskRepository)(nil)
+)
+
+// projectColumns lists the columns scanned by scanProject, in order.
+const projectColumns = "id, name, description, created_at, updated_at"
+
+// ProjectRepository implements ports.ProjectRepository on the database of a TaskRepository.
+type ProjectRepository struct {
+	db *sql.DB
+}
+
+// Projects returns the repository of projects stored in the same database.
+func (r *TaskRepository) Projects() ports.ProjectRepository {
+	return &ProjectRepository{db: r.db}
+}
+
+// Create inserts a new project.
+// Returns domain.ErrProjectExists if a project with the same ID already exists.
+func (r *ProjectRepository) Create(ctx context.Context, project *domain.Project) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO projects (`+projectColumns+`) VALUES (?, ?, ?, ?, ?)`,
+		project.ID, project.Name, project.Description,
+		project.CreatedAt.UnixNano(), project.UpdatedAt.UnixNano(),
+	)
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY {
+		return domain.ErrProjectExists
+	}
+
+	return err
+}
+
+// GetByID returns the project with the given ID.
+// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+func (r *ProjectRepository) GetByID(ctx context.Context, id string) (*domain.Project, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+projectColumns+` FROM projects WHERE id = ?`, id)
+
+	project, err := scanProject(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrProjectNotFound
+	}
+
+	return project, err
+}
+
+// GetAll returns all projects, oldest first.
+func (r *ProjectRepository) GetAll(ctx context.Context) ([]*domain.Project, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+projectColumns+` FROM projects ORDER BY created_at, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	projects := make([]*domain.Project, 0)
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+
+	return projects, rows.Err()
+}
+
+// Update modifies an existing project.
+// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+func (r *ProjectRepository) Update(ctx context.Context, project *domain.Project) error {
+	result, err := r.db.ExecContext(
+		ctx,
+		`UPDATE projects SET name = ?, description = ?, updated_at = ? WHERE id = ?`,
+		project.Name, project.Description, project.UpdatedAt.UnixNano(), project.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkProjectAffected(result)
+}
+
+// Delete removes the project with the given ID.
+// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+func (r *ProjectRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM projects WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	return checkProjectAffected(result)
+}
+
+// checkProjectAffected returns domain.ErrProjectNotFound if the statement changed no row.
+func checkProjectAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrProjectNotFound
+	}
+
+	return nil
+}
+
+// scanProject reads a project from a row with the projectColumns.
+func scanProject(row scanner) (*domain.Project, error) {
+	var (
+		project              domain.Project
+		createdAt, updatedAt int64
+	)
+	err := row.Scan(&project.ID, &project.Name, &project.Description, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	project.CreatedAt = time.Unix(0, createdAt)
+	project.UpdatedAt = time.Unix(0, updatedAt)
+	return &project, nil
+}
@@ -0,0 +1,128 @@
+//go:build sqlite
+
+package sqlite
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/adapters/repository/querylog"
+)
+
+var _ driver.Connector = (*loggingConnector)(nil)
+
+// driverStmt lists the interfaces implemented by the statements of the SQLite driver.
+type driverStmt interface {
+	driver.Stmt
+	driver.StmtExecContext
+	driver.StmtQueryContext
+}
+
+// driverConn lists the interfaces implemented by the connections of the SQLite driver,
+// so a wrapped connection keeps them.
+type driverConn interface {
+	driver.Conn
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.ExecerContext
+	driver.QueryerContext
+	driver.Pinger
+	driver.SessionResetter
+	driver.Validator
+}
+
+// loggingConnector opens connections of the SQLite driver that record their queries.
+type loggingConnector struct {
+	driver   driver.Driver
+	dsn      string
+	recorder *querylog.Recorder
+}
+
+// Connect opens a connection to the database.
+func (c *loggingConnector) Connect(_ context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped, ok := conn.(driverConn)
+	if !ok {
+		_ = conn.Close()
+		return nil, fmt.Errorf("unsupported sqlite connection type %T", conn)
+	}
+
+	return &loggedConn{driverConn: wrapped, recorder: c.recorder}, nil
+}
+
+// Driver returns the SQLite driver.
+func (c *loggingConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// loggedConn records the statements executed on a connection. A query is timed until
+// its rows are returned, not until they are read.
+type loggedConn struct {
+	driverConn
+	recorder *querylog.Recorder
+}
+
+// ExecContext executes query and records it.
+func (c *loggedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	result, err := c.driverConn.ExecContext(ctx, query, args)
+	c.recorder.Record(ctx, query, time.Since(start), err)
+
+	return result, err
+}
+
+// QueryContext executes query and records it.
+func (c *loggedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	rows, err := c.driverConn.QueryContext(ctx, query, args)
+	c.recorder.Record(ctx, query, time.Since(start), err)
+
+	return rows, err
+}
+
+// PrepareContext prepares query, so the executions of the statement are recorded.
+func (c *loggedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.driverConn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped, ok := stmt.(driverStmt)
+	if !ok {
+		_ = stmt.Close()
+		return nil, fmt.Errorf("unsupported sqlite statement type %T", stmt)
+	}
+
+	return &loggedStmt{driverStmt: wrapped, query: query, recorder: c.recorder}, nil
+}
+
+// loggedStmt records the executions of a prepared statement.
+type loggedStmt struct {
+	driverStmt
+	query    string
+	recorder *querylog.Recorder
+}
+
+// ExecContext executes the statement and records it.
+func (s *loggedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	result, err := s.driverStmt.ExecContext(ctx, args)
+	s.recorder.Record(ctx, s.query, time.Since(start), err)
+
+	return result, err
+}
+
+// QueryContext executes the statement and records it.
+func (s *loggedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	rows, err := s.driverStmt.QueryContext(ctx, args)
+	s.recorder.Record(ctx, s.query, time.Since(start), err)
+
+	return rows, err
+}
@@ -0,0 +1,164 @@
+//go:build sqlite
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	sqlite "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.SavedFilterRepository = (*SavedFilterRepository)(nil)
+	_ ports.SavedFilterStore      = (*TaskRepository)(nil)
+)
+
+// savedFilterColumns lists the columns scanned by scanSavedFilter, in order.
+// The filter is stored as a JSON object.
+const savedFilterColumns = "name, description, filter, sort_field, sort_order, created_by, created_at, updated_at"
+
+// SavedFilterRepository implements ports.SavedFilterRepository on the database of a TaskRepository.
+type SavedFilterRepository struct {
+	db *sql.DB
+}
+
+// SavedFilters returns the repository of saved filters stored in the same database.
+func (r *TaskRepository) SavedFilters() ports.SavedFilterRepository {
+	return &SavedFilterRepository{db: r.db}
+}
+
+// Create inserts a new saved filter.
+// Returns domain.ErrSavedFilterExists if a filter with the same name already exists.
+func (r *SavedFilterRepository) Create(ctx context.Context, filter *domain.SavedFilter) error {
+	encoded, err := json.Marshal(filter.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to encode filter: %w", err)
+	}
+
+	_, err = r.db.ExecContext(
+		ctx,
+		`INSERT INTO saved_filters (`+savedFilterColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		filter.Name, filter.Description, string(encoded), filter.SortField, filter.SortOrder,
+		filter.CreatedBy, filter.CreatedAt.UnixNano(), filter.UpdatedAt.UnixNano(),
+	)
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY {
+		return domain.ErrSavedFilterExists
+	}
+
+	return err
+}
+
+// GetByName returns the saved filter with the given name.
+// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+func (r *SavedFilterRepository) GetByName(ctx context.Context, name string) (*domain.SavedFilter, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+savedFilterColumns+` FROM saved_filters WHERE name = ?`, name)
+
+	filter, err := scanSavedFilter(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrSavedFilterNotFound
+	}
+
+	return filter, err
+}
+
+// GetAll returns all saved filters ordered by name.
+func (r *SavedFilterRepository) GetAll(ctx context.Context) ([]*domain.SavedFilter, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+savedFilterColumns+` FROM saved_filters ORDER BY name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	filters := make([]*domain.SavedFilter, 0)
+	for rows.Next() {
+		filter, err := scanSavedFilter(rows)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	return filters, rows.Err()
+}
+
+// Update replaces an existing saved filter.
+// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+func (r *SavedFilterRepository) Update(ctx context.Context, filter *domain.SavedFilter) error {
+	encoded, err := json.Marshal(filter.Filter)
+	if err != nil {
+		return fmt.Errorf("failed to encode filter: %w", err)
+	}
+
+	result, err := r.db.ExecContext(
+		ctx,
+		`UPDATE saved_filters SET description = ?, filter = ?, sort_field = ?, sort_order = ?, updated_at = ?
+		WHERE name = ?`,
+		filter.Description, string(encoded), filter.SortField, filter.SortOrder,
+		filter.UpdatedAt.UnixNano(), filter.Name,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkSavedFilterAffected(result)
+}
+
+// Delete removes the saved filter with the given name.
+// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+func (r *SavedFilterRepository) Delete(ctx context.Context, name string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM saved_filters WHERE name = ?`, name)
+	if err != nil {
+		return err
+	}
+
+	return checkSavedFilterAffected(result)
+}
+
+// checkSavedFilterAffected returns domain.ErrSavedFilterNotFound if the statement changed no row.
+func checkSavedFilterAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrSavedFilterNotFound
+	}
+
+	return nil
+}
+
+// scanSavedFilter reads a saved filter from a row with the savedFilterColumns.
+func scanSavedFilter(row scanner) (*domain.SavedFilter, error) {
+	var (
+		filter               domain.SavedFilter
+		encoded              string
+		createdAt, updatedAt int64
+	)
+	err := row.Scan(
+		&filter.Name, &filter.Description, &encoded, &filter.SortField, &filter.SortOrder,
+		&filter.CreatedBy, &createdAt, &updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(encoded), &filter.Filter); err != nil {
+		return nil, fmt.Errorf("invalid stored filter of saved filter %s: %w", filter.Name, err)
+	}
+
+	filter.CreatedAt = time.Unix(0, createdAt)
+	filter.UpdatedAt = time.Unix(0, updatedAt)
+	return &filter, nil
+}
@@ -0,0 +1,672 @@
+//go:build sqlite
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	sqlite "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/asp3cto/task-manager/internal/adapters/repository/querylog"
+	"github.com/asp3cto/task-manager/internal/adapters/repository/sqlpool"
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/migrations"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.TaskRepository      = (*TaskRepository)(nil)
+	_ ports.RepositoryInspector = (*TaskRepository)(nil)
+	_ ports.RepositoryDumper    = (*TaskRepository)(nil)
+)
+
+// taskColumns lists the columns scanned by scanTask, in order.
+// The priority is stored as its Priority.Rank, so it orders by urgency, the subtasks, attachments and tags
+// as JSON arrays.
+const taskColumns = "id, title, description, status, created_at, updated_at, version, due_date, priority, assignee, " +
+	"subtasks, attachments, project_id, rank, estimate, progress, tags"
+
+// busyTimeout is how long a write waits for the lock held by another connection.
+const busyTimeout = 5 * time.Second
+
+// TaskRepository implements ports.TaskRepository on an SQLite database file.
+// The database is opened in WAL mode, so reads are not blocked by writes.
+// Timestamps are stored as Unix nanoseconds.
+type TaskRepository struct {
+	db          *sql.DB
+	path        string
+	autoMigrate bool
+	// queries records the executed queries, nil disables query logging
+	queries *querylog.Recorder
+	// poolConfig limits the connections of the database handle
+	poolConfig sqlpool.Config
+	// stmts caches the prepared statements of the task queries
+	stmts *stmtCache
+}
+
+// Open opens or creates the database file at path and migrates the schema if needed.
+func Open(ctx context.Context, path string, opts ...Option) (*TaskRepository, error) {
+	r := &TaskRepository{path: path, autoMigrate: true, poolConfig: sqlpool.DefaultConfig()}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	pragmas := url.Values{}
+	pragmas.Add("_pragma", "journal_mode(WAL)")
+	pragmas.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", busyTimeout.Milliseconds()))
+	pragmas.Add("_pragma", "synchronous(NORMAL)")
+	pragmas.Add("_pragma", "foreign_keys(1)")
+
+	dsn := "file:" + path + "?" + pragmas.Encode()
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	if r.queries != nil {
+		// sql.Open does not connect, so the handle is only used to get the driver.
+		db = sql.OpenDB(&loggingConnector{driver: db.Driver(), dsn: dsn, recorder: r.queries})
+	}
+
+	db.SetMaxOpenConns(r.poolConfig.MaxOpenConns)
+	if r.poolConfig.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(r.poolConfig.MaxIdleConns)
+	}
+	db.SetConnMaxLifetime(r.poolConfig.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(r.poolConfig.ConnMaxIdleTime)
+
+	r.db = db
+	if err := r.migrate(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	// The statements are prepared after the migration, as they refer to the schema.
+	r.stmts = newStmtCache(db, r.poolConfig.StatementCacheSize)
+	return r, nil
+}
+
+// migrate applies pending migrations, or only checks that there are none if
+// auto-migration is disabled.
+func (r *TaskRepository) migrate(ctx context.Context) error {
+	migrator, err := migrations.New(r.db, migrations.SQLite)
+	if err != nil {
+		return err
+	}
+
+	if !r.autoMigrate {
+		return migrator.Check(ctx)
+	}
+
+	if _, err := migrator.Up(ctx); err != nil {
+		return fmt.Errorf("failed to migrate schema: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the cached statements and the database.
+func (r *TaskRepository) Close() error {
+	return errors.Join(r.stmts.close(), r.db.Close())
+}
+
+// PoolStats returns the utilization of the connections and the number of cached statements.
+func (r *TaskRepository) PoolStats() sqlpool.Stats {
+	stats := r.db.Stats()
+	return sqlpool.Stats{
+		MaxOpen:          stats.MaxOpenConnections,
+		Open:             stats.OpenConnections,
+		InUse:            stats.InUse,
+		Idle:             stats.Idle,
+		Waits:            stats.WaitCount,
+		WaitDuration:     stats.WaitDuration,
+		CachedStatements: r.stmts.len(),
+	}
+}
+
+// exec executes query with the cached statement of the query, if any.
+func (r *TaskRepository) exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if stmt := r.stmts.get(ctx, query); stmt != nil {
+		return stmt.ExecContext(ctx, args...)
+	}
+	return r.db.ExecContext(ctx, query, args...)
+}
+
+// query runs query with the cached statement of the query, if any.
+func (r *TaskRepository) query(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if stmt := r.stmts.get(ctx, query); stmt != nil {
+		return stmt.QueryContext(ctx, args...)
+	}
+	return r.db.QueryContext(ctx, query, args...)
+}
+
+// queryRow runs query, expected to return at most one row, with the cached statement
+// of the query, if any.
+func (r *TaskRepository) queryRow(ctx context.Context, query string, args ...any) *sql.Row {
+	if stmt := r.stmts.get(ctx, query); stmt != nil {
+		return stmt.QueryRowContext(ctx, args...)
+	}
+	return r.db.QueryRowContext(ctx, query, args...)
+}
+
+// Create inserts a new task.
+// Returns domain.ErrTaskExists if a task with the same ID already exists.
+func (r *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	subtasks, err := encodeJSONArray("subtasks", task.Subtasks)
+	if err != nil {
+		return err
+	}
+
+	attachments, err := encodeJSONArray("attachments", task.Attachments)
+	if err != nil {
+		return err
+	}
+
+	tags, err := encodeJSONArray("tags", task.Tags)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.exec(
+		ctx,
+		`INSERT INTO tasks (`+taskColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		task.ID, task.Title, task.Description, string(task.Status),
+		task.CreatedAt.UnixNano(), task.UpdatedAt.UnixNano(), task.Version, unixNanoOrNil(task.DueDate),
+		task.Priority.Rank(), task.Assignee, subtasks, attachments, task.ProjectID, task.Rank,
+		task.Estimate, task.Progress, tags,
+	)
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY {
+		return domain.ErrTaskExists
+	}
+
+	return err
+}
+
+// GetByID retrieves a task by its unique identifier.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (r *TaskRepository) GetByID(ctx context.Context, id string) (*domain.Task, error) {
+	row := r.queryRow(ctx, `SELECT `+taskColumns+` FROM tasks WHERE id = ?`, id)
+
+	task, err := scanTask(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrTaskNotFound
+	}
+
+	return task, err
+}
+
+// GetByIDs retrieves the tasks with the given identifiers with a single query.
+// Unknown IDs are skipped; tasks are returned in the order of ids.
+func (r *TaskRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.Task, error) {
+	if len(ids) == 0 {
+		return make([]*domain.Task, 0), nil
+	}
+
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(ids)), ", ")
+	found, err := r.queryTasks(ctx, `SELECT `+taskColumns+` FROM tasks WHERE id IN (`+placeholders+`)`, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*domain.Task, len(found))
+	for _, task := range found {
+		byID[task.ID] = task
+	}
+
+	tasks := make([]*domain.Task, 0, len(found))
+	for _, id := range ids {
+		if task, ok := byID[id]; ok {
+			tasks = append(tasks, task)
+		}
+	}
+
+	return tasks, nil
+}
+
+// GetAll retrieves the page of tasks described by the query. The filter, the sort,
+// the cursor and the page limit are translated into SQL, with the text queries
+// matched by LIKE if they only contain ASCII. The case folding of SQLite only covers
+// ASCII, so other text queries are matched in Go: every task passing the other
+// conditions is then read, and the page is cut in Go as well.
+func (r *TaskRepository) GetAll(ctx context.Context, query ports.ListQuery) (ports.TaskPage, error) {
+	clauses, args, exact, err := listClauses(query)
+	if err != nil {
+		return ports.TaskPage{}, err
+	}
+
+	tasks, err := r.queryTasks(ctx, `SELECT `+taskColumns+` FROM tasks`+clauses, args...)
+	if err != nil {
+		return ports.TaskPage{}, err
+	}
+
+	if !exact {
+		tasks = slices.DeleteFunc(tasks, func(task *domain.Task) bool {
+			return !query.Filter.Matches(task)
+		})
+	}
+
+	return ports.NewTaskPage(tasks, query), nil
+}
+
+// Iterate streams the tasks matching the filter from a single query, reading the rows
+// as fn consumes them. As in GetAll, text queries that are not ASCII are matched in Go.
+func (r *TaskRepository) Iterate(ctx context.Context, filter ports.ListFilter, fn func(*domain.Task) error) error {
+	query := `SELECT ` + taskColumns + ` FROM tasks`
+	conditions, args, exact := filterConditions(filter)
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := r.query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return err
+		}
+
+		if !exact && !filter.Matches(task) {
+			continue
+		}
+
+		if err := fn(task); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// Update modifies an existing task if its stored version still equals task.Version,
+// and increments the version.
+// Returns domain.ErrVersionConflict if the task was modified concurrently.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	subtasks, err := encodeJSONArray("subtasks", task.Subtasks)
+	if err != nil {
+		return err
+	}
+
+	attachments, err := encodeJSONArray("attachments", task.Attachments)
+	if err != nil {
+		return err
+	}
+
+	tags, err := encodeJSONArray("tags", task.Tags)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.exec(
+		ctx,
+		`UPDATE tasks SET title = ?, description = ?, status = ?, updated_at = ?, due_date = ?, priority = ?,
+		assignee = ?, subtasks = ?, attachments = ?, project_id = ?, rank = ?, estimate = ?,
+		progress = ?, tags = ?, version = version + 1
+		WHERE id = ? AND version = ?`,
+		task.Title, task.Description, string(task.Status), task.UpdatedAt.UnixNano(), unixNanoOrNil(task.DueDate),
+		task.Priority.Rank(), task.Assignee, subtasks, attachments, task.ProjectID, task.Rank, task.Estimate,
+		task.Progress, tags, task.ID, task.Version,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		var exists bool
+		err := r.queryRow(ctx, `SELECT EXISTS (SELECT 1 FROM tasks WHERE id = ?)`, task.ID).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return domain.ErrTaskNotFound
+		}
+		return domain.ErrVersionConflict
+	}
+
+	task.Version++
+	return nil
+}
+
+// Delete removes a task by its ID.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (r *TaskRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.exec(ctx, `DELETE FROM tasks WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrTaskNotFound
+	}
+
+	return nil
+}
+
+// Ping checks that the database file can be queried.
+func (r *TaskRepository) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+// Inspect reports task counts per status and the size of the database file,
+// not counting the write-ahead log.
+func (r *TaskRepository) Inspect(ctx context.Context) (ports.RepositoryStats, error) {
+	stats := ports.RepositoryStats{
+		Backend:    "sqlite",
+		TaskCounts: make(map[domain.TaskStatus]int),
+	}
+
+	rows, err := r.query(ctx, `SELECT status, count(*) FROM tasks GROUP BY status`)
+	if err != nil {
+		return ports.RepositoryStats{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			status string
+			count  int
+		)
+		if err := rows.Scan(&status, &count); err != nil {
+			return ports.RepositoryStats{}, err
+		}
+		stats.TaskCounts[domain.TaskStatus(status)] = count
+	}
+	if err := rows.Err(); err != nil {
+		return ports.RepositoryStats{}, err
+	}
+
+	if info, err := os.Stat(r.path); err == nil {
+		stats.SizeBytes = info.Size()
+	}
+
+	return stats, nil
+}
+
+// Dump returns a page of stored tasks ordered by ID.
+func (r *TaskRepository) Dump(ctx context.Context, offset, limit int) ([]*domain.Task, error) {
+	return r.queryTasks(ctx, `SELECT `+taskColumns+` FROM tasks ORDER BY id LIMIT ? OFFSET ?`, limit, offset)
+}
+
+// queryTasks runs a query selecting the taskColumns and reads all rows.
+func (r *TaskRepository) queryTasks(ctx context.Context, query string, args ...any) ([]*domain.Task, error) {
+	rows, err := r.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := make([]*domain.Task, 0)
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks, rows.Err()
+}
+
+// listClauses translates query into WHERE, ORDER BY and LIMIT clauses and their arguments,
+// and reports whether the clauses cover the whole filter, see filterConditions. Otherwise
+// the LIMIT clause is left out, as the page can only be cut after matching the text queries.
+// The limit exceeds the page limit by one, which tells whether there is a next page.
+// Returns domain.ErrInvalidCursor if the page cursor is malformed.
+func listClauses(query ports.ListQuery) (string, []any, bool, error) {
+	if err := query.Sort.Validate(); err != nil {
+		return "", nil, false, err
+	}
+
+	sort := query.Sort.Normalize()
+	// The sort fields are named after their columns; text is compared bytewise
+	// by the default BINARY collation, so the order agrees with the cursor keys.
+	column := string(sort.Field)
+	direction, comparison := "ASC", ">"
+	if sort.Order == ports.SortDesc {
+		direction, comparison = "DESC", "<"
+	}
+
+	conditions, args, exact := filterConditions(query.Filter)
+	if query.Page.Cursor != "" {
+		after, err := ports.DecodeCursor(sort, query.Page.Cursor)
+		if err != nil {
+			return "", nil, false, err
+		}
+
+		value, _ := after.Value()
+		if t, ok := value.(time.Time); ok {
+			value = t.UnixNano()
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s (?, ?)", column, comparison))
+		args = append(args, value, after.ID)
+	}
+
+	var clauses strings.Builder
+	if len(conditions) > 0 {
+		clauses.WriteString(" WHERE " + strings.Join(conditions, " AND "))
+	}
+
+	fmt.Fprintf(&clauses, " ORDER BY %s %s, id %s", column, direction, direction)
+	if exact && query.Page.Limit > 0 {
+		clauses.WriteString(" LIMIT ?")
+		args = append(args, query.Page.Limit+1)
+	}
+
+	return clauses.String(), args, exact, nil
+}
+
+// filterConditions translates filter into the conditions of a WHERE clause, none if no
+// field is set, and their arguments. The text queries are only translated if they are
+// ASCII; the returned flag reports whether the conditions cover the whole filter.
+func filterConditions(filter ports.ListFilter) ([]string, []any, bool) {
+	var (
+		conditions []string
+		args       []any
+	)
+
+	if len(filter.Statuses) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(filter.Statuses)), ", ")
+		conditions = append(conditions, "status IN ("+placeholders+")")
+		for _, status := range filter.Statuses {
+			args = append(args, string(status))
+		}
+	}
+
+	if len(filter.Priorities) > 0 {
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(filter.Priorities)), ", ")
+		conditions = append(conditions, "priority IN ("+placeholders+")")
+		for _, priority := range filter.Priorities {
+			args = append(args, priority.Rank())
+		}
+	}
+
+	if filter.Assignee != "" {
+		conditions = append(conditions, "assignee = ?")
+		args = append(args, filter.Assignee)
+	}
+
+	if filter.Unassigned {
+		conditions = append(conditions, "assignee = ''")
+	}
+
+	if filter.ProjectID != "" {
+		conditions = append(conditions, "project_id = ?")
+		args = append(args, filter.ProjectID)
+	}
+
+	if filter.MinProgress > 0 {
+		conditions = append(conditions, "progress >= ?")
+		args = append(args, filter.MinProgress)
+	}
+
+	bounds := []struct {
+		condition string
+		value     time.Time
+	}{
+		{"created_at > ?", filter.CreatedAfter},
+		{"created_at < ?", filter.CreatedBefore},
+		{"updated_at > ?", filter.UpdatedAfter},
+		{"updated_at < ?", filter.UpdatedBefore},
+		{"due_date > ?", filter.DueAfter},
+		{"due_date < ?", filter.DueBefore},
+	}
+	for _, bound := range bounds {
+		if !bound.value.IsZero() {
+			conditions = append(conditions, bound.condition)
+			args = append(args, bound.value.UnixNano())
+		}
+	}
+
+	if !filter.OverdueAt.IsZero() {
+		conditions = append(conditions, "due_date < ? AND status NOT IN (?, ?)")
+		args = append(args, filter.OverdueAt.UnixNano(), string(domain.StatusCompleted), string(domain.StatusCancelled))
+	}
+
+	if !isASCII(filter.Query) || !isASCII(filter.TitleContains) {
+		return conditions, args, false
+	}
+
+	if filter.Query != "" {
+		pattern := likePattern(filter.Query)
+		conditions = append(conditions, "("+foldedLike("title")+" OR "+foldedLike("description")+")")
+		args = append(args, pattern, pattern)
+	}
+
+	if filter.TitleContains != "" {
+		conditions = append(conditions, foldedLike("title"))
+		args = append(args, likePattern(filter.TitleContains))
+	}
+
+	return conditions, args, true
+}
+
+// foldedLike returns a case-insensitive LIKE condition on column for an ASCII pattern.
+// LIKE only folds ASCII letters, so the only two letters that Go lowercases to ASCII,
+// the dotted capital I (U+0130) and the Kelvin sign (U+212A), are replaced first;
+// the condition then matches the same values as the comparison of ports.ListFilter.
+func foldedLike(column string) string {
+	return "replace(replace(" + column + ", char(304), 'i'), char(8490), 'k') LIKE ? ESCAPE '\\'"
+}
+
+// likePattern returns a LIKE pattern matching values containing s literally.
+func likePattern(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + replacer.Replace(s) + "%"
+}
+
+// isASCII reports whether s only contains ASCII characters.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// scanner is implemented by *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTask reads a task from a row with the taskColumns.
+func scanTask(row scanner) (*domain.Task, error) {
+	var (
+		task                 domain.Task
+		status               string
+		createdAt, updatedAt int64
+		dueDate              sql.NullInt64
+		rank                 int
+		subtasks             string
+		attachments          string
+		tags                 string
+	)
+	err := row.Scan(
+		&task.ID, &task.Title, &task.Description, &status, &createdAt, &updatedAt, &task.Version, &dueDate, &rank,
+		&task.Assignee, &subtasks, &attachments, &task.ProjectID, &task.Rank,
+		&task.Estimate, &task.Progress, &tags,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(subtasks), &task.Subtasks); err != nil {
+		return nil, fmt.Errorf("invalid stored subtasks of task %s: %w", task.ID, err)
+	}
+
+	if err := json.Unmarshal([]byte(attachments), &task.Attachments); err != nil {
+		return nil, fmt.Errorf("invalid stored attachments of task %s: %w", task.ID, err)
+	}
+
+	if err := json.Unmarshal([]byte(tags), &task.Tags); err != nil {
+		return nil, fmt.Errorf("invalid stored tags of task %s: %w", task.ID, err)
+	}
+
+	priority, ok := domain.PriorityOfRank(rank)
+	if !ok {
+		return nil, fmt.Errorf("invalid stored priority %d of task %s", rank, task.ID)
+	}
+	task.Priority = priority
+
+	task.Status = domain.TaskStatus(status)
+	task.CreatedAt = time.Unix(0, createdAt)
+	task.UpdatedAt = time.Unix(0, updatedAt)
+	if dueDate.Valid {
+		due := time.Unix(0, dueDate.Int64)
+		task.DueDate = &due
+	}
+	return &task, nil
+}
+
+// encodeJSONArray returns the JSON array of items stored in the named column.
+func encodeJSONArray[T any](column string, items []T) (string, error) {
+	if items == nil {
+		items = []T{}
+	}
+
+	encoded, err := json.Marshal(items)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode %s: %w", column, err)
+	}
+	return string(encoded), nil
+}
+
+// unixNanoOrNil converts an optional timestamp to Unix nanoseconds, or NULL if it is nil.
+func unixNanoOrNil(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UnixNano()
+}
@@ -0,0 +1,145 @@
+//go:build sqlite
+
+package sqlite_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/adapters/repository/querylog"
+	"github.com/asp3cto/task-manager/internal/adapters/repository/repositorytest"
+	"github.com/asp3cto/task-manager/internal/adapters/repository/sqlite"
+	"github.com/asp3cto/task-manager/internal/adapters/repository/sqlpool"
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+func TestTaskRepository(t *testing.T) {
+	repositorytest.RunRepositoryTests(t, func(t *testing.T) ports.TaskRepository {
+		repo, err := sqlite.Open(context.Background(), filepath.Join(t.TempDir(), "tasks.db"))
+		if err != nil {
+			t.Fatalf("failed to open repository: %v", err)
+		}
+		t.Cleanup(func() { _ = repo.Close() })
+
+		return repo
+	})
+}
+
+func TestTaskRepositoryWithQueryLog(t *testing.T) {
+	log := logger.New(io.Discard, slog.LevelDebug, 1024)
+	log.Start(context.Background())
+	t.Cleanup(log.Close)
+
+	queries := querylog.New(log, querylog.DefaultSlowThreshold)
+
+	repositorytest.RunRepositoryTests(t, func(t *testing.T) ports.TaskRepository {
+		repo, err := sqlite.Open(
+			context.Background(), filepath.Join(t.TempDir(), "tasks.db"), sqlite.WithQueryLog(queries),
+		)
+		if err != nil {
+			t.Fatalf("failed to open repository: %v", err)
+		}
+		t.Cleanup(func() { _ = repo.Close() })
+
+		return repo
+	})
+
+	if stats := queries.Stats(); stats.Queries == 0 {
+		t.Errorf("expected the queries to be recorded, got %+v", stats)
+	}
+}
+
+func TestTaskRepositoryWithPoolConfig(t *testing.T) {
+	// The cache holds fewer statements than the queries of the tests, so both cached
+	// and uncached queries are exercised.
+	config := sqlpool.Config{MaxOpenConns: 4, MaxIdleConns: 2, StatementCacheSize: 2}
+
+	repositorytest.RunRepositoryTests(t, func(t *testing.T) ports.TaskRepository {
+		repo, err := sqlite.Open(
+			context.Background(), filepath.Join(t.TempDir(), "tasks.db"), sqlite.WithPoolConfig(config),
+		)
+		if err != nil {
+			t.Fatalf("failed to open repository: %v", err)
+		}
+		t.Cleanup(func() { _ = repo.Close() })
+
+		t.Cleanup(func() {
+			stats := repo.PoolStats()
+			if stats.MaxOpen != config.MaxOpenConns || stats.CachedStatements > config.StatementCacheSize {
+				t.Errorf("expected the pool configuration to be applied, got %+v", stats)
+			}
+		})
+
+		return repo
+	})
+}
+
+func TestGetAllTextSearch(t *testing.T) {
+	ctx := context.Background()
+	repo, err := sqlite.Open(ctx, filepath.Join(t.TempDir(), "tasks.db"))
+	if err != nil {
+		t.Fatalf("failed to open repository: %v", err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+
+	// The titles hold LIKE wildcards, the escape character and the non-ASCII letters
+	// that Go lowercases to ASCII.
+	titles := []string{"50% done", "snake_case", `back\slash`, "5 \u212A", "\u0130stanbul", "Ärger", "plain"}
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var tasks []*domain.Task
+	for i, title := range titles {
+		created := base.Add(time.Duration(i) * time.Minute)
+		task := &domain.Task{
+			ID: fmt.Sprintf("t%d", i), Title: title, Status: domain.StatusPending,
+			CreatedAt: created, UpdatedAt: created, Version: 1, Priority: domain.PriorityMedium,
+			Rank: domain.InitialRank(created),
+		}
+		if err := repo.Create(ctx, task); err != nil {
+			t.Fatalf("failed to create task: %v", err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	filters := []ports.ListFilter{
+		{Query: "%"}, {Query: "_"}, {Query: `\`}, {Query: "k"}, {Query: "I"}, {Query: "istanbul"},
+		{Query: "e"}, {TitleContains: "ä"}, {Query: "ÄRGER"}, {TitleContains: "5"}, {Query: "%", TitleContains: "done"},
+	}
+
+	for _, filter := range filters {
+		var want []string
+		for _, task := range tasks {
+			if filter.Matches(task) {
+				want = append(want, task.ID)
+			}
+		}
+
+		// Pages of a single task exercise the LIMIT of the query.
+		var got []string
+		query := ports.ListQuery{Filter: filter, Page: ports.PageRequest{Limit: 1}}
+		for {
+			page, err := repo.GetAll(ctx, query)
+			if err != nil {
+				t.Fatalf("query %q, title %q: GetAll failed: %v", filter.Query, filter.TitleContains, err)
+			}
+			for _, task := range page.Tasks {
+				got = append(got, task.ID)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			query.Page.Cursor = page.NextCursor
+		}
+
+		if !slices.Equal(got, want) {
+			t.Errorf("query %q, title %q: GetAll returned %v, want %v", filter.Query, filter.TitleContains, got, want)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+//go:build sqlite
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+)
+
+// stmtCache keeps the statements prepared on a database, so frequent queries are parsed
+// once per connection instead of on every execution. It holds at most size statements;
+// once it is full, other queries are executed without a cached statement.
+type stmtCache struct {
+	db   *sql.DB
+	size int
+
+	mu    sync.Mutex
+	stmts map[string]*sql.Stmt
+}
+
+// newStmtCache creates a cache of at most size statements prepared on db.
+func newStmtCache(db *sql.DB, size int) *stmtCache {
+	return &stmtCache{db: db, size: size, stmts: make(map[string]*sql.Stmt)}
+}
+
+// get returns the statement of query, preparing it if it is not cached yet.
+// Returns nil if the cache is full or the query cannot be prepared; executing it
+// directly then reports the error.
+func (c *stmtCache) get(ctx context.Context, query string) *sql.Stmt {
+	c.mu.Lock()
+	stmt, ok := c.stmts[query]
+	full := len(c.stmts) >= c.size
+	c.mu.Unlock()
+
+	if ok {
+		return stmt
+	}
+	if full {
+		return nil
+	}
+
+	// Preparing outside the lock does not block the other queries; a statement prepared
+	// concurrently for the same query is closed.
+	prepared, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if stmt, ok := c.stmts[query]; ok {
+		_ = prepared.Close()
+		return stmt
+	}
+	if len(c.stmts) >= c.size {
+		_ = prepared.Close()
+		return nil
+	}
+
+	c.stmts[query] = prepared
+	return prepared
+}
+
+// len returns the number of cached statements.
+func (c *stmtCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.stmts)
+}
+
+// close closes the cached statements.
+func (c *stmtCache) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var errs []error
+	for query, stmt := range c.stmts {
+		errs = append(errs, stmt.Close())
+		delete(c.stmts, query)
+	}
+
+	return errors.Join(errs...)
+}
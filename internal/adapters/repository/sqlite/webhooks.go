@@ -0,0 +1,273 @@
+//go:build sqlite
+
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	sqlite "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.WebhookRepository           = (*WebhookRepository)(nil)
+	_ ports.WebhookDeadLetterRepository = (*WebhookDeadLetterRepository)(nil)
+	_ ports.WebhookStore                = (*TaskRepository)(nil)
+)
+
+// webhookColumns lists the columns scanned by scanWebhook, in order.
+// The event types are stored as a JSON array.
+const webhookColumns = "id, url, events, secret, created_at, updated_at"
+
+// deadLetterColumns lists the columns scanned by scanDeadLetter, in order.
+// The event is stored as a JSON object.
+const deadLetterColumns = "id, webhook_id, event, attempts, last_error, failed_at"
+
+// WebhookRepository implements ports.WebhookRepository on the database of a TaskRepository.
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+// Webhooks returns the repository of webhooks stored in the same database.
+func (r *TaskRepository) Webhooks() ports.WebhookRepository {
+	return &WebhookRepository{db: r.db}
+}
+
+// WebhookDeadLetters returns the repository of webhook dead letters stored in the same database.
+func (r *TaskRepository) WebhookDeadLetters() ports.WebhookDeadLetterRepository {
+	return &WebhookDeadLetterRepository{db: r.db}
+}
+
+// Create inserts a new webhook.
+// Returns domain.ErrWebhookExists if a webhook with the same ID already exists.
+func (r *WebhookRepository) Create(ctx context.Context, webhook *domain.Webhook) error {
+	events, err := encodeJSONArray("events", webhook.Events)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ExecContext(
+		ctx,
+		`INSERT INTO webhooks (`+webhookColumns+`) VALUES (?, ?, ?, ?, ?, ?)`,
+		webhook.ID, webhook.URL, events, webhook.Secret,
+		webhook.CreatedAt.UnixNano(), webhook.UpdatedAt.UnixNano(),
+	)
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY {
+		return domain.ErrWebhookExists
+	}
+
+	return err
+}
+
+// GetByID returns the webhook with the given ID.
+// Returns domain.ErrWebhookNotFound if no webhook exists with the given ID.
+func (r *WebhookRepository) GetByID(ctx context.Context, id string) (*domain.Webhook, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+webhookColumns+` FROM webhooks WHERE id = ?`, id)
+
+	webhook, err := scanWebhook(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrWebhookNotFound
+	}
+
+	return webhook, err
+}
+
+// GetAll returns all webhooks, oldest first.
+func (r *WebhookRepository) GetAll(ctx context.Context) ([]*domain.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+webhookColumns+` FROM webhooks ORDER BY created_at, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := make([]*domain.Webhook, 0)
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// Update modifies an existing webhook.
+// Returns domain.ErrWebhookNotFound if no webhook exists with the given ID.
+func (r *WebhookRepository) Update(ctx context.Context, webhook *domain.Webhook) error {
+	events, err := encodeJSONArray("events", webhook.Events)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(
+		ctx,
+		`UPDATE webhooks SET url = ?, events = ?, secret = ?, updated_at = ? WHERE id = ?`,
+		webhook.URL, events, webhook.Secret, webhook.UpdatedAt.UnixNano(), webhook.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return checkWebhookAffected(result)
+}
+
+// Delete removes the webhook with the given ID.
+// Returns domain.ErrWebhookNotFound if no webhook exists with the given ID.
+func (r *WebhookRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	return checkWebhookAffected(result)
+}
+
+// checkWebhookAffected returns domain.ErrWebhookNotFound if the statement changed no row.
+func checkWebhookAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// scanWebhook reads a webhook from a row with the webhookColumns.
+func scanWebhook(row scanner) (*domain.Webhook, error) {
+	var (
+		webhook              domain.Webhook
+		events               string
+		createdAt, updatedAt int64
+	)
+	err := row.Scan(&webhook.ID, &webhook.URL, &events, &webhook.Secret, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(events), &webhook.Events); err != nil {
+		return nil, fmt.Errorf("invalid stored events of webhook %s: %w", webhook.ID, err)
+	}
+
+	webhook.CreatedAt = time.Unix(0, createdAt)
+	webhook.UpdatedAt = time.Unix(0, updatedAt)
+	return &webhook, nil
+}
+
+// WebhookDeadLetterRepository implements ports.WebhookDeadLetterRepository on the
+// database of a TaskRepository.
+type WebhookDeadLetterRepository struct {
+	db *sql.DB
+}
+
+// Create inserts a new dead letter.
+// Returns domain.ErrDeadLetterExists if a dead letter with the same ID already exists.
+func (r *WebhookDeadLetterRepository) Create(ctx context.Context, letter *domain.WebhookDeadLetter) error {
+	event, err := json.Marshal(letter.Event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	_, err = r.db.ExecContext(
+		ctx,
+		`INSERT INTO webhook_dead_letters (`+deadLetterColumns+`) VALUES (?, ?, ?, ?, ?, ?)`,
+		letter.ID, letter.WebhookID, string(event), letter.Attempts, letter.LastError, letter.FailedAt.UnixNano(),
+	)
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY {
+		return domain.ErrDeadLetterExists
+	}
+
+	return err
+}
+
+// GetByID returns the dead letter with the given ID.
+// Returns domain.ErrDeadLetterNotFound if no dead letter exists with the given ID.
+func (r *WebhookDeadLetterRepository) GetByID(ctx context.Context, id string) (*domain.WebhookDeadLetter, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+deadLetterColumns+` FROM webhook_dead_letters WHERE id = ?`, id)
+
+	letter, err := scanDeadLetter(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrDeadLetterNotFound
+	}
+
+	return letter, err
+}
+
+// GetAll returns all dead letters, oldest first.
+func (r *WebhookDeadLetterRepository) GetAll(ctx context.Context) ([]*domain.WebhookDeadLetter, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT `+deadLetterColumns+` FROM webhook_dead_letters ORDER BY failed_at, id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	letters := make([]*domain.WebhookDeadLetter, 0)
+	for rows.Next() {
+		letter, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, err
+		}
+		letters = append(letters, letter)
+	}
+
+	return letters, rows.Err()
+}
+
+// Delete removes the dead letter with the given ID.
+// Returns domain.ErrDeadLetterNotFound if no dead letter exists with the given ID.
+func (r *WebhookDeadLetterRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhook_dead_letters WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrDeadLetterNotFound
+	}
+
+	return nil
+}
+
+// scanDeadLetter reads a dead letter from a row with the deadLetterColumns.
+func scanDeadLetter(row scanner) (*domain.WebhookDeadLetter, error) {
+	var (
+		letter   domain.WebhookDeadLetter
+		event    string
+		failedAt int64
+	)
+	err := row.Scan(&letter.ID, &letter.WebhookID, &event, &letter.Attempts, &letter.LastError, &failedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(event), &letter.Event); err != nil {
+		return nil, fmt.Errorf("invalid stored event of dead letter %s: %w", letter.ID, err)
+	}
+
+	letter.FailedAt = time.Unix(0, failedAt)
+	return &letter, nil
+}
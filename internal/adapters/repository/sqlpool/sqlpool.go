@@ -0,0 +1,117 @@
+// Package sqlpool configures the connection pools and statement caches of the SQL repositories
+// and describes the utilization of their pools.
+package sqlpool
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// DefaultStatementCacheSize is the default number of prepared statements cached per pool.
+const DefaultStatementCacheSize = 512
+
+// Config limits the connections of a pool. Zero limits keep the defaults of the backend.
+type Config struct {
+	// MaxOpenConns is the maximum number of open connections
+	MaxOpenConns int
+	// MaxIdleConns is the maximum number of idle connections kept open, SQLite only:
+	// idle PostgreSQL connections are closed after ConnMaxIdleTime
+	MaxIdleConns int
+	// ConnMaxLifetime is how long a connection is reused before it is closed
+	ConnMaxLifetime time.Duration
+	// ConnMaxIdleTime is how long a connection stays idle before it is closed
+	ConnMaxIdleTime time.Duration
+	// StatementCacheSize is the maximum number of cached prepared statements, 0 disables the cache
+	StatementCacheSize int
+}
+
+// DefaultConfig returns the backend defaults with a statement cache of DefaultStatementCacheSize.
+func DefaultConfig() Config {
+	return Config{StatementCacheSize: DefaultStatementCacheSize}
+}
+
+// ConfigFromEnv reads the DB_MAX_OPEN_CONNS, DB_MAX_IDLE_CONNS, DB_CONN_MAX_LIFETIME,
+// DB_CONN_MAX_IDLE_TIME and DB_STATEMENT_CACHE_SIZE environment variables.
+// Unset variables keep the values of DefaultConfig.
+func ConfigFromEnv() (Config, error) {
+	config := DefaultConfig()
+
+	var err error
+	if config.MaxOpenConns, err = intFromEnv("DB_MAX_OPEN_CONNS", config.MaxOpenConns, 1); err != nil {
+		return Config{}, err
+	}
+	if config.MaxIdleConns, err = intFromEnv("DB_MAX_IDLE_CONNS", config.MaxIdleConns, 1); err != nil {
+		return Config{}, err
+	}
+	if config.ConnMaxLifetime, err = durationFromEnv("DB_CONN_MAX_LIFETIME", config.ConnMaxLifetime); err != nil {
+		return Config{}, err
+	}
+	if config.ConnMaxIdleTime, err = durationFromEnv("DB_CONN_MAX_IDLE_TIME", config.ConnMaxIdleTime); err != nil {
+		return Config{}, err
+	}
+	if config.StatementCacheSize, err = intFromEnv(
+		"DB_STATEMENT_CACHE_SIZE", config.StatementCacheSize, 0,
+	); err != nil {
+		return Config{}, err
+	}
+
+	if config.MaxOpenConns > 0 && config.MaxIdleConns > config.MaxOpenConns {
+		return Config{}, fmt.Errorf(
+			"DB_MAX_IDLE_CONNS must not exceed DB_MAX_OPEN_CONNS, got: %d > %d", config.MaxIdleConns, config.MaxOpenConns,
+		)
+	}
+
+	return config, nil
+}
+
+// intFromEnv parses the integer of at least min in the environment variable name,
+// or returns def if it is not set.
+func intFromEnv(name string, def, min int) (int, error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return def, nil
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < min {
+		return 0, fmt.Errorf("%s must be an integer of at least %d, got: %s", name, min, raw)
+	}
+
+	return n, nil
+}
+
+// durationFromEnv parses the positive duration in the environment variable name,
+// or returns def if it is not set.
+func durationFromEnv(name string, def time.Duration) (time.Duration, error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return def, nil
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%s must be a positive duration, got: %s", name, raw)
+	}
+
+	return d, nil
+}
+
+// Stats describes the utilization of a pool.
+type Stats struct {
+	// MaxOpen is the maximum number of open connections, 0 if unlimited
+	MaxOpen int
+	// Open is the number of open connections
+	Open int
+	// InUse is the number of connections in use
+	InUse int
+	// Idle is the number of idle connections
+	Idle int
+	// Waits is the number of times a connection was waited for because none was idle
+	Waits int64
+	// WaitDuration is the total time spent waiting for connections
+	WaitDuration time.Duration
+	// CachedStatements is the number of cached prepared statements, if the backend reports it
+	CachedStatements int
+}
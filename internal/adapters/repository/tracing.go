@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.TaskRepository      = (*TracingTaskRepository)(nil)
+	_ ports.RepositoryInspector = (*TracingTaskRepository)(nil)
+	_ ports.RepositoryDumper    = (*TracingTaskRepository)(nil)
+)
+
+// tracerName is the instrumentation scope of the repository spans.
+const tracerName = "github.com/asp3cto/task-manager/internal/adapters/repository"
+
+// TracingTaskRepository decorates a TaskRepository with an OpenTelemetry span per operation,
+// giving the same visibility into every storage backend. Spans carry the task_id and status
+// of the task concerned and the number of tasks returned by listings. Failures of the store
+// mark the span as failed, while domain errors such as a missing task are only recorded.
+type TracingTaskRepository struct {
+	next   ports.TaskRepository
+	tracer trace.Tracer
+}
+
+// NewTracingTaskRepository wraps next with spans created by a tracer of provider.
+func NewTracingTaskRepository(next ports.TaskRepository, provider trace.TracerProvider) *TracingTaskRepository {
+	return &TracingTaskRepository{
+		next:   next,
+		tracer: provider.Tracer(tracerName),
+	}
+}
+
+// Create stores a new task within a span.
+func (r *TracingTaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	ctx, span := r.start(ctx, "Create", taskAttributes(task)...)
+	err := r.next.Create(ctx, task)
+	endSpan(span, err)
+
+	return err
+}
+
+// GetByID retrieves a task by its ID within a span.
+func (r *TracingTaskRepository) GetByID(ctx context.Context, id string) (*domain.Task, error) {
+	ctx, span := r.start(ctx, "GetByID", attribute.String("task_id", id))
+	task, err := r.next.GetByID(ctx, id)
+	if err == nil {
+		span.SetAttributes(attribute.String("status", string(task.Status)))
+	}
+	endSpan(span, err)
+
+	return task, err
+}
+
+// GetByIDs retrieves tasks by their IDs within a span.
+func (r *TracingTaskRepository) GetByIDs(ctx context.Context, ids []string) ([]*domain.Task, error) {
+	ctx, span := r.start(ctx, "GetByIDs", attribute.Int("task_ids", len(ids)))
+	tasks, err := r.next.GetByIDs(ctx, ids)
+	span.SetAttributes(attribute.Int("tasks", len(tasks)))
+	endSpan(span, err)
+
+	return tasks, err
+}
+
+// GetAll retrieves a page of tasks matching the query within a span.
+func (r *TracingTaskRepository) GetAll(ctx context.Context, query ports.ListQuery) (ports.TaskPage, error) {
+	statuses := make([]string, len(query.Filter.Statuses))
+	for i, status := range query.Filter.Statuses {
+		statuses[i] = string(status)
+	}
+
+	ctx, span := r.start(
+		ctx, "GetAll",
+		attribute.StringSlice("status", statuses),
+		attribute.String("sort", string(query.Sort.Normalize().Field)),
+		attribute.Int("limit", query.Page.Limit),
+	)
+	page, err := r.next.GetAll(ctx, query)
+	span.SetAttributes(attribute.Int("tasks", len(page.Tasks)))
+	endSpan(span, err)
+
+	return page, err
+}
+
+// Iterate streams the tasks matching the filter within a span covering the whole iteration.
+func (r *TracingTaskRepository) Iterate(
+	ctx context.Context, filter ports.ListFilter, fn func(*domain.Task) error,
+) error {
+	statuses := make([]string, len(filter.Statuses))
+	for i, status := range filter.Statuses {
+		statuses[i] = string(status)
+	}
+
+	ctx, span := r.start(ctx, "Iterate", attribute.StringSlice("status", statuses))
+	visited := 0
+	err := r.next.Iterate(ctx, filter, func(task *domain.Task) error {
+		visited++
+		return fn(task)
+	})
+	span.SetAttributes(attribute.Int("tasks", visited))
+	endSpan(span, err)
+
+	return err
+}
+
+// Update persists a task within a span.
+func (r *TracingTaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	ctx, span := r.start(ctx, "Update", taskAttributes(task)...)
+	err := r.next.Update(ctx, task)
+	endSpan(span, err)
+
+	return err
+}
+
+// Delete removes a task within a span.
+func (r *TracingTaskRepository) Delete(ctx context.Context, id string) error {
+	ctx, span := r.start(ctx, "Delete", attribute.String("task_id", id))
+	err := r.next.Delete(ctx, id)
+	endSpan(span, err)
+
+	return err
+}
+
+// Ping checks the health of the underlying repository within a span.
+func (r *TracingTaskRepository) Ping(ctx context.Context) error {
+	ctx, span := r.start(ctx, "Ping")
+	err := r.next.Ping(ctx)
+	endSpan(span, err)
+
+	return err
+}
+
+// Inspect reports the state of the underlying repository within a span.
+func (r *TracingTaskRepository) Inspect(ctx context.Context) (ports.RepositoryStats, error) {
+	inspector, ok := r.next.(ports.RepositoryInspector)
+	if !ok {
+		return ports.RepositoryStats{}, ErrNotSupported
+	}
+
+	ctx, span := r.start(ctx, "Inspect")
+	stats, err := inspector.Inspect(ctx)
+	span.SetAttributes(attribute.String("backend", stats.Backend))
+	endSpan(span, err)
+
+	return stats, err
+}
+
+// Dump returns raw tasks from the underlying repository within a span.
+func (r *TracingTaskRepository) Dump(ctx context.Context, offset, limit int) ([]*domain.Task, error) {
+	dumper, ok := r.next.(ports.RepositoryDumper)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+
+	ctx, span := r.start(ctx, "Dump", attribute.Int("offset", offset), attribute.Int("limit", limit))
+	tasks, err := dumper.Dump(ctx, offset, limit)
+	span.SetAttributes(attribute.Int("tasks", len(tasks)))
+	endSpan(span, err)
+
+	return tasks, err
+}
+
+// start opens a client span named after the repository operation.
+func (r *TracingTaskRepository) start(
+	ctx context.Context, operation string, attrs ...attribute.KeyValue,
+) (context.Context, trace.Span) {
+	return r.tracer.Start(
+		ctx, "TaskRepository."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// taskAttributes returns the span attributes identifying task.
+func taskAttributes(task *domain.Task) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("task_id", task.ID),
+		attribute.String("status", string(task.Status)),
+	}
+}
+
+// endSpan records err on span and ends it. Only errors other than domain errors fail the span.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		if !isDomainError(err) {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+
+	span.End()
+}
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var (
+	_ ports.WebhookRepository           = (*MemoryWebhookRepository)(nil)
+	_ ports.WebhookDeadLetterRepository = (*MemoryWebhookDeadLetterRepository)(nil)
+)
+
+// MemoryWebhookRepository provides an in-memory implementation of the WebhookRepository interface.
+// Data is lost when the application restarts since it's stored only in memory.
+type MemoryWebhookRepository struct {
+	// webhooks stores all webhooks indexed by their ID
+	webhooks map[string]*domain.Webhook
+	// mu provides thread-safe access to the webhooks map
+	mu sync.RWMutex
+}
+
+// NewMemoryWebhookRepository creates a new instance of the in-memory webhook repository.
+func NewMemoryWebhookRepository() *MemoryWebhookRepository {
+	return &MemoryWebhookRepository{
+		webhooks: make(map[string]*domain.Webhook),
+	}
+}
+
+// Create stores a copy of a new webhook.
+// Returns domain.ErrWebhookExists if a webhook with the same ID already exists.
+func (r *MemoryWebhookRepository) Create(_ context.Context, webhook *domain.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.webhooks[webhook.ID]; exists {
+		return domain.ErrWebhookExists
+	}
+
+	webhookCopy := *webhook
+	r.webhooks[webhook.ID] = &webhookCopy
+	return nil
+}
+
+// GetByID returns a copy of the webhook with the given ID.
+// Returns domain.ErrWebhookNotFound if no webhook exists with the given ID.
+func (r *MemoryWebhookRepository) GetByID(_ context.Context, id string) (*domain.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhook, exists := r.webhooks[id]
+	if !exists {
+		return nil, domain.ErrWebhookNotFound
+	}
+
+	webhookCopy := *webhook
+	return &webhookCopy, nil
+}
+
+// GetAll returns copies of all webhooks, oldest first. Webhooks created at the
+// same instant are ordered by ID.
+func (r *MemoryWebhookRepository) GetAll(_ context.Context) ([]*domain.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhooks := make([]*domain.Webhook, 0, len(r.webhooks))
+	for _, webhook := range r.webhooks {
+		webhookCopy := *webhook
+		webhooks = append(webhooks, &webhookCopy)
+	}
+
+	slices.SortFunc(webhooks, func(a, b *domain.Webhook) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return webhooks, nil
+}
+
+// Update replaces the stored webhook with a copy of webhook.
+// Returns domain.ErrWebhookNotFound if no webhook exists with the given ID.
+func (r *MemoryWebhookRepository) Update(_ context.Context, webhook *domain.Webhook) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.webhooks[webhook.ID]; !exists {
+		return domain.ErrWebhookNotFound
+	}
+
+	webhookCopy := *webhook
+	r.webhooks[webhook.ID] = &webhookCopy
+	return nil
+}
+
+// Delete removes the webhook with the given ID.
+// Returns domain.ErrWebhookNotFound if no webhook exists with the given ID.
+func (r *MemoryWebhookRepository) Delete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.webhooks[id]; !exists {
+		return domain.ErrWebhookNotFound
+	}
+
+	delete(r.webhooks, id)
+	return nil
+}
+
+// MemoryWebhookDeadLetterRepository provides an in-memory implementation of the
+// WebhookDeadLetterRepository interface.
+// Data is lost when the application restarts since it's stored only in memory.
+type MemoryWebhookDeadLetterRepository struct {
+	// letters stores all dead letters indexed by their ID
+	letters map[string]*domain.WebhookDeadLetter
+	// mu provides thread-safe access to the letters map
+	mu sync.RWMutex
+}
+
+// NewMemoryWebhookDeadLetterRepository creates a new instance of the in-memory dead letter repository.
+func NewMemoryWebhookDeadLetterRepository() *MemoryWebhookDeadLetterRepository {
+	return &MemoryWebhookDeadLetterRepository{
+		letters: make(map[string]*domain.WebhookDeadLetter),
+	}
+}
+
+// Create stores a copy of a new dead letter.
+// Returns domain.ErrDeadLetterExists if a dead letter with the same ID already exists.
+func (r *MemoryWebhookDeadLetterRepository) Create(_ context.Context, letter *domain.WebhookDeadLetter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.letters[letter.ID]; exists {
+		return domain.ErrDeadLetterExists
+	}
+
+	letterCopy := *letter
+	r.letters[letter.ID] = &letterCopy
+	return nil
+}
+
+// GetByID returns a copy of the dead letter with the given ID.
+// Returns domain.ErrDeadLetterNotFound if no dead letter exists with the given ID.
+func (r *MemoryWebhookDeadLetterRepository) GetByID(_ context.Context, id string) (*domain.WebhookDeadLetter, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	letter, exists := r.letters[id]
+	if !exists {
+		return nil, domain.ErrDeadLetterNotFound
+	}
+
+	letterCopy := *letter
+	return &letterCopy, nil
+}
+
+// GetAll returns copies of all dead letters, oldest first. Dead letters stored at the
+// same instant are ordered by ID.
+func (r *MemoryWebhookDeadLetterRepository) GetAll(_ context.Context) ([]*domain.WebhookDeadLetter, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	letters := make([]*domain.WebhookDeadLetter, 0, len(r.letters))
+	for _, letter := range r.letters {
+		letterCopy := *letter
+		letters = append(letters, &letterCopy)
+	}
+
+	slices.SortFunc(letters, func(a, b *domain.WebhookDeadLetter) int {
+		if c := a.FailedAt.Compare(b.FailedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return letters, nil
+}
+
+// Delete removes the dead letter with the given ID.
+// Returns domain.ErrDeadLetterNotFound if no dead letter exists with the given ID.
+func (r *MemoryWebhookDeadLetterRepository) Delete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.letters[id]; !exists {
+		return domain.ErrDeadLetterNotFound
+	}
+
+	delete(r.letters, id)
+	return nil
+}
@@ -0,0 +1,60 @@
+package statusconfig
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+)
+
+// WorkflowFromEnv reads the workflow of the built-in statuses extended by the
+// TASK_STATUS_TRANSITIONS environment variable.
+//
+// The variable holds a comma-separated list of transitions in the form
+// "from:to1|to2", for example:
+//
+//	TASK_STATUS_TRANSITIONS=in_progress:in_review,in_review:completed|in_progress
+//
+// Statuses that are not built in are added by naming them in a transition.
+// Returns the built-in workflow if the variable is not set.
+func WorkflowFromEnv() (*domain.Workflow, error) {
+	raw := os.Getenv("TASK_STATUS_TRANSITIONS")
+	if raw == "" {
+		return domain.DefaultWorkflow(), nil
+	}
+
+	var transitions []domain.Transition
+	for _, part := range strings.Split(raw, ",") {
+		parsed, err := parseTransitions(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		transitions = append(transitions, parsed...)
+	}
+
+	workflow, err := domain.DefaultWorkflow().With(transitions...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TASK_STATUS_TRANSITIONS %q: %w", raw, err)
+	}
+
+	return workflow, nil
+}
+
+// parseTransitions parses a single "from:to1|to2" entry.
+func parseTransitions(raw string) ([]domain.Transition, error) {
+	from, targets, ok := strings.Cut(raw, ":")
+	if !ok || from == "" || targets == "" {
+		return nil, fmt.Errorf("invalid status transition %q: expected from:to1|to2", raw)
+	}
+
+	var transitions []domain.Transition
+	for _, to := range strings.Split(targets, "|") {
+		transitions = append(transitions, domain.Transition{
+			From: domain.TaskStatus(from),
+			To:   domain.TaskStatus(to),
+		})
+	}
+
+	return transitions, nil
+}
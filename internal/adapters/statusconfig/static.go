@@ -0,0 +1,26 @@
+// Package statusconfig provides the task statuses configured by the operator.
+package statusconfig
+
+import (
+	"context"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var _ ports.StatusConfig = (*StaticConfig)(nil)
+
+// StaticConfig provides a workflow fixed when the application starts.
+type StaticConfig struct {
+	workflow *domain.Workflow
+}
+
+// NewStaticConfig creates a StatusConfig that always provides workflow.
+func NewStaticConfig(workflow *domain.Workflow) *StaticConfig {
+	return &StaticConfig{workflow: workflow}
+}
+
+// Workflow returns the workflow the configuration was created with.
+func (c *StaticConfig) Workflow(context.Context) (*domain.Workflow, error) {
+	return c.workflow, nil
+}
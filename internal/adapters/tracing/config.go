@@ -0,0 +1,31 @@
+// Package tracing exports OpenTelemetry traces of the task manager.
+package tracing
+
+import (
+	"fmt"
+	"os"
+)
+
+// Trace exporters selectable with OTEL_TRACES_EXPORTER.
+const (
+	exporterNone = "none"
+	exporterOTLP = "otlp"
+)
+
+// OTLPEnabledFromEnv reports whether traces should be pushed over OTLP.
+//
+// Environment variables used:
+//   - OTEL_TRACES_EXPORTER: "otlp" enables the OTLP exporter; "none" leaves
+//     tracing disabled (default: disabled)
+//
+// Returns an error if the exporter name is not supported.
+func OTLPEnabledFromEnv() (bool, error) {
+	switch exporter := os.Getenv("OTEL_TRACES_EXPORTER"); exporter {
+	case "", exporterNone:
+		return false, nil
+	case exporterOTLP:
+		return true, nil
+	default:
+		return false, fmt.Errorf("unsupported OTEL_TRACES_EXPORTER %q, expected otlp or none", exporter)
+	}
+}
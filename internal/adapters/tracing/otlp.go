@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// serviceName identifies the service in exported traces unless OTEL_SERVICE_NAME overrides it.
+const serviceName = "task-manager"
+
+// OTLPExporter pushes spans in batches to an OpenTelemetry collector over OTLP/HTTP.
+type OTLPExporter struct {
+	provider *sdktrace.TracerProvider
+}
+
+// NewOTLPExporter creates a tracer provider exporting its spans to the OTLP endpoint.
+// The endpoint, headers, sampler and resource attributes are configured with the standard
+// OTEL_EXPORTER_OTLP_*, OTEL_TRACES_SAMPLER* and OTEL_RESOURCE_ATTRIBUTES variables.
+func NewOTLPExporter(ctx context.Context) (*OTLPExporter, error) {
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	res, err := resource.New(
+		ctx,
+		resource.WithAttributes(attribute.String("service.name", serviceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP resource: %w", err)
+	}
+
+	return &OTLPExporter{
+		provider: sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res)),
+	}, nil
+}
+
+// TracerProvider returns the provider of tracers whose spans are exported.
+func (e *OTLPExporter) TracerProvider() trace.TracerProvider {
+	return e.provider
+}
+
+// Shutdown pushes the pending spans and stops the exporter.
+func (e *OTLPExporter) Shutdown(ctx context.Context) error {
+	return e.provider.Shutdown(ctx)
+}
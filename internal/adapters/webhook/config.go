@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"fmt"
+	"net/netip"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OptionsFromEnv reads the Dispatcher configuration from environment variables.
+//
+// Environment variables used:
+//   - WEBHOOK_WORKERS: Number of concurrent deliveries (default: 4)
+//   - WEBHOOK_QUEUE_SIZE: Number of events waiting for delivery before events are dropped (default: 1000)
+//   - WEBHOOK_TIMEOUT: Time limit of a delivery (default: 10s)
+//   - WEBHOOK_RETRY_ATTEMPTS: Number of delivery attempts per webhook, including the first one (default: 6)
+//   - WEBHOOK_RETRY_BASE: Delay before the first retry, doubled with every further retry (default: 1s)
+//   - WEBHOOK_RETRY_MAX: Maximum delay between retries (default: 1m)
+//   - WEBHOOK_ALLOWED_NETWORKS: Comma-separated CIDR networks deliveries may reach although
+//     they are private or local, e.g. "10.0.0.0/8,127.0.0.1/32" (default: none)
+func OptionsFromEnv() ([]Option, error) {
+	var opts []Option
+
+	workers, err := positiveIntFromEnv("WEBHOOK_WORKERS")
+	if err != nil {
+		return nil, err
+	}
+	if workers > 0 {
+		opts = append(opts, WithWorkers(workers))
+	}
+
+	size, err := positiveIntFromEnv("WEBHOOK_QUEUE_SIZE")
+	if err != nil {
+		return nil, err
+	}
+	if size > 0 {
+		opts = append(opts, WithQueueSize(size))
+	}
+
+	timeout, err := durationFromEnv("WEBHOOK_TIMEOUT", defaultTimeout)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, WithTimeout(timeout))
+
+	attempts, err := positiveIntFromEnv("WEBHOOK_RETRY_ATTEMPTS")
+	if err != nil {
+		return nil, err
+	}
+	if attempts > 0 {
+		opts = append(opts, WithRetries(attempts))
+	}
+
+	base, err := durationFromEnv("WEBHOOK_RETRY_BASE", defaultRetryBase)
+	if err != nil {
+		return nil, err
+	}
+
+	max, err := durationFromEnv("WEBHOOK_RETRY_MAX", defaultRetryMax)
+	if err != nil {
+		return nil, err
+	}
+
+	if base > max {
+		return nil, fmt.Errorf("WEBHOOK_RETRY_BASE must not exceed WEBHOOK_RETRY_MAX, got: %s > %s", base, max)
+	}
+
+	opts = append(opts, WithRetryBackoff(base, max))
+
+	if raw := os.Getenv("WEBHOOK_ALLOWED_NETWORKS"); raw != "" {
+		var networks []netip.Prefix
+		for _, value := range strings.Split(raw, ",") {
+			network, err := netip.ParsePrefix(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("WEBHOOK_ALLOWED_NETWORKS must be a comma-separated list of CIDR networks, got: %s", raw)
+			}
+			networks = append(networks, network.Masked())
+		}
+		opts = append(opts, WithAllowedNetworks(networks...))
+	}
+
+	return opts, nil
+}
+
+// durationFromEnv parses the positive duration in the environment variable name,
+// or returns def if it is not set.
+func durationFromEnv(name string, def time.Duration) (time.Duration, error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return def, nil
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("%s must be a positive duration, got: %s", name, raw)
+	}
+
+	return d, nil
+}
+
+// positiveIntFromEnv parses the positive integer in the environment variable name,
+// or returns zero if it is not set.
+func positiveIntFromEnv(name string) (int, error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%s must be a positive integer, got: %s", name, raw)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,554 @@
+// Package webhook delivers task events to the endpoints subscribed to them.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/netip"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var _ ports.WebhookDeliverer = (*Dispatcher)(nil)
+
+// Headers of a delivery.
+const (
+	// HeaderEvent carries the type of the delivered event.
+	HeaderEvent = "X-Webhook-Event"
+	// HeaderDelivery carries the ID of the delivered event, so receivers can ignore redeliveries.
+	HeaderDelivery = "X-Webhook-Delivery"
+	// HeaderSignature carries the signature of the body, see Signature.
+	HeaderSignature = "X-Webhook-Signature"
+	// HeaderRequestID carries the ID of the request that changed the task, so receivers
+	// can correlate their logs with ours.
+	HeaderRequestID = "X-Request-ID"
+)
+
+// tracerName is the instrumentation scope of the delivery spans.
+const tracerName = "github.com/asp3cto/task-manager/internal/adapters/webhook"
+
+// Defaults of Dispatcher.
+const (
+	defaultWorkers   = 4
+	defaultQueueSize = 1000
+	defaultTimeout   = 10 * time.Second
+	defaultAttempts  = 6
+	defaultRetryBase = time.Second
+	defaultRetryMax  = time.Minute
+)
+
+// maxResponseSize bounds the part of a response body read before the connection is reused.
+const maxResponseSize = 64 << 10
+
+// Dispatcher delivers task events to the webhooks subscribed to their type.
+// Events are queued by Handle and POSTed by a pool of workers, so publishing an
+// event never waits for an endpoint. When the queue is full, events are dropped
+// and logged.
+//
+// A failed delivery is retried with exponential backoff and full jitter. Waiting
+// retries do not hold a worker; they are queued again when their delay is over and
+// see the current URL and secret of the webhook. A delivery whose last attempt fails,
+// or that is still waiting for a retry at Shutdown, is stored as a dead letter.
+//
+// Deliveries only connect to public addresses, unless the network of the address is
+// allowed by WithAllowedNetworks, so webhooks can't be used to reach internal services.
+type Dispatcher struct {
+	webhooks    ports.WebhookRepository
+	deadLetters ports.WebhookDeadLetterRepository
+	ids         ports.IDGenerator
+	client      *http.Client
+	tracer      trace.Tracer
+	workers     int
+	attempts    int
+	base        time.Duration
+	max         time.Duration
+	logger      logger.Logger
+	// allowed are the networks deliveries may reach although they are not public
+	allowed []netip.Prefix
+
+	// mu guards closed and retries against sending to a queue being closed
+	mu     sync.Mutex
+	closed bool
+	queue  chan job
+	// retries are the deliveries waiting for their next attempt
+	retries map[*retry]struct{}
+	// wg tracks the workers and the waiting retries
+	wg sync.WaitGroup
+}
+
+// job is a queued event with the context of the change that published it.
+// A job without a webhook ID is a new event for all subscribed webhooks,
+// otherwise it is a retry of the delivery to that webhook.
+type job struct {
+	ctx       context.Context
+	event     domain.Event
+	webhookID string
+	// attempts is the number of failed deliveries to the webhook so far
+	attempts int
+}
+
+// retry is a failed delivery waiting for its next attempt.
+type retry struct {
+	job   job
+	err   error
+	timer *time.Timer
+}
+
+// Option configures optional Dispatcher behavior.
+type Option func(*Dispatcher)
+
+// WithWorkers sets the number of concurrent deliveries. The default is 4.
+func WithWorkers(workers int) Option {
+	return func(d *Dispatcher) {
+		d.workers = workers
+	}
+}
+
+// WithQueueSize sets the number of events waiting for delivery before further events
+// are dropped. The default is 1000.
+func WithQueueSize(size int) Option {
+	return func(d *Dispatcher) {
+		d.queue = make(chan job, size)
+	}
+}
+
+// WithTimeout bounds the time of a delivery, including reading the response. The default is 10s.
+func WithTimeout(timeout time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.client.Timeout = timeout
+	}
+}
+
+// WithRetries sets the maximum number of delivery attempts per webhook, including
+// the first one. The default is 6.
+func WithRetries(attempts int) Option {
+	return func(d *Dispatcher) {
+		d.attempts = attempts
+	}
+}
+
+// WithRetryBackoff sets the delay before the first retry, which doubles with every
+// further retry up to max. The defaults are 1s and 1m.
+func WithRetryBackoff(base, max time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.base = base
+		d.max = max
+	}
+}
+
+// WithTracerProvider makes every delivery a client span created by a tracer of provider.
+// Without it deliveries still propagate the trace context of the change, but create no spans.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(d *Dispatcher) {
+		d.tracer = provider.Tracer(tracerName)
+	}
+}
+
+// WithAllowedNetworks allows deliveries to addresses in networks, such as internal
+// services on a private network, which are refused otherwise.
+func WithAllowedNetworks(networks ...netip.Prefix) Option {
+	return func(d *Dispatcher) {
+		d.allowed = append(d.allowed, networks...)
+	}
+}
+
+// WithDeadLetters stores the deliveries given up on in deadLetters, with IDs generated
+// by ids. Without it they are only logged.
+func WithDeadLetters(deadLetters ports.WebhookDeadLetterRepository, ids ports.IDGenerator) Option {
+	return func(d *Dispatcher) {
+		d.deadLetters = deadLetters
+		d.ids = ids
+	}
+}
+
+// NewDispatcher creates a dispatcher delivering events to the webhooks in webhooks
+// and starts its workers. Shutdown stops them.
+func NewDispatcher(webhooks ports.WebhookRepository, logger logger.Logger, opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		webhooks: webhooks,
+		client:   &http.Client{Timeout: defaultTimeout},
+		tracer:   noop.NewTracerProvider().Tracer(tracerName),
+		workers:  defaultWorkers,
+		attempts: defaultAttempts,
+		base:     defaultRetryBase,
+		max:      defaultRetryMax,
+		logger:   logger.With(slog.String("component", "webhooks")),
+		queue:    make(chan job, defaultQueueSize),
+		retries:  make(map[*retry]struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	// Proxies are not used, as the dialed address would be the one of the proxy.
+	d.client.Transport = &http.Transport{
+		DialContext:           (&net.Dialer{Timeout: 30 * time.Second, Control: d.control}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+
+	d.wg.Add(d.workers)
+	for range d.workers {
+		go d.work()
+	}
+
+	return d
+}
+
+// Handle queues event for delivery. It has the signature of an events.Handler,
+// so the dispatcher can subscribe to the event bus.
+// The deliveries keep the values of ctx, such as the request ID and the trace context,
+// but not its cancellation.
+func (d *Dispatcher) Handle(ctx context.Context, event domain.Event) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	log := d.logger.With(slog.String("event_id", event.ID), slog.String("event_type", string(event.Type)))
+	if d.closed {
+		log.Warn(ctx, "webhook dispatcher stopped, event dropped")
+		return
+	}
+
+	select {
+	case d.queue <- job{ctx: context.WithoutCancel(ctx), event: event}:
+	default:
+		log.Error(ctx, "webhook queue full, event dropped")
+	}
+}
+
+// Shutdown stops accepting events and waits until the queued events are delivered
+// or ctx is done, whichever comes first. Deliveries waiting for a retry, and those
+// failing from now on, are stored as dead letters instead of being retried.
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.queue)
+	}
+
+	var stopped []*retry
+	for r := range d.retries {
+		// A timer that already fired is handled by its retry, which sees closed.
+		if r.timer.Stop() {
+			delete(d.retries, r)
+			stopped = append(stopped, r)
+		}
+	}
+	d.mu.Unlock()
+
+	for _, r := range stopped {
+		d.deadLetter(r.job, r.err)
+		d.wg.Done()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// work delivers queued events until the queue is closed.
+func (d *Dispatcher) work() {
+	defer d.wg.Done()
+
+	for j := range d.queue {
+		if j.webhookID == "" {
+			d.dispatch(j)
+		} else {
+			d.redeliver(j)
+		}
+	}
+}
+
+// dispatch delivers the event of j to every webhook subscribed to its type.
+func (d *Dispatcher) dispatch(j job) {
+	ctx, event := j.ctx, j.event
+	log := d.logger.With(slog.String("event_id", event.ID), slog.String("event_type", string(event.Type)))
+
+	webhooks, err := d.webhooks.GetAll(ctx)
+	if err != nil {
+		log.Error(ctx, "failed to get webhooks, event dropped", slog.String("error", err.Error()))
+		return
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Error(ctx, "failed to encode event, event dropped", slog.String("error", err.Error()))
+		return
+	}
+
+	for _, webhook := range webhooks {
+		if webhook.Subscribes(event.Type) {
+			d.attempt(job{ctx: ctx, event: event, webhookID: webhook.ID}, webhook, body)
+		}
+	}
+}
+
+// redeliver retries the delivery of j to its webhook, unless the webhook was deleted since.
+func (d *Dispatcher) redeliver(j job) {
+	webhook, err := d.webhooks.GetByID(j.ctx, j.webhookID)
+	if errors.Is(err, domain.ErrWebhookNotFound) {
+		d.logger.Debug(
+			j.ctx,
+			"webhook deleted, retry dropped",
+			slog.String("event_id", j.event.ID), slog.String("webhook_id", j.webhookID),
+		)
+		return
+	}
+	if err != nil {
+		// The failure counts as an attempt, so an unavailable repository does not keep the delivery forever.
+		j.attempts++
+		d.fail(j, fmt.Errorf("failed to get webhook: %w", err))
+		return
+	}
+
+	body, err := json.Marshal(j.event)
+	if err != nil {
+		d.logger.Error(
+			j.ctx,
+			"failed to encode event, retry dropped",
+			slog.String("event_id", j.event.ID), slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	d.attempt(j, webhook, body)
+}
+
+// attempt makes the next delivery of j to webhook, scheduling a retry if it fails.
+func (d *Dispatcher) attempt(j job, webhook *domain.Webhook, body []byte) {
+	j.attempts++
+	if err := d.deliver(j.ctx, webhook, j.event, body); err != nil {
+		d.fail(j, err)
+		return
+	}
+
+	d.logger.Debug(
+		j.ctx,
+		"webhook delivered",
+		slog.String("event_id", j.event.ID), slog.String("webhook_id", webhook.ID), slog.Int("attempts", j.attempts),
+	)
+}
+
+// fail handles the failed delivery of j with err: it schedules a retry after the
+// backoff delay, or stores a dead letter once the attempts are used up or the
+// dispatcher is stopped.
+func (d *Dispatcher) fail(j job, err error) {
+	log := d.logger.With(
+		slog.String("event_id", j.event.ID),
+		slog.String("webhook_id", j.webhookID),
+		slog.Int("attempts", j.attempts),
+	)
+
+	if j.attempts >= d.attempts {
+		d.deadLetter(j, err)
+		return
+	}
+
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		d.deadLetter(j, err)
+		return
+	}
+
+	delay := d.backoff(j.attempts)
+	r := &retry{job: j, err: err}
+	d.wg.Add(1)
+	d.retries[r] = struct{}{}
+	// The timer cannot run retry before it is registered, as retry waits for mu.
+	r.timer = time.AfterFunc(delay, func() { d.retry(r) })
+	d.mu.Unlock()
+
+	log.Warn(
+		j.ctx,
+		"webhook delivery failed, will retry",
+		slog.String("error", err.Error()), slog.Duration("delay", delay),
+	)
+}
+
+// retry queues the waiting delivery r once its delay is over.
+func (d *Dispatcher) retry(r *retry) {
+	defer d.wg.Done()
+
+	d.mu.Lock()
+	delete(d.retries, r)
+	queued := false
+	if !d.closed {
+		select {
+		case d.queue <- r.job:
+			queued = true
+		default:
+			d.logger.Error(
+				r.job.ctx,
+				"webhook queue full, retry not queued",
+				slog.String("event_id", r.job.event.ID), slog.String("webhook_id", r.job.webhookID),
+			)
+		}
+	}
+	d.mu.Unlock()
+
+	if !queued {
+		d.deadLetter(r.job, r.err)
+	}
+}
+
+// backoff returns the delay before the retry following the given number of failed
+// attempts: a random duration up to base doubled for every attempt after the first, capped at max.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.base
+	for i := 1; i < attempts && delay < d.max; i++ {
+		delay *= 2
+	}
+
+	return rand.N(min(delay, d.max) + 1)
+}
+
+// deadLetter stores the delivery of j, given up on after failing with err.
+func (d *Dispatcher) deadLetter(j job, err error) {
+	log := d.logger.With(
+		slog.String("event_id", j.event.ID),
+		slog.String("webhook_id", j.webhookID),
+		slog.Int("attempts", j.attempts),
+		slog.String("error", err.Error()),
+	)
+
+	if d.deadLetters == nil {
+		log.Error(j.ctx, "webhook delivery given up")
+		return
+	}
+
+	id, idErr := d.ids.NewID()
+	if idErr != nil {
+		log.Error(j.ctx, "webhook delivery given up, failed to generate dead letter ID", slog.String("id_error", idErr.Error()))
+		return
+	}
+
+	letter := &domain.WebhookDeadLetter{
+		ID:        id,
+		WebhookID: j.webhookID,
+		Event:     j.event,
+		Attempts:  j.attempts,
+		LastError: err.Error(),
+		FailedAt:  time.Now(),
+	}
+	if storeErr := d.deadLetters.Create(j.ctx, letter); storeErr != nil {
+		log.Error(j.ctx, "webhook delivery given up, failed to store dead letter", slog.String("store_error", storeErr.Error()))
+		return
+	}
+
+	log.Warn(j.ctx, "webhook delivery given up, dead letter stored", slog.String("dead_letter_id", id))
+}
+
+// Deliver makes a single attempt to deliver event to webhook, e.g. to replay a dead letter.
+// It does not retry a failed delivery.
+func (d *Dispatcher) Deliver(ctx context.Context, webhook *domain.Webhook, event domain.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	return d.deliver(ctx, webhook, event, body)
+}
+
+// deliver POSTs body, the encoded event, to the URL of webhook within a client span.
+// The request carries the request ID and the W3C trace context of ctx.
+// Returns an error if the request fails or the response status is not 2xx.
+func (d *Dispatcher) deliver(ctx context.Context, webhook *domain.Webhook, event domain.Event, body []byte) error {
+	ctx, span := d.tracer.Start(
+		ctx, "webhook.deliver",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("webhook_id", webhook.ID),
+			attribute.String("event_id", event.ID),
+			attribute.String("event_type", string(event.Type)),
+		),
+	)
+	defer span.End()
+
+	err := d.post(ctx, span, webhook, event, body)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	return err
+}
+
+// post sends the delivery request of deliver and records the response status on span.
+func (d *Dispatcher) post(
+	ctx context.Context, span trace.Span, webhook *domain.Webhook, event domain.Event, body []byte,
+) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "task-manager-webhooks")
+	req.Header.Set(HeaderEvent, string(event.Type))
+	req.Header.Set(HeaderDelivery, event.ID)
+	req.Header.Set(HeaderSignature, Signature(webhook.Secret, body))
+	if requestID, ok := logger.RequestIDFromContext(ctx); ok {
+		req.Header.Set(HeaderRequestID, requestID)
+	}
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseSize))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Signature returns the value of the HeaderSignature of a delivery of body signed
+// with secret: sha256= followed by the hex-encoded HMAC-SHA256 of the body.
+// Receivers compute it from the raw body and compare it in constant time.
+func Signature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
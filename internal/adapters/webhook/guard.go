@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"syscall"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+)
+
+// blockedNetworks are the networks outside the public internet that the address
+// checks of net/netip do not cover.
+var blockedNetworks = []netip.Prefix{
+	// "This network", only valid as a source address
+	netip.MustParsePrefix("0.0.0.0/8"),
+	// Shared address space of carrier-grade NAT
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// allowedAddr reports whether deliveries may reach ip: it must be a public address,
+// or belong to one of the networks allowed by WithAllowedNetworks.
+func (d *Dispatcher) allowedAddr(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	for _, network := range d.allowed {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsMulticast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CheckURL returns domain.ErrWebhookURLNotAllowed if the host of rawURL is, or resolves
+// to, an address deliveries may not reach, such as a loopback, private or link-local one.
+// It gives early feedback when a webhook is saved; every connection is checked again
+// when it is dialed, so a host resolving to another address later is still refused.
+func (d *Dispatcher) CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return domain.ErrInvalidWebhookURL
+	}
+
+	host := u.Hostname()
+	if ip, err := netip.ParseAddr(host); err == nil {
+		if !d.allowedAddr(ip) {
+			return fmt.Errorf("%w: %s", domain.ErrWebhookURLNotAllowed, host)
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("%w: failed to resolve %s", domain.ErrInvalidWebhookURL, host)
+	}
+
+	for _, ip := range addrs {
+		if !d.allowedAddr(ip) {
+			return fmt.Errorf("%w: %s resolves to %s", domain.ErrWebhookURLNotAllowed, host, ip.Unmap())
+		}
+	}
+
+	return nil
+}
+
+// control refuses connections to addresses deliveries may not reach. It runs after the
+// host name is resolved, right before connecting, so it also covers redirects and DNS
+// answers that change after the webhook was saved.
+func (d *Dispatcher) control(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+
+	if !d.allowedAddr(ip) {
+		return fmt.Errorf("%w: %s", domain.ErrWebhookURLNotAllowed, ip.Unmap())
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package auth
+
+import "context"
+
+// actorKey is the context key of the actor making a request.
+type actorKey struct{}
+
+// ActorAdmin identifies requests made with the admin token.
+const ActorAdmin = "admin"
+
+// TokenActor returns the actor identifying requests made with the token of the given claims.
+func TokenActor(claims Claims) string {
+	return "token:" + claims.ID
+}
+
+// WithActor returns a copy of ctx carrying the identity of who makes the request.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored in ctx, or an empty string if the actor is unknown.
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+const defaultMaxTTL = 24 * time.Hour
+
+// NewIssuerFromEnv creates an Issuer configured from environment variables.
+//
+// Environment variables used:
+//   - AUTH_TOKEN_SECRET: HMAC secret for signing tokens (token auth disabled if empty)
+//   - AUTH_TOKEN_MAX_TTL: Maximum lifetime of minted tokens (default: 24h)
+//
+// Returns nil if AUTH_TOKEN_SECRET is not set.
+func NewIssuerFromEnv() (*Issuer, error) {
+	secret := os.Getenv("AUTH_TOKEN_SECRET")
+	if secret == "" {
+		return nil, nil
+	}
+
+	maxTTL := defaultMaxTTL
+	if raw := os.Getenv("AUTH_TOKEN_MAX_TTL"); raw != "" {
+		value, err := time.ParseDuration(raw)
+		if err != nil || value <= 0 {
+			return nil, fmt.Errorf("AUTH_TOKEN_MAX_TTL must be a positive duration, got: %s", raw)
+		}
+		maxTTL = value
+	}
+
+	return NewIssuer([]byte(secret), maxTTL), nil
+}
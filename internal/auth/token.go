@@ -0,0 +1,212 @@
+// Package auth implements scoped, short-lived access tokens.
+// Tokens are self-contained HMAC-SHA256 signed claims, so they can be validated
+// without any server-side storage.
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Token errors returned by the Issuer.
+var (
+	// ErrInvalidToken is returned when a token is malformed or its signature doesn't match.
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrTokenExpired is returned when a token's expiration time has passed.
+	ErrTokenExpired = errors.New("token expired")
+	// ErrInvalidScope is returned when minting a token with an unknown scope.
+	ErrInvalidScope = errors.New("invalid scope")
+	// ErrInvalidTTL is returned when the requested lifetime is not positive or exceeds the maximum.
+	ErrInvalidTTL = errors.New("invalid token lifetime")
+	// ErrInvalidResource is returned when minting a token restricted to an empty task or project ID.
+	ErrInvalidResource = errors.New("invalid resource")
+)
+
+// Scope grants access to a class of operations.
+type Scope string
+
+// Supported token scopes.
+const (
+	// ScopeTasksRead allows reading tasks.
+	ScopeTasksRead Scope = "tasks:read"
+	// ScopeTasksWrite allows creating, modifying and deleting tasks.
+	ScopeTasksWrite Scope = "tasks:write"
+	// ScopeWebhooks allows managing webhooks. It is separate from the task scopes, as a
+	// webhook receives every task event.
+	ScopeWebhooks Scope = "webhooks:manage"
+)
+
+// IsValidScope checks if the provided scope is one of the defined constants.
+func IsValidScope(scope Scope) bool {
+	switch scope {
+	case ScopeTasksRead, ScopeTasksWrite, ScopeWebhooks:
+		return true
+	default:
+		return false
+	}
+}
+
+// ProjectResourcePrefix marks a token resource as a project ID, as in "project:<id>";
+// other resources are task IDs.
+const ProjectResourcePrefix = "project:"
+
+// Resource identifies what a request operates on, to be matched against the resources
+// a token is restricted to.
+type Resource struct {
+	// TaskID is the task the request operates on, empty if it is not on a single task
+	TaskID string
+	// ProjectID is the project the request, or the task it operates on, belongs to; empty if none
+	ProjectID string
+}
+
+// Claims describes what a token grants and for how long.
+type Claims struct {
+	// ID is a random identifier of the token
+	ID string `json:"jti"`
+	// Scopes lists the operations the token allows
+	Scopes []Scope `json:"scopes"`
+	// Resources restricts the token to specific task IDs and, prefixed by ProjectResourcePrefix,
+	// project IDs; empty means all tasks
+	Resources []string `json:"resources,omitempty"`
+	// IssuedAt is when the token was minted
+	IssuedAt time.Time `json:"iat"`
+	// ExpiresAt is when the token stops being valid
+	ExpiresAt time.Time `json:"exp"`
+}
+
+// Allows reports whether the claims grant scope on the given resource.
+// A token restricted to specific resources allows a task if it lists the task or its project,
+// and a project if it lists the project. An empty resource denotes an operation that is not
+// on a single task or project, such as listing tasks, which is only allowed for tokens that
+// are not restricted to specific resources.
+func (c Claims) Allows(scope Scope, resource Resource) bool {
+	if !slices.Contains(c.Scopes, scope) {
+		return false
+	}
+
+	if len(c.Resources) == 0 {
+		return true
+	}
+
+	if resource.TaskID != "" && slices.Contains(c.Resources, resource.TaskID) {
+		return true
+	}
+
+	return resource.ProjectID != "" && slices.Contains(c.Resources, ProjectResourcePrefix+resource.ProjectID)
+}
+
+// HasProjectResources reports whether the token is restricted to at least one project,
+// so the project of a task is needed to authorize access to the task.
+func (c Claims) HasProjectResources() bool {
+	return slices.ContainsFunc(c.Resources, func(resource string) bool {
+		return strings.HasPrefix(resource, ProjectResourcePrefix)
+	})
+}
+
+// Issuer mints and verifies signed access tokens.
+type Issuer struct {
+	secret []byte
+	maxTTL time.Duration
+	now    func() time.Time
+}
+
+// NewIssuer creates an issuer signing tokens with secret.
+// Tokens can't be minted with a lifetime longer than maxTTL.
+func NewIssuer(secret []byte, maxTTL time.Duration) *Issuer {
+	return &Issuer{
+		secret: secret,
+		maxTTL: maxTTL,
+		now:    time.Now,
+	}
+}
+
+// Mint creates a token granting scopes on resources for ttl.
+// resources lists task IDs and project IDs prefixed by ProjectResourcePrefix.
+// Returns the encoded token together with its claims.
+func (i *Issuer) Mint(scopes []Scope, resources []string, ttl time.Duration) (string, Claims, error) {
+	if len(scopes) == 0 {
+		return "", Claims{}, ErrInvalidScope
+	}
+
+	for _, scope := range scopes {
+		if !IsValidScope(scope) {
+			return "", Claims{}, fmt.Errorf("%w: %s", ErrInvalidScope, scope)
+		}
+	}
+
+	for _, resource := range resources {
+		if resource == "" || resource == ProjectResourcePrefix {
+			return "", Claims{}, fmt.Errorf("%w: %q", ErrInvalidResource, resource)
+		}
+	}
+
+	if ttl <= 0 || ttl > i.maxTTL {
+		return "", Claims{}, fmt.Errorf("%w: must be between 0 and %s", ErrInvalidTTL, i.maxTTL)
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", Claims{}, fmt.Errorf("failed to generate token ID: %w", err)
+	}
+
+	now := i.now().UTC().Truncate(time.Second)
+	claims := Claims{
+		ID:        fmt.Sprintf("%x", id),
+		Scopes:    scopes,
+		Resources: resources,
+		IssuedAt:  now,
+		ExpiresAt: now.Add(ttl),
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", Claims{}, fmt.Errorf("failed to encode claims: %w", err)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(i.sign(encoded)), claims, nil
+}
+
+// Verify checks the token signature and expiration and returns its claims.
+func (i *Issuer) Verify(token string) (Claims, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return Claims{}, ErrInvalidToken
+	}
+
+	provided, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(provided, i.sign(encoded)) {
+		return Claims{}, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return Claims{}, ErrInvalidToken
+	}
+
+	if !i.now().Before(claims.ExpiresAt) {
+		return Claims{}, ErrTokenExpired
+	}
+
+	return claims, nil
+}
+
+// sign computes the HMAC-SHA256 signature of the encoded payload.
+func (i *Issuer) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, i.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
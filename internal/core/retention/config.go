@@ -0,0 +1,98 @@
+package retention
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+)
+
+const defaultInterval = time.Hour
+
+// RulesFromEnv reads retention rules from the RETENTION_RULES environment variable.
+//
+// The variable holds a comma-separated list of rules in the form
+// "status:max_age:action", for example:
+//
+//	RETENTION_RULES=cancelled:180d:purge,completed:730d:archive
+//
+// max_age accepts Go durations (e.g. "72h") and whole days with a "d" suffix,
+// status must be one of the statuses of workflow.
+// Returns nil if the variable is not set, which disables the engine.
+func RulesFromEnv(workflow *domain.Workflow) ([]Rule, error) {
+	raw := os.Getenv("RETENTION_RULES")
+	if raw == "" {
+		return nil, nil
+	}
+
+	var rules []Rule
+	for _, part := range strings.Split(raw, ",") {
+		rule, err := parseRule(strings.TrimSpace(part), workflow)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// IntervalFromEnv reads the RETENTION_INTERVAL environment variable.
+// Returns one hour if the variable is not set.
+func IntervalFromEnv() (time.Duration, error) {
+	raw := os.Getenv("RETENTION_INTERVAL")
+	if raw == "" {
+		return defaultInterval, nil
+	}
+
+	interval, err := parseAge(raw)
+	if err != nil || interval <= 0 {
+		return 0, fmt.Errorf("RETENTION_INTERVAL must be a positive duration, got: %s", raw)
+	}
+
+	return interval, nil
+}
+
+// parseRule parses a single "status:max_age:action" rule.
+func parseRule(raw string, workflow *domain.Workflow) (Rule, error) {
+	fields := strings.Split(raw, ":")
+	if len(fields) != 3 {
+		return Rule{}, fmt.Errorf("invalid retention rule %q: expected status:max_age:action", raw)
+	}
+
+	if !workflow.IsValid(domain.TaskStatus(fields[0])) {
+		return Rule{}, fmt.Errorf("invalid retention rule %q: unknown status %q", raw, fields[0])
+	}
+
+	maxAge, err := parseAge(fields[1])
+	if err != nil || maxAge <= 0 {
+		return Rule{}, fmt.Errorf("invalid retention rule %q: bad max age %q", raw, fields[1])
+	}
+
+	action := Action(fields[2])
+	if action != ActionPurge && action != ActionAnonymize && action != ActionArchive {
+		return Rule{}, fmt.Errorf("invalid retention rule %q: unknown action %q", raw, fields[2])
+	}
+
+	return Rule{
+		Status: domain.TaskStatus(fields[0]),
+		MaxAge: maxAge,
+		Action: action,
+	}, nil
+}
+
+// parseAge parses a Go duration, additionally accepting whole days ("180d").
+func parseAge(raw string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(raw, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	return time.ParseDuration(raw)
+}
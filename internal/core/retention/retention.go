@@ -0,0 +1,362 @@
+// Package retention implements the data retention policy engine.
+// It periodically evaluates configured rules against stored tasks and purges,
+// archives or anonymizes the ones that have outlived their retention period.
+package retention
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/auth"
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// Action describes what happens to a task once its retention period expires.
+type Action string
+
+// Retention actions supported by the engine.
+const (
+	// ActionPurge permanently removes the task from the repository.
+	ActionPurge Action = "purge"
+	// ActionAnonymize keeps the task but erases its user-provided content, comments,
+	// attachments and history, see ports.TaskService.AnonymizeTask.
+	ActionAnonymize Action = "anonymize"
+	// ActionArchive stores the task as JSON in the archive blob store and then removes it
+	// from the repository.
+	ActionArchive Action = "archive"
+)
+
+// actor identifies the engine as the one changing tasks, in the log and history of the task service.
+const actor = "retention"
+
+// archivePrefix is the prefix of the blob keys of archived tasks; the key ends with the task ID.
+const archivePrefix = "retention-archive/"
+
+// Rule defines a single retention rule.
+// A task matches the rule when it has the given status and was last updated
+// more than MaxAge ago.
+type Rule struct {
+	// Status is the task status the rule applies to
+	Status domain.TaskStatus
+	// MaxAge is how long a task may stay in Status before the rule fires
+	MaxAge time.Duration
+	// Action is applied to every matching task
+	Action Action
+}
+
+// String returns the rule in its configuration form, e.g. "cancelled:4320h0m0s:purge".
+func (r Rule) String() string {
+	return fmt.Sprintf("%s:%s:%s", r.Status, r.MaxAge, r.Action)
+}
+
+// Result summarizes a single evaluation pass of the engine.
+type Result struct {
+	// Purged is the number of tasks removed from the repository
+	Purged int
+	// Anonymized is the number of tasks whose content was erased
+	Anonymized int
+	// Archived is the number of tasks moved to the archive
+	Archived int
+}
+
+// Encrypter encrypts the sensitive fields of archived tasks, e.g. the keyring of the
+// encrypted task repository.
+type Encrypter interface {
+	// Encrypt returns the encrypted form of plaintext, a value of the task with the ID owner.
+	Encrypt(plaintext, owner string) (string, error)
+}
+
+// Engine evaluates retention rules against the task repository.
+// Tasks are deleted and anonymized through the task service, so their comments,
+// history and attachments go with them, deletions are published as events and
+// the read cache forgets the changed tasks.
+type Engine struct {
+	repo     ports.TaskRepository
+	tasks    ports.TaskService
+	logger   logger.Logger
+	rules    []Rule
+	interval time.Duration
+	now      func() time.Time
+	archive  ports.BlobStore
+	// encrypter encrypts the descriptions of archived tasks, nil if they are archived as is
+	encrypter Encrypter
+}
+
+// Option configures optional Engine behavior.
+type Option func(*Engine)
+
+// WithArchive stores the tasks of archive rules in archive, see ActionArchive.
+// Without it archive rules fail.
+func WithArchive(archive ports.BlobStore) Option {
+	return func(e *Engine) {
+		e.archive = archive
+	}
+}
+
+// WithArchiveEncryption encrypts the description of archived tasks with encrypter,
+// so archives are protected like the tasks in the repository. It must be set when the
+// repository encrypts tasks, as the engine sees them decrypted.
+func WithArchiveEncryption(encrypter Encrypter) Option {
+	return func(e *Engine) {
+		e.encrypter = encrypter
+	}
+}
+
+// WithClock evaluates the age of tasks against the time of clock instead of the system clock.
+func WithClock(clock ports.Clock) Option {
+	return func(e *Engine) {
+		e.now = clock.Now
+	}
+}
+
+// NewEngine creates a retention engine applying rules every interval to the tasks
+// of repo. Tasks are deleted and anonymized through tasks.
+func NewEngine(
+	repo ports.TaskRepository, tasks ports.TaskService, logger logger.Logger, rules []Rule,
+	interval time.Duration, opts ...Option,
+) *Engine {
+	e := &Engine{
+		repo:     repo,
+		tasks:    tasks,
+		logger:   logger.With(slog.String("component", "retention")),
+		rules:    rules,
+		interval: interval,
+		now:      time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Run evaluates the rules as a dry run immediately and then applies them on every tick
+// of the configured interval, so the tasks the rules are about to change are logged one
+// interval before the first destructive run. It blocks until the context is cancelled.
+func (e *Engine) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	if _, err := e.DryRun(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		e.logger.Error(ctx, "retention dry run failed", slog.String("error", err.Error()))
+	}
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		if _, err := e.Apply(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			e.logger.Error(ctx, "retention run failed", slog.String("error", err.Error()))
+		}
+	}
+}
+
+// Apply performs a single evaluation pass over all rules.
+// Every purge, archival and anonymization is recorded as an audit log entry.
+// Tasks that are gone or changed by the time a rule is applied are skipped and not counted.
+func (e *Engine) Apply(ctx context.Context) (Result, error) {
+	return e.evaluate(ctx, false)
+}
+
+// DryRun performs a single evaluation pass over all rules without changing any task.
+// Every task a rule would change is logged; the result counts them.
+func (e *Engine) DryRun(ctx context.Context) (Result, error) {
+	return e.evaluate(ctx, true)
+}
+
+// evaluate performs an evaluation pass over all rules, applying them unless dryRun is set.
+func (e *Engine) evaluate(ctx context.Context, dryRun bool) (Result, error) {
+	var result Result
+
+	ctx = auth.WithActor(ctx, actor)
+	now := e.now()
+	for _, rule := range e.rules {
+		page, err := e.repo.GetAll(ctx, ports.ListQuery{
+			Filter: ports.ListFilter{Statuses: []domain.TaskStatus{rule.Status}},
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to get tasks for rule %s: %w", rule, err)
+		}
+
+		for _, task := range page.Tasks {
+			if now.Sub(task.UpdatedAt) < rule.MaxAge {
+				continue
+			}
+
+			if rule.Action == ActionAnonymize && task.IsAnonymized() {
+				continue
+			}
+
+			if dryRun {
+				e.logger.Info(
+					ctx,
+					"retention dry run: task would be changed",
+					slog.String("task_id", task.ID),
+					slog.String("status", string(task.Status)),
+					slog.String("rule", rule.String()),
+				)
+			} else {
+				applied, err := e.apply(ctx, task, rule)
+				if err != nil {
+					return result, err
+				}
+				if !applied {
+					continue
+				}
+			}
+
+			switch rule.Action {
+			case ActionPurge:
+				result.Purged++
+			case ActionArchive:
+				result.Archived++
+			case ActionAnonymize:
+				result.Anonymized++
+			}
+		}
+	}
+
+	if dryRun {
+		e.logger.Info(
+			ctx,
+			"retention dry run finished",
+			slog.Int("purged", result.Purged), slog.Int("archived", result.Archived),
+			slog.Int("anonymized", result.Anonymized), slog.String("first_run_in", e.interval.String()),
+		)
+		return result, nil
+	}
+
+	e.logger.Debug(
+		ctx,
+		"retention run finished",
+		slog.Int("purged", result.Purged), slog.Int("archived", result.Archived),
+		slog.Int("anonymized", result.Anonymized),
+	)
+
+	return result, nil
+}
+
+// apply applies the action of rule to the task.
+// Returns false if the task was deleted or changed since it was listed, so it was left alone.
+func (e *Engine) apply(ctx context.Context, task *domain.Task, rule Rule) (bool, error) {
+	switch rule.Action {
+	case ActionArchive:
+		if err := e.store(ctx, task); err != nil {
+			return false, err
+		}
+		return e.purge(ctx, task, rule)
+	case ActionAnonymize:
+		return e.anonymize(ctx, task, rule)
+	default:
+		return e.purge(ctx, task, rule)
+	}
+}
+
+// store writes the task as JSON to the archive blob store under archivePrefix and its ID.
+// The description is encrypted if archive encryption is enabled.
+func (e *Engine) store(ctx context.Context, task *domain.Task) error {
+	if e.archive == nil {
+		return fmt.Errorf("failed to archive task %s: no archive store configured", task.ID)
+	}
+
+	archived := *task
+	if e.encrypter != nil {
+		description, err := e.encrypter.Encrypt(task.Description, task.ID)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt task %s: %w", task.ID, err)
+		}
+		archived.Description = description
+	}
+
+	data, err := json.Marshal(&archived)
+	if err != nil {
+		return fmt.Errorf("failed to encode task %s: %w", task.ID, err)
+	}
+
+	err = e.archive.Put(ctx, archivePrefix+task.ID, bytes.NewReader(data), int64(len(data)), "application/json")
+	if err != nil {
+		return fmt.Errorf("failed to archive task %s: %w", task.ID, err)
+	}
+
+	return nil
+}
+
+// purge deletes the task through the task service and records an audit entry,
+// naming the action of rule. The task is deleted even if it is in progress, but
+// only in the version the rule was evaluated against.
+func (e *Engine) purge(ctx context.Context, task *domain.Task, rule Rule) (bool, error) {
+	err := e.tasks.DeleteTask(ctx, task.ID, true, task.Version)
+	if errors.Is(err, domain.ErrTaskNotFound) {
+		e.logger.Debug(ctx, "retention: task already deleted, skipping", slog.String("task_id", task.ID))
+		return false, nil
+	}
+
+	if errors.Is(err, domain.ErrVersionConflict) {
+		// The task changed since it was listed; it is re-evaluated on the next run.
+		e.logger.Debug(ctx, "retention: task modified concurrently, skipping", slog.String("task_id", task.ID))
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to purge task %s: %w", task.ID, err)
+	}
+
+	message := "retention: task purged"
+	if rule.Action == ActionArchive {
+		message = "retention: task archived"
+	}
+
+	e.logger.Info(
+		ctx,
+		message,
+		slog.Bool("audit", true),
+		slog.String("task_id", task.ID),
+		slog.String("status", string(task.Status)),
+		slog.String("rule", rule.String()),
+	)
+
+	return true, nil
+}
+
+// anonymize erases the content of the task through the task service, keeping its lifecycle
+// data, but only in the version the rule was evaluated against.
+// UpdatedAt is left untouched so the task keeps aging under other rules.
+func (e *Engine) anonymize(ctx context.Context, task *domain.Task, rule Rule) (bool, error) {
+	_, err := e.tasks.AnonymizeTask(ctx, task.ID, task.Version)
+	if errors.Is(err, domain.ErrTaskNotFound) {
+		e.logger.Debug(ctx, "retention: task already deleted, skipping", slog.String("task_id", task.ID))
+		return false, nil
+	}
+
+	if errors.Is(err, domain.ErrVersionConflict) {
+		// The task changed since it was listed; it is re-evaluated on the next run.
+		e.logger.Debug(ctx, "retention: task modified concurrently, skipping", slog.String("task_id", task.ID))
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to anonymize task %s: %w", task.ID, err)
+	}
+
+	e.logger.Info(
+		ctx,
+		"retention: task anonymized",
+		slog.Bool("audit", true),
+		slog.String("task_id", task.ID),
+		slog.String("status", string(task.Status)),
+		slog.String("rule", rule.String()),
+	)
+
+	return true, nil
+}
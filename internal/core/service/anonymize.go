@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/asp3cto/task-manager/internal/auth"
+	"github.com/asp3cto/task-manager/internal/domain"
+)
+
+// AnonymizeTask erases the user-provided content of an existing task, see domain.Task.Anonymize,
+// and returns the anonymized task. The comments of the task and the content of its attachments
+// are deleted, and its history, which holds the erased values, is replaced by a single entry
+// recording the anonymization.
+// A non-zero version must match the current task version.
+// Returns domain.ErrVersionConflict if the task was modified since that version.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (s *TaskService) AnonymizeTask(ctx context.Context, id string, version int64) (*domain.Task, error) {
+	log := s.logger.With(slog.String("task_id", id))
+	log.Debug(ctx, "anonymizing task")
+
+	defer s.cache.evict(id)
+
+	// The history is not passed on, as the changes would record the erased values.
+	var attachments []domain.Attachment
+	task, err := modifyTask(ctx, s.repo, nil, log, id, version, func(task *domain.Task) error {
+		attachments = task.Attachments
+		task.Anonymize()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(ctx, "task anonymized successfully", slog.String("actor", auth.ActorFromContext(ctx)))
+
+	// The task itself is anonymized, so leftovers are only logged, like in DeleteTask.
+	if s.comments != nil {
+		if err := s.comments.DeleteByTask(ctx, id); err != nil {
+			log.Error(ctx, "failed to delete comments of task", slog.String("error", err.Error()))
+		}
+	}
+
+	if s.history != nil {
+		if err := s.history.DeleteByTask(ctx, id); err != nil {
+			log.Error(ctx, "failed to delete history of task", slog.String("error", err.Error()))
+		}
+	}
+	recordHistory(ctx, s.history, log, []domain.HistoryEntry{
+		domain.AnonymizationEntry(task, auth.ActorFromContext(ctx), s.clock.Now()),
+	})
+
+	if s.blobs != nil {
+		for _, attachment := range attachments {
+			if err := s.blobs.Delete(ctx, attachment.BlobKey(id)); err != nil {
+				log.Error(
+					ctx,
+					"failed to delete attachment content of task",
+					slog.String("attachment_id", attachment.ID),
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+	}
+
+	return task, nil
+}
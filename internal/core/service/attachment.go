@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var _ ports.AttachmentService = (*AttachmentService)(nil)
+
+// AttachmentService implements the files attached to tasks.
+// The attachment metadata is kept in the task, the content in a blob store under
+// the key given by domain.Attachment.BlobKey.
+type AttachmentService struct {
+	repo    ports.TaskRepository
+	blobs   ports.BlobStore
+	history ports.TaskHistoryRepository
+	ids     ports.IDGenerator
+	clock   ports.Clock
+	logger  logger.Logger
+}
+
+// NewAttachmentService creates a new instance of AttachmentService keeping the tasks in repo
+// and the attachment content in blobs. Attached and removed files are recorded in history
+// unless it is nil. Attachment IDs are generated with ids and timestamps taken from clock.
+func NewAttachmentService(
+	repo ports.TaskRepository, blobs ports.BlobStore, history ports.TaskHistoryRepository,
+	ids ports.IDGenerator, clock ports.Clock, logger logger.Logger,
+) *AttachmentService {
+	return &AttachmentService{
+		repo:    repo,
+		blobs:   blobs,
+		history: history,
+		ids:     ids,
+		clock:   clock,
+		logger:  logger.With(slog.String("component", "service")),
+	}
+}
+
+// AddAttachment stores the content in the blob store and records it in the task.
+// The task is checked before the upload so rejected files are not stored, and the
+// stored content is removed again if the task cannot be updated afterwards.
+// Returns domain.ErrTooManyAttachments if the task already has domain.MaxAttachments files.
+// Returns domain.ErrVersionConflict if the task was modified since that version.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (s *AttachmentService) AddAttachment(
+	ctx context.Context, taskID, filename, contentType string, content io.ReadSeeker, size, version int64,
+) (*domain.Task, domain.Attachment, error) {
+	log := s.logger.With(slog.String("task_id", taskID))
+	log.Debug(ctx, "adding attachment", slog.String("filename", filename), slog.Int64("size", size))
+
+	id, err := s.ids.NewID()
+	if err != nil {
+		log.Error(ctx, "failed to generate ID", slog.String("error", err.Error()))
+		return nil, domain.Attachment{}, fmt.Errorf("failed to generate ID: %w", err)
+	}
+
+	attachment := domain.Attachment{
+		ID:          id,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        size,
+		CreatedAt:   s.clock.Now(),
+	}
+
+	if err := s.checkAttachable(ctx, log, taskID, version); err != nil {
+		return nil, domain.Attachment{}, err
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		log.Error(ctx, "failed to read attachment content", slog.String("error", err.Error()))
+		return nil, domain.Attachment{}, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	attachment.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		log.Error(ctx, "failed to rewind attachment content", slog.String("error", err.Error()))
+		return nil, domain.Attachment{}, fmt.Errorf("failed to read attachment: %w", err)
+	}
+
+	key := attachment.BlobKey(taskID)
+	if err := s.blobs.Put(ctx, key, content, size, contentType); err != nil {
+		log.Error(ctx, "failed to store attachment content", slog.String("error", err.Error()))
+		return nil, domain.Attachment{}, fmt.Errorf("failed to store attachment: %w", err)
+	}
+
+	task, err := modifyTask(ctx, s.repo, s.history, log, taskID, version, func(task *domain.Task) error {
+		return task.Attach(attachment, s.clock.Now())
+	})
+	if err != nil {
+		s.deleteBlob(ctx, log, key)
+		return nil, domain.Attachment{}, err
+	}
+
+	log.Info(ctx, "attachment added successfully", slog.String("attachment_id", attachment.ID))
+	return task, attachment, nil
+}
+
+// OpenAttachment returns an attachment of a task and opens its content.
+// Returns domain.ErrAttachmentNotFound if the task has no such attachment or its content is missing.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (s *AttachmentService) OpenAttachment(
+	ctx context.Context, taskID, id string,
+) (domain.Attachment, io.ReadCloser, error) {
+	log := s.logger.With(slog.String("task_id", taskID), slog.String("attachment_id", id))
+	log.Debug(ctx, "opening attachment")
+
+	task, err := s.repo.GetByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Debug(ctx, "task not found")
+			return domain.Attachment{}, nil, err
+		}
+
+		log.Error(
+			ctx,
+			"failed to get task from repository",
+			slog.String("error", err.Error()),
+		)
+		return domain.Attachment{}, nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	attachment, err := task.Attachment(id)
+	if err != nil {
+		log.Debug(ctx, "attachment not found")
+		return domain.Attachment{}, nil, err
+	}
+
+	content, err := s.blobs.Get(ctx, attachment.BlobKey(taskID))
+	if err != nil {
+		if errors.Is(err, ports.ErrBlobNotFound) {
+			log.Warn(ctx, "attachment content missing from blob store")
+			return domain.Attachment{}, nil, domain.ErrAttachmentNotFound
+		}
+
+		log.Error(ctx, "failed to open attachment content", slog.String("error", err.Error()))
+		return domain.Attachment{}, nil, fmt.Errorf("failed to open attachment: %w", err)
+	}
+
+	return attachment, content, nil
+}
+
+// DeleteAttachment removes an attachment from a task, then its content from the blob store.
+// A non-zero version must match the current task version.
+// Returns domain.ErrAttachmentNotFound if the task has no such attachment.
+// Returns domain.ErrVersionConflict if the task was modified since that version.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (s *AttachmentService) DeleteAttachment(
+	ctx context.Context, taskID, id string, version int64,
+) (*domain.Task, error) {
+	log := s.logger.With(slog.String("task_id", taskID), slog.String("attachment_id", id))
+	log.Debug(ctx, "deleting attachment")
+
+	var attachment domain.Attachment
+	task, err := modifyTask(ctx, s.repo, s.history, log, taskID, version, func(task *domain.Task) error {
+		var err error
+		attachment, err = task.Detach(id, s.clock.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.deleteBlob(ctx, log, attachment.BlobKey(taskID))
+
+	log.Info(ctx, "attachment deleted successfully")
+	return task, nil
+}
+
+// checkAttachable returns the error that attaching a file to the task with the given ID
+// and version would fail with, if any.
+func (s *AttachmentService) checkAttachable(ctx context.Context, log logger.Logger, taskID string, version int64) error {
+	task, err := s.repo.GetByID(ctx, taskID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Debug(ctx, "task not found")
+			return err
+		}
+
+		log.Error(
+			ctx,
+			"failed to get task from repository",
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if err := checkVersion(ctx, log, task, version); err != nil {
+		return err
+	}
+
+	if err := task.CanAttach(); err != nil {
+		log.Warn(ctx, "attachment rejected", slog.String("error", err.Error()))
+		return err
+	}
+
+	return nil
+}
+
+// deleteBlob removes content that no task refers to anymore. Failures are only logged:
+// the content is unreachable either way.
+func (s *AttachmentService) deleteBlob(ctx context.Context, log logger.Logger, key string) {
+	if err := s.blobs.Delete(ctx, key); err != nil {
+		log.Error(ctx, "failed to delete attachment content", slog.String("error", err.Error()))
+	}
+}
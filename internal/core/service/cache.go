@@ -0,0 +1,204 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// WithReadCache keeps the tasks returned by GetTaskByID and the pages of unfiltered
+// GetAllTasks listings for ttl, so polling clients are served without reading the
+// repository. A change made through the service evicts the cached tasks and pages it
+// affects; changes made by other components, such as attachments or retention, are
+// seen once the cached entries expire.
+func WithReadCache(ttl time.Duration) Option {
+	return func(s *TaskService) {
+		s.cache = newReadCache(ttl)
+	}
+}
+
+// ReadCacheTTLFromEnv reads the TASK_READ_CACHE_TTL environment variable, a positive duration
+// such as 50ms. Returns zero, disabling the cache, if the variable is not set.
+func ReadCacheTTLFromEnv() (time.Duration, error) {
+	raw := os.Getenv("TASK_READ_CACHE_TTL")
+	if raw == "" {
+		return 0, nil
+	}
+
+	ttl, err := time.ParseDuration(raw)
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("TASK_READ_CACHE_TTL must be a positive duration, got: %s", raw)
+	}
+	return ttl, nil
+}
+
+// readCache keeps tasks and unfiltered task pages for a short time.
+// A nil readCache caches nothing.
+// Entries are copied on the way in and out, so callers can modify what they get.
+type readCache struct {
+	ttl time.Duration
+
+	mu sync.Mutex
+	// generation counts the evictions, so a read that started before an eviction
+	// does not cache what it read
+	generation uint64
+	tasks      map[string]cachedTask
+	pages      map[pageKey]cachedPage
+	// sweepAt is when expired entries are next removed
+	sweepAt time.Time
+}
+
+// cachedTask is a cached result of GetTaskByID.
+type cachedTask struct {
+	task      domain.Task
+	expiresAt time.Time
+}
+
+// pageKey identifies an unfiltered listing.
+type pageKey struct {
+	sort ports.Sort
+	page ports.PageRequest
+}
+
+// cachedPage is a cached result of an unfiltered GetAllTasks.
+type cachedPage struct {
+	page      ports.TaskPage
+	expiresAt time.Time
+}
+
+// newReadCache creates a cache keeping entries for ttl.
+func newReadCache(ttl time.Duration) *readCache {
+	return &readCache{
+		ttl:   ttl,
+		tasks: make(map[string]cachedTask),
+		pages: make(map[pageKey]cachedPage),
+	}
+}
+
+// start returns the generation to pass to a put after reading the repository.
+func (c *readCache) start() uint64 {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// task returns a copy of the cached task with the given ID, if it has not expired at now.
+func (c *readCache) task(id string, now time.Time) (*domain.Task, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.tasks[id]
+	if !ok || !now.Before(entry.expiresAt) {
+		return nil, false
+	}
+
+	task := entry.task
+	return &task, true
+}
+
+// putTask caches a copy of task read at now, unless an eviction happened since generation.
+func (c *readCache) putTask(generation uint64, task *domain.Task, now time.Time) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	c.sweep(now)
+	c.tasks[task.ID] = cachedTask{task: *task, expiresAt: now.Add(c.ttl)}
+}
+
+// page returns a copy of the cached page of the unfiltered listing, if it has not expired at now.
+func (c *readCache) page(query ports.ListQuery, now time.Time) (ports.TaskPage, bool) {
+	if c == nil || !query.Filter.IsZero() {
+		return ports.TaskPage{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.pages[pageKey{query.Sort, query.Page}]
+	if !ok || !now.Before(entry.expiresAt) {
+		return ports.TaskPage{}, false
+	}
+	return copyPage(entry.page), true
+}
+
+// putPage caches a copy of the page of the listing read at now, if the listing is unfiltered
+// and no eviction happened since generation.
+func (c *readCache) putPage(generation uint64, query ports.ListQuery, page ports.TaskPage, now time.Time) {
+	if c == nil || !query.Filter.IsZero() {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	c.sweep(now)
+	c.pages[pageKey{query.Sort, query.Page}] = cachedPage{page: copyPage(page), expiresAt: now.Add(c.ttl)}
+}
+
+// evict removes the tasks with the given IDs and all pages, as a change of a task can
+// move it between and within pages.
+func (c *readCache) evict(ids ...string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	for _, id := range ids {
+		delete(c.tasks, id)
+	}
+	clear(c.pages)
+}
+
+// sweep removes the entries expired at now, at most once per ttl. c.mu must be held.
+func (c *readCache) sweep(now time.Time) {
+	if now.Before(c.sweepAt) {
+		return
+	}
+
+	for id, entry := range c.tasks {
+		if !now.Before(entry.expiresAt) {
+			delete(c.tasks, id)
+		}
+	}
+	for key, entry := range c.pages {
+		if !now.Before(entry.expiresAt) {
+			delete(c.pages, key)
+		}
+	}
+	c.sweepAt = now.Add(c.ttl)
+}
+
+// copyPage returns a page with copies of the tasks of page.
+func copyPage(page ports.TaskPage) ports.TaskPage {
+	tasks := make([]*domain.Task, len(page.Tasks))
+	for i, task := range page.Tasks {
+		taskCopy := *task
+		tasks[i] = &taskCopy
+	}
+	return ports.TaskPage{Tasks: tasks, NextCursor: page.NextCursor}
+}
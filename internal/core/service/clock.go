@@ -0,0 +1,21 @@
+package service
+
+import (
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// WithClock takes the timestamps of tasks from clock instead of the system clock.
+func WithClock(clock ports.Clock) Option {
+	return func(s *TaskService) {
+		s.clock = clock
+	}
+}
+
+// systemClock tells the system time in UTC when no clock is configured.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now().UTC()
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var _ ports.CommentService = (*CommentService)(nil)
+
+// CommentService implements the discussion on tasks.
+// Comments are kept in their own repository; the task repository is consulted
+// to reject comments on tasks that do not exist.
+type CommentService struct {
+	comments ports.CommentRepository
+	tasks    ports.TaskRepository
+	ids      ports.IDGenerator
+	clock    ports.Clock
+	logger   logger.Logger
+}
+
+// NewCommentService creates a new instance of CommentService storing comments in comments,
+// looking up their tasks in tasks, generating their IDs with ids and taking their timestamps from clock.
+func NewCommentService(
+	comments ports.CommentRepository, tasks ports.TaskRepository, ids ports.IDGenerator, clock ports.Clock,
+	logger logger.Logger,
+) *CommentService {
+	return &CommentService{
+		comments: comments,
+		tasks:    tasks,
+		ids:      ids,
+		clock:    clock,
+		logger:   logger.With(slog.String("component", "service")),
+	}
+}
+
+// AddComment adds a comment by author to an existing task.
+// Returns domain.ErrEmptyCommentAuthor if the author is empty.
+// Returns domain.ErrEmptyCommentBody if the body is empty.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (s *CommentService) AddComment(ctx context.Context, taskID, author, body string) (*domain.Comment, error) {
+	log := s.logger.With(slog.String("task_id", taskID))
+	log.Debug(ctx, "adding comment", slog.String("author", author))
+
+	id, err := s.ids.NewID()
+	if err != nil {
+		log.Error(ctx, "failed to generate ID", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to generate ID: %w", err)
+	}
+
+	comment, err := domain.NewComment(id, taskID, author, body, s.clock.Now())
+	if err != nil {
+		log.Warn(ctx, "comment rejected", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := s.checkTask(ctx, log, taskID); err != nil {
+		return nil, err
+	}
+
+	if err := s.comments.Create(ctx, comment); err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Debug(ctx, "task deleted before comment was stored")
+			return nil, err
+		}
+
+		log.Error(
+			ctx,
+			"failed to create comment in repository",
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	log.Info(ctx, "comment added successfully", slog.String("comment_id", comment.ID))
+	return comment, nil
+}
+
+// ListComments returns the comments of an existing task, oldest first.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (s *CommentService) ListComments(ctx context.Context, taskID string) ([]*domain.Comment, error) {
+	log := s.logger.With(slog.String("task_id", taskID))
+	log.Debug(ctx, "listing comments")
+
+	if err := s.checkTask(ctx, log, taskID); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.comments.ListByTask(ctx, taskID)
+	if err != nil {
+		log.Error(
+			ctx,
+			"failed to list comments from repository",
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	log.Debug(ctx, "comments retrieved successfully", slog.Int("count", len(comments)))
+	return comments, nil
+}
+
+// DeleteComment removes a comment from a task.
+// Returns domain.ErrCommentNotFound if the task has no comment with the given ID.
+func (s *CommentService) DeleteComment(ctx context.Context, taskID, id string) error {
+	log := s.logger.With(slog.String("task_id", taskID), slog.String("comment_id", id))
+	log.Debug(ctx, "deleting comment")
+
+	if err := s.comments.Delete(ctx, taskID, id); err != nil {
+		if errors.Is(err, domain.ErrCommentNotFound) {
+			log.Debug(ctx, "comment not found for deletion")
+			return err
+		}
+
+		log.Error(
+			ctx,
+			"failed to delete comment from repository",
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	log.Info(ctx, "comment deleted successfully")
+	return nil
+}
+
+// checkTask returns domain.ErrTaskNotFound if the task with the given ID does not exist.
+func (s *CommentService) checkTask(ctx context.Context, log logger.Logger, taskID string) error {
+	if _, err := s.tasks.GetByID(ctx, taskID); err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Debug(ctx, "task not found")
+			return err
+		}
+
+		log.Error(
+			ctx,
+			"failed to get task from repository",
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// DuplicatePolicy decides what CreateTask does with a task whose title matches an unfinished task.
+type DuplicatePolicy string
+
+// Duplicate policies.
+const (
+	// DuplicatesAllow creates duplicate tasks without checking; it is the default.
+	DuplicatesAllow DuplicatePolicy = "allow"
+	// DuplicatesWarn creates duplicate tasks but logs a warning naming the existing task.
+	DuplicatesWarn DuplicatePolicy = "warn"
+	// DuplicatesReject refuses to create duplicate tasks with a *domain.DuplicateTaskError.
+	DuplicatesReject DuplicatePolicy = "reject"
+)
+
+// WithDuplicatePolicy checks new tasks for duplicates of unfinished tasks according to policy.
+func WithDuplicatePolicy(policy DuplicatePolicy) Option {
+	return func(s *TaskService) {
+		s.duplicates = policy
+	}
+}
+
+// DuplicatePolicyFromEnv reads the TASK_DUPLICATE_TITLES environment variable:
+// allow, warn or reject. Returns DuplicatesAllow if the variable is not set.
+func DuplicatePolicyFromEnv() (DuplicatePolicy, error) {
+	switch policy := DuplicatePolicy(os.Getenv("TASK_DUPLICATE_TITLES")); policy {
+	case "":
+		return DuplicatesAllow, nil
+	case DuplicatesAllow, DuplicatesWarn, DuplicatesReject:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("TASK_DUPLICATE_TITLES must be allow, warn or reject, got: %s", policy)
+	}
+}
+
+// checkDuplicate applies the duplicate policy to a new task with the given title.
+// The tasks containing the title are streamed from the repository and compared with
+// domain.Task.IsDuplicateOf. The check is made before the task is stored, so two
+// concurrent requests can still create the same task twice.
+// Returns a *domain.DuplicateTaskError if the policy rejects duplicates and one exists.
+func (s *TaskService) checkDuplicate(ctx context.Context, log logger.Logger, title string) error {
+	if s.duplicates == "" || s.duplicates == DuplicatesAllow {
+		return nil
+	}
+
+	err := s.repo.Iterate(ctx, ports.ListFilter{TitleContains: title}, func(task *domain.Task) error {
+		if task.IsDuplicateOf(title) {
+			return &domain.DuplicateTaskError{ExistingID: task.ID}
+		}
+		return nil
+	})
+
+	var duplicate *domain.DuplicateTaskError
+	if !errors.As(err, &duplicate) {
+		if err != nil {
+			log.Error(ctx, "failed to look up duplicate tasks", slog.String("error", err.Error()))
+			return fmt.Errorf("failed to look up duplicate tasks: %w", err)
+		}
+		return nil
+	}
+
+	if s.duplicates == DuplicatesWarn {
+		log.Warn(ctx, "task duplicates an unfinished task", slog.String("existing_task_id", duplicate.ExistingID))
+		return nil
+	}
+
+	log.Warn(ctx, "task creation rejected: duplicate title", slog.String("existing_task_id", duplicate.ExistingID))
+	return duplicate
+}
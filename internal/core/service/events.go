@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/asp3cto/task-manager/internal/auth"
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// WithEvents publishes the creation, status changes and deletion of tasks to events.
+func WithEvents(events ports.EventPublisher) Option {
+	return func(s *TaskService) {
+		s.events = events
+	}
+}
+
+// noopEvents discards task events when no publisher is configured.
+type noopEvents struct{}
+
+func (noopEvents) Publish(context.Context, domain.Event) {}
+
+// publish publishes an event of type eventType about task, made by the actor of ctx.
+// oldStatus is the status before a status change and empty for other events.
+// An event whose ID cannot be generated is logged and dropped, as the change is already stored.
+func (s *TaskService) publish(
+	ctx context.Context, eventType domain.EventType, task *domain.Task, oldStatus domain.TaskStatus,
+) {
+	id, err := s.ids.NewID()
+	if err != nil {
+		s.logger.Error(
+			ctx,
+			"failed to generate event ID, event dropped",
+			slog.String("task_id", task.ID),
+			slog.String("event_type", string(eventType)),
+			slog.String("error", err.Error()),
+		)
+		return
+	}
+
+	s.events.Publish(ctx, domain.Event{
+		ID:         id,
+		Type:       eventType,
+		OccurredAt: s.clock.Now(),
+		Actor:      auth.ActorFromContext(ctx),
+		Task:       *task,
+		OldStatus:  oldStatus,
+	})
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// WithHistory records every change of a task, with the actor from the request context, in history.
+// Without it no history is kept.
+func WithHistory(history ports.TaskHistoryRepository) Option {
+	return func(s *TaskService) {
+		s.history = history
+	}
+}
+
+// GetTaskHistory returns the changes of an existing task, oldest first.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (s *TaskService) GetTaskHistory(ctx context.Context, id string) ([]domain.HistoryEntry, error) {
+	log := s.logger.With(slog.String("task_id", id))
+	log.Debug(ctx, "getting task history")
+
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Debug(ctx, "task not found")
+			return nil, err
+		}
+
+		log.Error(
+			ctx,
+			"failed to get task from repository",
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if s.history == nil {
+		return []domain.HistoryEntry{}, nil
+	}
+
+	entries, err := s.history.ListByTask(ctx, id)
+	if err != nil {
+		log.Error(
+			ctx,
+			"failed to list task history from repository",
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to list task history: %w", err)
+	}
+
+	log.Debug(ctx, "task history retrieved successfully", slog.Int("count", len(entries)))
+	return entries, nil
+}
+
+// recordHistory appends entries to history, if any. The changes are already stored,
+// so a failure is only logged.
+func recordHistory(
+	ctx context.Context, history ports.TaskHistoryRepository, log logger.Logger, entries []domain.HistoryEntry,
+) {
+	if history == nil || len(entries) == 0 {
+		return
+	}
+
+	if err := history.Append(ctx, entries); err != nil {
+		log.Error(ctx, "failed to record task history", slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"crypto/rand"
+	"fmt"
+
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// WithIDGenerator generates the IDs of new tasks and subtasks with ids.
+func WithIDGenerator(ids ports.IDGenerator) Option {
+	return func(s *TaskService) {
+		s.ids = ids
+	}
+}
+
+// idLength defines the number of bytes used for generating random IDs.
+const idLength = 16
+
+// randomIDs generates random IDs when no ID generator is configured.
+// It generates a 16-byte random value and returns it as a hexadecimal string.
+type randomIDs struct{}
+
+func (randomIDs) NewID() (string, error) {
+	bytes := make([]byte, idLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", bytes), nil
+}
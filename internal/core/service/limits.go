@@ -0,0 +1,46 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+)
+
+// WithLimits bounds the text fields of tasks by limits instead of domain.DefaultLimits.
+func WithLimits(limits domain.Limits) Option {
+	return func(s *TaskService) {
+		s.limits = limits
+	}
+}
+
+// LimitsFromEnv reads the limits of the text fields of tasks from the environment:
+//   - TASK_TITLE_MAX_LENGTH: Maximum title length in characters (default: 255)
+//   - TASK_DESCRIPTION_MAX_LENGTH: Maximum description length in characters (default: 1000)
+//
+// Unset variables keep the defaults of domain.DefaultLimits.
+func LimitsFromEnv() (domain.Limits, error) {
+	limits := domain.DefaultLimits()
+
+	for _, variable := range []struct {
+		name   string
+		target *int
+	}{
+		{"TASK_TITLE_MAX_LENGTH", &limits.MaxTitleLength},
+		{"TASK_DESCRIPTION_MAX_LENGTH", &limits.MaxDescriptionLength},
+	} {
+		raw := os.Getenv(variable.name)
+		if raw == "" {
+			continue
+		}
+
+		value, err := strconv.Atoi(raw)
+		if err != nil || value <= 0 {
+			return domain.Limits{}, fmt.Errorf("%s must be a positive integer, got: %s", variable.name, raw)
+		}
+		*variable.target = value
+	}
+
+	return limits, nil
+}
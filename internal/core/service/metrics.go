@@ -0,0 +1,10 @@
+package service
+
+import "github.com/asp3cto/task-manager/internal/domain"
+
+// noopMetrics discards task metrics when none are configured.
+type noopMetrics struct{}
+
+func (noopMetrics) TaskCreated(domain.TaskStatus)                          {}
+func (noopMetrics) TaskStatusChanged(domain.TaskStatus, domain.TaskStatus) {}
+func (noopMetrics) TaskDeleted(domain.TaskStatus)                          {}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var _ ports.ProjectService = (*ProjectService)(nil)
+
+// ProjectService implements the lists that group tasks.
+// Projects are kept in their own repository; the task repository is consulted
+// to list the tasks of a project and to keep projects with tasks from being deleted.
+type ProjectService struct {
+	projects ports.ProjectRepository
+	tasks    ports.TaskRepository
+	statuses ports.StatusConfig
+	ids      ports.IDGenerator
+	clock    ports.Clock
+	logger   logger.Logger
+}
+
+// NewProjectService creates a new instance of ProjectService storing projects in projects
+// and looking up their tasks in tasks. Status filters are checked against statuses.
+// Project IDs are generated with ids and timestamps taken from clock.
+func NewProjectService(
+	projects ports.ProjectRepository, tasks ports.TaskRepository, statuses ports.StatusConfig,
+	ids ports.IDGenerator, clock ports.Clock, logger logger.Logger,
+) *ProjectService {
+	return &ProjectService{
+		projects: projects,
+		tasks:    tasks,
+		statuses: statuses,
+		ids:      ids,
+		clock:    clock,
+		logger:   logger.With(slog.String("component", "service")),
+	}
+}
+
+// CreateProject creates a new project with the given name and description.
+// Returns domain.ErrEmptyProjectName if the name is empty.
+func (s *ProjectService) CreateProject(ctx context.Context, name, description string) (*domain.Project, error) {
+	s.logger.Debug(ctx, "creating project", slog.String("name", name))
+
+	id, err := s.ids.NewID()
+	if err != nil {
+		s.logger.Error(ctx, "failed to generate ID", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to generate ID: %w", err)
+	}
+
+	log := s.logger.With(slog.String("project_id", id))
+	project, err := domain.NewProject(id, name, description, s.clock.Now())
+	if err != nil {
+		log.Warn(ctx, "project rejected", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := s.projects.Create(ctx, project); err != nil {
+		log.Error(
+			ctx,
+			"failed to create project in repository",
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+
+	log.Info(ctx, "project created successfully", slog.String("name", name))
+	return project, nil
+}
+
+// GetProject retrieves a project by its unique identifier.
+// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+func (s *ProjectService) GetProject(ctx context.Context, id string) (*domain.Project, error) {
+	log := s.logger.With(slog.String("project_id", id))
+	log.Debug(ctx, "getting project")
+
+	return s.getProject(ctx, log, id)
+}
+
+// ListProjects returns all projects, oldest first.
+func (s *ProjectService) ListProjects(ctx context.Context) ([]*domain.Project, error) {
+	s.logger.Debug(ctx, "listing projects")
+
+	projects, err := s.projects.GetAll(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "failed to list projects from repository", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	s.logger.Debug(ctx, "projects retrieved successfully", slog.Int("count", len(projects)))
+	return projects, nil
+}
+
+// UpdateProject changes the name and/or description of a project.
+// Nil arguments leave the corresponding field unchanged.
+// Returns domain.ErrEmptyProjectName if the name is explicitly set to an empty string.
+// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+func (s *ProjectService) UpdateProject(
+	ctx context.Context, id string, name, description *string,
+) (*domain.Project, error) {
+	log := s.logger.With(slog.String("project_id", id))
+	log.Debug(ctx, "updating project")
+
+	project, err := s.getProject(ctx, log, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := project.Update(name, description, s.clock.Now()); err != nil {
+		log.Warn(ctx, "project update rejected", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := s.projects.Update(ctx, project); err != nil {
+		if errors.Is(err, domain.ErrProjectNotFound) {
+			log.Debug(ctx, "project deleted before update was stored")
+			return nil, err
+		}
+
+		log.Error(
+			ctx,
+			"failed to update project in repository",
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to update project: %w", err)
+	}
+
+	log.Info(ctx, "project updated successfully")
+	return project, nil
+}
+
+// DeleteProject removes a project that has no tasks.
+// The tasks are checked before the delete, so a task created in the project
+// in between is left pointing to a project that no longer exists.
+// Returns domain.ErrProjectNotEmpty if tasks still belong to the project.
+// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+func (s *ProjectService) DeleteProject(ctx context.Context, id string) error {
+	log := s.logger.With(slog.String("project_id", id))
+	log.Debug(ctx, "deleting project")
+
+	if _, err := s.getProject(ctx, log, id); err != nil {
+		return err
+	}
+
+	page, err := s.tasks.GetAll(ctx, ports.ListQuery{
+		Filter: ports.ListFilter{ProjectID: id},
+		Page:   ports.PageRequest{Limit: 1},
+	})
+	if err != nil {
+		log.Error(ctx, "failed to get project tasks from repository", slog.String("error", err.Error()))
+		return fmt.Errorf("failed to get project tasks: %w", err)
+	}
+
+	if len(page.Tasks) > 0 {
+		log.Warn(ctx, "project deletion rejected: project still has tasks")
+		return domain.ErrProjectNotEmpty
+	}
+
+	if err := s.projects.Delete(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrProjectNotFound) {
+			log.Debug(ctx, "project not found for deletion")
+			return err
+		}
+
+		log.Error(
+			ctx,
+			"failed to delete project from repository",
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	log.Info(ctx, "project deleted successfully")
+	return nil
+}
+
+// ListProjectTasks retrieves the tasks of a project described by the query,
+// as TaskService.GetAllTasks does with the filter restricted to the project.
+// Returns domain.ErrInvalidStatus if the filter selects a status that is not configured.
+// Returns domain.ErrInvalidCursor if the page cursor is malformed.
+// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+func (s *ProjectService) ListProjectTasks(
+	ctx context.Context, id string, query ports.ListQuery,
+) (ports.TaskPage, error) {
+	log := s.logger.With(slog.String("project_id", id))
+	log.Debug(ctx, "listing project tasks")
+
+	if _, err := s.getProject(ctx, log, id); err != nil {
+		return ports.TaskPage{}, err
+	}
+
+	if err := checkStatusFilter(ctx, s.statuses, log, query.Filter); err != nil {
+		return ports.TaskPage{}, err
+	}
+
+	query.Filter = query.Filter.ResolveOverdue(s.clock.Now())
+	query.Filter.ProjectID = id
+	result, err := s.tasks.GetAll(ctx, query)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCursor) {
+			log.Debug(ctx, "invalid pagination cursor", slog.String("cursor", query.Page.Cursor))
+			return ports.TaskPage{}, err
+		}
+
+		log.Error(ctx, "failed to get project tasks from repository", slog.String("error", err.Error()))
+		return ports.TaskPage{}, fmt.Errorf("failed to get project tasks: %w", err)
+	}
+
+	log.Debug(ctx, "project tasks retrieved successfully", slog.Int("count", len(result.Tasks)))
+	return result, nil
+}
+
+// getProject reads a project from the repository.
+// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+func (s *ProjectService) getProject(ctx context.Context, log logger.Logger, id string) (*domain.Project, error) {
+	project, err := s.projects.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrProjectNotFound) {
+			log.Debug(ctx, "project not found")
+			return nil, err
+		}
+
+		log.Error(
+			ctx,
+			"failed to get project from repository",
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return project, nil
+}
+
+// GetProjectProgress rolls up the estimates and the progress of the tasks of a project,
+// see domain.ProjectProgress. The tasks are streamed from the repository, so large
+// projects are rolled up in bounded memory.
+// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+func (s *ProjectService) GetProjectProgress(ctx context.Context, id string) (*domain.ProjectProgress, error) {
+	log := s.logger.With(slog.String("project_id", id))
+	log.Debug(ctx, "rolling up project progress")
+
+	if _, err := s.getProject(ctx, log, id); err != nil {
+		return nil, err
+	}
+
+	progress := &domain.ProjectProgress{ProjectID: id}
+	err := s.tasks.Iterate(ctx, ports.ListFilter{ProjectID: id}, func(task *domain.Task) error {
+		progress.Add(task)
+		return nil
+	})
+	if err != nil {
+		log.Error(ctx, "failed to iterate project tasks in repository", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to iterate project tasks: %w", err)
+	}
+
+	log.Debug(ctx, "project progress rolled up successfully", slog.Int("tasks", progress.Tasks))
+	return progress, nil
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// errQuotaReached stops counting tasks once a quota is reached.
+var errQuotaReached = errors.New("quota reached")
+
+// Quotas bounds the number of stored tasks. A zero limit means unlimited.
+type Quotas struct {
+	// MaxTasks is the number of tasks that can exist in total.
+	MaxTasks int
+	// MaxPerStatus is the number of tasks that can have each status, such as
+	// a work-in-progress limit on domain.StatusInProgress.
+	MaxPerStatus map[domain.TaskStatus]int
+}
+
+// WithQuotas rejects creating tasks and changing their status beyond quotas.
+func WithQuotas(quotas Quotas) Option {
+	return func(s *TaskService) {
+		s.quotas = quotas
+	}
+}
+
+// QuotasFromEnv reads the task quotas from the environment:
+//   - MAX_TASKS: Maximum number of tasks in total
+//   - MAX_IN_PROGRESS: Maximum number of tasks in progress
+//   - TASK_STATUS_QUOTAS: Maximum number of tasks per status as comma-separated
+//     status=limit pairs, e.g. in_review=3,pending=100
+//
+// Unset variables leave the quotas unlimited.
+func QuotasFromEnv() (Quotas, error) {
+	var quotas Quotas
+	if raw := os.Getenv("MAX_TASKS"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return Quotas{}, fmt.Errorf("MAX_TASKS must be a positive integer, got: %s", raw)
+		}
+		quotas.MaxTasks = limit
+	}
+
+	perStatus := make(map[domain.TaskStatus]int)
+	if raw := os.Getenv("MAX_IN_PROGRESS"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 {
+			return Quotas{}, fmt.Errorf("MAX_IN_PROGRESS must be a positive integer, got: %s", raw)
+		}
+		perStatus[domain.StatusInProgress] = limit
+	}
+
+	if raw := os.Getenv("TASK_STATUS_QUOTAS"); raw != "" {
+		for _, pair := range strings.Split(raw, ",") {
+			status, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+			limit, err := strconv.Atoi(value)
+			if !ok || status == "" || err != nil || limit <= 0 {
+				return Quotas{}, fmt.Errorf("TASK_STATUS_QUOTAS must be status=limit pairs with positive limits, got: %s", raw)
+			}
+			if _, exists := perStatus[domain.TaskStatus(status)]; exists {
+				return Quotas{}, fmt.Errorf("TASK_STATUS_QUOTAS sets the quota of %s more than once", status)
+			}
+			perStatus[domain.TaskStatus(status)] = limit
+		}
+	}
+
+	if len(perStatus) > 0 {
+		quotas.MaxPerStatus = perStatus
+	}
+	return quotas, nil
+}
+
+// checkQuota reports whether n more tasks with the given status fit the quotas: in total
+// if total is set, and with the status. The tasks are counted by streaming them from the
+// repository until the limit is reached. The check is made before the tasks are stored, so
+// concurrent requests can still exceed a quota.
+// Returns a *domain.QuotaExceededError if a quota is exhausted.
+func (s *TaskService) checkQuota(
+	ctx context.Context, log logger.Logger, status domain.TaskStatus, n int, total bool,
+) error {
+	if limit := s.quotas.MaxTasks; total && limit > 0 {
+		if err := s.checkLimit(ctx, log, ports.ListFilter{}, limit, n, ""); err != nil {
+			return err
+		}
+	}
+
+	if limit := s.quotas.MaxPerStatus[status]; limit > 0 {
+		filter := ports.ListFilter{Statuses: []domain.TaskStatus{status}}
+		if err := s.checkLimit(ctx, log, filter, limit, n, status); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkLimit returns a *domain.QuotaExceededError for status unless n more tasks matching
+// the filter stay within limit.
+func (s *TaskService) checkLimit(
+	ctx context.Context, log logger.Logger, filter ports.ListFilter, limit, n int, status domain.TaskStatus,
+) error {
+	err := errQuotaReached
+	if n <= limit {
+		count := n
+		err = s.repo.Iterate(ctx, filter, func(*domain.Task) error {
+			if count++; count > limit {
+				return errQuotaReached
+			}
+			return nil
+		})
+	}
+
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, errQuotaReached) {
+		log.Error(ctx, "failed to count tasks for quota", slog.String("error", err.Error()))
+		return fmt.Errorf("failed to count tasks: %w", err)
+	}
+
+	log.Warn(ctx, "task quota exceeded", slog.String("status", string(status)), slog.Int("limit", limit))
+	return &domain.QuotaExceededError{Status: status, Limit: limit}
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/auth"
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var _ ports.SavedFilterService = (*SavedFilterService)(nil)
+
+// SavedFilterService implements the saved filters, task filters stored under a name.
+// Saved filters are kept in their own repository; the task repository is consulted
+// to list the tasks a filter selects.
+type SavedFilterService struct {
+	filters  ports.SavedFilterRepository
+	tasks    ports.TaskRepository
+	statuses ports.StatusConfig
+	clock    ports.Clock
+	logger   logger.Logger
+}
+
+// NewSavedFilterService creates a new instance of SavedFilterService storing filters in filters
+// and listing the tasks they select from tasks. Status filters are checked against statuses.
+// Timestamps are taken from clock.
+func NewSavedFilterService(
+	filters ports.SavedFilterRepository, tasks ports.TaskRepository, statuses ports.StatusConfig,
+	clock ports.Clock, logger logger.Logger,
+) *SavedFilterService {
+	return &SavedFilterService{
+		filters:  filters,
+		tasks:    tasks,
+		statuses: statuses,
+		clock:    clock,
+		logger:   logger.With(slog.String("component", "service")),
+	}
+}
+
+// CreateSavedFilter stores filter and sort under name, recording the actor of ctx as its creator.
+// Returns domain.ErrInvalidFilterName if the name is not a lowercase identifier.
+// Returns domain.ErrInvalidFilter if the filter selects an invalid priority or progress.
+// Returns domain.ErrInvalidStatus if the filter selects a status that is not configured.
+// Returns domain.ErrSavedFilterExists if a filter with the same name already exists.
+func (s *SavedFilterService) CreateSavedFilter(
+	ctx context.Context, name, description string, filter domain.TaskFilter, sort ports.Sort,
+) (*domain.SavedFilter, error) {
+	log := s.logger.With(slog.String("filter_name", name))
+	log.Debug(ctx, "creating saved filter")
+
+	saved, err := domain.NewSavedFilter(
+		name, description, filter, string(sort.Field), string(sort.Order), auth.ActorFromContext(ctx), s.clock.Now(),
+	)
+	if err != nil {
+		log.Warn(ctx, "saved filter rejected", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := checkStatusFilter(ctx, s.statuses, log, listFilter(saved.Filter, time.Time{})); err != nil {
+		return nil, err
+	}
+
+	if err := s.filters.Create(ctx, saved); err != nil {
+		if errors.Is(err, domain.ErrSavedFilterExists) {
+			log.Warn(ctx, "saved filter rejected: name already taken")
+			return nil, err
+		}
+
+		log.Error(
+			ctx,
+			"failed to create saved filter in repository",
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to create saved filter: %w", err)
+	}
+
+	log.Info(ctx, "saved filter created successfully")
+	return saved, nil
+}
+
+// GetSavedFilter retrieves a saved filter by its name.
+// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+func (s *SavedFilterService) GetSavedFilter(ctx context.Context, name string) (*domain.SavedFilter, error) {
+	log := s.logger.With(slog.String("filter_name", name))
+	log.Debug(ctx, "getting saved filter")
+
+	return s.getSavedFilter(ctx, log, name)
+}
+
+// ListSavedFilters returns all saved filters ordered by name.
+func (s *SavedFilterService) ListSavedFilters(ctx context.Context) ([]*domain.SavedFilter, error) {
+	s.logger.Debug(ctx, "listing saved filters")
+
+	filters, err := s.filters.GetAll(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "failed to list saved filters from repository", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list saved filters: %w", err)
+	}
+
+	s.logger.Debug(ctx, "saved filters retrieved successfully", slog.Int("count", len(filters)))
+	return filters, nil
+}
+
+// UpdateSavedFilter replaces the description, filter and sort of a saved filter.
+// Returns domain.ErrInvalidFilter if the filter selects an invalid priority or progress.
+// Returns domain.ErrInvalidStatus if the filter selects a status that is not configured.
+// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+func (s *SavedFilterService) UpdateSavedFilter(
+	ctx context.Context, name, description string, filter domain.TaskFilter, sort ports.Sort,
+) (*domain.SavedFilter, error) {
+	log := s.logger.With(slog.String("filter_name", name))
+	log.Debug(ctx, "updating saved filter")
+
+	saved, err := s.getSavedFilter(ctx, log, name)
+	if err != nil {
+		return nil, err
+	}
+
+	err = saved.Update(description, filter, string(sort.Field), string(sort.Order), s.clock.Now())
+	if err != nil {
+		log.Warn(ctx, "saved filter update rejected", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := checkStatusFilter(ctx, s.statuses, log, listFilter(saved.Filter, time.Time{})); err != nil {
+		return nil, err
+	}
+
+	if err := s.filters.Update(ctx, saved); err != nil {
+		if errors.Is(err, domain.ErrSavedFilterNotFound) {
+			log.Debug(ctx, "saved filter deleted before update was stored")
+			return nil, err
+		}
+
+		log.Error(
+			ctx,
+			"failed to update saved filter in repository",
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to update saved filter: %w", err)
+	}
+
+	log.Info(ctx, "saved filter updated successfully")
+	return saved, nil
+}
+
+// DeleteSavedFilter removes a saved filter.
+// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+func (s *SavedFilterService) DeleteSavedFilter(ctx context.Context, name string) error {
+	log := s.logger.With(slog.String("filter_name", name))
+	log.Debug(ctx, "deleting saved filter")
+
+	if err := s.filters.Delete(ctx, name); err != nil {
+		if errors.Is(err, domain.ErrSavedFilterNotFound) {
+			log.Debug(ctx, "saved filter not found for deletion")
+			return err
+		}
+
+		log.Error(
+			ctx,
+			"failed to delete saved filter from repository",
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to delete saved filter: %w", err)
+	}
+
+	log.Info(ctx, "saved filter deleted successfully")
+	return nil
+}
+
+// ListSavedFilterTasks retrieves a page of the tasks selected by a saved filter,
+// in the order of its sort. An overdue filter selects the tasks overdue now.
+// Returns domain.ErrInvalidStatus if the filter selects a status no longer configured.
+// Returns domain.ErrInvalidCursor if the page cursor is malformed.
+// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+func (s *SavedFilterService) ListSavedFilterTasks(
+	ctx context.Context, name string, page ports.PageRequest,
+) (ports.TaskPage, error) {
+	log := s.logger.With(slog.String("filter_name", name))
+	log.Debug(ctx, "listing saved filter tasks")
+
+	saved, err := s.getSavedFilter(ctx, log, name)
+	if err != nil {
+		return ports.TaskPage{}, err
+	}
+
+	query := ports.ListQuery{
+		Filter: listFilter(saved.Filter, s.clock.Now()),
+		Sort:   ports.Sort{Field: ports.SortField(saved.SortField), Order: ports.SortOrder(saved.SortOrder)}.Normalize(),
+		Page:   page,
+	}
+	if err := checkStatusFilter(ctx, s.statuses, log, query.Filter); err != nil {
+		return ports.TaskPage{}, err
+	}
+
+	result, err := s.tasks.GetAll(ctx, query)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCursor) {
+			log.Debug(ctx, "invalid pagination cursor", slog.String("cursor", page.Cursor))
+			return ports.TaskPage{}, err
+		}
+
+		log.Error(ctx, "failed to get saved filter tasks from repository", slog.String("error", err.Error()))
+		return ports.TaskPage{}, fmt.Errorf("failed to get saved filter tasks: %w", err)
+	}
+
+	log.Debug(ctx, "saved filter tasks retrieved successfully", slog.Int("count", len(result.Tasks)))
+	return result, nil
+}
+
+// getSavedFilter reads a saved filter from the repository.
+// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+func (s *SavedFilterService) getSavedFilter(
+	ctx context.Context, log logger.Logger, name string,
+) (*domain.SavedFilter, error) {
+	saved, err := s.filters.GetByName(ctx, name)
+	if err != nil {
+		if errors.Is(err, domain.ErrSavedFilterNotFound) {
+			log.Debug(ctx, "saved filter not found")
+			return nil, err
+		}
+
+		log.Error(
+			ctx,
+			"failed to get saved filter from repository",
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to get saved filter: %w", err)
+	}
+
+	return saved, nil
+}
+
+// listFilter maps a saved task filter to the ListFilter of a listing made at now,
+// which is when an overdue filter is evaluated.
+func listFilter(filter domain.TaskFilter, now time.Time) ports.ListFilter {
+	result := ports.ListFilter{
+		Statuses:      filter.Statuses,
+		Priorities:    filter.Priorities,
+		Assignee:      filter.Assignee,
+		Unassigned:    filter.Unassigned,
+		ProjectID:     filter.ProjectID,
+		Query:         filter.Query,
+		TitleContains: filter.TitleContains,
+		CreatedAfter:  timeOrZero(filter.CreatedAfter),
+		CreatedBefore: timeOrZero(filter.CreatedBefore),
+		UpdatedAfter:  timeOrZero(filter.UpdatedAfter),
+		UpdatedBefore: timeOrZero(filter.UpdatedBefore),
+		DueAfter:      timeOrZero(filter.DueAfter),
+		DueBefore:     timeOrZero(filter.DueBefore),
+		MinProgress:   filter.MinProgress,
+	}
+	if filter.Overdue {
+		result.OverdueAt = now
+	}
+
+	return result
+}
+
+// timeOrZero dereferences an optional timestamp.
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
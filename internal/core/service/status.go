@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// defaultStatuses provides the built-in workflow when no status configuration is set.
+type defaultStatuses struct{}
+
+func (defaultStatuses) Workflow(context.Context) (*domain.Workflow, error) {
+	return domain.DefaultWorkflow(), nil
+}
+
+// loadWorkflow reads the workflow from the status configuration.
+func loadWorkflow(
+	ctx context.Context, statuses ports.StatusConfig, log logger.Logger,
+) (*domain.Workflow, error) {
+	workflow, err := statuses.Workflow(ctx)
+	if err != nil {
+		log.Error(ctx, "failed to load status configuration", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to load status configuration: %w", err)
+	}
+
+	return workflow, nil
+}
+
+// checkStatusFilter returns domain.ErrInvalidStatus if a status the filter selects
+// is not one of the configured statuses.
+func checkStatusFilter(
+	ctx context.Context, statuses ports.StatusConfig, log logger.Logger, filter ports.ListFilter,
+) error {
+	if len(filter.Statuses) == 0 {
+		return nil
+	}
+
+	workflow, err := loadWorkflow(ctx, statuses, log)
+	if err != nil {
+		return err
+	}
+
+	for _, status := range filter.Statuses {
+		if !workflow.IsValid(status) {
+			log.Debug(ctx, "unknown status in filter", slog.String("status", string(status)))
+			return domain.ErrInvalidStatus
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/asp3cto/task-manager/internal/auth"
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// AddSubtask adds a subtask with the given title to an existing task at the zero-based
+// position, or at the end if position is nil. The subtask gets a generated ID.
+// A non-zero version must match the current task version.
+// Returns domain.ErrEmptyTitle if the title is empty.
+// Returns domain.ErrTooManySubtasks if the task already has domain.MaxSubtasks subtasks.
+// Returns domain.ErrVersionConflict if the task was modified since that version.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (s *TaskService) AddSubtask(
+	ctx context.Context, id, title string, position *int, version int64,
+) (*domain.Task, domain.Subtask, error) {
+	log := s.logger.With(slog.String("task_id", id))
+	log.Debug(ctx, "adding subtask", slog.String("title", title))
+
+	defer s.cache.evict(id)
+
+	subtaskID, err := s.ids.NewID()
+	if err != nil {
+		log.Error(ctx, "failed to generate ID", slog.String("error", err.Error()))
+		return nil, domain.Subtask{}, fmt.Errorf("failed to generate ID: %w", err)
+	}
+
+	var subtask domain.Subtask
+	task, err := modifyTask(ctx, s.repo, s.history, log, id, version, func(task *domain.Task) error {
+		subtask, err = task.AddSubtask(subtaskID, title, position, s.clock.Now())
+		return err
+	})
+	if err != nil {
+		return nil, domain.Subtask{}, err
+	}
+
+	log.Info(ctx, "subtask added successfully", slog.String("subtask_id", subtask.ID))
+	return task, subtask, nil
+}
+
+// UpdateSubtask changes the title, done flag and/or position of a subtask.
+// Nil arguments leave the corresponding field unchanged.
+// A non-zero version must match the current task version.
+// Returns domain.ErrEmptyTitle if the title is explicitly set to an empty string.
+// Returns domain.ErrSubtaskNotFound if the task has no such subtask.
+// Returns domain.ErrVersionConflict if the task was modified since that version.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (s *TaskService) UpdateSubtask(
+	ctx context.Context, id, subtaskID string, title *string, done *bool, position *int, version int64,
+) (*domain.Task, domain.Subtask, error) {
+	log := s.logger.With(slog.String("task_id", id), slog.String("subtask_id", subtaskID))
+	log.Debug(ctx, "updating subtask")
+
+	defer s.cache.evict(id)
+
+	var subtask domain.Subtask
+	task, err := modifyTask(ctx, s.repo, s.history, log, id, version, func(task *domain.Task) error {
+		var err error
+		subtask, err = task.UpdateSubtask(subtaskID, title, done, position, s.clock.Now())
+		return err
+	})
+	if err != nil {
+		return nil, domain.Subtask{}, err
+	}
+
+	log.Info(ctx, "subtask updated successfully")
+	return task, subtask, nil
+}
+
+// DeleteSubtask removes a subtask from an existing task.
+// A non-zero version must match the current task version.
+// Returns domain.ErrSubtaskNotFound if the task has no such subtask.
+// Returns domain.ErrVersionConflict if the task was modified since that version.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (s *TaskService) DeleteSubtask(ctx context.Context, id, subtaskID string, version int64) (*domain.Task, error) {
+	log := s.logger.With(slog.String("task_id", id), slog.String("subtask_id", subtaskID))
+	log.Debug(ctx, "deleting subtask")
+
+	defer s.cache.evict(id)
+
+	task, err := modifyTask(ctx, s.repo, s.history, log, id, version, func(task *domain.Task) error {
+		return task.RemoveSubtask(subtaskID, s.clock.Now())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(ctx, "subtask deleted successfully")
+	return task, nil
+}
+
+// modifyTask reads the task from repo, checks its version, applies fn and stores the result.
+// The changes made by fn are recorded in history, if any.
+// Errors returned by fn are business rule violations and are returned unwrapped.
+func modifyTask(
+	ctx context.Context, repo ports.TaskRepository, history ports.TaskHistoryRepository, log logger.Logger,
+	id string, version int64, fn func(*domain.Task) error,
+) (*domain.Task, error) {
+	task, err := repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Debug(ctx, "task not found for modification")
+			return nil, err
+		}
+
+		log.Error(
+			ctx,
+			"failed to get task for modification",
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if err := checkVersion(ctx, log, task, version); err != nil {
+		return nil, err
+	}
+
+	// The domain methods replace the subtask and attachment slices rather than
+	// modify them, so a shallow copy keeps the previous state.
+	before := *task
+	if err := fn(task); err != nil {
+		log.Warn(ctx, "task modification rejected", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := repo.Update(ctx, task); err != nil {
+		if errors.Is(err, domain.ErrVersionConflict) {
+			log.Warn(ctx, "task modified concurrently")
+			return nil, err
+		}
+
+		log.Error(
+			ctx,
+			"failed to update task in repository",
+			slog.String("error", err.Error()),
+		)
+
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	recordHistory(ctx, history, log, domain.Changes(&before, task, auth.ActorFromContext(ctx)))
+	return task, nil
+}
@@ -5,11 +5,12 @@ package service
 
 import (
 	"context"
-	"crypto/rand"
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
+	"github.com/asp3cto/task-manager/internal/auth"
 	"github.com/asp3cto/task-manager/internal/domain"
 	"github.com/asp3cto/task-manager/internal/logger"
 	"github.com/asp3cto/task-manager/internal/ports"
@@ -21,156 +22,737 @@ var _ ports.TaskService = (*TaskService)(nil)
 // It orchestrates domain entities and repository interactions while
 // enforcing business rules and validation.
 type TaskService struct {
-	repo   ports.TaskRepository
-	logger logger.Logger
+	repo       ports.TaskRepository
+	logger     logger.Logger
+	metrics    ports.TaskMetrics
+	events     ports.EventPublisher
+	comments   ports.CommentRepository
+	blobs      ports.BlobStore
+	projects   ports.ProjectRepository
+	statuses   ports.StatusConfig
+	history    ports.TaskHistoryRepository
+	limits     domain.Limits
+	duplicates DuplicatePolicy
+	ids        ports.IDGenerator
+	clock      ports.Clock
+	undoWindow time.Duration
+	quotas     Quotas
+	cache      *readCache
+}
+
+// Option configures optional TaskService behavior.
+type Option func(*TaskService)
+
+// WithMetrics reports task lifecycle events to metrics.
+func WithMetrics(metrics ports.TaskMetrics) Option {
+	return func(s *TaskService) {
+		s.metrics = metrics
+	}
+}
+
+// WithComments removes the comments of deleted tasks from comments.
+func WithComments(comments ports.CommentRepository) Option {
+	return func(s *TaskService) {
+		s.comments = comments
+	}
+}
+
+// WithBlobStore removes the attachment content of deleted tasks from blobs.
+func WithBlobStore(blobs ports.BlobStore) Option {
+	return func(s *TaskService) {
+		s.blobs = blobs
+	}
+}
+
+// WithProjects looks up the projects that tasks are created in or moved to in projects.
+// Without it no project exists, so tasks can only be kept outside of projects.
+func WithProjects(projects ports.ProjectRepository) Option {
+	return func(s *TaskService) {
+		s.projects = projects
+	}
+}
+
+// WithStatusConfig validates task statuses against the workflow configured in statuses.
+// Without it tasks can only have the built-in statuses.
+func WithStatusConfig(statuses ports.StatusConfig) Option {
+	return func(s *TaskService) {
+		s.statuses = statuses
+	}
 }
 
 // NewTaskService creates a new instance of TaskService with the provided repository.
 // The repository is used for all data persistence operations.
-func NewTaskService(repo ports.TaskRepository, logger logger.Logger) *TaskService {
-	return &TaskService{
-		repo:   repo,
-		logger: logger,
+func NewTaskService(repo ports.TaskRepository, logger logger.Logger, opts ...Option) *TaskService {
+	s := &TaskService{
+		repo:       repo,
+		logger:     logger.With(slog.String("component", "service")),
+		metrics:    noopMetrics{},
+		events:     noopEvents{},
+		statuses:   defaultStatuses{},
+		limits:     domain.DefaultLimits(),
+		ids:        randomIDs{},
+		clock:      systemClock{},
+		undoWindow: DefaultUndoWindow,
+	}
+
+	for _, opt := range opts {
+		opt(s)
 	}
+
+	return s
 }
 
-// CreateTask creates a new task with the given title and description.
-// It validates the input, generates a unique ID, and stores the task.
+// CreateTask creates a new task with the given title, description, optional due date and priority
+// in the project with the given ID, or in no project if projectID is empty.
+// It validates the input, generates a unique ID unless the client supplied one, and stores the task.
+// The title and description are trimmed of surrounding whitespace.
+// An empty priority defaults to domain.PriorityMedium.
+// If a task with the supplied ID already exists and is the same as the new one, the creation
+// is a retry: the existing task is returned and created is false.
+// Returns domain.ErrInvalidTaskID if the supplied ID does not have the format of task IDs.
+// Returns domain.ErrTaskExists if a different task with the supplied ID already exists.
+// Returns a *domain.ValidationError if the title or description violates the limits.
 // Returns domain.ErrEmptyTitle if the title is empty.
-func (s *TaskService) CreateTask(ctx context.Context, title, description string) (*domain.Task, error) {
+// Returns domain.ErrInvalidPriority if the priority is not valid.
+// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+// Returns a *domain.DuplicateTaskError if the duplicate policy rejects the title.
+// Returns a *domain.QuotaExceededError if no more tasks, or no more pending tasks, are allowed.
+func (s *TaskService) CreateTask(
+	ctx context.Context, id, title, description string, dueDate *time.Time, priority domain.Priority,
+	projectID string,
+) (task *domain.Task, created bool, err error) {
 	s.logger.Debug(ctx, "creating task", slog.String("title", title))
 
+	title, description, err = s.limits.NormalizeTask(title, description)
+	if err != nil {
+		s.logger.Warn(ctx, "task creation failed: invalid input", slog.String("error", err.Error()))
+		return nil, false, err
+	}
+
 	if title == "" {
 		s.logger.Warn(ctx, "task creation failed: empty title")
-		return nil, domain.ErrEmptyTitle
+		return nil, false, domain.ErrEmptyTitle
 	}
 
-	id, err := generateID()
-	if err != nil {
+	supplied := id != ""
+	if supplied {
+		if err := domain.ValidateTaskID(id); err != nil {
+			s.logger.Warn(ctx, "task creation failed: invalid ID", slog.String("task_id", id))
+			return nil, false, err
+		}
+	} else if id, err = s.ids.NewID(); err != nil {
 		s.logger.Error(ctx, "failed to generate ID", slog.String("error", err.Error()))
-		return nil, fmt.Errorf("failed to generate ID: %w", err)
+		return nil, false, fmt.Errorf("failed to generate ID: %w", err)
+	}
+
+	log := s.logger.With(slog.String("task_id", id))
+	task, err = domain.NewTask(id, title, description, dueDate, priority, s.clock.Now())
+	if err != nil {
+		log.Warn(ctx, "task creation failed: invalid priority", slog.String("priority", string(priority)))
+		return nil, false, err
+	}
+	task.ProjectID = projectID
+
+	if supplied {
+		existing, err := s.findRetry(ctx, log, task)
+		if err != nil || existing != nil {
+			return existing, false, err
+		}
+	}
+
+	if err := s.checkProject(ctx, log, projectID); err != nil {
+		return nil, false, err
+	}
+
+	if err := s.checkDuplicate(ctx, log, title); err != nil {
+		return nil, false, err
 	}
 
-	task := domain.NewTask(id, title, description)
+	if err := s.checkQuota(ctx, log, task.Status, 1, true); err != nil {
+		return nil, false, err
+	}
 
 	if err := s.repo.Create(ctx, task); err != nil {
-		s.logger.Error(
+		if supplied && errors.Is(err, domain.ErrTaskExists) {
+			// A concurrent request created a task with the same ID since findRetry.
+			existing, err := s.findRetry(ctx, log, task)
+			if err != nil || existing != nil {
+				return existing, false, err
+			}
+		}
+
+		log.Error(
 			ctx,
 			"failed to create task in repository",
-			slog.String("task_id", id), slog.String("error", err.Error()),
+			slog.String("error", err.Error()),
 		)
-		return nil, fmt.Errorf("failed to create task: %w", err)
+		return nil, false, fmt.Errorf("failed to create task: %w", err)
 	}
 
-	s.logger.Info(
+	log.Info(
 		ctx,
 		"task created successfully",
-		slog.String("task_id", id), slog.String("title", title),
+		slog.String("title", title),
 	)
+	s.cache.evict(task.ID)
+	s.metrics.TaskCreated(task.Status)
+	recordHistory(ctx, s.history, log, []domain.HistoryEntry{domain.CreationEntry(task, auth.ActorFromContext(ctx))})
+	s.publish(ctx, domain.EventTaskCreated, task, "")
 
-	return task, nil
+	return task, true, nil
 }
 
-// GetTaskByID retrieves a task by its unique identifier.
+// findRetry looks up the task with the client-supplied ID of the new task.
+// Returns the stored task if it is the same as the new one, so the creation is a retry,
+// or nil if no task has the ID yet.
+// Returns domain.ErrTaskExists if a different task has the ID.
+func (s *TaskService) findRetry(ctx context.Context, log logger.Logger, task *domain.Task) (*domain.Task, error) {
+	existing, err := s.repo.GetByID(ctx, task.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			return nil, nil
+		}
+
+		log.Error(ctx, "failed to get task with supplied ID", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if !existing.SameAs(task) {
+		log.Warn(ctx, "task creation failed: a different task has the ID")
+		return nil, domain.ErrTaskExists
+	}
+
+	log.Info(ctx, "task creation retried, returning existing task")
+	return existing, nil
+}
+
+// GetTaskByID retrieves a task by its unique identifier, from the read cache if enabled.
 // Returns domain.ErrTaskNotFound if no task exists with the given ID.
 func (s *TaskService) GetTaskByID(ctx context.Context, id string) (*domain.Task, error) {
-	s.logger.Debug(ctx, "getting task by ID", slog.String("task_id", id))
+	log := s.logger.With(slog.String("task_id", id))
+	log.Debug(ctx, "getting task by ID")
+
+	if task, ok := s.cache.task(id, s.clock.Now()); ok {
+		log.Debug(ctx, "task retrieved from cache")
+		return task, nil
+	}
 
+	generation := s.cache.start()
 	task, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, domain.ErrTaskNotFound) {
-			s.logger.Debug(ctx, "task not found", slog.String("task_id", id))
+			log.Debug(ctx, "task not found")
 			return nil, err
 		}
 
-		s.logger.Error(
+		log.Error(
 			ctx,
 			"failed to get task from repository",
-			slog.String("task_id", id), slog.String("error", err.Error()),
+			slog.String("error", err.Error()),
 		)
 
 		return nil, fmt.Errorf("failed to get task: %w", err)
 	}
 
-	s.logger.Debug(ctx, "task retrieved successfully", slog.String("task_id", id))
+	s.cache.putTask(generation, task, s.clock.Now())
+	log.Debug(ctx, "task retrieved successfully")
 	return task, nil
 }
 
-// GetAllTasks retrieves all tasks, optionally filtered by status.
-// If status is empty, returns all tasks regardless of their status.
-func (s *TaskService) GetAllTasks(ctx context.Context, status string) ([]*domain.Task, error) {
-	s.logger.Debug(ctx, "getting all tasks", slog.String("status_filter", status))
+// GetTasksByIDs retrieves the tasks with the given identifiers with a single repository call.
+// Unknown IDs are skipped; tasks are returned in the order of ids.
+func (s *TaskService) GetTasksByIDs(ctx context.Context, ids []string) ([]*domain.Task, error) {
+	s.logger.Debug(ctx, "getting tasks by IDs", slog.Int("requested", len(ids)))
 
-	tasks, err := s.repo.GetAll(ctx, status)
+	tasks, err := s.repo.GetByIDs(ctx, ids)
 	if err != nil {
-		s.logger.Error(ctx, "failed to get tasks from repository", slog.String("error", err.Error()))
+		s.logger.Error(ctx, "failed to get tasks by IDs from repository", slog.String("error", err.Error()))
 		return nil, fmt.Errorf("failed to get tasks: %w", err)
 	}
 
 	s.logger.Debug(
 		ctx,
 		"tasks retrieved successfully",
-		slog.Int("count", len(tasks)), slog.String("status_filter", status),
+		slog.Int("requested", len(ids)), slog.Int("count", len(tasks)),
 	)
 	return tasks, nil
 }
 
+// GetAllTasks retrieves the tasks described by the query.
+// A zero filter matches all tasks, a zero page returns every matching task.
+// Results are ordered by the query sort with ties broken by ID, so cursors stay
+// stable while tasks are created concurrently. Filtering, ordering and paging
+// are left to the repository. Unfiltered results come from the read cache if enabled.
+// Returns domain.ErrInvalidStatus if the filter selects a status that is not configured.
+// Returns domain.ErrInvalidCursor if the page cursor is malformed.
+func (s *TaskService) GetAllTasks(ctx context.Context, query ports.ListQuery) (ports.TaskPage, error) {
+	s.logger.Debug(
+		ctx,
+		"getting all tasks",
+		slog.Any("status_filter", query.Filter.Statuses),
+		slog.String("query", query.Filter.Query),
+		slog.String("sort", string(query.Sort.Field)),
+		slog.Int("limit", query.Page.Limit),
+	)
+
+	if err := checkStatusFilter(ctx, s.statuses, s.logger, query.Filter); err != nil {
+		return ports.TaskPage{}, err
+	}
+
+	query.Filter = query.Filter.ResolveOverdue(s.clock.Now())
+	if result, ok := s.cache.page(query, s.clock.Now()); ok {
+		s.logger.Debug(ctx, "tasks retrieved from cache", slog.Int("count", len(result.Tasks)))
+		return result, nil
+	}
+
+	generation := s.cache.start()
+	result, err := s.repo.GetAll(ctx, query)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCursor) {
+			s.logger.Debug(ctx, "invalid pagination cursor", slog.String("cursor", query.Page.Cursor))
+			return ports.TaskPage{}, err
+		}
+
+		s.logger.Error(ctx, "failed to get tasks from repository", slog.String("error", err.Error()))
+		return ports.TaskPage{}, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	s.logger.Debug(
+		ctx,
+		"tasks retrieved successfully",
+		slog.Int("count", len(result.Tasks)), slog.Any("status_filter", query.Filter.Statuses),
+	)
+	s.cache.putPage(generation, query, result, s.clock.Now())
+	return result, nil
+}
+
+// GetWorkflow returns the configured task statuses and the transitions allowed between them.
+func (s *TaskService) GetWorkflow(ctx context.Context) (*domain.Workflow, error) {
+	s.logger.Debug(ctx, "getting workflow")
+	return loadWorkflow(ctx, s.statuses, s.logger)
+}
+
 // UpdateTaskStatus changes the status of an existing task.
 // It retrieves the task, updates its status using domain methods, and persists the change.
+// The status and the transition to it are checked against the configured workflow.
+// A non-zero version must match the current task version.
+// Returns domain.ErrInvalidStatus if the status is not configured.
+// Returns domain.ErrInvalidTransition if the task cannot move from its current status to status.
+// Returns a *domain.QuotaExceededError if no more tasks are allowed to have the status.
+// Returns domain.ErrVersionConflict if the task was modified since that version.
 // Returns domain.ErrTaskNotFound if no task exists with the given ID.
-func (s *TaskService) UpdateTaskStatus(ctx context.Context, id string, status domain.TaskStatus) (*domain.Task, error) {
-	s.logger.Debug(
+func (s *TaskService) UpdateTaskStatus(
+	ctx context.Context, id string, status domain.TaskStatus, version int64,
+) (*domain.Task, error) {
+	log := s.logger.With(slog.String("task_id", id))
+	log.Debug(
 		ctx,
 		"updating task status",
-		slog.String("task_id", id), slog.String("new_status", string(status)),
+		slog.String("new_status", string(status)),
 	)
 
+	defer s.cache.evict(id)
+
 	task, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, domain.ErrTaskNotFound) {
-			s.logger.Debug(ctx, "task not found for status update", slog.String("task_id", id))
+			log.Debug(ctx, "task not found for status update")
 			return nil, err
 		}
 
-		s.logger.Error(
+		log.Error(
 			ctx,
 			"failed to get task for status update",
-			slog.String("task_id", id), slog.String("error", err.Error()),
+			slog.String("error", err.Error()),
 		)
 		return nil, fmt.Errorf("failed to get task: %w", err)
 	}
 
+	if err := checkVersion(ctx, log, task, version); err != nil {
+		return nil, err
+	}
+
+	workflow, err := loadWorkflow(ctx, s.statuses, log)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *task
 	oldStatus := task.Status
-	task.UpdateStatus(status)
+	if err := task.UpdateStatus(status, workflow, s.clock.Now()); err != nil {
+		log.Warn(
+			ctx, "status change rejected",
+			slog.String("old_status", string(oldStatus)),
+			slog.String("new_status", string(status)),
+			slog.String("error", err.Error()),
+		)
+		return nil, err
+	}
+
+	if task.Status != oldStatus {
+		if err := s.checkQuota(ctx, log, task.Status, 1, false); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := s.repo.Update(ctx, task); err != nil {
-		s.logger.Error(
+		if errors.Is(err, domain.ErrVersionConflict) {
+			log.Warn(ctx, "task modified concurrently")
+			return nil, err
+		}
+
+		log.Error(
 			ctx,
 			"failed to update task in repository",
-			slog.String("task_id", id), slog.String("error", err.Error()),
+			slog.String("error", err.Error()),
 		)
 
 		return nil, fmt.Errorf("failed to update task: %w", err)
 	}
 
-	s.logger.Info(
+	log.Info(
 		ctx, "task status updated successfully",
-		slog.String("task_id", id),
 		slog.String("old_status", string(oldStatus)),
 		slog.String("new_status", string(status)),
 	)
+	s.metrics.TaskStatusChanged(oldStatus, task.Status)
+	recordHistory(ctx, s.history, log, domain.Changes(&before, task, auth.ActorFromContext(ctx)))
+	if task.Status != oldStatus {
+		s.publish(ctx, domain.EventTaskStatusChanged, task, oldStatus)
+	}
+	return task, nil
+}
+
+// UpdateTask applies a partial update of the details of an existing task, see domain.TaskUpdate.
+// The title and description are trimmed of surrounding whitespace.
+// A non-zero version must match the current task version.
+// Returns a *domain.ValidationError if the title, description or tags violate the limits.
+// Returns domain.ErrEmptyTitle if the title is explicitly set to an empty string.
+// Returns domain.ErrInvalidPriority if the priority is not valid.
+// Returns domain.ErrInvalidEstimate if the estimate is negative.
+// Returns domain.ErrInvalidProgress if the progress is not between 0 and domain.MaxProgress.
+// Returns domain.ErrInvalidTag or domain.ErrTooManyTags if the tags are not valid.
+// Returns domain.ErrVersionConflict if the task was modified since that version.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (s *TaskService) UpdateTask(
+	ctx context.Context, id string, update domain.TaskUpdate, version int64,
+) (*domain.Task, error) {
+	log := s.logger.With(slog.String("task_id", id))
+	log.Debug(ctx, "updating task")
+
+	defer s.cache.evict(id)
+
+	if err := s.limits.NormalizeUpdate(&update); err != nil {
+		log.Warn(ctx, "task update failed: invalid input", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	task, err := modifyTask(ctx, s.repo, s.history, log, id, version, func(task *domain.Task) error {
+		return task.Update(update, s.clock.Now())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(ctx, "task updated successfully")
+	return task, nil
+}
+
+// AssignTask sets the assignee of an existing task; an empty assignee unassigns the task.
+// A non-zero version must match the current task version.
+// Returns domain.ErrVersionConflict if the task was modified since that version.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (s *TaskService) AssignTask(ctx context.Context, id, assignee string, version int64) (*domain.Task, error) {
+	log := s.logger.With(slog.String("task_id", id))
+	log.Debug(ctx, "assigning task", slog.String("assignee", assignee))
+
+	defer s.cache.evict(id)
+
+	task, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Debug(ctx, "task not found for assignment")
+			return nil, err
+		}
+
+		log.Error(
+			ctx,
+			"failed to get task for assignment",
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if err := checkVersion(ctx, log, task, version); err != nil {
+		return nil, err
+	}
+
+	before := *task
+	task.Assign(assignee, s.clock.Now())
+
+	if err := s.repo.Update(ctx, task); err != nil {
+		if errors.Is(err, domain.ErrVersionConflict) {
+			log.Warn(ctx, "task modified concurrently")
+			return nil, err
+		}
+
+		log.Error(
+			ctx,
+			"failed to update task in repository",
+			slog.String("error", err.Error()),
+		)
+
+		return nil, fmt.Errorf("failed to update task: %w", err)
+	}
+
+	log.Info(
+		ctx, "task assigned successfully",
+		slog.String("old_assignee", before.Assignee),
+		slog.String("new_assignee", assignee),
+	)
+	recordHistory(ctx, s.history, log, domain.Changes(&before, task, auth.ActorFromContext(ctx)))
+	return task, nil
+}
+
+// MoveTaskToProject moves an existing task into the project with the given ID;
+// an empty projectID removes the task from its project.
+// A non-zero version must match the current task version.
+// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+// Returns domain.ErrVersionConflict if the task was modified since that version.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (s *TaskService) MoveTaskToProject(
+	ctx context.Context, id, projectID string, version int64,
+) (*domain.Task, error) {
+	log := s.logger.With(slog.String("task_id", id))
+	log.Debug(ctx, "moving task to project", slog.String("project_id", projectID))
+
+	defer s.cache.evict(id)
+
+	if err := s.checkProject(ctx, log, projectID); err != nil {
+		return nil, err
+	}
+
+	task, err := modifyTask(ctx, s.repo, s.history, log, id, version, func(task *domain.Task) error {
+		task.MoveToProject(projectID, s.clock.Now())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(ctx, "task moved to project successfully", slog.String("project_id", projectID))
 	return task, nil
 }
 
-// idLength defines the number of bytes used for generating task IDs.
-const idLength = 16
+// MoveTask places an existing task in the manual order right after the task afterID
+// and right before the task beforeID; an empty ID stands for the start or the end
+// of the order. The new rank is computed from the ranks of the two tasks, so a
+// concurrent move next to the same tasks may give both moved tasks the same rank.
+// A non-zero version must match the current task version.
+// Returns domain.ErrInvalidMove if afterID does not sort before beforeID, neither is given
+// or one of them is the moved task.
+// Returns domain.ErrMoveTargetNotFound if afterID or beforeID does not exist.
+// Returns domain.ErrVersionConflict if the task was modified since that version.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (s *TaskService) MoveTask(ctx context.Context, id, afterID, beforeID string, version int64) (*domain.Task, error) {
+	log := s.logger.With(slog.String("task_id", id))
+	log.Debug(ctx, "moving task", slog.String("after", afterID), slog.String("before", beforeID))
+
+	defer s.cache.evict(id)
 
-// generateID creates a random ID for tasks.
-// It generates a 16-byte random value and returns it as a hexadecimal string.
-func generateID() (string, error) {
-	bytes := make([]byte, idLength)
-	if _, err := rand.Read(bytes); err != nil {
-		return "", err
+	if (afterID == "" && beforeID == "") || afterID == id || beforeID == id {
+		log.Warn(ctx, "task move rejected: invalid neighbors")
+		return nil, domain.ErrInvalidMove
 	}
-	return fmt.Sprintf("%x", bytes), nil
+
+	prev, err := s.neighborRank(ctx, log, afterID)
+	if err != nil {
+		return nil, err
+	}
+
+	next, err := s.neighborRank(ctx, log, beforeID)
+	if err != nil {
+		return nil, err
+	}
+
+	rank, err := domain.RankBetween(prev, next)
+	if err != nil {
+		log.Warn(ctx, "task move rejected: neighbors out of order", slog.String("after_rank", prev),
+			slog.String("before_rank", next))
+		return nil, err
+	}
+
+	task, err := modifyTask(ctx, s.repo, s.history, log, id, version, func(task *domain.Task) error {
+		task.Reorder(rank, s.clock.Now())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info(ctx, "task moved successfully", slog.String("rank", rank))
+	return task, nil
+}
+
+// neighborRank returns the rank of the task with the given ID, or an empty rank if id is empty.
+// Returns domain.ErrMoveTargetNotFound if no task exists with the given ID.
+func (s *TaskService) neighborRank(ctx context.Context, log logger.Logger, id string) (string, error) {
+	if id == "" {
+		return "", nil
+	}
+
+	task, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Debug(ctx, "move target task not found", slog.String("target_id", id))
+			return "", domain.ErrMoveTargetNotFound
+		}
+
+		log.Error(
+			ctx,
+			"failed to get move target task from repository",
+			slog.String("error", err.Error()),
+		)
+		return "", fmt.Errorf("failed to get move target task: %w", err)
+	}
+
+	return task.Rank, nil
+}
+
+// checkProject returns domain.ErrProjectNotFound if projectID is not empty
+// and no project exists with that ID.
+func (s *TaskService) checkProject(ctx context.Context, log logger.Logger, projectID string) error {
+	if projectID == "" {
+		return nil
+	}
+
+	if s.projects == nil {
+		log.Debug(ctx, "project not found", slog.String("project_id", projectID))
+		return domain.ErrProjectNotFound
+	}
+
+	if _, err := s.projects.GetByID(ctx, projectID); err != nil {
+		if errors.Is(err, domain.ErrProjectNotFound) {
+			log.Debug(ctx, "project not found", slog.String("project_id", projectID))
+			return err
+		}
+
+		log.Error(
+			ctx,
+			"failed to get project from repository",
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteTask removes a task by its unique identifier.
+// The task is read first to check its version and the delete policy, and to report
+// its status to metrics. A task in progress is deleted only if force is set.
+// A non-zero version must match the current task version. The checks are made
+// before the delete, so they do not guard against a concurrent update in between.
+// Returns domain.ErrTaskInProgress if the task is in progress and force is not set.
+// Returns domain.ErrVersionConflict if the task was modified since that version.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (s *TaskService) DeleteTask(ctx context.Context, id string, force bool, version int64) error {
+	log := s.logger.With(slog.String("task_id", id))
+	log.Debug(ctx, "deleting task", slog.Bool("force", force))
+
+	defer s.cache.evict(id)
+
+	task, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Debug(ctx, "task not found for deletion")
+			return err
+		}
+
+		log.Error(
+			ctx,
+			"failed to get task for deletion",
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to get task: %w", err)
+	}
+
+	if err := checkVersion(ctx, log, task, version); err != nil {
+		return err
+	}
+
+	if err := task.CanDelete(force); err != nil {
+		log.Warn(ctx, "task deletion rejected", slog.String("status", string(task.Status)))
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrTaskNotFound) {
+			log.Debug(ctx, "task not found for deletion")
+			return err
+		}
+
+		log.Error(
+			ctx,
+			"failed to delete task from repository",
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to delete task: %w", err)
+	}
+
+	// The deletion cannot be undone and erases the task history, so the log keeps who deleted what.
+	log.Info(
+		ctx,
+		"task deleted successfully",
+		slog.String("status", string(task.Status)),
+		slog.String("title", task.Title),
+		slog.String("actor", auth.ActorFromContext(ctx)),
+		slog.Bool("force", force),
+	)
+	s.metrics.TaskDeleted(task.Status)
+	s.publish(ctx, domain.EventTaskDeleted, task, "")
+
+	// The task is gone either way, so leftover comments, history and attachment content
+	// are only logged; they are unreachable without their task.
+	if s.comments != nil {
+		if err := s.comments.DeleteByTask(ctx, id); err != nil {
+			log.Error(ctx, "failed to delete comments of task", slog.String("error", err.Error()))
+		}
+	}
+
+	if s.history != nil {
+		if err := s.history.DeleteByTask(ctx, id); err != nil {
+			log.Error(ctx, "failed to delete history of task", slog.String("error", err.Error()))
+		}
+	}
+
+	if s.blobs != nil {
+		for _, attachment := range task.Attachments {
+			if err := s.blobs.Delete(ctx, attachment.BlobKey(id)); err != nil {
+				log.Error(
+					ctx,
+					"failed to delete attachment content of task",
+					slog.String("attachment_id", attachment.ID),
+					slog.String("error", err.Error()),
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// checkVersion returns domain.ErrVersionConflict if task does not have the expected version.
+// A zero version matches any task. A mismatch is logged to log, the logger scoped to the task.
+func checkVersion(ctx context.Context, log logger.Logger, task *domain.Task, version int64) error {
+	if version == 0 || task.Version == version {
+		return nil
+	}
+
+	log.Warn(
+		ctx,
+		"task version mismatch",
+		slog.Int64("expected_version", version), slog.Int64("actual_version", task.Version),
+	)
+	return domain.ErrVersionConflict
 }
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/asp3cto/task-manager/internal/auth"
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+// TransitionTasks moves every task matching the filter to status, such as cancelling all
+// pending tasks created before a date, and returns the number of tasks it changed.
+// Tasks that already have the status are left unchanged and not counted.
+// The transition is best-effort rather than atomic, as the tasks are stored one by one:
+// every task is checked against the workflow and the quota of the status before any task
+// is changed, and if storing a task fails, the tasks stored before it are restored.
+// Readers may observe the tasks changed before the restore, and a task modified meanwhile
+// cannot be restored; such a task is left moved, recorded like any other move, and
+// reported by an error together with the number of tasks left moved.
+// Returns domain.ErrInvalidStatus if the status or a status in the filter is not configured.
+// Returns domain.ErrInvalidTransition if a matching task cannot move to status.
+// Returns a *domain.QuotaExceededError if the quota of the status cannot take the tasks.
+// Returns domain.ErrVersionConflict if a task was modified during the transition and every
+// task already moved was restored.
+func (s *TaskService) TransitionTasks(
+	ctx context.Context, filter ports.ListFilter, status domain.TaskStatus,
+) (int, error) {
+	log := s.logger.With(slog.String("new_status", string(status)))
+	log.Debug(ctx, "transitioning tasks", slog.Any("status_filter", filter.Statuses))
+
+	if err := checkStatusFilter(ctx, s.statuses, log, filter); err != nil {
+		return 0, err
+	}
+
+	workflow, err := loadWorkflow(ctx, s.statuses, log)
+	if err != nil {
+		return 0, err
+	}
+
+	if !workflow.IsValid(status) {
+		log.Warn(ctx, "tasks transition rejected: unknown status")
+		return 0, domain.ErrInvalidStatus
+	}
+
+	now := s.clock.Now()
+	filter = filter.ResolveOverdue(now)
+	var before, after []*domain.Task
+	err = s.repo.Iterate(ctx, filter, func(task *domain.Task) error {
+		if task.Status == status {
+			return nil
+		}
+
+		changed := *task
+		if err := changed.UpdateStatus(status, workflow, now); err != nil {
+			log.Warn(
+				ctx, "tasks transition rejected",
+				slog.String("task_id", task.ID),
+				slog.String("old_status", string(task.Status)),
+				slog.String("error", err.Error()),
+			)
+			return err
+		}
+
+		before = append(before, task)
+		after = append(after, &changed)
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidTransition) {
+			return 0, err
+		}
+
+		log.Error(ctx, "failed to get tasks for transition", slog.String("error", err.Error()))
+		return 0, fmt.Errorf("failed to get tasks: %w", err)
+	}
+
+	if len(after) == 0 {
+		log.Info(ctx, "no tasks to transition")
+		return 0, nil
+	}
+
+	if err := s.checkQuota(ctx, log, status, len(after), false); err != nil {
+		return 0, err
+	}
+
+	ids := make([]string, len(after))
+	for i, task := range after {
+		ids[i] = task.ID
+	}
+	defer s.cache.evict(ids...)
+
+	for i, task := range after {
+		if err := s.repo.Update(ctx, task); err != nil {
+			if moved := s.restoreTasks(ctx, log, before[:i], after[:i]); len(moved) > 0 {
+				for _, j := range moved {
+					s.recordTransition(ctx, log, before[j], after[j])
+				}
+
+				log.Error(
+					ctx,
+					"tasks transition partially applied",
+					slog.Int("moved", len(moved)),
+					slog.String("task_id", task.ID),
+					slog.String("error", err.Error()),
+				)
+				return len(moved), fmt.Errorf(
+					"failed to update task %s, %d tasks already moved could not be restored: %w",
+					task.ID, len(moved), errTransitionPartial,
+				)
+			}
+
+			if errors.Is(err, domain.ErrVersionConflict) || errors.Is(err, domain.ErrTaskNotFound) {
+				log.Warn(ctx, "task modified during transition", slog.String("task_id", task.ID))
+				return 0, domain.ErrVersionConflict
+			}
+
+			log.Error(
+				ctx,
+				"failed to update task in repository",
+				slog.String("task_id", task.ID),
+				slog.String("error", err.Error()),
+			)
+			return 0, fmt.Errorf("failed to update task: %w", err)
+		}
+	}
+
+	for i, task := range after {
+		s.recordTransition(ctx, log, before[i], task)
+	}
+
+	log.Info(ctx, "tasks transitioned successfully", slog.Int("count", len(after)))
+	return len(after), nil
+}
+
+// errTransitionPartial reports a failed transition that left some tasks moved.
+var errTransitionPartial = errors.New("tasks transition partially applied")
+
+// recordTransition records the move of a task from its before to its after state
+// in the metrics and the history, and publishes it.
+func (s *TaskService) recordTransition(ctx context.Context, log logger.Logger, before, after *domain.Task) {
+	s.metrics.TaskStatusChanged(before.Status, after.Status)
+	recordHistory(ctx, s.history, log, domain.Changes(before, after, auth.ActorFromContext(ctx)))
+	s.publish(ctx, domain.EventTaskStatusChanged, after, before.Status)
+}
+
+// restoreTasks stores the before state of tasks that a failed transition already changed
+// to their after state, and returns the indexes of the tasks that could not be restored,
+// such as tasks modified meanwhile. These tasks are left changed.
+func (s *TaskService) restoreTasks(ctx context.Context, log logger.Logger, before, after []*domain.Task) []int {
+	var moved []int
+	for i, task := range before {
+		restored := *task
+		restored.Version = after[i].Version
+		if err := s.repo.Update(ctx, &restored); err != nil {
+			log.Error(
+				ctx,
+				"failed to restore task after failed transition",
+				slog.String("task_id", task.ID),
+				slog.String("error", err.Error()),
+			)
+			moved = append(moved, i)
+		}
+	}
+
+	return moved
+}
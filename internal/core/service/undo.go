@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+)
+
+// DefaultUndoWindow is how long after a change the change can be undone by default.
+const DefaultUndoWindow = 15 * time.Minute
+
+// WithUndoWindow allows the last change of a task to be undone for window after it was made.
+func WithUndoWindow(window time.Duration) Option {
+	return func(s *TaskService) {
+		s.undoWindow = window
+	}
+}
+
+// UndoWindowFromEnv reads the TASK_UNDO_WINDOW environment variable, a positive duration.
+// Returns DefaultUndoWindow if the variable is not set.
+func UndoWindowFromEnv() (time.Duration, error) {
+	raw := os.Getenv("TASK_UNDO_WINDOW")
+	if raw == "" {
+		return DefaultUndoWindow, nil
+	}
+
+	window, err := time.ParseDuration(raw)
+	if err != nil || window <= 0 {
+		return 0, fmt.Errorf("TASK_UNDO_WINDOW must be a positive duration, got: %s", raw)
+	}
+	return window, nil
+}
+
+// UndoTask reverts the most recent change of an existing task, as recorded in its history,
+// and returns the restored task. The revert is itself a change of the task, so undoing
+// twice restores the undone change.
+// A non-zero version must match the current task version.
+// Returns domain.ErrNothingToUndo if the task has not changed since it was created or no history is kept.
+// Returns domain.ErrUndoExpired if the last change is older than the undo window.
+// Returns domain.ErrCannotUndo if the last change cannot be reverted, restores a status that is
+// no longer configured, or the history does not record the current version of the task.
+// Returns a *domain.QuotaExceededError if the restored status is full.
+// Returns domain.ErrVersionConflict if the task was modified since that version.
+// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+func (s *TaskService) UndoTask(ctx context.Context, id string, version int64) (*domain.Task, error) {
+	log := s.logger.With(slog.String("task_id", id))
+	log.Debug(ctx, "undoing last task change")
+
+	defer s.cache.evict(id)
+
+	if s.history == nil {
+		log.Warn(ctx, "task undo rejected: no history is kept")
+		return nil, domain.ErrNothingToUndo
+	}
+
+	entries, err := s.history.ListByTask(ctx, id)
+	if err != nil {
+		log.Error(
+			ctx,
+			"failed to list task history from repository",
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to list task history: %w", err)
+	}
+	change := domain.LastChange(entries)
+
+	var oldStatus domain.TaskStatus
+	task, err := modifyTask(ctx, s.repo, s.history, log, id, version, func(task *domain.Task) error {
+		if len(change) == 0 {
+			return domain.ErrNothingToUndo
+		}
+
+		// A change recorded after the history was read, or not recorded at all, would be lost.
+		if change[0].Version != task.Version {
+			return domain.ErrCannotUndo
+		}
+
+		now := s.clock.Now()
+		if now.Sub(change[0].ChangedAt) > s.undoWindow {
+			return domain.ErrUndoExpired
+		}
+
+		oldStatus = task.Status
+		oldProject := task.ProjectID
+		if err := task.Revert(change, now); err != nil {
+			return err
+		}
+
+		// The old status may have been removed from the configuration since, and moving
+		// the task back counts against the quota of that status like any other move.
+		if task.Status != oldStatus {
+			workflow, err := loadWorkflow(ctx, s.statuses, log)
+			if err != nil {
+				return err
+			}
+			if !workflow.IsValid(task.Status) {
+				log.Warn(
+					ctx, "task undo rejected: restored status is not configured",
+					slog.String("status", string(task.Status)),
+				)
+				return domain.ErrCannotUndo
+			}
+
+			if err := s.checkQuota(ctx, log, task.Status, 1, false); err != nil {
+				return err
+			}
+		}
+
+		if task.ProjectID != oldProject {
+			if err := s.checkProject(ctx, log, task.ProjectID); err != nil {
+				if errors.Is(err, domain.ErrProjectNotFound) {
+					return domain.ErrCannotUndo
+				}
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Status != oldStatus {
+		s.metrics.TaskStatusChanged(oldStatus, task.Status)
+		s.publish(ctx, domain.EventTaskStatusChanged, task, oldStatus)
+	}
+
+	log.Info(ctx, "task change undone successfully", slog.Int64("undone_version", change[0].Version))
+	return task, nil
+}
@@ -0,0 +1,307 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var _ ports.WebhookService = (*WebhookService)(nil)
+
+// WebhookService implements the subscriptions of external endpoints to task events.
+// It manages the subscriptions and the deliveries given up on; the events are
+// delivered by an adapter subscribed to the event bus.
+type WebhookService struct {
+	webhooks    ports.WebhookRepository
+	deadLetters ports.WebhookDeadLetterRepository
+	deliverer   ports.WebhookDeliverer
+	ids         ports.IDGenerator
+	clock       ports.Clock
+	logger      logger.Logger
+}
+
+// NewWebhookService creates a new instance of WebhookService storing webhooks in webhooks.
+// The deliveries given up on are read from deadLetters and replayed with deliverer.
+// Webhook IDs are generated with ids and timestamps taken from clock.
+func NewWebhookService(
+	webhooks ports.WebhookRepository,
+	deadLetters ports.WebhookDeadLetterRepository,
+	deliverer ports.WebhookDeliverer,
+	ids ports.IDGenerator,
+	clock ports.Clock,
+	logger logger.Logger,
+) *WebhookService {
+	return &WebhookService{
+		webhooks:    webhooks,
+		deadLetters: deadLetters,
+		deliverer:   deliverer,
+		ids:         ids,
+		clock:       clock,
+		logger:      logger.With(slog.String("component", "service")),
+	}
+}
+
+// CreateWebhook subscribes url to the events of the given types, signing deliveries with secret.
+// Returns domain.ErrInvalidWebhookURL if the URL is not an absolute http or https URL.
+// Returns domain.ErrWebhookURLNotAllowed if the URL points to a private or local address.
+// Returns domain.ErrNoWebhookEvents if no event type is given.
+// Returns domain.ErrInvalidEventType if an event type is unknown.
+// Returns domain.ErrEmptyWebhookSecret if the secret is empty.
+func (s *WebhookService) CreateWebhook(
+	ctx context.Context, url string, events []domain.EventType, secret string,
+) (*domain.Webhook, error) {
+	s.logger.Debug(ctx, "creating webhook", slog.Any("events", events))
+
+	id, err := s.ids.NewID()
+	if err != nil {
+		s.logger.Error(ctx, "failed to generate ID", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to generate ID: %w", err)
+	}
+
+	log := s.logger.With(slog.String("webhook_id", id))
+	webhook, err := domain.NewWebhook(id, url, events, secret, s.clock.Now())
+	if err == nil {
+		err = s.deliverer.CheckURL(ctx, webhook.URL)
+	}
+	if err != nil {
+		log.Warn(ctx, "webhook rejected", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := s.webhooks.Create(ctx, webhook); err != nil {
+		log.Error(
+			ctx,
+			"failed to create webhook in repository",
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	// The URL is not logged, as endpoints often carry credentials in it.
+	log.Info(ctx, "webhook created successfully", slog.Any("events", webhook.Events))
+	return webhook, nil
+}
+
+// GetWebhook retrieves a webhook by its unique identifier.
+// Returns domain.ErrWebhookNotFound if no webhook exists with the given ID.
+func (s *WebhookService) GetWebhook(ctx context.Context, id string) (*domain.Webhook, error) {
+	log := s.logger.With(slog.String("webhook_id", id))
+	log.Debug(ctx, "getting webhook")
+
+	return s.getWebhook(ctx, log, id)
+}
+
+// ListWebhooks returns all webhooks, oldest first.
+func (s *WebhookService) ListWebhooks(ctx context.Context) ([]*domain.Webhook, error) {
+	s.logger.Debug(ctx, "listing webhooks")
+
+	webhooks, err := s.webhooks.GetAll(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "failed to list webhooks from repository", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	s.logger.Debug(ctx, "webhooks retrieved successfully", slog.Int("count", len(webhooks)))
+	return webhooks, nil
+}
+
+// UpdateWebhook changes the URL, event types and/or secret of a webhook.
+// Nil arguments leave the corresponding field unchanged.
+// Returns the errors of CreateWebhook for invalid values.
+// Returns domain.ErrWebhookNotFound if no webhook exists with the given ID.
+func (s *WebhookService) UpdateWebhook(
+	ctx context.Context, id string, url *string, events []domain.EventType, secret *string,
+) (*domain.Webhook, error) {
+	log := s.logger.With(slog.String("webhook_id", id))
+	log.Debug(ctx, "updating webhook")
+
+	webhook, err := s.getWebhook(ctx, log, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = webhook.Update(url, events, secret, s.clock.Now())
+	if err == nil && url != nil {
+		err = s.deliverer.CheckURL(ctx, webhook.URL)
+	}
+	if err != nil {
+		log.Warn(ctx, "webhook update rejected", slog.String("error", err.Error()))
+		return nil, err
+	}
+
+	if err := s.webhooks.Update(ctx, webhook); err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			log.Debug(ctx, "webhook deleted before update was stored")
+			return nil, err
+		}
+
+		log.Error(
+			ctx,
+			"failed to update webhook in repository",
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	log.Info(ctx, "webhook updated successfully")
+	return webhook, nil
+}
+
+// DeleteWebhook removes a webhook. Deliveries already queued for it are still made.
+// Returns domain.ErrWebhookNotFound if no webhook exists with the given ID.
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id string) error {
+	log := s.logger.With(slog.String("webhook_id", id))
+	log.Debug(ctx, "deleting webhook")
+
+	if err := s.webhooks.Delete(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			log.Debug(ctx, "webhook not found for deletion")
+			return err
+		}
+
+		log.Error(
+			ctx,
+			"failed to delete webhook from repository",
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	log.Info(ctx, "webhook deleted successfully")
+	return nil
+}
+
+// ListDeadLetters returns the deliveries given up on, oldest first.
+func (s *WebhookService) ListDeadLetters(ctx context.Context) ([]*domain.WebhookDeadLetter, error) {
+	s.logger.Debug(ctx, "listing dead letters")
+
+	letters, err := s.deadLetters.GetAll(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "failed to list dead letters from repository", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+
+	s.logger.Debug(ctx, "dead letters retrieved successfully", slog.Int("count", len(letters)))
+	return letters, nil
+}
+
+// GetDeadLetter retrieves a delivery given up on by its unique identifier.
+// Returns domain.ErrDeadLetterNotFound if no dead letter exists with the given ID.
+func (s *WebhookService) GetDeadLetter(ctx context.Context, id string) (*domain.WebhookDeadLetter, error) {
+	log := s.logger.With(slog.String("dead_letter_id", id))
+	log.Debug(ctx, "getting dead letter")
+
+	return s.getDeadLetter(ctx, log, id)
+}
+
+// ReplayDeadLetter delivers the event of a dead letter to its webhook once more,
+// with the current URL and secret of the webhook, and removes the dead letter on success.
+// A failed replay leaves the dead letter as it was.
+// Returns domain.ErrDeadLetterNotFound if no dead letter exists with the given ID.
+// Returns domain.ErrWebhookNotFound if the webhook was deleted since.
+// Returns domain.ErrWebhookDeliveryFailed if the delivery fails again.
+func (s *WebhookService) ReplayDeadLetter(ctx context.Context, id string) error {
+	log := s.logger.With(slog.String("dead_letter_id", id))
+	log.Debug(ctx, "replaying dead letter")
+
+	letter, err := s.getDeadLetter(ctx, log, id)
+	if err != nil {
+		return err
+	}
+
+	log = log.With(slog.String("webhook_id", letter.WebhookID), slog.String("event_id", letter.Event.ID))
+	webhook, err := s.getWebhook(ctx, log, letter.WebhookID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.deliverer.Deliver(ctx, webhook, letter.Event); err != nil {
+		log.Warn(ctx, "dead letter replay failed", slog.String("error", err.Error()))
+		return fmt.Errorf("%w: %v", domain.ErrWebhookDeliveryFailed, err)
+	}
+
+	if err := s.deadLetters.Delete(ctx, id); err != nil && !errors.Is(err, domain.ErrDeadLetterNotFound) {
+		// The event was delivered, so the replay succeeded; the dead letter is only left over.
+		log.Error(
+			ctx,
+			"failed to delete replayed dead letter from repository",
+			slog.String("error", err.Error()),
+		)
+	}
+
+	log.Info(ctx, "dead letter replayed successfully")
+	return nil
+}
+
+// DeleteDeadLetter removes a delivery given up on without replaying it.
+// Returns domain.ErrDeadLetterNotFound if no dead letter exists with the given ID.
+func (s *WebhookService) DeleteDeadLetter(ctx context.Context, id string) error {
+	log := s.logger.With(slog.String("dead_letter_id", id))
+	log.Debug(ctx, "deleting dead letter")
+
+	if err := s.deadLetters.Delete(ctx, id); err != nil {
+		if errors.Is(err, domain.ErrDeadLetterNotFound) {
+			log.Debug(ctx, "dead letter not found for deletion")
+			return err
+		}
+
+		log.Error(
+			ctx,
+			"failed to delete dead letter from repository",
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to delete dead letter: %w", err)
+	}
+
+	log.Info(ctx, "dead letter deleted successfully")
+	return nil
+}
+
+// getDeadLetter reads a dead letter from the repository.
+// Returns domain.ErrDeadLetterNotFound if no dead letter exists with the given ID.
+func (s *WebhookService) getDeadLetter(
+	ctx context.Context, log logger.Logger, id string,
+) (*domain.WebhookDeadLetter, error) {
+	letter, err := s.deadLetters.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrDeadLetterNotFound) {
+			log.Debug(ctx, "dead letter not found")
+			return nil, err
+		}
+
+		log.Error(
+			ctx,
+			"failed to get dead letter from repository",
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to get dead letter: %w", err)
+	}
+
+	return letter, nil
+}
+
+// getWebhook reads a webhook from the repository.
+// Returns domain.ErrWebhookNotFound if no webhook exists with the given ID.
+func (s *WebhookService) getWebhook(ctx context.Context, log logger.Logger, id string) (*domain.Webhook, error) {
+	webhook, err := s.webhooks.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			log.Debug(ctx, "webhook not found")
+			return nil, err
+		}
+
+		log.Error(
+			ctx,
+			"failed to get webhook from repository",
+			slog.String("error", err.Error()),
+		)
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	return webhook, nil
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// AnonymizedTitle replaces the title and the subtask titles of anonymized tasks.
+const AnonymizedTitle = "[anonymized]"
+
+// Anonymize erases the user-provided content of the task: the title and subtask titles
+// are replaced by AnonymizedTitle, and the description, assignee, tags and attachments
+// are removed. The status, dates, priority, project and progress are kept, as is
+// UpdatedAt, so the task keeps aging like before. The attachment content has to be
+// removed by the caller.
+func (t *Task) Anonymize() {
+	t.Title = AnonymizedTitle
+	t.Description = ""
+	t.Assignee = ""
+	t.Tags = nil
+	t.Attachments = nil
+
+	if len(t.Subtasks) > 0 {
+		subtasks := make([]Subtask, len(t.Subtasks))
+		for i, subtask := range t.Subtasks {
+			subtasks[i] = Subtask{ID: subtask.ID, Title: AnonymizedTitle, Done: subtask.Done}
+		}
+		t.Subtasks = subtasks
+	}
+}
+
+// IsAnonymized reports whether the content of the task has already been erased by Anonymize.
+func (t *Task) IsAnonymized() bool {
+	return t.Title == AnonymizedTitle && t.Description == "" && t.Assignee == "" &&
+		len(t.Tags) == 0 && len(t.Attachments) == 0 &&
+		!slices.ContainsFunc(t.Subtasks, func(s Subtask) bool { return s.Title != AnonymizedTitle })
+}
+
+// AnonymizationEntry returns the history entry recording that task was anonymized by actor at now.
+// It replaces the earlier history of the task, which holds the erased content, so, like
+// the creation of a task, it cannot be undone.
+func AnonymizationEntry(task *Task, actor string, now time.Time) HistoryEntry {
+	return HistoryEntry{
+		TaskID:    task.ID,
+		Version:   task.Version,
+		Field:     HistoryFieldTask,
+		NewValue:  "anonymized",
+		Actor:     actor,
+		ChangedAt: now,
+	}
+}
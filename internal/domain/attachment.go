@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// MaxAttachments is the maximum number of files that can be attached to a single task.
+const MaxAttachments = 20
+
+var (
+	// ErrAttachmentNotFound is returned when an attachment with the specified ID does not exist in the task.
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	// ErrTooManyAttachments is returned when attaching a file to a task that already has MaxAttachments.
+	ErrTooManyAttachments = errors.New("too many attachments")
+)
+
+// Attachment describes a file attached to a task. The content itself is kept in a blob store.
+type Attachment struct {
+	// ID is the identifier of the attachment, unique within its task.
+	ID string `json:"id"`
+	// Filename is the name of the file as uploaded.
+	Filename string `json:"filename"`
+	// ContentType is the media type of the file.
+	ContentType string `json:"content_type"`
+	// Size is the length of the file in bytes.
+	Size int64 `json:"size"`
+	// Checksum is the hex-encoded SHA-256 digest of the file.
+	Checksum string `json:"checksum"`
+	// CreatedAt is the timestamp when the file was attached.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BlobKey returns the key of the attachment content in the blob store.
+func (a Attachment) BlobKey(taskID string) string {
+	return taskID + "/" + a.ID
+}
+
+// CanAttach returns ErrTooManyAttachments if the task already has MaxAttachments.
+func (t *Task) CanAttach() error {
+	if len(t.Attachments) >= MaxAttachments {
+		return ErrTooManyAttachments
+	}
+	return nil
+}
+
+// Attach records an uploaded file in the task and sets UpdatedAt to now.
+// Returns ErrTooManyAttachments if the task already has MaxAttachments.
+func (t *Task) Attach(attachment Attachment, now time.Time) error {
+	if err := t.CanAttach(); err != nil {
+		return err
+	}
+
+	t.Attachments = append(slices.Clone(t.Attachments), attachment)
+	t.UpdatedAt = now
+	return nil
+}
+
+// Attachment returns the attachment with the given ID.
+// Returns ErrAttachmentNotFound if the task has no such attachment.
+func (t *Task) Attachment(id string) (Attachment, error) {
+	i := t.attachmentIndex(id)
+	if i < 0 {
+		return Attachment{}, ErrAttachmentNotFound
+	}
+	return t.Attachments[i], nil
+}
+
+// Detach removes the attachment with the given ID from the task, sets UpdatedAt to now
+// and returns the attachment.
+// Returns ErrAttachmentNotFound if the task has no such attachment.
+func (t *Task) Detach(id string, now time.Time) (Attachment, error) {
+	i := t.attachmentIndex(id)
+	if i < 0 {
+		return Attachment{}, ErrAttachmentNotFound
+	}
+
+	attachment := t.Attachments[i]
+	t.Attachments = slices.Delete(slices.Clone(t.Attachments), i, i+1)
+	t.UpdatedAt = now
+	return attachment, nil
+}
+
+// attachmentIndex returns the index of the attachment with the given ID, or -1 if there is none.
+func (t *Task) attachmentIndex(id string) int {
+	return slices.IndexFunc(t.Attachments, func(attachment Attachment) bool {
+		return attachment.ID == id
+	})
+}
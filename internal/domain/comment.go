@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrCommentNotFound is returned when a comment with the specified ID does not exist on the task.
+	ErrCommentNotFound = errors.New("comment not found")
+	// ErrEmptyCommentBody is returned when attempting to create a comment without a body.
+	ErrEmptyCommentBody = errors.New("comment body cannot be empty")
+	// ErrEmptyCommentAuthor is returned when attempting to create a comment without an author.
+	ErrEmptyCommentAuthor = errors.New("comment author cannot be empty")
+)
+
+// Comment is a remark left on a task as part of the discussion around it.
+type Comment struct {
+	// ID is the unique identifier for the comment.
+	ID string `json:"id"`
+	// TaskID identifies the task the comment belongs to.
+	TaskID string `json:"task_id"`
+	// Author identifies who wrote the comment.
+	Author string `json:"author"`
+	// Body is the text of the comment.
+	Body string `json:"body"`
+	// CreatedAt is the timestamp when the comment was written.
+	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt is the timestamp when the comment was last modified.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewComment creates a new comment on the task with the given ID, written at now.
+// Returns ErrEmptyCommentAuthor if the author is empty.
+// Returns ErrEmptyCommentBody if the body is empty.
+func NewComment(id, taskID, author, body string, now time.Time) (*Comment, error) {
+	if author == "" {
+		return nil, ErrEmptyCommentAuthor
+	}
+
+	if body == "" {
+		return nil, ErrEmptyCommentBody
+	}
+
+	return &Comment{
+		ID:        id,
+		TaskID:    taskID,
+		Author:    author,
+		Body:      body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
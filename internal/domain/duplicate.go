@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrDuplicateTask is matched by every DuplicateTaskError.
+var ErrDuplicateTask = errors.New("task with the same title already exists")
+
+// DuplicateTaskError is returned when a new task duplicates an unfinished task.
+type DuplicateTaskError struct {
+	// ExistingID identifies the unfinished task with the same title.
+	ExistingID string
+}
+
+// Error returns the message of ErrDuplicateTask with the ID of the existing task.
+func (e *DuplicateTaskError) Error() string {
+	return ErrDuplicateTask.Error() + ": " + e.ExistingID
+}
+
+// Is reports whether target is ErrDuplicateTask.
+func (e *DuplicateTaskError) Is(target error) bool {
+	return target == ErrDuplicateTask
+}
+
+// IsDuplicateOf reports whether a new task with the given title would duplicate the task:
+// the task is unfinished, neither completed nor cancelled, and its title equals title
+// ignoring case.
+func (t *Task) IsDuplicateOf(title string) bool {
+	if t.Status == StatusCompleted || t.Status == StatusCancelled {
+		return false
+	}
+	return strings.EqualFold(t.Title, title)
+}
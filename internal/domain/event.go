@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// EventType names a kind of change in the task lifecycle.
+type EventType string
+
+// Task lifecycle events.
+const (
+	// EventTaskCreated is emitted after a task is created.
+	EventTaskCreated EventType = "task.created"
+	// EventTaskStatusChanged is emitted after a task moves to another status.
+	EventTaskStatusChanged EventType = "task.status_changed"
+	// EventTaskDeleted is emitted after a task is deleted.
+	EventTaskDeleted EventType = "task.deleted"
+)
+
+// EventTypes lists all event types, in the order of the task lifecycle.
+var EventTypes = []EventType{EventTaskCreated, EventTaskStatusChanged, EventTaskDeleted}
+
+// IsValidEventType reports whether t is a known event type.
+func IsValidEventType(t EventType) bool {
+	return slices.Contains(EventTypes, t)
+}
+
+// Event records a change in the task lifecycle, so other components can react to it
+// without being called by the code making the change.
+type Event struct {
+	// ID uniquely identifies the event, so consumers can ignore redeliveries.
+	ID string `json:"id"`
+	// Type is the kind of change.
+	Type EventType `json:"type"`
+	// OccurredAt is the timestamp of the change.
+	OccurredAt time.Time `json:"occurred_at"`
+	// Actor identifies who made the change; empty if unknown.
+	Actor string `json:"actor,omitempty"`
+	// Task is the task after the change, or before it for a deleted task.
+	Task Task `json:"task"`
+	// OldStatus is the status the task had before a status change; empty for other events.
+	OldStatus TaskStatus `json:"old_status,omitempty"`
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HistoryFieldTask is the field of the history entry recording the creation of a task.
+const HistoryFieldTask = "task"
+
+// HistoryEntry records a change of one field of a task.
+type HistoryEntry struct {
+	// TaskID identifies the changed task.
+	TaskID string `json:"task_id"`
+	// Version is the version of the task the change produced.
+	Version int64 `json:"version"`
+	// Field names the changed field, such as status or subtasks.<id>.done.
+	Field string `json:"field"`
+	// OldValue is the value before the change; empty if the field had no value.
+	OldValue string `json:"old_value"`
+	// NewValue is the value after the change; empty if the field has no value anymore.
+	NewValue string `json:"new_value"`
+	// Actor identifies who made the change; empty if unknown.
+	Actor string `json:"actor,omitempty"`
+	// ChangedAt is the timestamp of the change.
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// CreationEntry returns the history entry recording that task was created by actor.
+func CreationEntry(task *Task, actor string) HistoryEntry {
+	return HistoryEntry{
+		TaskID:    task.ID,
+		Version:   task.Version,
+		Field:     HistoryFieldTask,
+		NewValue:  "created",
+		Actor:     actor,
+		ChangedAt: task.CreatedAt,
+	}
+}
+
+// Changes returns the history entries for the fields that differ between before and
+// after, two states of the same task, as changed by actor.
+// Subtasks and attachments are compared one by one: an added or removed item is
+// recorded under subtasks.<id> or attachments.<id>, a changed subtask under
+// subtasks.<id>.title or subtasks.<id>.done, and a new order of the subtasks
+// under subtasks as the comma-separated subtask IDs.
+func Changes(before, after *Task, actor string) []HistoryEntry {
+	var entries []HistoryEntry
+	add := func(field, oldValue, newValue string) {
+		if oldValue == newValue {
+			return
+		}
+
+		entries = append(entries, HistoryEntry{
+			TaskID:    after.ID,
+			Version:   after.Version,
+			Field:     field,
+			OldValue:  oldValue,
+			NewValue:  newValue,
+			Actor:     actor,
+			ChangedAt: after.UpdatedAt,
+		})
+	}
+
+	add("title", before.Title, after.Title)
+	add("description", before.Description, after.Description)
+	add("status", string(before.Status), string(after.Status))
+	add("due_date", formatDueDate(before.DueDate), formatDueDate(after.DueDate))
+	add("priority", string(before.Priority), string(after.Priority))
+	add("assignee", before.Assignee, after.Assignee)
+	add("project_id", before.ProjectID, after.ProjectID)
+	add("rank", before.Rank, after.Rank)
+	add("estimate", strconv.Itoa(before.Estimate), strconv.Itoa(after.Estimate))
+	add("progress", strconv.Itoa(before.Progress), strconv.Itoa(after.Progress))
+	add("tags", strings.Join(before.Tags, ","), strings.Join(after.Tags, ","))
+
+	var oldOrder, newOrder []string
+	for _, old := range before.Subtasks {
+		i := slices.IndexFunc(after.Subtasks, func(s Subtask) bool { return s.ID == old.ID })
+		if i < 0 {
+			add("subtasks."+old.ID, old.Title, "")
+			continue
+		}
+
+		oldOrder = append(oldOrder, old.ID)
+		add("subtasks."+old.ID+".title", old.Title, after.Subtasks[i].Title)
+		add("subtasks."+old.ID+".done", strconv.FormatBool(old.Done), strconv.FormatBool(after.Subtasks[i].Done))
+	}
+
+	for _, subtask := range after.Subtasks {
+		if slices.ContainsFunc(before.Subtasks, func(s Subtask) bool { return s.ID == subtask.ID }) {
+			newOrder = append(newOrder, subtask.ID)
+		} else {
+			add("subtasks."+subtask.ID, "", subtask.Title)
+		}
+	}
+	add("subtasks", strings.Join(oldOrder, ","), strings.Join(newOrder, ","))
+
+	for _, old := range before.Attachments {
+		if !slices.ContainsFunc(after.Attachments, func(a Attachment) bool { return a.ID == old.ID }) {
+			add("attachments."+old.ID, old.Filename, "")
+		}
+	}
+
+	for _, attachment := range after.Attachments {
+		if !slices.ContainsFunc(before.Attachments, func(a Attachment) bool { return a.ID == attachment.ID }) {
+			add("attachments."+attachment.ID, "", attachment.Filename)
+		}
+	}
+
+	return entries
+}
+
+// formatDueDate formats a due date for the history; a missing due date is empty.
+func formatDueDate(dueDate *time.Time) string {
+	if dueDate == nil {
+		return ""
+	}
+	return dueDate.UTC().Format(time.RFC3339)
+}
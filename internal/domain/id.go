@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+)
+
+// ErrInvalidTaskID is returned when a client-supplied task ID does not have the format of task IDs.
+var ErrInvalidTaskID = errors.New("invalid task ID")
+
+// taskID is the format of task IDs in any of the formats IDs are generated in:
+// 32 lowercase hexadecimal digits, a lowercase UUID or a ULID.
+var taskID = regexp.MustCompile(
+	`^([a-f0-9]{32}|[a-f0-9]{8}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{4}-[a-f0-9]{12}|[0-7][0-9A-HJKMNP-TV-Z]{25})$`,
+)
+
+// ValidateTaskID checks that id has one of the formats of the IDs generated for tasks,
+// so that a client can choose the ID of a new task.
+// Returns ErrInvalidTaskID if it does not.
+func ValidateTaskID(id string) error {
+	if !taskID.MatchString(id) {
+		return ErrInvalidTaskID
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package domain
+
+import "errors"
+
+var (
+	// ErrInvalidEstimate is returned when a task estimate is negative.
+	ErrInvalidEstimate = errors.New("task estimate cannot be negative")
+	// ErrInvalidProgress is returned when a task progress is not a percentage between 0 and 100.
+	ErrInvalidProgress = errors.New("task progress must be between 0 and 100")
+)
+
+// MaxProgress is the progress of a task that is fully done, in percent.
+const MaxProgress = 100
+
+// ProjectProgress rolls up the estimates and the progress of the tasks of a project.
+// Cancelled tasks are left out, and completed tasks count as fully done whatever
+// progress they report.
+type ProjectProgress struct {
+	// ProjectID identifies the project.
+	ProjectID string `json:"project_id"`
+	// Tasks counts the tasks of the project that are not cancelled.
+	Tasks int `json:"tasks"`
+	// CompletedTasks counts the completed tasks of the project.
+	CompletedTasks int `json:"completed_tasks"`
+	// Estimate is the sum of the estimates of the tasks, in story points.
+	Estimate int `json:"estimate"`
+	// Done is the part of Estimate already done: the estimates of the tasks weighted
+	// by their progress, rounded down to whole story points.
+	Done int `json:"done"`
+	// Progress is the overall progress of the project in percent: Done relative to
+	// Estimate, or the average progress of the tasks if none of them is estimated.
+	Progress int `json:"progress"`
+
+	// weighted sums the estimates multiplied by the progress of their tasks.
+	weighted int
+	// progress sums the progress of the tasks.
+	progress int
+}
+
+// Add counts task in the rollup.
+func (p *ProjectProgress) Add(task *Task) {
+	if task.Status == StatusCancelled {
+		return
+	}
+
+	progress := task.Progress
+	if task.Status == StatusCompleted {
+		progress = MaxProgress
+		p.CompletedTasks++
+	}
+
+	p.Tasks++
+	p.Estimate += task.Estimate
+	p.weighted += task.Estimate * progress
+	p.progress += progress
+
+	p.Done = p.weighted / MaxProgress
+	if p.Estimate > 0 {
+		p.Progress = p.weighted / p.Estimate
+	} else {
+		p.Progress = p.progress / p.Tasks
+	}
+}
+
+// validateProgress checks an estimate and a progress to set on a task; nil values are not checked.
+// Returns ErrInvalidEstimate if the estimate is negative.
+// Returns ErrInvalidProgress if the progress is not between 0 and MaxProgress.
+func validateProgress(estimate, progress *int) error {
+	if estimate != nil && *estimate < 0 {
+		return ErrInvalidEstimate
+	}
+
+	if progress != nil && (*progress < 0 || *progress > MaxProgress) {
+		return ErrInvalidProgress
+	}
+
+	return nil
+}
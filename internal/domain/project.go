@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrProjectNotFound is returned when a project with the specified ID does not exist.
+	ErrProjectNotFound = errors.New("project not found")
+	// ErrProjectExists is returned when creating a project with an ID that is already taken.
+	ErrProjectExists = errors.New("project already exists")
+	// ErrEmptyProjectName is returned when attempting to create a project without a name.
+	ErrEmptyProjectName = errors.New("project name cannot be empty")
+	// ErrProjectNotEmpty is returned when attempting to delete a project that still has tasks.
+	ErrProjectNotEmpty = errors.New("project still has tasks")
+)
+
+// Project groups related tasks into a list.
+type Project struct {
+	// ID is the unique identifier for the project.
+	ID string `json:"id"`
+	// Name is the short name of the project.
+	Name string `json:"name"`
+	// Description provides detailed information about the project.
+	Description string `json:"description"`
+	// CreatedAt is the timestamp when the project was created.
+	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt is the timestamp when the project was last modified.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewProject creates a new project with the given name and description, created at now.
+// Returns ErrEmptyProjectName if the name is empty.
+func NewProject(id, name, description string, now time.Time) (*Project, error) {
+	if name == "" {
+		return nil, ErrEmptyProjectName
+	}
+
+	return &Project{
+		ID:          id,
+		Name:        name,
+		Description: description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// Update changes the project's name and/or description.
+// Nil arguments leave the corresponding field unchanged. UpdatedAt is set to now
+// only if a field actually changes.
+// Returns ErrEmptyProjectName if the name is explicitly set to an empty string.
+func (p *Project) Update(name, description *string, now time.Time) error {
+	if name != nil && *name == "" {
+		return ErrEmptyProjectName
+	}
+
+	changed := false
+	if name != nil && *name != p.Name {
+		p.Name = *name
+		changed = true
+	}
+
+	if description != nil && *description != p.Description {
+		p.Description = *description
+		changed = true
+	}
+
+	if changed {
+		p.UpdatedAt = now
+	}
+
+	return nil
+}
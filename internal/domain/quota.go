@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+)
+
+// ErrQuotaExceeded is matched by every QuotaExceededError.
+var ErrQuotaExceeded = errors.New("task quota exceeded")
+
+// QuotaExceededError is returned when a task would exceed the configured number of tasks,
+// in total or with one status.
+type QuotaExceededError struct {
+	// Status is the status whose quota is exhausted; empty for the quota on all tasks.
+	Status TaskStatus
+	// Limit is the number of tasks the quota allows.
+	Limit int
+}
+
+// Error returns the message of ErrQuotaExceeded with the exhausted quota.
+func (e *QuotaExceededError) Error() string {
+	if e.Status == "" {
+		return ErrQuotaExceeded.Error() + ": at most " + strconv.Itoa(e.Limit) + " tasks"
+	}
+	return ErrQuotaExceeded.Error() + ": at most " + strconv.Itoa(e.Limit) + " tasks " + string(e.Status)
+}
+
+// Is reports whether target is ErrQuotaExceeded.
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// rankDigits are the digits of a rank in ascending order. A rank is read as the
+// base-36 fraction 0.d1d2d3..., and since the digits are in ASCII order, ranks
+// without trailing zeros compare as strings the same way as the fractions they denote.
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+var (
+	// ErrInvalidMove is returned when a task cannot be placed between the given tasks,
+	// because none is given, one of them is the moved task, or they are out of order.
+	ErrInvalidMove = errors.New("invalid move")
+	// ErrMoveTargetNotFound is returned when a task to place the moved task next to does not exist.
+	ErrMoveTargetNotFound = errors.New("move target task not found")
+)
+
+// InitialRank returns the rank of a task created at t. Ranks of tasks created later
+// sort after it, so new tasks are appended to the manual order.
+func InitialRank(t time.Time) string {
+	return strings.TrimRight(fmt.Sprintf("%016x", t.UnixNano()), "0")
+}
+
+// RankBetween returns a rank that sorts after prev and before next. An empty prev
+// stands for the start of the order and an empty next for its end.
+// Returns ErrInvalidMove if prev does not sort before next.
+func RankBetween(prev, next string) (string, error) {
+	if !validRank(prev) || !validRank(next) || (next != "" && prev >= next) {
+		return "", ErrInvalidMove
+	}
+
+	return midpoint(prev, next), nil
+}
+
+// validRank reports whether rank is empty or consists of rank digits without a trailing zero.
+func validRank(rank string) bool {
+	if rank == "" {
+		return true
+	}
+
+	for i := range len(rank) {
+		if strings.IndexByte(rankDigits, rank[i]) < 0 {
+			return false
+		}
+	}
+
+	return rank[len(rank)-1] != rankDigits[0]
+}
+
+// midpoint returns a rank between a and b, where a sorts before b and an empty b
+// stands for 1. The result never ends with a zero, so it can be split again.
+func midpoint(a, b string) string {
+	if b != "" {
+		// Keep the common prefix and split the remainder; a missing digit of a is zero.
+		n := 0
+		for n < len(b) && rankDigitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			return b[:n] + midpoint(rankSuffix(a, n), b[n:])
+		}
+	}
+
+	low := strings.IndexByte(rankDigits, rankDigitAt(a, 0))
+	high := len(rankDigits)
+	if b != "" {
+		high = strings.IndexByte(rankDigits, b[0])
+	}
+
+	if high-low > 1 {
+		return string(rankDigits[(low+high)/2])
+	}
+
+	// The first digits are adjacent: b shortened to its first digit fits in between
+	// if it has more digits, otherwise extend a with a rank after its remainder.
+	if len(b) > 1 {
+		return b[:1]
+	}
+
+	return string(rankDigits[low]) + midpoint(rankSuffix(a, 1), "")
+}
+
+// rankDigitAt returns the i-th digit of rank, or zero past its end.
+func rankDigitAt(rank string, i int) byte {
+	if i < len(rank) {
+		return rank[i]
+	}
+	return rankDigits[0]
+}
+
+// rankSuffix returns rank without its first n digits.
+func rankSuffix(rank string, n int) string {
+	if n < len(rank) {
+		return rank[n:]
+	}
+	return ""
+}
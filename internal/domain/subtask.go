@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"time"
+)
+
+// MaxSubtasks is the maximum number of subtasks a single task can have.
+const MaxSubtasks = 100
+
+var (
+	// ErrSubtaskNotFound is returned when a subtask with the specified ID does not exist in the task.
+	ErrSubtaskNotFound = errors.New("subtask not found")
+	// ErrTooManySubtasks is returned when adding a subtask to a task that already has MaxSubtasks.
+	ErrTooManySubtasks = errors.New("too many subtasks")
+)
+
+// Subtask is a checklist item of a task.
+type Subtask struct {
+	// ID is the identifier of the subtask, unique within its task.
+	ID string `json:"id"`
+	// Title is the short description of the subtask.
+	Title string `json:"title"`
+	// Done reports whether the subtask has been completed.
+	Done bool `json:"done"`
+}
+
+// SubtaskSummary counts the subtasks of a task.
+type SubtaskSummary struct {
+	// Completed is the number of done subtasks.
+	Completed int `json:"completed"`
+	// Total is the number of all subtasks.
+	Total int `json:"total"`
+}
+
+// SubtaskSummary returns how many of the task's subtasks are done.
+func (t *Task) SubtaskSummary() SubtaskSummary {
+	summary := SubtaskSummary{Total: len(t.Subtasks)}
+	for _, subtask := range t.Subtasks {
+		if subtask.Done {
+			summary.Completed++
+		}
+	}
+	return summary
+}
+
+// AddSubtask inserts a new pending subtask at position, or appends it if position is nil,
+// and sets UpdatedAt to now. Positions are zero-based; a position past the end appends the subtask.
+// Returns ErrEmptyTitle if the title is empty.
+// Returns ErrTooManySubtasks if the task already has MaxSubtasks subtasks.
+func (t *Task) AddSubtask(id, title string, position *int, now time.Time) (Subtask, error) {
+	if title == "" {
+		return Subtask{}, ErrEmptyTitle
+	}
+
+	if len(t.Subtasks) >= MaxSubtasks {
+		return Subtask{}, ErrTooManySubtasks
+	}
+
+	subtask := Subtask{ID: id, Title: title}
+	t.Subtasks = slices.Insert(slices.Clone(t.Subtasks), clampPosition(position, len(t.Subtasks)), subtask)
+	t.UpdatedAt = now
+	return subtask, nil
+}
+
+// UpdateSubtask changes the title, done flag and/or position of the subtask with the given ID.
+// Nil arguments leave the corresponding field unchanged; a position past the end moves
+// the subtask to the end. UpdatedAt is set to now only if something actually changes.
+// Returns ErrSubtaskNotFound if the task has no such subtask.
+// Returns ErrEmptyTitle if the title is explicitly set to an empty string.
+func (t *Task) UpdateSubtask(
+	id string, title *string, done *bool, position *int, now time.Time,
+) (Subtask, error) {
+	i := t.subtaskIndex(id)
+	if i < 0 {
+		return Subtask{}, ErrSubtaskNotFound
+	}
+
+	if title != nil && *title == "" {
+		return Subtask{}, ErrEmptyTitle
+	}
+
+	subtask := t.Subtasks[i]
+	if title != nil {
+		subtask.Title = *title
+	}
+	if done != nil {
+		subtask.Done = *done
+	}
+
+	target := i
+	if position != nil {
+		target = clampPosition(position, len(t.Subtasks)-1)
+	}
+
+	if subtask == t.Subtasks[i] && target == i {
+		return subtask, nil
+	}
+
+	subtasks := slices.Delete(slices.Clone(t.Subtasks), i, i+1)
+	t.Subtasks = slices.Insert(subtasks, target, subtask)
+	t.UpdatedAt = now
+	return subtask, nil
+}
+
+// RemoveSubtask deletes the subtask with the given ID and sets UpdatedAt to now.
+// Returns ErrSubtaskNotFound if the task has no such subtask.
+func (t *Task) RemoveSubtask(id string, now time.Time) error {
+	i := t.subtaskIndex(id)
+	if i < 0 {
+		return ErrSubtaskNotFound
+	}
+
+	t.Subtasks = slices.Delete(slices.Clone(t.Subtasks), i, i+1)
+	t.UpdatedAt = now
+	return nil
+}
+
+// subtaskIndex returns the index of the subtask with the given ID, or -1 if there is none.
+func (t *Task) subtaskIndex(id string) int {
+	return slices.IndexFunc(t.Subtasks, func(subtask Subtask) bool {
+		return subtask.ID == id
+	})
+}
+
+// clampPosition returns position limited to [0, last], or last if position is nil.
+func clampPosition(position *int, last int) int {
+	if position == nil || *position > last {
+		return last
+	}
+	return max(*position, 0)
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxTags is the maximum number of tags a single task can have.
+	MaxTags = 20
+	// MaxTagLength is the maximum length of a tag in characters.
+	MaxTagLength = 32
+)
+
+var (
+	// ErrInvalidTag is returned when a tag is empty, too long or contains a comma.
+	ErrInvalidTag = errors.New("invalid task tag")
+	// ErrTooManyTags is returned when a task would get more than MaxTags tags.
+	ErrTooManyTags = errors.New("too many task tags")
+)
+
+// normalizeTags returns the tags with surrounding whitespace trimmed and duplicates
+// removed, in the order of their first occurrence. Commas are reserved to separate
+// tags in query parameters, so a tag cannot contain one.
+// Returns ErrInvalidTag if a tag is empty, longer than MaxTagLength or contains a comma.
+// Returns ErrTooManyTags if more than MaxTags distinct tags remain.
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || utf8.RuneCountInString(tag) > MaxTagLength || strings.Contains(tag, ",") {
+			return nil, ErrInvalidTag
+		}
+
+		if !slices.Contains(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+
+	if len(normalized) > MaxTags {
+		return nil, ErrTooManyTags
+	}
+
+	return normalized, nil
+}
@@ -3,7 +3,9 @@
 package domain
 
 import (
+	"encoding/json"
 	"errors"
+	"slices"
 	"time"
 )
 
@@ -15,12 +17,25 @@ var (
 	ErrEmptyTitle = errors.New("title in task cannot be empty")
 	// ErrTaskExists is returned when attempting to create a task with an ID that already exists.
 	ErrTaskExists = errors.New("task already exists")
+	// ErrInvalidCursor is returned when a pagination cursor is malformed.
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
+	// ErrVersionConflict is returned when a task was modified since the version the caller read.
+	ErrVersionConflict = errors.New("task version conflict")
+	// ErrInvalidPriority is returned when a task priority is not one of the defined priorities.
+	ErrInvalidPriority = errors.New("invalid task priority")
+	// ErrInvalidStatus is returned when a task status is not one of the statuses of the workflow.
+	ErrInvalidStatus = errors.New("invalid task status")
+	// ErrInvalidTransition is returned when a task cannot move from its current status to the requested one.
+	ErrInvalidTransition = errors.New("invalid task status transition")
+	// ErrTaskInProgress is returned when deleting a task that is in progress without forcing it.
+	ErrTaskInProgress = errors.New("task in progress cannot be deleted")
 )
 
 // TaskStatus represents the current state of a task in its lifecycle.
 type TaskStatus string
 
-// Task status constants define the possible states a task can be in.
+// Task status constants define the built-in states a task can be in.
+// Operators can configure additional statuses in a Workflow.
 const (
 	// StatusPending indicates a task that has been created but not yet started.
 	StatusPending TaskStatus = "pending"
@@ -32,6 +47,49 @@ const (
 	StatusCancelled TaskStatus = "cancelled"
 )
 
+// Priority represents how urgent a task is.
+type Priority string
+
+// Task priority constants, from the least to the most urgent.
+const (
+	// PriorityLow marks a task that can wait.
+	PriorityLow Priority = "low"
+	// PriorityMedium marks a task of ordinary urgency; it is the default priority.
+	PriorityMedium Priority = "medium"
+	// PriorityHigh marks a task that should be done before ordinary ones.
+	PriorityHigh Priority = "high"
+	// PriorityUrgent marks a task that needs immediate attention.
+	PriorityUrgent Priority = "urgent"
+)
+
+// priorities lists the priorities by rank, from the least to the most urgent.
+var priorities = []Priority{PriorityLow, PriorityMedium, PriorityHigh, PriorityUrgent}
+
+// Rank returns the position of the priority in the order of urgency, starting
+// from 1 for PriorityLow, or 0 for an invalid priority.
+func (p Priority) Rank() int {
+	for i, priority := range priorities {
+		if priority == p {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// PriorityOfRank returns the priority with the given rank, as returned by Rank.
+// Returns false if no priority has that rank.
+func PriorityOfRank(rank int) (Priority, bool) {
+	if rank < 1 || rank > len(priorities) {
+		return "", false
+	}
+	return priorities[rank-1], true
+}
+
+// IsValidPriority checks if the provided priority string is a valid Priority.
+func IsValidPriority(priority string) bool {
+	return Priority(priority).Rank() > 0
+}
+
 // Task represents a work item in the task management system.
 // It contains all the information needed to track and manage a single task.
 type Task struct {
@@ -47,13 +105,66 @@ type Task struct {
 	CreatedAt time.Time `json:"created_at"`
 	// UpdatedAt is the timestamp when the task was last modified.
 	UpdatedAt time.Time `json:"updated_at"`
+	// Version is incremented by the repository on every successful update.
+	Version int64 `json:"version"`
+	// DueDate is the deadline of the task; nil if the task has none.
+	DueDate *time.Time `json:"due_date,omitempty"`
+	// Priority indicates how urgent the task is.
+	Priority Priority `json:"priority"`
+	// Assignee identifies the person responsible for the task; empty if the task is unassigned.
+	Assignee string `json:"assignee,omitempty"`
+	// ProjectID identifies the project the task belongs to; empty if the task is in no project.
+	ProjectID string `json:"project_id,omitempty"`
+	// Rank is the position of the task in the manual order of a board; tasks are
+	// ordered by comparing their ranks as strings.
+	Rank string `json:"rank"`
+	// Estimate is the estimated effort of the task in story points; zero if the task is not estimated.
+	Estimate int `json:"estimate,omitempty"`
+	// Progress is the part of the task already done, in percent from 0 to MaxProgress.
+	Progress int `json:"progress"`
+	// Tags are free-form labels of the task, in the order they were given; like
+	// Subtasks, the slice is replaced rather than modified in place.
+	Tags []string `json:"tags,omitempty"`
+	// Subtasks is the ordered checklist of the task. The subtask methods replace
+	// the slice rather than modify it in place, so copies of a task may share it.
+	Subtasks []Subtask `json:"subtasks,omitempty"`
+	// Attachments describes the files attached to the task; like Subtasks, the
+	// slice is replaced rather than modified in place.
+	Attachments []Attachment `json:"attachments,omitempty"`
+}
+
+// MarshalJSON encodes the task together with a subtask_summary of its subtasks,
+// if it has any.
+func (t Task) MarshalJSON() ([]byte, error) {
+	type task Task
+
+	var summary *SubtaskSummary
+	if len(t.Subtasks) > 0 {
+		s := t.SubtaskSummary()
+		summary = &s
+	}
+
+	return json.Marshal(struct {
+		task
+		SubtaskSummary *SubtaskSummary `json:"subtask_summary,omitempty"`
+	}{task(t), summary})
 }
 
-// NewTask creates a new task with the provided details.
-// The task is initialized with StatusPending and current timestamps.
-// The id parameter should be unique across all tasks.
-func NewTask(id, title, description string) *Task {
-	now := time.Now()
+// NewTask creates a new task with the provided details, created at now.
+// The task is initialized with StatusPending, timestamps set to now and a rank after
+// the tasks created before it.
+// The id parameter should be unique across all tasks; a nil dueDate creates a task without deadline,
+// an empty priority creates a task with PriorityMedium.
+// Returns ErrInvalidPriority if the priority is neither empty nor valid.
+func NewTask(id, title, description string, dueDate *time.Time, priority Priority, now time.Time) (*Task, error) {
+	if priority == "" {
+		priority = PriorityMedium
+	}
+
+	if !IsValidPriority(string(priority)) {
+		return nil, ErrInvalidPriority
+	}
+
 	return &Task{
 		ID:          id,
 		Title:       title,
@@ -61,23 +172,188 @@ func NewTask(id, title, description string) *Task {
 		Status:      StatusPending,
 		CreatedAt:   now,
 		UpdatedAt:   now,
-	}
+		Version:     1,
+		DueDate:     dueDate,
+		Priority:    priority,
+		Rank:        InitialRank(now),
+	}, nil
 }
 
-// UpdateStatus changes the task's status and updates the UpdatedAt timestamp.
+// UpdateStatus changes the task's status and sets the UpdatedAt timestamp to now.
 // This method should be used whenever the task's state changes.
-func (t *Task) UpdateStatus(status TaskStatus) {
+// Setting the current status leaves the task unchanged; completing the task sets its progress to MaxProgress.
+// Returns ErrInvalidStatus if the status is not one of the statuses of workflow.
+// Returns ErrInvalidTransition if workflow does not allow the task to move from its current status to status.
+func (t *Task) UpdateStatus(status TaskStatus, workflow *Workflow, now time.Time) error {
+	if !workflow.IsValid(status) {
+		return ErrInvalidStatus
+	}
+
+	if !workflow.CanTransition(t.Status, status) {
+		return ErrInvalidTransition
+	}
+
+	if status == t.Status {
+		return nil
+	}
+
 	t.Status = status
-	t.UpdatedAt = time.Now()
+	if status == StatusCompleted {
+		t.Progress = MaxProgress
+	}
+
+	t.UpdatedAt = now
+	return nil
+}
+
+// Assign makes assignee responsible for the task; an empty assignee unassigns it.
+// UpdatedAt is set to now only if the assignee actually changes.
+func (t *Task) Assign(assignee string, now time.Time) {
+	if assignee == t.Assignee {
+		return
+	}
+
+	t.Assignee = assignee
+	t.UpdatedAt = now
+}
+
+// MoveToProject makes the task part of the project with the given ID; an empty ID
+// removes it from its project. UpdatedAt is set to now only if the project actually changes.
+func (t *Task) MoveToProject(projectID string, now time.Time) {
+	if projectID == t.ProjectID {
+		return
+	}
+
+	t.ProjectID = projectID
+	t.UpdatedAt = now
 }
 
-// IsValidStatus checks if the provided status string is a valid TaskStatus.
-// Returns true if the status is one of the defined constants, false otherwise.
-func IsValidStatus(status string) bool {
-	switch TaskStatus(status) {
-	case StatusPending, StatusInProgress, StatusCompleted, StatusCancelled:
-		return true
-	default:
+// Reorder moves the task to the given rank in the manual order and sets the UpdatedAt timestamp to now.
+func (t *Task) Reorder(rank string, now time.Time) {
+	t.Rank = rank
+	t.UpdatedAt = now
+}
+
+// TaskUpdate describes a partial change of the details of a task.
+// Nil fields leave the corresponding detail unchanged.
+type TaskUpdate struct {
+	// Title is the new title; it cannot be empty.
+	Title *string
+	// Description is the new description.
+	Description *string
+	// DueDate is the new deadline.
+	DueDate *time.Time
+	// Priority is the new priority.
+	Priority *Priority
+	// Estimate is the new estimate in story points; zero removes the estimate.
+	Estimate *int
+	// Progress is the new progress in percent.
+	Progress *int
+	// Tags replaces the tags of the task; an empty non-nil slice removes them all.
+	Tags []string
+}
+
+// Update applies the update to the task. The update is validated as a whole before
+// any field changes, so a rejected update leaves the task unchanged. UpdatedAt is
+// set to now only if a field actually changes.
+// Returns ErrEmptyTitle if the title is explicitly set to an empty string.
+// Returns ErrInvalidPriority if the priority is not valid.
+// Returns ErrInvalidEstimate if the estimate is negative.
+// Returns ErrInvalidProgress if the progress is not between 0 and MaxProgress.
+// Returns ErrInvalidTag or ErrTooManyTags if the tags are not valid.
+func (t *Task) Update(update TaskUpdate, now time.Time) error {
+	if update.Title != nil && *update.Title == "" {
+		return ErrEmptyTitle
+	}
+
+	if update.Priority != nil && !IsValidPriority(string(*update.Priority)) {
+		return ErrInvalidPriority
+	}
+
+	if err := validateProgress(update.Estimate, update.Progress); err != nil {
+		return err
+	}
+
+	var tags []string
+	if update.Tags != nil {
+		var err error
+		if tags, err = normalizeTags(update.Tags); err != nil {
+			return err
+		}
+	}
+
+	changed := false
+	if update.Title != nil && *update.Title != t.Title {
+		t.Title = *update.Title
+		changed = true
+	}
+
+	if update.Description != nil && *update.Description != t.Description {
+		t.Description = *update.Description
+		changed = true
+	}
+
+	if update.DueDate != nil && (t.DueDate == nil || !update.DueDate.Equal(*t.DueDate)) {
+		t.DueDate = update.DueDate
+		changed = true
+	}
+
+	if update.Priority != nil && *update.Priority != t.Priority {
+		t.Priority = *update.Priority
+		changed = true
+	}
+
+	if update.Estimate != nil && *update.Estimate != t.Estimate {
+		t.Estimate = *update.Estimate
+		changed = true
+	}
+
+	if update.Progress != nil && *update.Progress != t.Progress {
+		t.Progress = *update.Progress
+		changed = true
+	}
+
+	// The slice is replaced rather than modified in place, like the subtasks.
+	if update.Tags != nil && !slices.Equal(tags, t.Tags) {
+		t.Tags = tags
+		changed = true
+	}
+
+	if changed {
+		t.UpdatedAt = now
+	}
+
+	return nil
+}
+
+// CanDelete checks whether the task may be deleted. A task in progress is being
+// worked on, so it is deleted only if force is set.
+// Returns ErrTaskInProgress if the task is in progress and force is not set.
+func (t *Task) CanDelete(force bool) error {
+	if t.Status == StatusInProgress && !force {
+		return ErrTaskInProgress
+	}
+	return nil
+}
+
+// SameAs reports whether the task has the title, description, due date, priority and
+// project of other, the fields a task is created with. A client retrying the creation
+// of a task with its own ID gets the stored task back only if it is the same.
+func (t *Task) SameAs(other *Task) bool {
+	if (t.DueDate == nil) != (other.DueDate == nil) || t.DueDate != nil && !t.DueDate.Equal(*other.DueDate) {
 		return false
 	}
+
+	return t.Title == other.Title && t.Description == other.Description &&
+		t.Priority == other.Priority && t.ProjectID == other.ProjectID
+}
+
+// IsOverdue reports whether the task has a due date before now and is neither
+// completed nor cancelled.
+func (t *Task) IsOverdue(now time.Time) bool {
+	if t.DueDate == nil || !t.DueDate.Before(now) {
+		return false
+	}
+
+	return t.Status != StatusCompleted && t.Status != StatusCancelled
 }
@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNothingToUndo is returned when undoing a task that has not changed since it was created.
+	ErrNothingToUndo = errors.New("task has no change to undo")
+	// ErrUndoExpired is returned when the last change of a task is older than the undo window.
+	ErrUndoExpired = errors.New("last task change is too old to undo")
+	// ErrCannotUndo is returned when the last change of a task cannot be reverted,
+	// such as a removed subtask or attachment whose content is gone.
+	ErrCannotUndo = errors.New("last task change cannot be undone")
+)
+
+// LastChange returns the entries of the most recent change in history, a task history
+// ordered oldest first: the trailing entries sharing the version of the last entry.
+// Returns nil if history is empty.
+func LastChange(history []HistoryEntry) []HistoryEntry {
+	if len(history) == 0 {
+		return nil
+	}
+
+	version := history[len(history)-1].Version
+	start := len(history)
+	for start > 0 && history[start-1].Version == version {
+		start--
+	}
+	return history[start:]
+}
+
+// Revert undoes change, the history entries of the last change of the task, by restoring
+// the old value of every changed field, and sets UpdatedAt to now. The entries are checked
+// as a whole before any field changes, so a rejected revert leaves the task unchanged.
+// Status changes are reverted without consulting the workflow, as the old status was reached
+// through it. Reverting a subtask removal or an attachment is not supported.
+// Returns ErrNothingToUndo if change is empty or records the creation or anonymization of the task.
+// Returns ErrCannotUndo if an entry cannot be reverted.
+func (t *Task) Revert(change []HistoryEntry, now time.Time) error {
+	if len(change) == 0 || change[0].Field == HistoryFieldTask {
+		return ErrNothingToUndo
+	}
+
+	reverted := *t
+	for _, entry := range change {
+		if err := reverted.revertField(entry.Field, entry.OldValue); err != nil {
+			return err
+		}
+	}
+
+	reverted.UpdatedAt = now
+	*t = reverted
+	return nil
+}
+
+// revertField sets the field recorded under the history field name back to value.
+// Slices are replaced rather than modified, so the task being reverted is left intact.
+func (t *Task) revertField(field, value string) error {
+	var err error
+	switch field {
+	case "title":
+		if value == "" {
+			return ErrCannotUndo
+		}
+		t.Title = value
+	case "description":
+		t.Description = value
+	case "status":
+		t.Status = TaskStatus(value)
+	case "due_date":
+		t.DueDate, err = parseDueDate(value)
+	case "priority":
+		if !IsValidPriority(value) {
+			return ErrCannotUndo
+		}
+		t.Priority = Priority(value)
+	case "assignee":
+		t.Assignee = value
+	case "project_id":
+		t.ProjectID = value
+	case "rank":
+		t.Rank = value
+	case "estimate":
+		t.Estimate, err = strconv.Atoi(value)
+	case "progress":
+		t.Progress, err = strconv.Atoi(value)
+	case "tags":
+		t.Tags = nil
+		if value != "" {
+			t.Tags = strings.Split(value, ",")
+		}
+	case "subtasks":
+		return t.revertSubtaskOrder(value)
+	default:
+		return t.revertSubtask(field, value)
+	}
+
+	if err != nil {
+		return ErrCannotUndo
+	}
+	return nil
+}
+
+// revertSubtask reverts a change of a single subtask: an added subtask is removed again
+// and a changed title or done flag restored.
+func (t *Task) revertSubtask(field, value string) error {
+	path, found := strings.CutPrefix(field, "subtasks.")
+	if !found {
+		return ErrCannotUndo
+	}
+
+	id, property, ok := strings.Cut(path, ".")
+	i := t.subtaskIndex(id)
+	if i < 0 {
+		return ErrCannotUndo
+	}
+
+	subtasks := slices.Clone(t.Subtasks)
+	switch {
+	case !ok && value == "":
+		subtasks = slices.Delete(subtasks, i, i+1)
+	case property == "title" && value != "":
+		subtasks[i].Title = value
+	case property == "done":
+		done, err := strconv.ParseBool(value)
+		if err != nil {
+			return ErrCannotUndo
+		}
+		subtasks[i].Done = done
+	default:
+		return ErrCannotUndo
+	}
+
+	t.Subtasks = subtasks
+	return nil
+}
+
+// revertSubtaskOrder restores the order of the subtasks given as comma-separated IDs.
+func (t *Task) revertSubtaskOrder(value string) error {
+	ids := strings.Split(value, ",")
+	if len(ids) != len(t.Subtasks) {
+		return ErrCannotUndo
+	}
+
+	subtasks := make([]Subtask, 0, len(ids))
+	for _, id := range ids {
+		i := t.subtaskIndex(id)
+		if i < 0 {
+			return ErrCannotUndo
+		}
+		subtasks = append(subtasks, t.Subtasks[i])
+	}
+
+	t.Subtasks = subtasks
+	return nil
+}
+
+// parseDueDate parses a due date formatted for the history; an empty value is no due date.
+func parseDueDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	dueDate, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &dueDate, nil
+}
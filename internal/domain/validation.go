@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Default limits of the text fields of a task.
+const (
+	// DefaultMaxTitleLength is the default maximum length of a task title in characters.
+	DefaultMaxTitleLength = 255
+	// DefaultMaxDescriptionLength is the default maximum length of a task description in characters.
+	DefaultMaxDescriptionLength = 1000
+)
+
+// ErrValidation is matched by every ValidationError, so callers can test for
+// invalid input with errors.Is regardless of the offending fields.
+var ErrValidation = errors.New("task validation failed")
+
+// FieldError describes why the value of a single field was rejected.
+type FieldError struct {
+	// Field names the rejected field, such as title or description.
+	Field string
+	// Message explains what is wrong with the value.
+	Message string
+}
+
+// ValidationError lists every field of an input that violates the Limits.
+type ValidationError struct {
+	// Fields holds one entry per rejected field, in the order the fields were checked.
+	Fields []FieldError
+}
+
+// Error joins the messages of all rejected fields.
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Field + ": " + field.Message
+	}
+	return ErrValidation.Error() + ": " + strings.Join(messages, "; ")
+}
+
+// Is reports whether target is ErrValidation.
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+// Limits bounds the text fields of a task. The zero value of a limit means no limit.
+type Limits struct {
+	// MaxTitleLength is the maximum length of a title in characters.
+	MaxTitleLength int
+	// MaxDescriptionLength is the maximum length of a description in characters.
+	MaxDescriptionLength int
+}
+
+// DefaultLimits returns the limits used unless configured otherwise.
+func DefaultLimits() Limits {
+	return Limits{
+		MaxTitleLength:       DefaultMaxTitleLength,
+		MaxDescriptionLength: DefaultMaxDescriptionLength,
+	}
+}
+
+// NormalizeTask checks the title and description of a new task and returns them
+// with surrounding whitespace trimmed. An empty title is left to NewTask to reject.
+// Returns a ValidationError listing every field that is not valid UTF-8, contains
+// control characters or exceeds its limit.
+func (l Limits) NormalizeTask(title, description string) (string, string, error) {
+	var v validator
+	title = v.text("title", title, l.MaxTitleLength, false)
+	description = v.text("description", description, l.MaxDescriptionLength, true)
+	return title, description, v.err()
+}
+
+// NormalizeUpdate checks the title, description and tags of update like NormalizeTask
+// and trims them in place. Nil fields are not checked.
+// Returns a ValidationError listing every rejected field.
+func (l Limits) NormalizeUpdate(update *TaskUpdate) error {
+	var v validator
+	if update.Title != nil {
+		title := v.text("title", *update.Title, l.MaxTitleLength, false)
+		update.Title = &title
+	}
+
+	if update.Description != nil {
+		description := v.text("description", *update.Description, l.MaxDescriptionLength, true)
+		update.Description = &description
+	}
+
+	for _, tag := range update.Tags {
+		if !utf8.ValidString(tag) {
+			v.reject("tags", "must be valid UTF-8")
+			break
+		}
+	}
+
+	return v.err()
+}
+
+// validator collects the rejected fields of an input.
+type validator struct {
+	fields []FieldError
+}
+
+// text trims value and checks it is valid UTF-8 of at most maxLength characters
+// without control characters; multiline text may contain line breaks and tabs.
+// A zero maxLength does not limit the length.
+func (v *validator) text(field, value string, maxLength int, multiline bool) string {
+	if !utf8.ValidString(value) {
+		v.reject(field, "must be valid UTF-8")
+		return value
+	}
+
+	value = strings.TrimSpace(value)
+	if strings.ContainsFunc(value, func(r rune) bool {
+		return unicode.IsControl(r) && !(multiline && (r == '\n' || r == '\r' || r == '\t'))
+	}) {
+		v.reject(field, "must not contain control characters")
+	}
+
+	if maxLength > 0 && utf8.RuneCountInString(value) > maxLength {
+		v.reject(field, "must be at most "+strconv.Itoa(maxLength)+" characters long")
+	}
+
+	return value
+}
+
+// reject records that field was rejected with message.
+func (v *validator) reject(field, message string) {
+	v.fields = append(v.fields, FieldError{Field: field, Message: message})
+}
+
+// err returns a ValidationError if any field was rejected, and nil otherwise.
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.fields}
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"errors"
+	"net/url"
+	"slices"
+	"time"
+)
+
+var (
+	// ErrWebhookNotFound is returned when a webhook with the specified ID does not exist.
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrWebhookExists is returned when creating a webhook with an ID that is already taken.
+	ErrWebhookExists = errors.New("webhook already exists")
+	// ErrInvalidWebhookURL is returned when a webhook URL is not an absolute http or https URL.
+	ErrInvalidWebhookURL = errors.New("webhook URL must be an absolute http or https URL")
+	// ErrWebhookURLNotAllowed is returned when a webhook URL points to an address inside
+	// a private or local network, which deliveries must not reach.
+	ErrWebhookURLNotAllowed = errors.New("webhook URL must not point to a private or local address")
+	// ErrInvalidEventType is returned when a webhook subscribes to an unknown event type.
+	ErrInvalidEventType = errors.New("invalid event type")
+	// ErrNoWebhookEvents is returned when a webhook subscribes to no event type.
+	ErrNoWebhookEvents = errors.New("webhook must subscribe to at least one event type")
+	// ErrEmptyWebhookSecret is returned when a webhook has no secret to sign its deliveries with.
+	ErrEmptyWebhookSecret = errors.New("webhook secret cannot be empty")
+	// ErrWebhookDeliveryFailed is returned when an endpoint does not accept a delivery.
+	ErrWebhookDeliveryFailed = errors.New("webhook delivery failed")
+	// ErrDeadLetterNotFound is returned when a dead letter with the specified ID does not exist.
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+	// ErrDeadLetterExists is returned when storing a dead letter with an ID that is already taken.
+	ErrDeadLetterExists = errors.New("dead letter already exists")
+)
+
+// Webhook is a subscription of an external endpoint to task events.
+// Every event of a subscribed type is POSTed to the URL, signed with the secret.
+type Webhook struct {
+	// ID is the unique identifier for the webhook.
+	ID string `json:"id"`
+	// URL is the endpoint the events are POSTed to.
+	URL string `json:"url"`
+	// Events are the types of the events delivered to the URL.
+	Events []EventType `json:"events"`
+	// Secret is the key of the HMAC-SHA256 signature of the deliveries.
+	// It is never returned to clients.
+	Secret string `json:"-"`
+	// CreatedAt is the timestamp when the webhook was created.
+	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt is the timestamp when the webhook was last modified.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewWebhook creates a webhook delivering the events of the given types to rawURL,
+// signed with secret, created at now. Repeated event types are kept once.
+// Returns ErrInvalidWebhookURL if the URL is not an absolute http or https URL.
+// Returns ErrNoWebhookEvents if no event type is given.
+// Returns ErrInvalidEventType if an event type is unknown.
+// Returns ErrEmptyWebhookSecret if the secret is empty.
+func NewWebhook(id, rawURL string, events []EventType, secret string, now time.Time) (*Webhook, error) {
+	if len(events) == 0 {
+		return nil, ErrNoWebhookEvents
+	}
+
+	webhook := &Webhook{ID: id, CreatedAt: now}
+	if err := webhook.Update(&rawURL, events, &secret, now); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// Update changes the URL, event types and/or secret of the webhook.
+// Nil arguments leave the corresponding field unchanged. UpdatedAt is set to now.
+// Returns the errors of NewWebhook for invalid values.
+func (w *Webhook) Update(rawURL *string, events []EventType, secret *string, now time.Time) error {
+	if rawURL != nil && !isWebhookURL(*rawURL) {
+		return ErrInvalidWebhookURL
+	}
+
+	if events != nil {
+		if len(events) == 0 {
+			return ErrNoWebhookEvents
+		}
+
+		for _, event := range events {
+			if !IsValidEventType(event) {
+				return ErrInvalidEventType
+			}
+		}
+	}
+
+	if secret != nil && *secret == "" {
+		return ErrEmptyWebhookSecret
+	}
+
+	if rawURL != nil {
+		w.URL = *rawURL
+	}
+
+	if events != nil {
+		unique := make([]EventType, 0, len(events))
+		for _, event := range events {
+			if !slices.Contains(unique, event) {
+				unique = append(unique, event)
+			}
+		}
+		w.Events = unique
+	}
+
+	if secret != nil {
+		w.Secret = *secret
+	}
+
+	w.UpdatedAt = now
+	return nil
+}
+
+// Subscribes reports whether the webhook delivers events of type t.
+func (w *Webhook) Subscribes(t EventType) bool {
+	return slices.Contains(w.Events, t)
+}
+
+// WebhookDeadLetter is a delivery of an event to a webhook that was given up on after
+// its last attempt failed. It is kept until it is replayed or deleted.
+type WebhookDeadLetter struct {
+	// ID is the unique identifier for the dead letter.
+	ID string `json:"id"`
+	// WebhookID identifies the webhook the event was not delivered to.
+	WebhookID string `json:"webhook_id"`
+	// Event is the undelivered event.
+	Event Event `json:"event"`
+	// Attempts is the number of failed delivery attempts.
+	Attempts int `json:"attempts"`
+	// LastError describes the failure of the last attempt.
+	LastError string `json:"last_error"`
+	// FailedAt is the timestamp when the delivery was given up on.
+	FailedAt time.Time `json:"failed_at"`
+}
+
+// isWebhookURL reports whether raw is an absolute http or https URL with a host.
+func isWebhookURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
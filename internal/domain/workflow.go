@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"regexp"
+	"slices"
+)
+
+// statusName is the format of a custom status name, such as in_review.
+var statusName = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+// Transition allows a task to move from one status to another.
+type Transition struct {
+	// From is the status the task has.
+	From TaskStatus
+	// To is the status the task can move to.
+	To TaskStatus
+}
+
+// Workflow defines the statuses a task can have and the transitions allowed between them.
+// A Workflow is never modified once built, so it can be shared.
+type Workflow struct {
+	// statuses lists the valid statuses, the built-in ones first.
+	statuses []TaskStatus
+	// transitions lists the statuses a task can move to from each status.
+	transitions map[TaskStatus][]TaskStatus
+}
+
+// defaultWorkflow is the workflow of the built-in statuses.
+// A task can be paused back to pending while in progress, and a finished task can
+// only be reopened: a completed task goes back to work, a cancelled one back to the queue.
+var defaultWorkflow = &Workflow{
+	statuses: []TaskStatus{StatusPending, StatusInProgress, StatusCompleted, StatusCancelled},
+	transitions: map[TaskStatus][]TaskStatus{
+		StatusPending:    {StatusInProgress, StatusCompleted, StatusCancelled},
+		StatusInProgress: {StatusPending, StatusCompleted, StatusCancelled},
+		StatusCompleted:  {StatusInProgress},
+		StatusCancelled:  {StatusPending},
+	},
+}
+
+// DefaultWorkflow returns the workflow of the four built-in statuses.
+func DefaultWorkflow() *Workflow {
+	return defaultWorkflow
+}
+
+// With returns a copy of the workflow that also allows the given transitions.
+// Statuses named by the transitions that the workflow lacks are added to it, so
+// a custom status such as in_review is defined by the transitions into and out of it.
+// Returns ErrInvalidStatus if a status name is not a lowercase identifier of at most 32 characters.
+func (w *Workflow) With(transitions ...Transition) (*Workflow, error) {
+	extended := &Workflow{
+		statuses:    slices.Clone(w.statuses),
+		transitions: make(map[TaskStatus][]TaskStatus, len(w.transitions)),
+	}
+	for status, next := range w.transitions {
+		extended.transitions[status] = slices.Clone(next)
+	}
+
+	for _, transition := range transitions {
+		for _, status := range []TaskStatus{transition.From, transition.To} {
+			if !statusName.MatchString(string(status)) {
+				return nil, ErrInvalidStatus
+			}
+			if !extended.IsValid(status) {
+				extended.statuses = append(extended.statuses, status)
+			}
+		}
+
+		if transition.From != transition.To && !extended.CanTransition(transition.From, transition.To) {
+			extended.transitions[transition.From] = append(extended.transitions[transition.From], transition.To)
+		}
+	}
+
+	return extended, nil
+}
+
+// Statuses returns the valid statuses, the built-in ones first.
+func (w *Workflow) Statuses() []TaskStatus {
+	return slices.Clone(w.statuses)
+}
+
+// Next returns the statuses a task with the given status can move to.
+func (w *Workflow) Next(status TaskStatus) []TaskStatus {
+	return slices.Clone(w.transitions[status])
+}
+
+// IsValid reports whether status is one of the statuses of the workflow.
+func (w *Workflow) IsValid(status TaskStatus) bool {
+	return slices.Contains(w.statuses, status)
+}
+
+// CanTransition reports whether a task with status from can move to status to.
+// Keeping the current status is always allowed for a valid status.
+func (w *Workflow) CanTransition(from, to TaskStatus) bool {
+	if !w.IsValid(to) {
+		return false
+	}
+
+	return from == to || slices.Contains(w.transitions[from], to)
+}
@@ -0,0 +1,99 @@
+// Package events dispatches the events of the task lifecycle to subscribers
+// inside the process.
+//
+// The task service publishes to a Bus, and features such as webhooks or
+// notifications subscribe to it instead of being called by the service.
+package events
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+	"github.com/asp3cto/task-manager/internal/logger"
+	"github.com/asp3cto/task-manager/internal/ports"
+)
+
+var _ ports.EventPublisher = (*Bus)(nil)
+
+// Handler reacts to an event. It runs synchronously in the goroutine publishing the
+// event, with its context, so a handler doing slow work, such as network calls, must
+// hand the event off to its own goroutine, detaching the context with context.WithoutCancel.
+type Handler func(ctx context.Context, event domain.Event)
+
+// Bus is a registry of subscribers that Publish delivers events to.
+// It is safe for concurrent use.
+type Bus struct {
+	logger logger.Logger
+
+	mu sync.RWMutex
+	// subscribers are kept in the order of their subscription, which is the order of delivery
+	subscribers []*subscriber
+}
+
+// subscriber is a registered handler.
+type subscriber struct {
+	name    string
+	types   []domain.EventType
+	handler Handler
+}
+
+// New creates a bus without subscribers.
+func New(logger logger.Logger) *Bus {
+	return &Bus{logger: logger.With(slog.String("component", "events"))}
+}
+
+// Subscribe registers handler under name for the events of the given types, or for all
+// events if no type is given. The name identifies the subscriber in logs.
+// The returned function removes the subscription; it is safe to call more than once.
+func (b *Bus) Subscribe(name string, handler Handler, types ...domain.EventType) (unsubscribe func()) {
+	sub := &subscriber{name: name, types: types, handler: handler}
+
+	b.mu.Lock()
+	b.subscribers = append(b.subscribers, sub)
+	b.mu.Unlock()
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		b.subscribers = slices.DeleteFunc(b.subscribers, func(s *subscriber) bool { return s == sub })
+	}
+}
+
+// Publish delivers event to every subscriber of its type, one after another.
+// A handler that panics is logged and does not keep the event from the other subscribers.
+func (b *Bus) Publish(ctx context.Context, event domain.Event) {
+	b.mu.RLock()
+	subscribers := slices.Clone(b.subscribers)
+	b.mu.RUnlock()
+
+	for _, sub := range subscribers {
+		if len(sub.types) > 0 && !slices.Contains(sub.types, event.Type) {
+			continue
+		}
+
+		b.deliver(ctx, sub, event)
+	}
+}
+
+// deliver calls the handler of sub, recovering from a panic in it.
+func (b *Bus) deliver(ctx context.Context, sub *subscriber, event domain.Event) {
+	defer func() {
+		if p := recover(); p != nil {
+			b.logger.Error(
+				ctx,
+				"event handler panicked",
+				slog.String("subscriber", sub.name),
+				slog.String("event_id", event.ID),
+				slog.String("event_type", string(event.Type)),
+				slog.String("panic", fmt.Sprint(p)),
+			)
+		}
+	}()
+
+	sub.handler(ctx, event)
+}
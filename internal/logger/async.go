@@ -4,11 +4,14 @@ package logger
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"io"
 	"log/slog"
+	"math"
 	"os"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -16,6 +19,10 @@ var (
 	_ Logger = (*AsyncLogger)(nil)
 )
 
+// ErrStopped is returned by Flush once the worker has stopped, as nothing writes
+// the queued entries anymore.
+var ErrStopped = errors.New("logger stopped")
+
 // LogEntry represents a single log entry that will be processed asynchronously.
 // It contains all the information needed to generate a JSON log line.
 type LogEntry struct {
@@ -27,20 +34,89 @@ type LogEntry struct {
 	Time time.Time
 	// Attrs contains structured attributes to be included in the log output
 	Attrs []slog.Attr
+	// flushed is closed by the worker instead of writing the entry, marking a Flush
+	flushed chan struct{}
 }
 
+// allLevels is the sink level accepting every entry that passes the logger level.
+const allLevels = slog.Level(math.MinInt)
+
 // AsyncLogger provides asynchronous logging with JSON output format.
 // It uses a single background goroutine to process log entries from a buffered channel,
 // ensuring non-blocking log operations in the calling goroutines.
 type AsyncLogger struct {
 	// ch is the buffered channel for log entries
 	ch chan LogEntry
-	// output is where log entries are written (e.g., os.Stdout, file)
-	output io.Writer
-	// level is the minimum log level to process
-	level slog.Level
+	// sinks are the outputs every entry is written to (e.g., os.Stdout, file)
+	sinks []sink
+	// level is the minimum log level to process, changeable at runtime
+	level slog.LevelVar
 	// wg ensures graceful shutdown waits for worker completion
 	wg sync.WaitGroup
+	// closing is closed by Close to stop the worker; ch itself is never closed,
+	// so loggers still running at Close can't send on a closed channel
+	closing   chan struct{}
+	closeOnce sync.Once
+	// stopping is closed by the worker once it stops taking entries
+	stopping chan struct{}
+	// pushMu is held for reading while an entry is queued or written synchronously, and
+	// for writing by the worker when it stops, so no entry is queued after the final drain
+	// and no synchronous write reaches a stopped sink worker
+	pushMu sync.RWMutex
+	// done is closed when the worker exits
+	done chan struct{}
+	// stats counts entries at every stage of the pipeline
+	stats counters
+	// sampler drops repeated low-level entries, nil if sampling is disabled
+	sampler *sampler
+	// encode appends an entry to the bytes written to the sinks
+	encode func(dst []byte, entry LogEntry) ([]byte, error)
+	// overflow decides what happens to entries logged while the queue is full
+	overflow OverflowPolicy
+	// writeMu serializes writes to the sinks by the worker and synchronous fallback writes
+	// when sink workers are disabled
+	writeMu sync.Mutex
+	// batch buffers entries in the worker, nil if every entry is written immediately
+	batch *batcher
+	// redactor hides sensitive attribute values, nil if redaction is disabled
+	redactor *redactor
+	// sinkQueueSize is the queue size of every sink worker, 0 if the worker writes to the sinks itself
+	sinkQueueSize int
+	// sinkQueues feed the sink workers, indexed like sinks; nil if sink workers are disabled
+	sinkQueues []chan sinkWrite
+	// sinkWG waits for the sink workers to finish
+	sinkWG sync.WaitGroup
+}
+
+// counters holds the pipeline statistics of an AsyncLogger.
+type counters struct {
+	enqueued     atomic.Uint64
+	written      atomic.Uint64
+	dropped      atomic.Uint64
+	encodeFailed atomic.Uint64
+	writeFailed  atomic.Uint64
+	sampled      atomic.Uint64
+}
+
+// Stats is a snapshot of the pipeline statistics of an AsyncLogger.
+type Stats struct {
+	// Enqueued is the number of entries accepted into the queue
+	Enqueued uint64
+	// Written is the number of entries written to every sink accepting them
+	Written uint64
+	// Dropped is the number of entries discarded by the overflow policy, because
+	// the context was done before they were queued or because the logger had stopped
+	Dropped uint64
+	// EncodeFailed is the number of entries that could not be encoded
+	EncodeFailed uint64
+	// WriteFailed is the number of entries at least one sink failed to write
+	WriteFailed uint64
+	// Sampled is the number of entries discarded by sampling
+	Sampled uint64
+	// QueueDepth is the number of entries currently waiting in the queue
+	QueueDepth int
+	// QueueCapacity is the size of the queue
+	QueueCapacity int
 }
 
 // New creates a new AsyncLogger instance with the specified configuration.
@@ -59,87 +135,272 @@ func New(output io.Writer, level slog.Level, bufSize int) *AsyncLogger {
 	}
 
 	logger := &AsyncLogger{
-		ch:     make(chan LogEntry, bufSize),
-		output: output,
-		level:  level,
+		ch:       make(chan LogEntry, bufSize),
+		closing:  make(chan struct{}),
+		stopping: make(chan struct{}),
+		done:     make(chan struct{}),
+		sinks:    []sink{{writer: output, level: allLevels}},
+		encode:   encodeJSON,
+		overflow: OverflowBlock,
 	}
+	logger.level.Set(level)
 
 	return logger
 }
 
+// Settings describes the effective configuration of an AsyncLogger.
+type Settings struct {
+	// Level is the minimum log level processed
+	Level string `json:"level"`
+	// BufferSize is the capacity of the log entry channel
+	BufferSize int `json:"buffer_size"`
+	// BatchSize is the number of bytes buffered before a write, 0 if batching is disabled
+	BatchSize int `json:"batch_size"`
+	// FlushInterval is the longest time a batched entry waits to be written
+	FlushInterval string `json:"flush_interval,omitempty"`
+	// SinkWorkers reports whether every sink is written by its own goroutine
+	SinkWorkers bool `json:"sink_workers"`
+	// Redact lists the attribute keys whose values are hidden
+	Redact []string `json:"redact,omitempty"`
+	// RedactMode is how the values of redacted attributes are hidden
+	RedactMode string `json:"redact_mode,omitempty"`
+}
+
+// Settings returns the configuration the logger was created with.
+func (l *AsyncLogger) Settings() Settings {
+	settings := Settings{
+		Level:       l.level.Level().String(),
+		BufferSize:  cap(l.ch),
+		SinkWorkers: l.sinkQueueSize > 0,
+	}
+
+	if l.batch != nil {
+		settings.BatchSize = l.batch.size
+		settings.FlushInterval = l.batch.interval.String()
+	}
+
+	if l.redactor != nil {
+		for key := range l.redactor.keys {
+			settings.Redact = append(settings.Redact, key)
+		}
+		slices.Sort(settings.Redact)
+		settings.RedactMode = string(l.redactor.mode)
+	}
+
+	return settings
+}
+
+// Level returns the current minimum log level.
+func (l *AsyncLogger) Level() slog.Level {
+	return l.level.Level()
+}
+
+// SetLevel changes the minimum log level. It is safe to call while the logger is running;
+// entries already queued are still filtered against the new level.
+func (l *AsyncLogger) SetLevel(level slog.Level) {
+	l.level.Set(level)
+}
+
+// Stats returns a snapshot of the pipeline statistics.
+func (l *AsyncLogger) Stats() Stats {
+	return Stats{
+		Enqueued:      l.stats.enqueued.Load(),
+		Written:       l.stats.written.Load(),
+		Dropped:       l.stats.dropped.Load(),
+		EncodeFailed:  l.stats.encodeFailed.Load(),
+		WriteFailed:   l.stats.writeFailed.Load(),
+		Sampled:       l.stats.sampled.Load(),
+		QueueDepth:    len(l.ch),
+		QueueCapacity: cap(l.ch),
+	}
+}
+
 // Start initializes and launches the background worker goroutine.
+// The worker runs until Close is called or ctx is done; to keep writing the entries
+// logged during shutdown, pass a context that is not canceled before Close.
 func (l *AsyncLogger) Start(ctx context.Context) {
+	l.startSinkWorkers()
+
 	l.wg.Add(1)
 	go l.worker(ctx)
 }
 
 // worker is the background goroutine that processes log entries.
 // It continuously reads from the log channel and writes entries to the output.
-// During shutdown, it processes all remaining entries before exiting.
+// When stopped, it processes all remaining entries before exiting.
 func (l *AsyncLogger) worker(ctx context.Context) {
 	defer l.wg.Done()
+	defer close(l.done)
+	defer l.flushBatch()
+
+	var tick <-chan time.Time
+	if l.batch != nil {
+		ticker := time.NewTicker(l.batch.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
 
 	for {
 		select {
 		case entry := <-l.ch:
-			l.writeEntry(entry)
+			l.process(entry)
+		case <-tick:
+			l.flushBatch()
+		case <-l.closing:
+			l.stop()
+			return
 		case <-ctx.Done():
-			for len(l.ch) > 0 {
-				entry := <-l.ch
-				l.writeEntry(entry)
-			}
-
+			l.stop()
 			return
 		}
 	}
 }
 
-// writeEntry formats and writes a single log entry as JSON.
-// It filters entries based on the configured log level and marshals
-// the entry data into JSON format with a newline terminator.
+// stop makes push drop new entries, waits for the pushes in progress and writes
+// the entries they queued.
+func (l *AsyncLogger) stop() {
+	close(l.stopping)
+
+	// Pushes hold the read lock, so taking the write lock waits until they are done.
+	l.pushMu.Lock()
+	//nolint:staticcheck // The critical section is empty on purpose, the lock is a barrier.
+	l.pushMu.Unlock()
+
+	l.drain()
+}
+
+// drain processes the entries still waiting in the queue.
+func (l *AsyncLogger) drain() {
+	for len(l.ch) > 0 {
+		l.process(<-l.ch)
+	}
+}
+
+// process writes or batches entry, or completes the Flush it marks.
+func (l *AsyncLogger) process(entry LogEntry) {
+	if entry.flushed != nil {
+		l.flushBatch()
+		l.barrier(entry.flushed)
+		return
+	}
+
+	if l.batch != nil {
+		l.addToBatch(entry)
+		return
+	}
+
+	l.writeEntry(entry)
+}
+
+// Flush blocks until every entry queued before the call has been written,
+// or until ctx is done. Unlike Close, the logger stays usable afterwards.
+// Returns ErrStopped without waiting once the worker has stopped.
+func (l *AsyncLogger) Flush(ctx context.Context) error {
+	flushed := make(chan struct{})
+
+	select {
+	case <-l.done:
+		return ErrStopped
+	default:
+	}
+
+	select {
+	case l.ch <- LogEntry{flushed: flushed}:
+	case <-l.done:
+		return ErrStopped
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-flushed:
+		return nil
+	case <-l.done:
+		// The worker may have completed the marker while stopping.
+		select {
+		case <-flushed:
+			return nil
+		default:
+			return ErrStopped
+		}
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// writeEntry formats and writes a single log entry to every sink.
 func (l *AsyncLogger) writeEntry(entry LogEntry) {
-	if entry.Level < l.level {
+	buf := getBuffer()
+	data, ok := l.encodeEntry(*buf, entry)
+	if !ok {
+		putBuffer(buf, *buf)
 		return
 	}
 
-	logData := map[string]interface{}{
-		"time":    entry.Time.Format(time.RFC3339),
-		"level":   entry.Level.String(),
-		"message": entry.Message,
+	parts := make([]delivery, 0, len(l.sinks))
+	for i, sink := range l.sinks {
+		if entry.Level >= sink.level {
+			parts = append(parts, delivery{sink: i, level: entry.Level, data: data})
+		}
+	}
+
+	// The buffer is reused once every sink has written it.
+	l.deliver(parts, func(failed bool) {
+		l.recordWritten(1, failed)
+		putBuffer(buf, data)
+	})
+}
+
+// encodeEntry filters entries based on the configured log level, redacts sensitive
+// attributes and appends the entry data in the configured format with a newline terminator to dst.
+// It returns false if the entry must not be written.
+func (l *AsyncLogger) encodeEntry(dst []byte, entry LogEntry) ([]byte, bool) {
+	if entry.Level < l.level.Level() {
+		return nil, false
 	}
 
-	for _, attr := range entry.Attrs {
-		logData[attr.Key] = attr.Value.Any()
+	if l.redactor != nil {
+		entry.Attrs = l.redactor.redact(entry.Attrs)
 	}
 
-	jsonData, err := json.Marshal(logData)
+	data, err := l.encode(dst, entry)
 	if err != nil {
-		return
+		l.stats.encodeFailed.Add(1)
+		return nil, false
 	}
 
-	jsonData = append(jsonData, '\n')
-	_, _ = l.output.Write(jsonData)
+	return data, true
 }
 
 // log is the internal method that creates and queues log entries.
+// The request and trace IDs stored in ctx are added to the attributes.
 // if the context is done, it returns immediately.
 func (l *AsyncLogger) log(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr) {
-	if level < l.level {
+	if level < l.level.Level() {
 		return
 	}
 
-	entry := LogEntry{
+	l.enqueue(ctx, LogEntry{
 		Level:   level,
 		Message: msg,
 		Time:    time.Now(),
 		Attrs:   attrs,
-	}
+	})
+}
 
-	select {
-	case l.ch <- entry:
-	case <-ctx.Done():
+// enqueue adds the correlation attributes stored in ctx to entry and queues it,
+// unless the entry is discarded by sampling. A full queue is handled by the overflow policy.
+func (l *AsyncLogger) enqueue(ctx context.Context, entry LogEntry) {
+	if l.sampler != nil && !l.sampler.allow(entry.Level, entry.Message, entry.Time) {
+		l.stats.sampled.Add(1)
 		return
 	}
+
+	if correlation := contextAttrs(ctx); len(correlation) > 0 {
+		entry.Attrs = append(correlation, entry.Attrs...)
+	}
+
+	l.push(ctx, entry)
 }
 
 // Debug logs a debug-level message with optional structured attributes.
@@ -162,8 +423,30 @@ func (l *AsyncLogger) Error(ctx context.Context, msg string, attrs ...slog.Attr)
 	l.log(ctx, slog.LevelError, msg, attrs...)
 }
 
-// Close performs graceful shutdown of the async logger.
+// Reopen reopens the sinks that support it, e.g. a log file moved by an external tool.
+// Other sinks are left untouched.
+func (l *AsyncLogger) Reopen() error {
+	var errs []error
+	for _, sink := range l.sinks {
+		if r, ok := sink.writer.(interface{ Reopen() error }); ok {
+			errs = append(errs, r.Reopen())
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close performs graceful shutdown of the async logger: the entries queued so far are
+// written, while entries logged from now on are dropped.
+// Sinks registered with AddClosingSink are closed once all entries are written.
 func (l *AsyncLogger) Close() {
-	close(l.ch)
+	l.closeOnce.Do(func() { close(l.closing) })
 	l.wg.Wait()
+	l.stopSinkWorkers()
+
+	for _, sink := range l.sinks {
+		if sink.closer != nil {
+			_ = sink.closer.Close()
+		}
+	}
 }
@@ -0,0 +1,164 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+// benchmarkAttrs are the attributes of the entries logged by the benchmarks,
+// similar to those of a request log line.
+var benchmarkAttrs = []slog.Attr{
+	slog.String("method", "PATCH"),
+	slog.String("path", "/api/v1/tasks/1a2b3c4d5e6f7g8h"),
+	slog.Int("status", 200),
+	slog.Duration("duration", 1500*time.Microsecond),
+	slog.Any("error", errors.New("version conflict")),
+}
+
+func BenchmarkAsyncLogger_Enqueue(b *testing.B) {
+	for _, bench := range []struct {
+		name  string
+		setup func(l *AsyncLogger)
+	}{
+		{name: "block"},
+		{name: "drop_newest", setup: func(l *AsyncLogger) { l.SetOverflowPolicy(OverflowDropNewest) }},
+		{name: "sink_workers", setup: func(l *AsyncLogger) { l.EnableSinkWorkers(1000) }},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			l := New(io.Discard, slog.LevelInfo, 1000)
+			if bench.setup != nil {
+				bench.setup(l)
+			}
+			l.Start(context.Background())
+			defer l.Close()
+
+			ctx := WithTrace(WithRequestID(context.Background(), "req-123"), "trace", "span")
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					l.Info(ctx, "request completed", benchmarkAttrs...)
+				}
+			})
+			b.StopTimer()
+		})
+	}
+}
+
+func BenchmarkAsyncLogger_Encode(b *testing.B) {
+	entry := LogEntry{
+		Level:   slog.LevelInfo,
+		Message: "request completed",
+		Time:    time.Now(),
+		Attrs:   append(contextAttrs(WithRequestID(context.Background(), "req-123")), benchmarkAttrs...),
+	}
+
+	for _, bench := range []struct {
+		name  string
+		setup func(l *AsyncLogger)
+	}{
+		{name: "json"},
+		{name: "text", setup: func(l *AsyncLogger) { l.SetFormat(FormatText, false) }},
+		{name: "json_redacted", setup: func(l *AsyncLogger) { l.SetRedaction([]string{"path"}, RedactMask) }},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			l := New(io.Discard, slog.LevelInfo, 1)
+			if bench.setup != nil {
+				bench.setup(l)
+			}
+
+			b.ReportAllocs()
+			for b.Loop() {
+				buf := getBuffer()
+				data, ok := l.encodeEntry(*buf, entry)
+				if !ok {
+					b.Fatal("entry not encoded")
+				}
+				putBuffer(buf, data)
+			}
+		})
+	}
+}
+
+func TestAsyncLogger_LogDuringClose(t *testing.T) {
+	const loggers, entries = 8, 100
+
+	for _, overflow := range []OverflowPolicy{OverflowBlock, OverflowDropOldest, OverflowSync} {
+		t.Run(string(overflow), func(t *testing.T) {
+			for range 50 {
+				// A small queue makes the loggers hit the overflow policy, and sink workers
+				// make synchronous writes go through the sink queues closed by Close.
+				l := New(io.Discard, slog.LevelInfo, 4)
+				l.AddSink(io.Discard, slog.LevelInfo)
+				l.SetOverflowPolicy(overflow)
+				l.EnableSinkWorkers(4)
+				l.Start(context.Background())
+
+				var wg sync.WaitGroup
+				for range loggers {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						for range entries {
+							l.Info(context.Background(), "request completed", benchmarkAttrs...)
+						}
+					}()
+				}
+
+				l.Close()
+				wg.Wait()
+
+				// Every entry is either written before Close returns or dropped, none is
+				// left in the queue.
+				if stats := l.Stats(); stats.Written+stats.Dropped != loggers*entries || stats.QueueDepth != 0 {
+					t.Fatalf("entries lost at Close: %+v", stats)
+				}
+			}
+		})
+	}
+}
+
+// slowWriter discards the written data after a delay, like a remote collector.
+type slowWriter struct {
+	delay time.Duration
+}
+
+func (w slowWriter) Write(p []byte) (int, error) {
+	time.Sleep(w.delay)
+	return len(p), nil
+}
+
+func BenchmarkAsyncLogger_SlowSinks(b *testing.B) {
+	for _, bench := range []struct {
+		name  string
+		setup func(l *AsyncLogger)
+	}{
+		{name: "shared_worker"},
+		{name: "sink_workers", setup: func(l *AsyncLogger) { l.EnableSinkWorkers(1000) }},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			ctx := context.Background()
+			for b.Loop() {
+				l := New(slowWriter{delay: time.Millisecond}, slog.LevelInfo, 1000)
+				l.AddSink(slowWriter{delay: time.Millisecond}, slog.LevelInfo)
+				if bench.setup != nil {
+					bench.setup(l)
+				}
+				l.Start(ctx)
+
+				for range 300 {
+					l.Info(ctx, "request completed", benchmarkAttrs...)
+				}
+				if err := l.Flush(ctx); err != nil {
+					b.Fatalf("flush failed: %v", err)
+				}
+				l.Close()
+			}
+		})
+	}
+}
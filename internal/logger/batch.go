@@ -0,0 +1,107 @@
+package logger
+
+import (
+	"bytes"
+	"time"
+)
+
+// batcher buffers encoded entries in the worker, so that every sink receives
+// many entries with a single Write call instead of one call per entry.
+type batcher struct {
+	// size is the number of buffered bytes after which the batch is written
+	size int
+	// interval is the longest time an entry stays buffered
+	interval time.Duration
+	// buffers holds the pending data of every sink, indexed like AsyncLogger.sinks
+	buffers []bytes.Buffer
+	// entries is the number of buffered entries
+	entries int
+	// bytes is the encoded size of the buffered entries
+	bytes int
+}
+
+// EnableBatching makes the worker buffer entries and write them once size bytes
+// have accumulated or every interval, whichever comes first. Buffered entries are
+// also written by Flush and when the logger stops. Entries written synchronously
+// by OverflowSync bypass the batch.
+// Batching must be enabled before Start is called.
+func (l *AsyncLogger) EnableBatching(size int, interval time.Duration) {
+	l.batch = &batcher{size: size, interval: interval}
+}
+
+// addToBatch encodes entry into the buffers of the sinks accepting it
+// and writes the batch if it has reached its size.
+func (l *AsyncLogger) addToBatch(entry LogEntry) {
+	buf := getBuffer()
+	data, ok := l.encodeEntry(*buf, entry)
+	if !ok {
+		putBuffer(buf, *buf)
+		return
+	}
+	defer putBuffer(buf, data)
+
+	b := l.batch
+	if b.buffers == nil {
+		b.buffers = make([]bytes.Buffer, len(l.sinks))
+	}
+
+	for i, sink := range l.sinks {
+		if entry.Level < sink.level {
+			continue
+		}
+
+		// Sinks needing the level of every entry are written immediately,
+		// and their failures are counted apart from the batch.
+		if _, ok := sink.writer.(LevelWriter); ok {
+			part := data
+			if l.sinkQueues != nil {
+				part = bytes.Clone(data)
+			}
+			l.deliver([]delivery{{sink: i, level: entry.Level, data: part}}, func(failed bool) {
+				if failed {
+					l.stats.writeFailed.Add(1)
+				}
+			})
+			continue
+		}
+
+		b.buffers[i].Write(data)
+	}
+
+	b.entries++
+	b.bytes += len(data)
+
+	if b.bytes >= b.size {
+		l.flushBatch()
+	}
+}
+
+// flushBatch writes the buffered entries to the sinks. It is a no-op if batching is disabled.
+func (l *AsyncLogger) flushBatch() {
+	b := l.batch
+	if b == nil || b.bytes == 0 {
+		return
+	}
+
+	parts := make([]delivery, 0, len(b.buffers))
+	for i := range b.buffers {
+		data := b.buffers[i].Bytes()
+		if len(data) == 0 {
+			continue
+		}
+
+		// The buffers are reused, while sink workers write asynchronously.
+		if l.sinkQueues != nil {
+			data = bytes.Clone(data)
+		}
+		parts = append(parts, delivery{sink: i, data: data})
+	}
+
+	entries := b.entries
+	l.deliver(parts, func(failed bool) { l.recordWritten(entries, failed) })
+
+	for i := range b.buffers {
+		b.buffers[i].Reset()
+	}
+	b.entries, b.bytes = 0, 0
+}
@@ -0,0 +1,33 @@
+package logger
+
+import "sync"
+
+// maxPooledBuffer is the capacity above which encoding buffers are not reused,
+// so that a single huge entry does not keep its memory alive.
+const maxPooledBuffer = 64 << 10
+
+// bufferPool holds the buffers entries are encoded into.
+var bufferPool = sync.Pool{
+	New: func() any {
+		buf := make([]byte, 0, 1024)
+		return &buf
+	},
+}
+
+// getBuffer returns an empty encoding buffer from the pool.
+func getBuffer() *[]byte {
+	buf := bufferPool.Get().(*[]byte)
+	*buf = (*buf)[:0]
+	return buf
+}
+
+// putBuffer returns buf to the pool, keeping data, the slice last encoded into it,
+// since appending may have moved it to a larger array.
+func putBuffer(buf *[]byte, data []byte) {
+	if cap(data) > maxPooledBuffer {
+		return
+	}
+
+	*buf = data[:0]
+	bufferPool.Put(buf)
+}
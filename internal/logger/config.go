@@ -5,16 +5,41 @@ import (
 	"log/slog"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 const defaultBufferSize = 100
 
+// Defaults of the log file rotation.
+const (
+	defaultMaxSizeMB  = 100
+	defaultMaxBackups = 5
+)
+
 // NewFromEnv creates a new AsyncLogger configured from environment variables.
 // This is the recommended way to create a logger for most applications.
 //
 // Environment variables used:
 //   - LOG_BUFFER_SIZE: Buffer size for the log channel (default: 100)
 //   - LOG_LEVEL: Minimum log level - DEBUG, INFO, WARN, ERROR (default: INFO)
+//   - LOG_SINK: Primary output - stdout (the output argument), syslog or journald (default: stdout)
+//   - LOG_FILE: File logs are also written to (default: not set)
+//   - LOG_FILE_LEVEL: Minimum level written to LOG_FILE (default: LOG_LEVEL)
+//   - LOG_MAX_SIZE: Size in megabytes after which the log file is rotated (default: 100, 0 disables)
+//   - LOG_MAX_AGE: Age after which the log file is rotated (default: 0, disabled)
+//   - LOG_MAX_BACKUPS: Number of compressed rotated files kept (default: 5, 0 keeps all)
+//   - LOG_NETWORK: Collector logs are also sent to, as tcp://host:port or udp://host:port (default: not set)
+//   - LOG_NETWORK_LEVEL: Minimum level sent to LOG_NETWORK (default: LOG_LEVEL)
+//   - LOG_FORMAT: Output format - json or text (default: json); text is colorized unless NO_COLOR is set
+//   - LOG_OVERFLOW_POLICY: Behavior when the buffer is full - block, drop-newest, drop-oldest, sync (default: block)
+//   - LOG_REDACT: Comma-separated attribute keys whose values are hidden, e.g. title,description (default: not set)
+//   - LOG_REDACT_MODE: How redacted values are hidden - mask or hash (default: mask)
+//   - LOG_SINK_WORKERS: Write to every sink from its own goroutine, with a queue of LOG_BUFFER_SIZE writes (default: false)
+//   - LOG_BATCH_SIZE: Bytes of entries buffered before they are written together (default: 0, disabled)
+//   - LOG_FLUSH_INTERVAL: Longest time a batched entry waits to be written (default: 1s)
+//   - LOG_SAMPLING_INITIAL: Debug and info entries logged per message and second before sampling (default: 0, disabled)
+//   - LOG_SAMPLING_THEREAFTER: Log every N-th entry after the initial ones (default: 100, 0 drops them)
 //
 // Parameters:
 //   - output: Writer where log entries will be written (uses os.Stdout if nil)
@@ -22,9 +47,289 @@ const defaultBufferSize = 100
 // Returns a configured AsyncLogger ready for use.
 func NewFromEnv(output io.Writer) *AsyncLogger {
 	bufSize := getLogBufferSize()
-	level := getLogLevel()
+	level := LevelFromEnv()
+
+	logger := New(output, level, bufSize)
+
+	// The output is replaced, so e.g. the local syslog daemon is the only primary destination.
+	switch getLogSink() {
+	case "syslog":
+		writer := NewSyslogWriter()
+		logger.sinks[0] = sink{writer: writer, level: allLevels, closer: writer}
+	case "journald":
+		writer := NewJournaldWriter()
+		logger.sinks[0] = sink{writer: writer, level: allLevels, closer: writer}
+	}
+	logger.SetFormat(getLogFormat(), os.Getenv("NO_COLOR") == "")
+	logger.SetOverflowPolicy(getLogOverflowPolicy())
+
+	if keys := getLogRedact(); len(keys) > 0 {
+		logger.SetRedaction(keys, getLogRedactMode())
+	}
+
+	if getLogSinkWorkers() {
+		logger.EnableSinkWorkers(bufSize)
+	}
+
+	if batchSize := getLogBatchSize(); batchSize > 0 {
+		logger.EnableBatching(batchSize, getLogFlushInterval())
+	}
+
+	if initial := getLogSamplingInitial(); initial > 0 {
+		logger.EnableSampling(initial, getLogSamplingThereafter())
+	}
+
+	if path := os.Getenv("LOG_FILE"); path != "" {
+		file, err := OpenRotatingFile(path, getLogMaxSize(), getLogMaxAge(), getLogMaxBackups())
+		if err != nil {
+			panic("LOG_FILE can't be opened: " + err.Error())
+		}
+
+		logger.AddClosingSink(file, getSinkLevel("LOG_FILE_LEVEL"))
+	}
+
+	if endpoint := os.Getenv("LOG_NETWORK"); endpoint != "" {
+		network, addr, _ := strings.Cut(endpoint, "://")
+		writer, err := NewNetworkWriter(network, addr)
+		if err != nil {
+			panic("LOG_NETWORK must be tcp://host:port or udp://host:port, got: " + endpoint)
+		}
+
+		logger.AddClosingSink(writer, getSinkLevel("LOG_NETWORK_LEVEL"))
+	}
+
+	return logger
+}
+
+// getLogSink reads the LOG_SINK environment variable.
+//
+// Returns "stdout" if the environment variable is not set.
+func getLogSink() string {
+	switch sinkName := os.Getenv("LOG_SINK"); sinkName {
+	case "", "stdout":
+		return "stdout"
+	case "syslog", "journald":
+		return sinkName
+	default:
+		panic("LOG_SINK must be stdout, syslog or journald, got: " + sinkName)
+	}
+}
+
+// getSinkLevel reads the minimum level of a sink from the environment variable name.
+//
+// Returns a level accepting every entry of the logger if the environment variable is not set,
+// so the sink follows runtime changes of the logger level.
+func getSinkLevel(name string) slog.Level {
+	levelStr := os.Getenv(name)
+	if levelStr == "" {
+		return allLevels
+	}
+
+	level, ok := ParseLevel(levelStr)
+	if !ok {
+		panic(name + " must be one of DEBUG, INFO, WARN, ERROR, got: " + levelStr)
+	}
+
+	return level
+}
+
+// getLogFormat reads the LOG_FORMAT environment variable.
+//
+// Returns FormatJSON if the environment variable is not set.
+func getLogFormat() Format {
+	switch format := Format(os.Getenv("LOG_FORMAT")); format {
+	case "", FormatJSON:
+		return FormatJSON
+	case FormatText:
+		return FormatText
+	default:
+		panic("LOG_FORMAT must be json or text, got: " + string(format))
+	}
+}
+
+// getLogOverflowPolicy reads the LOG_OVERFLOW_POLICY environment variable.
+//
+// Returns OverflowBlock if the environment variable is not set.
+func getLogOverflowPolicy() OverflowPolicy {
+	switch policy := OverflowPolicy(os.Getenv("LOG_OVERFLOW_POLICY")); policy {
+	case "":
+		return OverflowBlock
+	case OverflowBlock, OverflowDropNewest, OverflowDropOldest, OverflowSync:
+		return policy
+	default:
+		panic("LOG_OVERFLOW_POLICY must be block, drop-newest, drop-oldest or sync, got: " + string(policy))
+	}
+}
+
+// getLogRedact reads the comma-separated LOG_REDACT environment variable.
+//
+// Returns nil (redaction disabled) if the environment variable is not set.
+func getLogRedact() []string {
+	var keys []string
+	for _, key := range strings.Split(os.Getenv("LOG_REDACT"), ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// getLogRedactMode reads the LOG_REDACT_MODE environment variable.
+//
+// Returns RedactMask if the environment variable is not set.
+func getLogRedactMode() RedactMode {
+	switch mode := RedactMode(os.Getenv("LOG_REDACT_MODE")); mode {
+	case "", RedactMask:
+		return RedactMask
+	case RedactHash:
+		return RedactHash
+	default:
+		panic("LOG_REDACT_MODE must be mask or hash, got: " + string(mode))
+	}
+}
+
+// defaultFlushInterval is the default longest time an entry stays in a batch.
+const defaultFlushInterval = time.Second
+
+// getLogSinkWorkers reads the LOG_SINK_WORKERS environment variable.
+//
+// Returns false if the environment variable is not set.
+func getLogSinkWorkers() bool {
+	enabledStr := os.Getenv("LOG_SINK_WORKERS")
+	if enabledStr == "" {
+		return false
+	}
+
+	enabled, err := strconv.ParseBool(enabledStr)
+	if err != nil {
+		panic("LOG_SINK_WORKERS must be a boolean, got: " + enabledStr)
+	}
+
+	return enabled
+}
+
+// getLogBatchSize reads the LOG_BATCH_SIZE environment variable in bytes.
+//
+// Returns 0 (batching disabled) if the environment variable is not set.
+func getLogBatchSize() int {
+	sizeStr := os.Getenv("LOG_BATCH_SIZE")
+	if sizeStr == "" {
+		return 0
+	}
+
+	size, err := strconv.Atoi(sizeStr)
+	if err != nil || size < 0 {
+		panic("LOG_BATCH_SIZE must be a non-negative integer, got: " + sizeStr)
+	}
+
+	return size
+}
+
+// getLogFlushInterval reads the LOG_FLUSH_INTERVAL environment variable.
+//
+// Returns 1s if the environment variable is not set.
+func getLogFlushInterval() time.Duration {
+	intervalStr := os.Getenv("LOG_FLUSH_INTERVAL")
+	if intervalStr == "" {
+		return defaultFlushInterval
+	}
+
+	interval, err := time.ParseDuration(intervalStr)
+	if err != nil || interval <= 0 {
+		panic("LOG_FLUSH_INTERVAL must be a positive duration, got: " + intervalStr)
+	}
+
+	return interval
+}
+
+// defaultSamplingThereafter is the default sampling rate after the initial entries.
+const defaultSamplingThereafter = 100
+
+// getLogSamplingInitial reads the LOG_SAMPLING_INITIAL environment variable.
+//
+// Returns 0 (sampling disabled) if the environment variable is not set.
+func getLogSamplingInitial() int {
+	initialStr := os.Getenv("LOG_SAMPLING_INITIAL")
+	if initialStr == "" {
+		return 0
+	}
+
+	initial, err := strconv.Atoi(initialStr)
+	if err != nil || initial < 0 {
+		panic("LOG_SAMPLING_INITIAL must be a non-negative integer, got: " + initialStr)
+	}
+
+	return initial
+}
+
+// getLogSamplingThereafter reads the LOG_SAMPLING_THEREAFTER environment variable.
+//
+// Returns 100 if the environment variable is not set.
+func getLogSamplingThereafter() int {
+	thereafterStr := os.Getenv("LOG_SAMPLING_THEREAFTER")
+	if thereafterStr == "" {
+		return defaultSamplingThereafter
+	}
+
+	thereafter, err := strconv.Atoi(thereafterStr)
+	if err != nil || thereafter < 0 {
+		panic("LOG_SAMPLING_THEREAFTER must be a non-negative integer, got: " + thereafterStr)
+	}
+
+	return thereafter
+}
+
+// getLogMaxSize reads the LOG_MAX_SIZE environment variable in megabytes
+// and returns the rotation size in bytes.
+//
+// Returns 100 MB if the environment variable is not set.
+func getLogMaxSize() int64 {
+	sizeStr := os.Getenv("LOG_MAX_SIZE")
+	if sizeStr == "" {
+		return defaultMaxSizeMB << 20
+	}
+
+	size, err := strconv.ParseInt(sizeStr, 10, 64)
+	if err != nil || size < 0 {
+		panic("LOG_MAX_SIZE must be a non-negative integer, got: " + sizeStr)
+	}
+
+	return size << 20
+}
+
+// getLogMaxAge reads the LOG_MAX_AGE environment variable.
+//
+// Returns 0 (no age-based rotation) if the environment variable is not set.
+func getLogMaxAge() time.Duration {
+	ageStr := os.Getenv("LOG_MAX_AGE")
+	if ageStr == "" {
+		return 0
+	}
+
+	age, err := time.ParseDuration(ageStr)
+	if err != nil || age < 0 {
+		panic("LOG_MAX_AGE must be a non-negative duration, got: " + ageStr)
+	}
 
-	return New(output, level, bufSize)
+	return age
+}
+
+// getLogMaxBackups reads the LOG_MAX_BACKUPS environment variable.
+//
+// Returns 5 if the environment variable is not set.
+func getLogMaxBackups() int {
+	backupsStr := os.Getenv("LOG_MAX_BACKUPS")
+	if backupsStr == "" {
+		return defaultMaxBackups
+	}
+
+	backups, err := strconv.Atoi(backupsStr)
+	if err != nil || backups < 0 {
+		panic("LOG_MAX_BACKUPS must be a non-negative integer, got: " + backupsStr)
+	}
+
+	return backups
 }
 
 // getLogBufferSize reads the LOG_BUFFER_SIZE environment variable
@@ -47,7 +352,7 @@ func getLogBufferSize() int {
 	return bufSize
 }
 
-// getLogLevel reads the LOG_LEVEL environment variable
+// LevelFromEnv reads the LOG_LEVEL environment variable
 // and returns the corresponding slog.Level.
 //
 // Supported values (case-sensitive):
@@ -58,18 +363,27 @@ func getLogBufferSize() int {
 //
 // Returns slog.LevelInfo if the environment variable is not set
 // or contains an unrecognized value.
-func getLogLevel() slog.Level {
-	levelStr := os.Getenv("LOG_LEVEL")
+func LevelFromEnv() slog.Level {
+	level, ok := ParseLevel(os.Getenv("LOG_LEVEL"))
+	if !ok {
+		return slog.LevelInfo
+	}
+
+	return level
+}
+
+// ParseLevel converts DEBUG, INFO, WARN or ERROR to the corresponding slog.Level.
+func ParseLevel(levelStr string) (slog.Level, bool) {
 	switch levelStr {
 	case "DEBUG":
-		return slog.LevelDebug
+		return slog.LevelDebug, true
 	case "INFO":
-		return slog.LevelInfo
+		return slog.LevelInfo, true
 	case "WARN":
-		return slog.LevelWarn
+		return slog.LevelWarn, true
 	case "ERROR":
-		return slog.LevelError
+		return slog.LevelError, true
 	default:
-		return slog.LevelInfo
+		return slog.LevelInfo, false
 	}
 }
@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+// contextKey is the type of the context keys set by this package.
+type contextKey int
+
+// Context keys of the correlation IDs attached to log entries.
+const (
+	requestIDKey contextKey = iota
+	traceKey
+)
+
+// traceContext identifies the trace and span a request belongs to.
+type traceContext struct {
+	traceID string
+	spanID  string
+}
+
+// WithRequestID returns a copy of ctx carrying the request ID.
+// Entries logged with the returned context include it as the request_id attribute.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, if any.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDKey).(string)
+	return requestID, ok
+}
+
+// WithTrace returns a copy of ctx carrying the trace and span IDs.
+// Entries logged with the returned context include them as the trace_id and span_id attributes.
+func WithTrace(ctx context.Context, traceID, spanID string) context.Context {
+	return context.WithValue(ctx, traceKey, traceContext{traceID: traceID, spanID: spanID})
+}
+
+// contextAttrs returns the correlation attributes stored in ctx.
+func contextAttrs(ctx context.Context) []slog.Attr {
+	var attrs []slog.Attr
+
+	if requestID, ok := RequestIDFromContext(ctx); ok {
+		attrs = append(attrs, slog.String("request_id", requestID))
+	}
+
+	if trace, ok := ctx.Value(traceKey).(traceContext); ok {
+		attrs = append(attrs, slog.String("trace_id", trace.traceID), slog.String("span_id", trace.spanID))
+	}
+
+	return attrs
+}
@@ -0,0 +1,130 @@
+package logger
+
+import (
+	"log/slog"
+	"sync/atomic"
+)
+
+// delivery is encoded data to be written to one sink.
+// A nil data is a marker acknowledged by the sink after the writes queued before it.
+type delivery struct {
+	// sink is the index of the sink in AsyncLogger.sinks
+	sink  int
+	level slog.Level
+	data  []byte
+}
+
+// sinkWrite is a delivery queued on the worker of its sink.
+type sinkWrite struct {
+	delivery
+	group *writeGroup
+}
+
+// writeGroup tracks the sinks writing the same entries and reports the outcome
+// once the last of them has finished.
+type writeGroup struct {
+	remaining atomic.Int32
+	failed    atomic.Bool
+	finish    func(failed bool)
+}
+
+// done records the result of one sink.
+func (g *writeGroup) done(err error) {
+	if err != nil {
+		g.failed.Store(true)
+	}
+
+	if g.remaining.Add(-1) == 0 {
+		g.finish(g.failed.Load())
+	}
+}
+
+// EnableSinkWorkers gives every sink its own goroutine and a queue of queueSize writes,
+// so a slow sink such as a remote collector does not delay the others. Entries are
+// encoded once and written to each sink in the order they were logged.
+// Sink workers must be enabled before Start is called.
+func (l *AsyncLogger) EnableSinkWorkers(queueSize int) {
+	l.sinkQueueSize = queueSize
+}
+
+// startSinkWorkers launches one worker per sink if sink workers are enabled.
+func (l *AsyncLogger) startSinkWorkers() {
+	if l.sinkQueueSize <= 0 {
+		return
+	}
+
+	l.sinkQueues = make([]chan sinkWrite, len(l.sinks))
+	for i, sink := range l.sinks {
+		queue := make(chan sinkWrite, l.sinkQueueSize)
+		l.sinkQueues[i] = queue
+
+		l.sinkWG.Add(1)
+		go func() {
+			defer l.sinkWG.Done()
+			for w := range queue {
+				var err error
+				if w.data != nil {
+					err = sink.write(w.level, w.data)
+				}
+				w.group.done(err)
+			}
+		}()
+	}
+}
+
+// stopSinkWorkers waits until the sink workers have written their queues.
+func (l *AsyncLogger) stopSinkWorkers() {
+	for _, queue := range l.sinkQueues {
+		close(queue)
+	}
+	l.sinkWG.Wait()
+}
+
+// deliver writes every part to its sink and calls finish once all writes have completed,
+// reporting whether any of them failed. With sink workers the writes are asynchronous
+// and the data must not be modified afterwards.
+func (l *AsyncLogger) deliver(parts []delivery, finish func(failed bool)) {
+	if l.sinkQueues == nil || len(parts) == 0 {
+		l.writeMu.Lock()
+		failed := false
+		for _, part := range parts {
+			if part.data == nil {
+				continue
+			}
+			if err := l.sinks[part.sink].write(part.level, part.data); err != nil {
+				failed = true
+			}
+		}
+		l.writeMu.Unlock()
+
+		finish(failed)
+		return
+	}
+
+	group := &writeGroup{finish: finish}
+	group.remaining.Store(int32(len(parts)))
+	for _, part := range parts {
+		l.sinkQueues[part.sink] <- sinkWrite{delivery: part, group: group}
+	}
+}
+
+// recordWritten counts n delivered entries as written or, if any sink failed, as failed.
+func (l *AsyncLogger) recordWritten(n int, failed bool) {
+	if failed {
+		l.stats.writeFailed.Add(uint64(n))
+		return
+	}
+
+	l.stats.written.Add(uint64(n))
+}
+
+// barrier delivers a marker to every sink and closes flushed once all of them
+// have written the data delivered before it.
+func (l *AsyncLogger) barrier(flushed chan struct{}) {
+	markers := make([]delivery, len(l.sinks))
+	for i := range markers {
+		markers[i].sink = i
+	}
+
+	l.deliver(markers, func(bool) { close(flushed) })
+}
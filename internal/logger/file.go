@@ -0,0 +1,198 @@
+package logger
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the timestamp added to the names of rotated files.
+// It sorts lexically in chronological order.
+const backupTimeFormat = "20060102T150405.000"
+
+// RotatingFile is an io.WriteCloser appending to a file that is rotated once it
+// exceeds a maximum size or age. Rotated files are gzip-compressed and only the
+// most recent backups are kept.
+type RotatingFile struct {
+	mu sync.Mutex
+	// path is the file the logs are written to
+	path string
+	// maxSize is the size in bytes after which the file is rotated, 0 disables the limit
+	maxSize int64
+	// maxAge is the age after which the file is rotated, 0 disables the limit
+	maxAge time.Duration
+	// maxBackups is the number of compressed backups kept, 0 keeps all of them
+	maxBackups int
+	file       *os.File
+	size       int64
+	openedAt   time.Time
+	now        func() time.Time
+}
+
+// OpenRotatingFile opens path for appending, creating it if needed.
+func OpenRotatingFile(path string, maxSize int64, maxAge time.Duration, maxBackups int) (*RotatingFile, error) {
+	f := &RotatingFile{
+		path:       path,
+		maxSize:    maxSize,
+		maxAge:     maxAge,
+		maxBackups: maxBackups,
+		now:        time.Now,
+	}
+
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Write appends p to the file, rotating it first if p would exceed the size
+// limit or the file has reached the maximum age.
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.shouldRotate(len(p)) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Reopen closes and reopens the file, e.g. after it was moved by an external tool.
+func (f *RotatingFile) Reopen() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+
+	return f.open()
+}
+
+// Close closes the file.
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.file.Close()
+}
+
+// shouldRotate reports whether writing n more bytes requires a rotation.
+// A file is never rotated while empty, so a single large entry can't cause a rotation loop.
+func (f *RotatingFile) shouldRotate(n int) bool {
+	if f.size == 0 {
+		return false
+	}
+
+	return f.maxSize > 0 && f.size+int64(n) > f.maxSize ||
+		f.maxAge > 0 && f.now().Sub(f.openedAt) >= f.maxAge
+}
+
+// open opens the file for appending and records its current size.
+func (f *RotatingFile) open() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = f.now()
+	return nil
+}
+
+// rotate renames the current file to a timestamped backup, opens a new file,
+// compresses the backup and removes the backups exceeding maxBackups.
+func (f *RotatingFile) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+
+	backup := f.path + "." + f.now().Format(backupTimeFormat)
+	if err := os.Rename(f.path, backup); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	if err := compressFile(backup); err != nil {
+		return err
+	}
+
+	return f.removeOldBackups()
+}
+
+// removeOldBackups deletes all but the newest maxBackups compressed backups.
+func (f *RotatingFile) removeOldBackups() error {
+	if f.maxBackups <= 0 {
+		return nil
+	}
+
+	backups, err := filepath.Glob(f.path + ".*.gz")
+	if err != nil {
+		return fmt.Errorf("failed to list log backups: %w", err)
+	}
+
+	if len(backups) <= f.maxBackups {
+		return nil
+	}
+
+	slices.Sort(backups)
+	for _, backup := range backups[:len(backups)-f.maxBackups] {
+		if err := os.Remove(backup); err != nil {
+			return fmt.Errorf("failed to remove log backup: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// compressFile gzips path into path.gz and removes the original.
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open log backup: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create compressed log backup: %w", err)
+	}
+
+	zw := gzip.NewWriter(dst)
+	zw.Name = filepath.Base(path)
+
+	_, err = io.Copy(zw, src)
+	if closeErr := zw.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path + ".gz")
+		return fmt.Errorf("failed to compress log backup: %w", err)
+	}
+
+	return os.Remove(path)
+}
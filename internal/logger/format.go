@@ -0,0 +1,267 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Format selects how log entries are encoded.
+type Format string
+
+// Supported log formats.
+const (
+	// FormatJSON writes one JSON object per line.
+	FormatJSON Format = "json"
+	// FormatText writes aligned, human-readable lines for local development.
+	FormatText Format = "text"
+)
+
+// ANSI escape sequences used by the text format.
+const (
+	colorReset = "\x1b[0m"
+	colorGray  = "\x1b[90m"
+	colorCyan  = "\x1b[36m"
+	colorBlue  = "\x1b[34m"
+	colorRed   = "\x1b[31m"
+	colorAmber = "\x1b[33m"
+)
+
+// messageWidth is the column width the message is padded to in the text format,
+// so the attributes of consecutive lines start at the same column.
+const messageWidth = 40
+
+// SetFormat selects the encoding of log entries. Colors are used by the text
+// format only if color is true. The format must be set before Start is called.
+func (l *AsyncLogger) SetFormat(format Format, color bool) {
+	switch format {
+	case FormatText:
+		l.encode = func(dst []byte, entry LogEntry) ([]byte, error) {
+			return encodeText(dst, entry, color), nil
+		}
+	default:
+		l.encode = encodeJSON
+	}
+}
+
+// encodeJSON appends entry as a JSON object terminated by a newline to dst.
+// The object starts with time, level and message, followed by the attributes in the
+// order they were given; a repeated key keeps only its last value, and attributes
+// named like the fixed fields are skipped.
+func encodeJSON(dst []byte, entry LogEntry) ([]byte, error) {
+	dst = append(dst, `{"time":"`...)
+	dst = entry.Time.AppendFormat(dst, time.RFC3339)
+	dst = append(dst, `","level":`...)
+	dst = appendJSONString(dst, entry.Level.String())
+	dst = append(dst, `,"message":`...)
+	dst = appendJSONString(dst, entry.Message)
+
+	var err error
+	for i, attr := range entry.Attrs {
+		if attr.Key == "time" || attr.Key == "level" || attr.Key == "message" || overridden(entry.Attrs[i+1:], attr.Key) {
+			continue
+		}
+
+		dst = append(dst, ',')
+		dst = appendJSONString(dst, attr.Key)
+		dst = append(dst, ':')
+		if dst, err = appendJSONValue(dst, attr.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	return append(dst, "}\n"...), nil
+}
+
+// overridden reports whether key is set again by one of the later attrs.
+func overridden(later []slog.Attr, key string) bool {
+	for _, attr := range later {
+		if attr.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// appendJSONValue appends value encoded as JSON to dst. Groups become nested objects and
+// errors their message; values of other kinds are encoded like encoding/json would encode value.Any().
+func appendJSONValue(dst []byte, value slog.Value) ([]byte, error) {
+	value = value.Resolve()
+
+	switch value.Kind() {
+	case slog.KindString:
+		return appendJSONString(dst, value.String()), nil
+	case slog.KindInt64:
+		return strconv.AppendInt(dst, value.Int64(), 10), nil
+	case slog.KindUint64:
+		return strconv.AppendUint(dst, value.Uint64(), 10), nil
+	case slog.KindBool:
+		return strconv.AppendBool(dst, value.Bool()), nil
+	case slog.KindDuration:
+		return strconv.AppendInt(dst, int64(value.Duration()), 10), nil
+	case slog.KindFloat64:
+		f := value.Float64()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("unsupported float value %v", f)
+		}
+		return strconv.AppendFloat(dst, f, 'g', -1, 64), nil
+	case slog.KindTime:
+		dst = append(dst, '"')
+		dst = value.Time().AppendFormat(dst, time.RFC3339Nano)
+		return append(dst, '"'), nil
+	case slog.KindGroup:
+		dst = append(dst, '{')
+		var err error
+		for i, attr := range value.Group() {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = appendJSONString(dst, attr.Key)
+			dst = append(dst, ':')
+			if dst, err = appendJSONValue(dst, attr.Value); err != nil {
+				return nil, err
+			}
+		}
+		return append(dst, '}'), nil
+	default:
+		// Errors usually have no exported fields, so their message is written instead.
+		if err, ok := value.Any().(error); ok {
+			return appendJSONString(dst, err.Error()), nil
+		}
+
+		data, err := json.Marshal(value.Any())
+		if err != nil {
+			return nil, err
+		}
+		return append(dst, data...), nil
+	}
+}
+
+// hexDigits are used to escape control characters in JSON strings.
+const hexDigits = "0123456789abcdef"
+
+// appendJSONString appends s as a quoted JSON string to dst. Invalid UTF-8 is replaced
+// with U+FFFD; unlike encoding/json, HTML characters are not escaped.
+func appendJSONString(dst []byte, s string) []byte {
+	dst = append(dst, '"')
+
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' {
+				i++
+				continue
+			}
+
+			dst = append(dst, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				dst = append(dst, '\\', c)
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf])
+			}
+			i++
+			start = i
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, `\ufffd`...)
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
+
+// encodeText appends entry as "time LEVEL message key=value ..." terminated by a newline to dst.
+// Attributes are sorted by key; values containing spaces or quotes are quoted.
+func encodeText(dst []byte, entry LogEntry, color bool) []byte {
+	var b strings.Builder
+
+	paint := func(code, s string) {
+		if color {
+			b.WriteString(code)
+			b.WriteString(s)
+			b.WriteString(colorReset)
+			return
+		}
+		b.WriteString(s)
+	}
+
+	paint(colorGray, entry.Time.Format("15:04:05.000"))
+	b.WriteByte(' ')
+	paint(levelColor(entry.Level), fmt.Sprintf("%-5s", entry.Level.String()))
+	b.WriteByte(' ')
+	b.WriteString(entry.Message)
+
+	if len(entry.Attrs) > 0 {
+		if pad := messageWidth - len(entry.Message); pad > 0 {
+			b.WriteString(strings.Repeat(" ", pad))
+		}
+	}
+
+	attrs := slices.Clone(entry.Attrs)
+	slices.SortStableFunc(attrs, func(a, b slog.Attr) int { return strings.Compare(a.Key, b.Key) })
+
+	for _, attr := range attrs {
+		b.WriteByte(' ')
+		paint(colorGray, attr.Key+"=")
+		b.WriteString(textValue(attr.Value))
+	}
+
+	b.WriteByte('\n')
+	return append(dst, b.String()...)
+}
+
+// levelColor returns the color of a level in the text format.
+func levelColor(level slog.Level) string {
+	switch {
+	case level >= slog.LevelError:
+		return colorRed
+	case level >= slog.LevelWarn:
+		return colorAmber
+	case level >= slog.LevelInfo:
+		return colorBlue
+	default:
+		return colorCyan
+	}
+}
+
+// textValue formats an attribute value, quoting strings that would be ambiguous.
+func textValue(value slog.Value) string {
+	var s string
+	switch value.Kind() {
+	case slog.KindString:
+		s = value.String()
+	case slog.KindTime:
+		s = value.Time().Format(time.RFC3339)
+	default:
+		s = fmt.Sprint(value.Any())
+	}
+
+	if s == "" || strings.ContainsAny(s, " \t\n\"=") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
@@ -0,0 +1,96 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+var _ slog.Handler = (*Handler)(nil)
+
+// Handler is an slog.Handler writing records through an AsyncLogger,
+// so code using the standard slog.Logger shares its queue, sinks and JSON format.
+// Attributes inside groups are flattened into dotted keys, e.g. "http.method".
+type Handler struct {
+	logger *AsyncLogger
+	// attrs are added to every record, already qualified with their groups
+	attrs []slog.Attr
+	// prefix is the dotted group path of the attributes of future records
+	prefix string
+}
+
+// NewHandler creates an slog.Handler backed by logger.
+func NewHandler(logger *AsyncLogger) *Handler {
+	return &Handler{logger: logger}
+}
+
+// Enabled reports whether records at level pass the logger level.
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.logger.Level()
+}
+
+// Handle queues the record on the async logger.
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+	attrs := slices.Clip(h.attrs)
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = appendFlattened(attrs, h.prefix, attr)
+		return true
+	})
+
+	h.logger.enqueue(ctx, LogEntry{
+		Level:   record.Level,
+		Message: record.Message,
+		Time:    record.Time,
+		Attrs:   attrs,
+	})
+
+	return nil
+}
+
+// WithAttrs returns a handler adding attrs to every record.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	child := *h
+	child.attrs = slices.Clip(h.attrs)
+	for _, attr := range attrs {
+		child.attrs = appendFlattened(child.attrs, h.prefix, attr)
+	}
+
+	return &child
+}
+
+// WithGroup returns a handler qualifying the attributes of future records with name.
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	child := *h
+	child.prefix = h.prefix + name + "."
+	return &child
+}
+
+// appendFlattened resolves attr and appends it to attrs with its key prefixed,
+// expanding groups into one attribute per member. Empty attributes are skipped.
+func appendFlattened(attrs []slog.Attr, prefix string, attr slog.Attr) []slog.Attr {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return attrs
+	}
+
+	if attr.Value.Kind() != slog.KindGroup {
+		attr.Key = prefix + attr.Key
+		return append(attrs, attr)
+	}
+
+	// Members of a group with an empty key are inlined.
+	if attr.Key != "" {
+		prefix += strings.TrimSuffix(attr.Key, ".") + "."
+	}
+
+	for _, member := range attr.Value.Group() {
+		attrs = appendFlattened(attrs, prefix, member)
+	}
+
+	return attrs
+}
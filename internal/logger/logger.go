@@ -18,4 +18,20 @@ type Logger interface {
 
 	// Error logs an error-level message with optional structured attributes.
 	Error(ctx context.Context, msg string, attrs ...slog.Attr)
+
+	// Flush blocks until the entries logged so far have been written or ctx is done.
+	Flush(ctx context.Context) error
+
+	// With returns a child logger that adds attrs to every entry, e.g. the component
+	// or the ID of the task being processed.
+	With(attrs ...slog.Attr) Logger
+}
+
+// LevelController is implemented by loggers whose minimum level can be changed at runtime.
+type LevelController interface {
+	// Level returns the current minimum log level.
+	Level() slog.Level
+
+	// SetLevel changes the minimum log level.
+	SetLevel(level slog.Level)
 }
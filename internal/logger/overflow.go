@@ -0,0 +1,83 @@
+package logger
+
+import "context"
+
+// OverflowPolicy decides what happens to an entry when the queue is full.
+type OverflowPolicy string
+
+// Supported overflow policies.
+const (
+	// OverflowBlock waits until the queue has room or the context is done,
+	// dropping the entry in the latter case or if the logger has stopped.
+	OverflowBlock OverflowPolicy = "block"
+	// OverflowDropNewest discards the entry being logged.
+	OverflowDropNewest OverflowPolicy = "drop-newest"
+	// OverflowDropOldest discards the oldest queued entry to make room for the new one.
+	OverflowDropOldest OverflowPolicy = "drop-oldest"
+	// OverflowSync writes the entry directly from the calling goroutine,
+	// so it may appear out of order with the queued entries.
+	OverflowSync OverflowPolicy = "sync"
+)
+
+// SetOverflowPolicy selects the behavior when the queue is full. The default is OverflowBlock.
+// The policy must be set before Start is called.
+func (l *AsyncLogger) SetOverflowPolicy(policy OverflowPolicy) {
+	l.overflow = policy
+}
+
+// push queues entry according to the overflow policy, or drops it once the worker is stopping.
+func (l *AsyncLogger) push(ctx context.Context, entry LogEntry) {
+	l.pushMu.RLock()
+	defer l.pushMu.RUnlock()
+
+	select {
+	case <-l.stopping:
+		l.stats.dropped.Add(1)
+		return
+	default:
+	}
+
+	select {
+	case l.ch <- entry:
+		l.stats.enqueued.Add(1)
+		return
+	default:
+	}
+
+	switch l.overflow {
+	case OverflowDropNewest:
+		l.stats.dropped.Add(1)
+	case OverflowDropOldest:
+		for {
+			select {
+			case l.ch <- entry:
+				l.stats.enqueued.Add(1)
+				return
+			default:
+			}
+
+			select {
+			case oldest := <-l.ch:
+				if oldest.flushed != nil {
+					// Everything queued before the marker has been processed.
+					close(oldest.flushed)
+					continue
+				}
+				l.stats.dropped.Add(1)
+			default:
+			}
+		}
+	case OverflowSync:
+		l.writeEntry(entry)
+	default:
+		select {
+		case l.ch <- entry:
+			l.stats.enqueued.Add(1)
+		case <-ctx.Done():
+			l.stats.dropped.Add(1)
+		case <-l.stopping:
+			// Nothing makes room in the queue anymore, and the worker waits for this push to stop.
+			l.stats.dropped.Add(1)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+	"strings"
+)
+
+// RedactMode selects how the values of sensitive attributes are hidden.
+type RedactMode string
+
+// Supported redaction modes.
+const (
+	// RedactMask replaces the value with a fixed placeholder.
+	RedactMask RedactMode = "mask"
+	// RedactHash replaces the value with a short SHA-256 digest, so entries
+	// with the same value can still be correlated without revealing it.
+	RedactHash RedactMode = "hash"
+)
+
+// redactedValue is written instead of masked attribute values.
+const redactedValue = "[REDACTED]"
+
+// hashLength is the number of hex digits of the digest kept by RedactHash.
+const hashLength = 16
+
+// redactor hides the values of attributes with sensitive keys.
+type redactor struct {
+	// keys are the lower-cased attribute keys to redact
+	keys map[string]struct{}
+	mode RedactMode
+}
+
+// SetRedaction hides the values of the attributes named by keys, compared case-insensitively,
+// before entries are encoded. Dotted keys produced by Handler match by their last segment,
+// e.g. "http.authorization" matches "authorization". An empty list disables redaction.
+// Redaction must be configured before Start is called.
+func (l *AsyncLogger) SetRedaction(keys []string, mode RedactMode) {
+	if len(keys) == 0 {
+		l.redactor = nil
+		return
+	}
+
+	r := &redactor{keys: make(map[string]struct{}, len(keys)), mode: mode}
+	for _, key := range keys {
+		r.keys[strings.ToLower(key)] = struct{}{}
+	}
+
+	l.redactor = r
+}
+
+// redact returns attrs with sensitive values replaced. The slice is copied before
+// the first replacement, since it may be shared with the caller or a scoped logger.
+func (r *redactor) redact(attrs []slog.Attr) []slog.Attr {
+	copied := false
+	for i, attr := range attrs {
+		if !r.sensitive(attr.Key) {
+			continue
+		}
+
+		if !copied {
+			attrs = append([]slog.Attr(nil), attrs...)
+			copied = true
+		}
+		attrs[i] = slog.String(attr.Key, r.hide(attr.Value))
+	}
+
+	return attrs
+}
+
+// sensitive reports whether the value of key must be hidden.
+func (r *redactor) sensitive(key string) bool {
+	key = strings.ToLower(key)
+	if _, ok := r.keys[key]; ok {
+		return true
+	}
+
+	if i := strings.LastIndexByte(key, '.'); i >= 0 {
+		_, ok := r.keys[key[i+1:]]
+		return ok
+	}
+
+	return false
+}
+
+// hide returns the replacement of value according to the mode.
+func (r *redactor) hide(value slog.Value) string {
+	if r.mode != RedactHash {
+		return redactedValue
+	}
+
+	sum := sha256.Sum256([]byte(value.Resolve().String()))
+	return "sha256:" + hex.EncodeToString(sum[:])[:hashLength]
+}
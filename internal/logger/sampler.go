@@ -0,0 +1,73 @@
+package logger
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// samplingTick is the window in which the first entries of every message are always logged.
+const samplingTick = time.Second
+
+// sampler limits the volume of repeated debug and info entries.
+// Within every tick the first initial entries with a given level and message are logged,
+// then only every thereafter-th one. Warnings and errors are never sampled.
+type sampler struct {
+	initial    uint64
+	thereafter uint64
+
+	mu sync.Mutex
+	// counts holds the number of entries seen per level and message in the current tick
+	counts map[samplingKey]uint64
+	// resetAt is when the current tick ends
+	resetAt time.Time
+}
+
+// samplingKey identifies the entries counted together.
+type samplingKey struct {
+	level   slog.Level
+	message string
+}
+
+// newSampler creates a sampler logging the first initial entries per message and
+// tick and every thereafter-th entry after that. A zero thereafter drops them all.
+func newSampler(initial, thereafter int) *sampler {
+	return &sampler{
+		initial:    uint64(initial),
+		thereafter: uint64(thereafter),
+		counts:     make(map[samplingKey]uint64),
+	}
+}
+
+// allow reports whether an entry with level and message logged at now should be kept.
+func (s *sampler) allow(level slog.Level, message string, now time.Time) bool {
+	if level >= slog.LevelWarn {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !now.Before(s.resetAt) {
+		clear(s.counts)
+		s.resetAt = now.Add(samplingTick)
+	}
+
+	key := samplingKey{level: level, message: message}
+	s.counts[key]++
+	n := s.counts[key]
+
+	if n <= s.initial {
+		return true
+	}
+
+	return s.thereafter > 0 && (n-s.initial)%s.thereafter == 0
+}
+
+// EnableSampling limits repeated debug and info entries: per second, the first initial
+// entries with the same level and message are logged, then every thereafter-th one.
+// A zero thereafter drops all entries beyond the initial ones. Warnings and errors are
+// always logged. Sampling must be enabled before Start is called.
+func (l *AsyncLogger) EnableSampling(initial, thereafter int) {
+	l.sampler = newSampler(initial, thereafter)
+}
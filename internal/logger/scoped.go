@@ -0,0 +1,60 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+var _ Logger = (*scopedLogger)(nil)
+
+// scopedLogger is a child of an AsyncLogger adding fixed attributes to every entry.
+type scopedLogger struct {
+	logger *AsyncLogger
+	attrs  []slog.Attr
+}
+
+// With returns a child logger adding attrs to every entry, before the attributes of the call.
+// The child shares the queue, sinks and level of l.
+func (l *AsyncLogger) With(attrs ...slog.Attr) Logger {
+	return &scopedLogger{logger: l, attrs: slices.Clip(attrs)}
+}
+
+// With returns a child logger adding attrs after the attributes of s.
+func (s *scopedLogger) With(attrs ...slog.Attr) Logger {
+	return &scopedLogger{logger: s.logger, attrs: slices.Concat(s.attrs, attrs)}
+}
+
+// log prepends the fixed attributes and logs through the parent.
+func (s *scopedLogger) log(ctx context.Context, level slog.Level, msg string, attrs []slog.Attr) {
+	if level < s.logger.Level() {
+		return
+	}
+
+	s.logger.log(ctx, level, msg, slices.Concat(s.attrs, attrs)...)
+}
+
+// Debug logs a debug-level message with the fixed and the given attributes.
+func (s *scopedLogger) Debug(ctx context.Context, msg string, attrs ...slog.Attr) {
+	s.log(ctx, slog.LevelDebug, msg, attrs)
+}
+
+// Info logs an info-level message with the fixed and the given attributes.
+func (s *scopedLogger) Info(ctx context.Context, msg string, attrs ...slog.Attr) {
+	s.log(ctx, slog.LevelInfo, msg, attrs)
+}
+
+// Warn logs a warning-level message with the fixed and the given attributes.
+func (s *scopedLogger) Warn(ctx context.Context, msg string, attrs ...slog.Attr) {
+	s.log(ctx, slog.LevelWarn, msg, attrs)
+}
+
+// Error logs an error-level message with the fixed and the given attributes.
+func (s *scopedLogger) Error(ctx context.Context, msg string, attrs ...slog.Attr) {
+	s.log(ctx, slog.LevelError, msg, attrs)
+}
+
+// Flush waits for the entries queued on the parent logger.
+func (s *scopedLogger) Flush(ctx context.Context) error {
+	return s.logger.Flush(ctx)
+}
@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"sync"
+	"time"
+)
+
+// sink is an output of the logger with its own minimum level.
+type sink struct {
+	writer io.Writer
+	// level is the minimum level of the entries written to the sink
+	level slog.Level
+	// closer is closed by AsyncLogger.Close, nil if the caller owns the writer
+	closer io.Closer
+}
+
+// LevelWriter is implemented by sinks that need the level of every entry,
+// e.g. to map it to a syslog priority. Such sinks receive one call per entry, even with batching.
+type LevelWriter interface {
+	// WriteLevel writes an encoded entry logged at level.
+	WriteLevel(level slog.Level, p []byte) (int, error)
+}
+
+// write writes an encoded entry logged at level to the sink.
+func (s sink) write(level slog.Level, p []byte) error {
+	if w, ok := s.writer.(LevelWriter); ok {
+		_, err := w.WriteLevel(level, p)
+		return err
+	}
+
+	_, err := s.writer.Write(p)
+	return err
+}
+
+// AddSink registers an additional writer receiving every entry at or above level.
+// Each entry is encoded once and written to all sinks in registration order.
+// Entries below the logger level are discarded before reaching any sink.
+// Sinks must be added before Start is called.
+func (l *AsyncLogger) AddSink(w io.Writer, level slog.Level) {
+	l.sinks = append(l.sinks, sink{writer: w, level: level})
+}
+
+// AddClosingSink registers w like AddSink and closes it when the logger is closed.
+func (l *AsyncLogger) AddClosingSink(w io.WriteCloser, level slog.Level) {
+	l.sinks = append(l.sinks, sink{writer: w, level: level, closer: w})
+}
+
+// networkDialTimeout bounds how long connecting to a network sink may take.
+const networkDialTimeout = 5 * time.Second
+
+// NetworkWriter writes log entries to a TCP or UDP endpoint, such as a log collector.
+// The connection is established on the first write and re-established after a failed write,
+// so a temporarily unavailable collector only loses the entries written while it is down.
+type NetworkWriter struct {
+	mu      sync.Mutex
+	network string
+	addr    string
+	conn    net.Conn
+}
+
+// NewNetworkWriter creates a writer sending entries to addr over network ("tcp" or "udp").
+func NewNetworkWriter(network, addr string) (*NetworkWriter, error) {
+	switch network {
+	case "tcp", "udp":
+	default:
+		return nil, fmt.Errorf("unsupported network %q, expected tcp or udp", network)
+	}
+
+	return &NetworkWriter{network: network, addr: addr}, nil
+}
+
+// Write sends p over the connection, connecting first if needed.
+func (w *NetworkWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		conn, err := net.DialTimeout(w.network, w.addr, networkDialTimeout)
+		if err != nil {
+			return 0, fmt.Errorf("failed to connect to log collector: %w", err)
+		}
+		w.conn = conn
+	}
+
+	n, err := w.conn.Write(p)
+	if err != nil {
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+
+	return n, err
+}
+
+// Close closes the connection, if any.
+func (w *NetworkWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.conn == nil {
+		return nil
+	}
+
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
@@ -0,0 +1,192 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	_ LevelWriter = (*SyslogWriter)(nil)
+	_ LevelWriter = (*JournaldWriter)(nil)
+)
+
+// syslogSockets are the local syslog sockets tried in order.
+var syslogSockets = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// journaldSocket is the socket of the systemd-journald native protocol.
+const journaldSocket = "/run/systemd/journal/socket"
+
+// syslogFacilityDaemon is the syslog facility of system daemons.
+const syslogFacilityDaemon = 3
+
+// Syslog severities, see RFC 5424.
+const (
+	severityError   = 3
+	severityWarning = 4
+	severityInfo    = 6
+	severityDebug   = 7
+)
+
+// syslogSeverity maps a slog level to the syslog severity.
+func syslogSeverity(level slog.Level) int {
+	switch {
+	case level >= slog.LevelError:
+		return severityError
+	case level >= slog.LevelWarn:
+		return severityWarning
+	case level >= slog.LevelInfo:
+		return severityInfo
+	default:
+		return severityDebug
+	}
+}
+
+// identifier returns the name the process logs under, i.e. its executable name.
+func identifier() string {
+	return filepath.Base(os.Args[0])
+}
+
+// datagramConn is a connection to a local unix datagram socket,
+// established on the first write and re-established after a failed write.
+type datagramConn struct {
+	mu    sync.Mutex
+	paths []string
+	conn  net.Conn
+}
+
+// write sends p to the first socket of paths accepting the connection.
+func (c *datagramConn) write(p []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		var err error
+		for _, path := range c.paths {
+			if c.conn, err = net.Dial("unixgram", path); err == nil {
+				break
+			}
+		}
+		if c.conn == nil {
+			return fmt.Errorf("failed to connect to local log daemon: %w", err)
+		}
+	}
+
+	if _, err := c.conn.Write(p); err != nil {
+		_ = c.conn.Close()
+		c.conn = nil
+		return err
+	}
+
+	return nil
+}
+
+// close closes the connection, if any.
+func (c *datagramConn) close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		return nil
+	}
+
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
+
+// SyslogWriter sends entries to the local syslog daemon with the daemon facility,
+// mapping slog levels to syslog severities.
+type SyslogWriter struct {
+	conn datagramConn
+	tag  string
+}
+
+// NewSyslogWriter creates a writer for the local syslog socket.
+// The connection is established on the first write.
+func NewSyslogWriter() *SyslogWriter {
+	return &SyslogWriter{conn: datagramConn{paths: syslogSockets}, tag: identifier()}
+}
+
+// Write sends p with the info severity.
+func (w *SyslogWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(slog.LevelInfo, p)
+}
+
+// WriteLevel sends p as a single message with the severity of level.
+func (w *SyslogWriter) WriteLevel(level slog.Level, p []byte) (int, error) {
+	priority := syslogFacilityDaemon*8 + syslogSeverity(level)
+	header := fmt.Sprintf("<%d>%s %s[%d]: ", priority, time.Now().Format(time.Stamp), w.tag, os.Getpid())
+
+	if err := w.conn.write(append([]byte(header), bytes.TrimSuffix(p, []byte("\n"))...)); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Close closes the connection to the syslog daemon.
+func (w *SyslogWriter) Close() error {
+	return w.conn.close()
+}
+
+// JournaldWriter sends entries to systemd-journald using its native protocol,
+// so the journal records the priority and identifier of every entry.
+type JournaldWriter struct {
+	conn datagramConn
+	tag  string
+}
+
+// NewJournaldWriter creates a writer for the journald socket.
+// The connection is established on the first write.
+func NewJournaldWriter() *JournaldWriter {
+	return &JournaldWriter{conn: datagramConn{paths: []string{journaldSocket}}, tag: identifier()}
+}
+
+// Write sends p with the info priority.
+func (w *JournaldWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(slog.LevelInfo, p)
+}
+
+// WriteLevel sends p as the MESSAGE field with the PRIORITY of level.
+func (w *JournaldWriter) WriteLevel(level slog.Level, p []byte) (int, error) {
+	var msg bytes.Buffer
+	writeJournalField(&msg, "PRIORITY", []byte(strconv.Itoa(syslogSeverity(level))))
+	writeJournalField(&msg, "SYSLOG_IDENTIFIER", []byte(w.tag))
+	writeJournalField(&msg, "MESSAGE", bytes.TrimSuffix(p, []byte("\n")))
+
+	if err := w.conn.write(msg.Bytes()); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Close closes the connection to journald.
+func (w *JournaldWriter) Close() error {
+	return w.conn.close()
+}
+
+// writeJournalField appends a field in the journald native format. Values containing
+// newlines are written in the binary form prefixed with their little-endian length.
+func writeJournalField(buf *bytes.Buffer, name string, value []byte) {
+	buf.WriteString(name)
+	if bytes.IndexByte(value, '\n') < 0 {
+		buf.WriteByte('=')
+		buf.Write(value)
+		buf.WriteByte('\n')
+		return
+	}
+
+	buf.WriteByte('\n')
+	_ = binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.Write(value)
+	buf.WriteByte('\n')
+}
@@ -0,0 +1,301 @@
+// Package migrations applies versioned SQL migrations to the databases of the
+// repository adapters.
+//
+// Migrations are embedded per dialect as files named NNNN_name.up.sql with an
+// optional NNNN_name.down.sql reverting them. Every migration runs in its own
+// transaction, and the versions applied are recorded in the schema_migrations table.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// files holds the migrations of all dialects, in a directory per dialect.
+//
+//go:embed postgres/*.sql sqlite/*.sql
+var files embed.FS
+
+// ErrPending is returned by Check when the database lacks migrations.
+var ErrPending = errors.New("database schema is out of date")
+
+// ErrIrreversible is returned by Down for a migration without a down file.
+var ErrIrreversible = errors.New("migration cannot be reverted")
+
+// Dialect describes the SQL dialect of a database.
+type Dialect struct {
+	// name is the directory of the migrations of the dialect
+	name string
+	// lock and unlock serialize migrators sharing the database; empty if not needed
+	lock, unlock string
+	// placeholder returns the parameter placeholder for argument n, counting from 1
+	placeholder func(n int) string
+}
+
+// Dialects supported by the migrator.
+var (
+	// Postgres serializes migrators of concurrently starting instances with an advisory lock.
+	Postgres = Dialect{
+		name:        "postgres",
+		lock:        "SELECT pg_advisory_lock(7346592001)",
+		unlock:      "SELECT pg_advisory_unlock(7346592001)",
+		placeholder: func(n int) string { return "$" + strconv.Itoa(n) },
+	}
+	// SQLite relies on the database file lock taken by every write transaction.
+	SQLite = Dialect{
+		name:        "sqlite",
+		placeholder: func(int) string { return "?" },
+	}
+)
+
+// Migration is a versioned change of the schema.
+type Migration struct {
+	// Version orders the migrations; it is the numeric prefix of the file names
+	Version int
+	// Name describes the migration; it is the rest of the file names
+	Name string
+	// Up applies the migration
+	Up string
+	// Down reverts the migration; empty if the migration cannot be reverted
+	Down string
+}
+
+// Load returns the migrations of dialect ordered by version.
+func Load(dialect Dialect) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, dialect.name)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		base, direction, ok := strings.Cut(strings.TrimSuffix(entry.Name(), ".sql"), ".")
+		rawVersion, name, found := strings.Cut(base, "_")
+		version, err := strconv.Atoi(rawVersion)
+		if !ok || !found || err != nil || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("invalid migration file name: %s", entry.Name())
+		}
+
+		data, err := fs.ReadFile(files, path.Join(dialect.name, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		}
+		if migration.Name != name {
+			return nil, fmt.Errorf("migrations %s and %s share version %d", migration.Name, name, version)
+		}
+
+		if direction == "up" {
+			migration.Up = string(data)
+		} else {
+			migration.Down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %04d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
+	slices.SortFunc(migrations, func(a, b Migration) int { return a.Version - b.Version })
+	return migrations, nil
+}
+
+// AutoMigrateFromEnv reports whether the adapters apply pending migrations on startup,
+// from the DB_AUTO_MIGRATE environment variable. Enabled by default.
+func AutoMigrateFromEnv() (bool, error) {
+	raw := os.Getenv("DB_AUTO_MIGRATE")
+	if raw == "" {
+		return true, nil
+	}
+
+	enabled, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("DB_AUTO_MIGRATE must be a boolean, got: %s", raw)
+	}
+
+	return enabled, nil
+}
+
+// Migrator applies and reverts the migrations of a dialect on a database.
+type Migrator struct {
+	db         *sql.DB
+	dialect    Dialect
+	migrations []Migration
+}
+
+// New returns a migrator for the database db using the migrations of dialect.
+func New(db *sql.DB, dialect Dialect) (*Migrator, error) {
+	migrations, err := Load(dialect)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
+	}
+
+	return &Migrator{db: db, dialect: dialect, migrations: migrations}, nil
+}
+
+// Latest returns the version of the last known migration, or 0 if there are none.
+func (m *Migrator) Latest() int {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version returns the highest version applied to the database, or 0 if none was applied.
+func (m *Migrator) Version(ctx context.Context) (int, error) {
+	var version int
+	err := m.withConn(ctx, func(conn *sql.Conn, applied []int) error {
+		if len(applied) > 0 {
+			version = applied[len(applied)-1]
+		}
+		return nil
+	})
+
+	return version, err
+}
+
+// Check returns an error wrapping ErrPending if a known migration is not applied to the database.
+func (m *Migrator) Check(ctx context.Context) error {
+	return m.withConn(ctx, func(conn *sql.Conn, applied []int) error {
+		for _, migration := range m.migrations {
+			if !slices.Contains(applied, migration.Version) {
+				return fmt.Errorf("%w: migration %04d_%s is not applied", ErrPending, migration.Version, migration.Name)
+			}
+		}
+		return nil
+	})
+}
+
+// Up applies all pending migrations in version order and returns how many were applied.
+// Migrations applied before a failure stay applied.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	count := 0
+	err := m.withConn(ctx, func(conn *sql.Conn, applied []int) error {
+		insert := "INSERT INTO schema_migrations (version) VALUES (" + m.dialect.placeholder(1) + ")"
+		for _, migration := range m.migrations {
+			if slices.Contains(applied, migration.Version) {
+				continue
+			}
+
+			if err := m.apply(ctx, conn, migration.Up, insert, migration.Version); err != nil {
+				return fmt.Errorf("failed to apply migration %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+			count++
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+// Down reverts up to steps of the most recently applied migrations and returns how many were reverted.
+// Returns an error wrapping ErrIrreversible for a migration without a down file.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	count := 0
+	err := m.withConn(ctx, func(conn *sql.Conn, applied []int) error {
+		remove := "DELETE FROM schema_migrations WHERE version = " + m.dialect.placeholder(1)
+		for i := len(applied) - 1; i >= 0 && count < steps; i-- {
+			index := slices.IndexFunc(m.migrations, func(migration Migration) bool {
+				return migration.Version == applied[i]
+			})
+			if index < 0 {
+				return fmt.Errorf("applied migration %04d is unknown", applied[i])
+			}
+
+			migration := m.migrations[index]
+			if migration.Down == "" {
+				return fmt.Errorf("%w: %04d_%s", ErrIrreversible, migration.Version, migration.Name)
+			}
+
+			if err := m.apply(ctx, conn, migration.Down, remove, migration.Version); err != nil {
+				return fmt.Errorf("failed to revert migration %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+			count++
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+// withConn calls fn with a connection holding the migration lock of the dialect
+// and the versions applied to the database in ascending order.
+func (m *Migrator) withConn(ctx context.Context, fn func(conn *sql.Conn, applied []int) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if m.dialect.lock != "" {
+		if _, err := conn.ExecContext(ctx, m.dialect.lock); err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		defer func() {
+			_, _ = conn.ExecContext(context.WithoutCancel(ctx), m.dialect.unlock)
+		}()
+	}
+
+	_, err = conn.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)")
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	rows, err := conn.QueryContext(ctx, "SELECT version FROM schema_migrations ORDER BY version")
+	if err != nil {
+		return err
+	}
+
+	var applied []int
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		applied = append(applied, version)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return fn(conn, applied)
+}
+
+// apply runs the script of a migration and the statement recording it in one transaction.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, script, record string, version int) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, record, version); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
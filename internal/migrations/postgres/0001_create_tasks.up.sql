@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS tasks (
+    id          TEXT PRIMARY KEY,
+    title       TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    status      TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL,
+    version     BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS tasks_status_idx ON tasks (status);
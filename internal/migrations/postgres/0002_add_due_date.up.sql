@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN due_date TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS tasks_due_date_idx ON tasks (due_date);
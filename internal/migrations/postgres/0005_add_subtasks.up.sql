@@ -0,0 +1 @@
+ALTER TABLE tasks ADD COLUMN subtasks JSONB NOT NULL DEFAULT '[]';
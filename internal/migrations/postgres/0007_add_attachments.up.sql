@@ -0,0 +1 @@
+ALTER TABLE tasks ADD COLUMN attachments JSONB NOT NULL DEFAULT '[]';
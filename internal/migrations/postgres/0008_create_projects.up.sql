@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS projects (
+    id          TEXT PRIMARY KEY,
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL
+);
+
+ALTER TABLE tasks ADD COLUMN project_id TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS tasks_project_id_idx ON tasks (project_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tasks_rank_idx;
+
+ALTER TABLE tasks DROP COLUMN rank;
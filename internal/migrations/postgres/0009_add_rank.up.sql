@@ -0,0 +1,6 @@
+ALTER TABLE tasks ADD COLUMN rank TEXT NOT NULL DEFAULT '';
+
+-- Existing tasks are ranked by creation time, as domain.InitialRank ranks new tasks.
+UPDATE tasks SET rank = rtrim(lpad(to_hex((extract(epoch FROM created_at) * 1000000)::BIGINT * 1000), 16, '0'), '0');
+
+CREATE INDEX IF NOT EXISTS tasks_rank_idx ON tasks (rank COLLATE "C", id COLLATE "C");
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS task_history (
+    seq        BIGSERIAL PRIMARY KEY,
+    task_id    TEXT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    version    BIGINT NOT NULL,
+    field      TEXT NOT NULL,
+    old_value  TEXT NOT NULL,
+    new_value  TEXT NOT NULL,
+    actor      TEXT NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS task_history_task_id_idx ON task_history (task_id, seq);
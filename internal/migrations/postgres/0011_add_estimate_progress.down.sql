@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS tasks_progress_idx;
+
+ALTER TABLE tasks DROP COLUMN progress;
+
+ALTER TABLE tasks DROP COLUMN estimate;
@@ -0,0 +1 @@
+ALTER TABLE tasks ADD COLUMN tags JSONB NOT NULL DEFAULT '[]';
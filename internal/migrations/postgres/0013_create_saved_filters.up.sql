@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS saved_filters (
+    name        TEXT PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    filter      JSONB NOT NULL DEFAULT '{}',
+    sort_field  TEXT NOT NULL DEFAULT '',
+    sort_order  TEXT NOT NULL DEFAULT '',
+    created_by  TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS webhook_dead_letters (
+    id          TEXT PRIMARY KEY,
+    webhook_id  TEXT NOT NULL,
+    event       JSONB NOT NULL,
+    attempts    INTEGER NOT NULL,
+    last_error  TEXT NOT NULL,
+    failed_at   TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tasks_priority_idx;
+
+ALTER TABLE tasks DROP COLUMN priority;
@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN priority INTEGER NOT NULL DEFAULT 2;
+
+CREATE INDEX IF NOT EXISTS tasks_priority_idx ON tasks (priority);
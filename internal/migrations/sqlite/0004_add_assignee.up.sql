@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN assignee TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS tasks_assignee_idx ON tasks (assignee);
@@ -0,0 +1 @@
+ALTER TABLE tasks ADD COLUMN subtasks TEXT NOT NULL DEFAULT '[]';
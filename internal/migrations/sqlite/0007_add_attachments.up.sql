@@ -0,0 +1 @@
+ALTER TABLE tasks ADD COLUMN attachments TEXT NOT NULL DEFAULT '[]';
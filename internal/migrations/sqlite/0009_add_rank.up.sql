@@ -0,0 +1,6 @@
+ALTER TABLE tasks ADD COLUMN rank TEXT NOT NULL DEFAULT '';
+
+-- Existing tasks are ranked by creation time, as domain.InitialRank ranks new tasks.
+UPDATE tasks SET rank = rtrim(printf('%016x', created_at), '0');
+
+CREATE INDEX IF NOT EXISTS tasks_rank_idx ON tasks (rank, id);
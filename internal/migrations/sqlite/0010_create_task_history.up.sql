@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS task_history (
+    seq        INTEGER PRIMARY KEY AUTOINCREMENT,
+    task_id    TEXT NOT NULL REFERENCES tasks (id) ON DELETE CASCADE,
+    version    INTEGER NOT NULL,
+    field      TEXT NOT NULL,
+    old_value  TEXT NOT NULL,
+    new_value  TEXT NOT NULL,
+    actor      TEXT NOT NULL,
+    changed_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS task_history_task_id_idx ON task_history (task_id, seq);
@@ -0,0 +1,8 @@
+ALTER TABLE tasks ADD COLUMN estimate INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE tasks ADD COLUMN progress INTEGER NOT NULL DEFAULT 0;
+
+-- Completed tasks are fully done, as domain.Task.UpdateStatus records for newly completed ones.
+UPDATE tasks SET progress = 100 WHERE status = 'completed';
+
+CREATE INDEX IF NOT EXISTS tasks_progress_idx ON tasks (progress);
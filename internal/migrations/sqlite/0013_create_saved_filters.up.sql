@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS saved_filters (
+    name        TEXT PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    filter      TEXT NOT NULL DEFAULT '{}',
+    sort_field  TEXT NOT NULL DEFAULT '',
+    sort_order  TEXT NOT NULL DEFAULT '',
+    created_by  TEXT NOT NULL DEFAULT '',
+    created_at  INTEGER NOT NULL,
+    updated_at  INTEGER NOT NULL
+);
@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrBlobNotFound is returned when no blob is stored under the requested key.
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore keeps binary content, such as the files attached to tasks, under string keys.
+// Keys consist of letters, digits, hyphens and underscores, in segments separated by slashes.
+type BlobStore interface {
+	HealthChecker
+
+	// Put stores size bytes read from content under key, replacing any blob stored there.
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+
+	// Get opens the blob stored under key. The caller must close the returned reader.
+	// Returns ErrBlobNotFound if no blob is stored under key.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the blob stored under key. Deleting a missing blob is not an error.
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,11 @@
+package ports
+
+import "time"
+
+// Clock tells the current time to the services and background jobs, so that they
+// can be run against a controlled time and every timestamp is taken the same way.
+// Implementations must be safe for concurrent use.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
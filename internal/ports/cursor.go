@@ -0,0 +1,120 @@
+package ports
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+)
+
+// Cursor identifies a position in an ordered task listing by the sort key and ID
+// of the last returned task. Unlike an offset it stays stable when tasks are
+// created or deleted concurrently.
+type Cursor struct {
+	// Field and Order bind the cursor to the sort it was produced for
+	Field SortField `json:"f"`
+	Order SortOrder `json:"o"`
+	// Key is the sort key of the last returned task, as produced by Sort.Key
+	Key string `json:"k"`
+	// ID is the ID of the last returned task
+	ID string `json:"i"`
+}
+
+// EncodeCursor creates an opaque cursor pointing right after the given task.
+func EncodeCursor(sort Sort, task *domain.Task) string {
+	raw, _ := json.Marshal(Cursor{
+		Field: sort.Field,
+		Order: sort.Order,
+		Key:   sort.Key(task),
+		ID:    task.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// DecodeCursor parses a cursor produced by EncodeCursor for the same sort.
+// Returns domain.ErrInvalidCursor if the cursor is malformed or belongs to a different sort.
+func DecodeCursor(sort Sort, encoded string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, domain.ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" {
+		return Cursor{}, domain.ErrInvalidCursor
+	}
+
+	if c.Field != sort.Field || c.Order != sort.Order {
+		return Cursor{}, domain.ErrInvalidCursor
+	}
+
+	if _, err := c.Value(); err != nil {
+		return Cursor{}, err
+	}
+
+	return c, nil
+}
+
+// Value returns the sort key of the cursor as a value of the sort field,
+// so adapters can compare it with stored values: a time.Time for the
+// timestamp fields, the int rank for the priority and a string otherwise.
+// Returns domain.ErrInvalidCursor if the key is malformed.
+func (c Cursor) Value() (any, error) {
+	switch c.Field {
+	case SortByCreatedAt, SortByUpdatedAt:
+		nanos, err := strconv.ParseInt(c.Key, 10, 64)
+		if err != nil || len(c.Key) != timeKeyWidth {
+			return nil, domain.ErrInvalidCursor
+		}
+		return time.Unix(0, nanos).UTC(), nil
+	case SortByPriority:
+		rank, err := strconv.Atoi(c.Key)
+		if _, ok := domain.PriorityOfRank(rank); err != nil || !ok {
+			return nil, domain.ErrInvalidCursor
+		}
+		return rank, nil
+	default:
+		return c.Key, nil
+	}
+}
+
+// Paginate orders tasks according to the query sort and cuts out the requested page.
+// Adapters without native sorting capabilities can use it to page in memory.
+// Returns domain.ErrInvalidCursor if the page cursor is malformed.
+func Paginate(tasks []*domain.Task, query ListQuery) (TaskPage, error) {
+	sort := query.Sort.Normalize()
+	slices.SortFunc(tasks, sort.Compare)
+
+	if query.Page.Cursor != "" {
+		after, err := DecodeCursor(sort, query.Page.Cursor)
+		if err != nil {
+			return TaskPage{}, err
+		}
+
+		start, found := slices.BinarySearchFunc(tasks, after, func(task *domain.Task, c Cursor) int {
+			return sort.CompareKey(task, c.Key, c.ID)
+		})
+		if found {
+			start++
+		}
+		tasks = tasks[start:]
+	}
+
+	return NewTaskPage(tasks, query), nil
+}
+
+// NewTaskPage builds the page of tasks that are already ordered by the query sort
+// and start right after the page cursor. Adapters sorting natively fetch one task
+// more than the page limit, which tells whether there is a next page.
+func NewTaskPage(tasks []*domain.Task, query ListQuery) TaskPage {
+	page := TaskPage{Tasks: tasks}
+	if limit := query.Page.Limit; limit > 0 && len(tasks) > limit {
+		page.Tasks = tasks[:limit]
+		page.NextCursor = EncodeCursor(query.Sort.Normalize(), page.Tasks[limit-1])
+	}
+
+	return page
+}
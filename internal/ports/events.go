@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+)
+
+// EventPublisher delivers the events of the task lifecycle to whoever reacts to them.
+// The core publishes every event after its change is stored; publishing never fails
+// the change, so implementations handle delivery errors themselves.
+type EventPublisher interface {
+	// Publish delivers event. It is called synchronously by the code making the change,
+	// so implementations must not block for long.
+	Publish(ctx context.Context, event domain.Event)
+}
+
+// WebhookDeliverer sends an event to the endpoint of a webhook.
+type WebhookDeliverer interface {
+	// Deliver makes a single attempt to deliver event to webhook.
+	// Returns an error if the endpoint cannot be reached or does not accept the event.
+	Deliver(ctx context.Context, webhook *domain.Webhook, event domain.Event) error
+
+	// CheckURL checks that deliveries to rawURL are allowed.
+	// Returns domain.ErrWebhookURLNotAllowed if its host is, or resolves to, an address
+	// inside a private or local network.
+	CheckURL(ctx context.Context, rawURL string) error
+}
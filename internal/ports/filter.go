@@ -0,0 +1,138 @@
+package ports
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+)
+
+// ListFilter describes which tasks a listing should return.
+// The zero value matches every task.
+type ListFilter struct {
+	// Statuses restricts the listing to tasks with any of these statuses; empty matches any status
+	Statuses []domain.TaskStatus
+	// Priorities restricts the listing to tasks with any of these priorities; empty matches any priority
+	Priorities []domain.Priority
+	// Assignee restricts the listing to tasks assigned to it; empty matches any task
+	Assignee string
+	// Unassigned restricts the listing to tasks without an assignee
+	Unassigned bool
+	// ProjectID restricts the listing to tasks of the project with this ID; empty matches any task
+	ProjectID string
+	// Query restricts the listing to tasks whose title or description contains it,
+	// case-insensitively; empty matches any task
+	Query string
+	// TitleContains restricts the listing to tasks whose title contains it,
+	// case-insensitively; empty matches any task
+	TitleContains string
+	// CreatedAfter restricts the listing to tasks created after this time; zero means unbounded
+	CreatedAfter time.Time
+	// CreatedBefore restricts the listing to tasks created before this time; zero means unbounded
+	CreatedBefore time.Time
+	// UpdatedAfter restricts the listing to tasks updated after this time; zero means unbounded
+	UpdatedAfter time.Time
+	// UpdatedBefore restricts the listing to tasks updated before this time; zero means unbounded
+	UpdatedBefore time.Time
+	// DueAfter restricts the listing to tasks due after this time; zero means unbounded.
+	// Tasks without a due date never match a due date bound.
+	DueAfter time.Time
+	// DueBefore restricts the listing to tasks due before this time; zero means unbounded
+	DueBefore time.Time
+	// Overdue restricts the listing to tasks overdue now. Services resolve it into OverdueAt
+	// with their clock, see ResolveOverdue, so repositories only ever see OverdueAt
+	Overdue bool
+	// OverdueAt restricts the listing to tasks overdue at this time, see domain.Task.IsOverdue;
+	// zero means no restriction
+	OverdueAt time.Time
+	// MinProgress restricts the listing to tasks with at least this progress, in percent;
+	// zero matches any task
+	MinProgress int
+}
+
+// IsZero reports whether the filter matches all tasks.
+func (f ListFilter) IsZero() bool {
+	return len(f.Statuses) == 0 && len(f.Priorities) == 0 && f.Assignee == "" && !f.Unassigned &&
+		f.ProjectID == "" && f.Query == "" && f.TitleContains == "" &&
+		f.CreatedAfter.IsZero() && f.CreatedBefore.IsZero() && f.UpdatedAfter.IsZero() && f.UpdatedBefore.IsZero() &&
+		f.DueAfter.IsZero() && f.DueBefore.IsZero() && !f.Overdue && f.OverdueAt.IsZero() && f.MinProgress == 0
+}
+
+// ResolveOverdue returns a copy of the filter in which Overdue is replaced by OverdueAt set to now.
+func (f ListFilter) ResolveOverdue(now time.Time) ListFilter {
+	if f.Overdue {
+		f.Overdue = false
+		f.OverdueAt = now
+	}
+
+	return f
+}
+
+// Matches reports whether the task satisfies the filter.
+// Adapters without native filtering capabilities can use it to filter in memory.
+func (f ListFilter) Matches(task *domain.Task) bool {
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, task.Status) {
+		return false
+	}
+
+	if len(f.Priorities) > 0 && !slices.Contains(f.Priorities, task.Priority) {
+		return false
+	}
+
+	if (f.Assignee != "" && task.Assignee != f.Assignee) || (f.Unassigned && task.Assignee != "") {
+		return false
+	}
+
+	if f.ProjectID != "" && task.ProjectID != f.ProjectID {
+		return false
+	}
+
+	if !inRange(task.CreatedAt, f.CreatedAfter, f.CreatedBefore) ||
+		!inRange(task.UpdatedAt, f.UpdatedAfter, f.UpdatedBefore) {
+		return false
+	}
+
+	if !f.DueAfter.IsZero() || !f.DueBefore.IsZero() {
+		if task.DueDate == nil || !inRange(*task.DueDate, f.DueAfter, f.DueBefore) {
+			return false
+		}
+	}
+
+	if !f.OverdueAt.IsZero() && !task.IsOverdue(f.OverdueAt) {
+		return false
+	}
+
+	if task.Progress < f.MinProgress {
+		return false
+	}
+
+	if f.TitleContains != "" &&
+		!strings.Contains(strings.ToLower(task.Title), strings.ToLower(f.TitleContains)) {
+		return false
+	}
+
+	if f.Query != "" {
+		query := strings.ToLower(f.Query)
+		if !strings.Contains(strings.ToLower(task.Title), query) &&
+			!strings.Contains(strings.ToLower(task.Description), query) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// inRange reports whether t lies strictly between after and before.
+// Zero bounds are ignored.
+func inRange(t, after, before time.Time) bool {
+	if !after.IsZero() && !t.After(after) {
+		return false
+	}
+
+	if !before.IsZero() && !t.Before(before) {
+		return false
+	}
+
+	return true
+}
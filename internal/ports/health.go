@@ -0,0 +1,12 @@
+package ports
+
+import "context"
+
+// HealthChecker defines the contract for checking the availability of an external dependency.
+// Adapters that talk to storage, brokers or other services implement it so the
+// readiness probe can report whether the instance is able to serve traffic.
+type HealthChecker interface {
+	// Ping verifies that the dependency is reachable and operational.
+	// Returns a non-nil error describing the failure if it is not.
+	Ping(ctx context.Context) error
+}
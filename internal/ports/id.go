@@ -0,0 +1,8 @@
+package ports
+
+// IDGenerator generates the IDs of new tasks, subtasks, attachments, comments and projects.
+// Implementations must be safe for concurrent use.
+type IDGenerator interface {
+	// NewID returns a new unique ID.
+	NewID() (string, error)
+}
@@ -0,0 +1,43 @@
+package ports
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Idempotency store errors.
+var (
+	// ErrIdempotencyKeyInUse is returned when a request with the same key is still being processed.
+	ErrIdempotencyKeyInUse = errors.New("idempotency key is in use by a request in progress")
+	// ErrIdempotencyKeyMismatch is returned when a key is reused for a different request.
+	ErrIdempotencyKeyMismatch = errors.New("idempotency key was used for a different request")
+)
+
+// IdempotentResponse is the recorded outcome of a request made with an idempotency key.
+type IdempotentResponse struct {
+	// StatusCode is the HTTP status code of the original response
+	StatusCode int
+	// Header holds the response headers to replay
+	Header map[string][]string
+	// Body is the original response body
+	Body []byte
+}
+
+// IdempotencyStore remembers responses to requests carrying an idempotency key,
+// so that retried requests are answered with the original response.
+type IdempotencyStore interface {
+	// Reserve claims key for a request identified by fingerprint for the given ttl.
+	// It returns (nil, nil) if the key was free and is now reserved; the caller must
+	// then call Complete or Release. If a response was already recorded for the key,
+	// it is returned instead.
+	// Returns ErrIdempotencyKeyInUse if the key is reserved by a request in progress
+	// and ErrIdempotencyKeyMismatch if the key was used with a different fingerprint.
+	Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (*IdempotentResponse, error)
+
+	// Complete records the response for a reserved key until its ttl expires.
+	Complete(ctx context.Context, key string, response IdempotentResponse) error
+
+	// Release frees a reserved key without recording a response, allowing a retry.
+	Release(ctx context.Context, key string) error
+}
@@ -0,0 +1,50 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+)
+
+// RepositoryStats describes the internal state of a task repository.
+type RepositoryStats struct {
+	// Backend is the name of the storage backend (e.g. "memory")
+	Backend string `json:"backend"`
+	// TaskCounts is the number of stored tasks per status
+	TaskCounts map[domain.TaskStatus]int `json:"task_counts"`
+	// SizeBytes is an estimate of the storage occupied by the tasks
+	SizeBytes int64 `json:"size_bytes"`
+}
+
+// RepositoryInspector is implemented by repositories that can report their internal state
+// for operators.
+type RepositoryInspector interface {
+	// Inspect returns the backend type, task counts and storage size estimate.
+	Inspect(ctx context.Context) (RepositoryStats, error)
+}
+
+// RepositoryDumper is implemented by repositories that can return their raw stored data.
+type RepositoryDumper interface {
+	// Dump returns up to limit stored tasks, as they are kept in the store,
+	// skipping the first offset tasks. Tasks are ordered by ID.
+	Dump(ctx context.Context, offset, limit int) ([]*domain.Task, error)
+}
+
+// ReencryptionResult counts the records rewritten by a Reencrypter.
+type ReencryptionResult struct {
+	// Tasks is the number of rewritten tasks
+	Tasks int `json:"tasks"`
+	// HistoryEntries is the number of rewritten history entries
+	HistoryEntries int `json:"history_entries"`
+	// Comments is the number of rewritten comments
+	Comments int `json:"comments"`
+	// StoredTasks is the number of tasks rewritten in the encrypted data files of the store
+	StoredTasks int `json:"stored_tasks"`
+}
+
+// Reencrypter is implemented by stores that encrypt data at rest and can rewrite it
+// with their current key, so the keys used before a rotation can be retired.
+type Reencrypter interface {
+	// Reencrypt rewrites every stored record with the current key.
+	Reencrypt(ctx context.Context) (ReencryptionResult, error)
+}
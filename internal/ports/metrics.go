@@ -0,0 +1,17 @@
+package ports
+
+import "github.com/asp3cto/task-manager/internal/domain"
+
+// TaskMetrics records business events of the task lifecycle.
+// The core reports every change in the number of tasks per status through it,
+// so adapters can export backlog and throughput without querying the repository.
+type TaskMetrics interface {
+	// TaskCreated records a new task with the given status.
+	TaskCreated(status domain.TaskStatus)
+
+	// TaskStatusChanged records a task moving from one status to another.
+	TaskStatusChanged(from, to domain.TaskStatus)
+
+	// TaskDeleted records the removal of a task with the given status.
+	TaskDeleted(status domain.TaskStatus)
+}
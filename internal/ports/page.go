@@ -0,0 +1,26 @@
+package ports
+
+import "github.com/asp3cto/task-manager/internal/domain"
+
+// PageRequest describes which page of a task listing to return.
+// The zero value requests all tasks without pagination.
+type PageRequest struct {
+	// Cursor is the opaque position returned as NextCursor by the previous page;
+	// empty starts from the beginning
+	Cursor string
+	// Limit is the maximum number of tasks on the page; 0 means no limit
+	Limit int
+}
+
+// IsZero reports whether no pagination was requested.
+func (p PageRequest) IsZero() bool {
+	return p.Cursor == "" && p.Limit == 0
+}
+
+// TaskPage is a single page of a task listing.
+type TaskPage struct {
+	// Tasks contains the tasks on this page
+	Tasks []*domain.Task `json:"tasks"`
+	// NextCursor points to the next page; empty if this is the last one
+	NextCursor string `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,131 @@
+package ports
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+)
+
+// SortField names a task attribute listings can be ordered by.
+type SortField string
+
+// Supported sort fields.
+const (
+	// SortByCreatedAt orders tasks by creation time.
+	SortByCreatedAt SortField = "created_at"
+	// SortByUpdatedAt orders tasks by last modification time.
+	SortByUpdatedAt SortField = "updated_at"
+	// SortByTitle orders tasks alphabetically by title.
+	SortByTitle SortField = "title"
+	// SortByStatus orders tasks alphabetically by status.
+	SortByStatus SortField = "status"
+	// SortByPriority orders tasks by urgency, from low to urgent when ascending.
+	SortByPriority SortField = "priority"
+	// SortByRank orders tasks manually, as arranged with moves between other tasks.
+	SortByRank SortField = "rank"
+)
+
+// SortOrder is the direction of a sort.
+type SortOrder string
+
+// Supported sort orders.
+const (
+	// SortAsc orders from the smallest to the largest value.
+	SortAsc SortOrder = "asc"
+	// SortDesc orders from the largest to the smallest value.
+	SortDesc SortOrder = "desc"
+)
+
+// timeKeyWidth zero-pads Unix nanosecond timestamps so they compare correctly as strings.
+const timeKeyWidth = 20
+
+// Sort describes the order of a task listing.
+// Ties are always broken by task ID, so the order is total and stable.
+// The zero value orders by creation time ascending.
+type Sort struct {
+	// Field is the attribute to order by; empty means created_at
+	Field SortField
+	// Order is the sort direction; empty means ascending
+	Order SortOrder
+}
+
+// Normalize returns the sort with defaults applied.
+func (s Sort) Normalize() Sort {
+	if s.Field == "" {
+		s.Field = SortByCreatedAt
+	}
+
+	if s.Order == "" {
+		s.Order = SortAsc
+	}
+
+	return s
+}
+
+// Validate checks that the sort field and order are supported.
+func (s Sort) Validate() error {
+	switch s.Field {
+	case "", SortByCreatedAt, SortByUpdatedAt, SortByTitle, SortByStatus, SortByPriority, SortByRank:
+	default:
+		return fmt.Errorf("unsupported sort field %q", s.Field)
+	}
+
+	switch s.Order {
+	case "", SortAsc, SortDesc:
+	default:
+		return fmt.Errorf("unsupported sort order %q", s.Order)
+	}
+
+	return nil
+}
+
+// Key returns the value of the sort field for task as an order-preserving string.
+func (s Sort) Key(task *domain.Task) string {
+	switch s.Normalize().Field {
+	case SortByUpdatedAt:
+		return fmt.Sprintf("%0*d", timeKeyWidth, task.UpdatedAt.UnixNano())
+	case SortByTitle:
+		return task.Title
+	case SortByStatus:
+		return string(task.Status)
+	case SortByPriority:
+		return strconv.Itoa(task.Priority.Rank())
+	case SortByRank:
+		return task.Rank
+	default:
+		return fmt.Sprintf("%0*d", timeKeyWidth, task.CreatedAt.UnixNano())
+	}
+}
+
+// Compare orders two tasks according to the sort.
+func (s Sort) Compare(a, b *domain.Task) int {
+	return s.CompareKey(a, s.Key(b), b.ID)
+}
+
+// CompareKey orders task relative to a position given by a sort key and task ID,
+// as produced by Key. It is used to resume listings from a cursor.
+func (s Sort) CompareKey(task *domain.Task, key, id string) int {
+	result := strings.Compare(s.Key(task), key)
+	if result == 0 {
+		result = strings.Compare(task.ID, id)
+	}
+
+	if s.Normalize().Order == SortDesc {
+		return -result
+	}
+
+	return result
+}
+
+// ListQuery is the complete description of a task listing request:
+// which tasks to return, in which order, and which page of them.
+type ListQuery struct {
+	// Filter selects the tasks to return
+	Filter ListFilter
+	// Sort defines the order of the returned tasks
+	Sort Sort
+	// Page selects a page of the ordered result
+	Page PageRequest
+}
@@ -5,14 +5,21 @@ package ports
 
 import (
 	"context"
+	"errors"
 
 	"github.com/asp3cto/task-manager/internal/domain"
 )
 
+// ErrRepositoryUnavailable is returned when the repository rejects operations
+// because its store is considered unavailable, e.g. while a circuit breaker is open.
+var ErrRepositoryUnavailable = errors.New("repository is temporarily unavailable")
+
 // TaskRepository defines the contract for task data persistence operations.
 // Implementations of this interface handle the storage and retrieval of tasks
 // from various data sources (memory, database, etc.).
 type TaskRepository interface {
+	HealthChecker
+
 	// Create stores a new task in the repository.
 	// Returns domain.ErrTaskExists if a task with the same ID already exists.
 	Create(ctx context.Context, task *domain.Task) error
@@ -21,12 +28,32 @@ type TaskRepository interface {
 	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
 	GetByID(ctx context.Context, id string) (*domain.Task, error)
 
-	// GetAll retrieves all tasks, optionally filtered by status.
-	// If status is empty, returns all tasks regardless of their status.
-	// The status parameter should match one of the domain.TaskStatus values.
-	GetAll(ctx context.Context, status string) ([]*domain.Task, error)
+	// GetByIDs retrieves the tasks with the given identifiers in a single operation.
+	// Unknown IDs are skipped; tasks are returned in the order of ids.
+	GetByIDs(ctx context.Context, ids []string) ([]*domain.Task, error)
+
+	// GetAll retrieves the page of tasks described by the query.
+	// A zero filter matches all tasks, a zero page returns every matching task.
+	// Tasks are ordered by the query sort with ties broken by ID. The text query is
+	// a case-insensitive substring match on title and description, which adapters
+	// may implement with native search capabilities, and the sort and page are
+	// translated into the native ordering and limits of the store where possible.
+	// Returns domain.ErrInvalidCursor if the page cursor is malformed.
+	GetAll(ctx context.Context, query ListQuery) (TaskPage, error)
+
+	// Iterate calls fn for every task matching the filter, streaming them from the
+	// store instead of loading them all at once, so exports of large result sets
+	// stay within bounded memory. Tasks are visited in an order defined by the store;
+	// tasks created, modified or deleted during the iteration may be visited in either
+	// state, more than once or not at all.
+	// fn may use the repository. An error returned by fn stops the iteration and is
+	// returned by Iterate.
+	Iterate(ctx context.Context, filter ListFilter, fn func(*domain.Task) error) error
 
 	// Update modifies an existing task in the repository.
+	// The stored version must equal task.Version; the check and the write are atomic.
+	// On success the stored version is incremented and task.Version is set to it.
+	// Returns domain.ErrVersionConflict if the stored task has a different version.
 	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
 	Update(ctx context.Context, task *domain.Task) error
 
@@ -34,3 +61,174 @@ type TaskRepository interface {
 	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
 	Delete(ctx context.Context, id string) error
 }
+
+// CommentStore is implemented by task repositories that can also keep the comments
+// on their tasks, e.g. in the same database.
+type CommentStore interface {
+	// Comments returns the repository of the comments on the stored tasks.
+	Comments() CommentRepository
+}
+
+// CommentRepository defines the contract for persistence of task comments.
+// Comments are stored separately from their tasks and addressed by task ID.
+type CommentRepository interface {
+	// Create stores a new comment.
+	// Returns domain.ErrTaskNotFound if the store knows the task does not exist.
+	Create(ctx context.Context, comment *domain.Comment) error
+
+	// ListByTask returns the comments of a task ordered by creation time, oldest first.
+	// Returns an empty list if the task has no comments.
+	ListByTask(ctx context.Context, taskID string) ([]*domain.Comment, error)
+
+	// Update replaces the author, body and update time of an existing comment.
+	// Returns domain.ErrCommentNotFound if the task has no comment with the ID of comment.
+	Update(ctx context.Context, comment *domain.Comment) error
+
+	// Delete removes a comment from a task.
+	// Returns domain.ErrCommentNotFound if the task has no comment with the given ID.
+	Delete(ctx context.Context, taskID, id string) error
+
+	// DeleteByTask removes all comments of a task.
+	DeleteByTask(ctx context.Context, taskID string) error
+}
+
+// ProjectStore is implemented by task repositories that can also keep the projects
+// grouping their tasks, e.g. in the same database.
+type ProjectStore interface {
+	// Projects returns the repository of the projects of the stored tasks.
+	Projects() ProjectRepository
+}
+
+// ProjectRepository defines the contract for persistence of projects.
+// Tasks refer to their project by ID; the repository does not know about tasks.
+type ProjectRepository interface {
+	// Create stores a new project.
+	// Returns domain.ErrProjectExists if a project with the same ID already exists.
+	Create(ctx context.Context, project *domain.Project) error
+
+	// GetByID retrieves a project by its unique identifier.
+	// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+	GetByID(ctx context.Context, id string) (*domain.Project, error)
+
+	// GetAll returns all projects ordered by creation time, oldest first.
+	GetAll(ctx context.Context) ([]*domain.Project, error)
+
+	// Update replaces a stored project.
+	// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+	Update(ctx context.Context, project *domain.Project) error
+
+	// Delete removes a project.
+	// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+	Delete(ctx context.Context, id string) error
+}
+
+// SavedFilterStore is implemented by task repositories that can also keep the saved
+// filters over their tasks, e.g. in the same database.
+type SavedFilterStore interface {
+	// SavedFilters returns the repository of the saved filters.
+	SavedFilters() SavedFilterRepository
+}
+
+// SavedFilterRepository defines the contract for persistence of saved filters.
+// Saved filters are addressed by their name.
+type SavedFilterRepository interface {
+	// Create stores a new saved filter.
+	// Returns domain.ErrSavedFilterExists if a filter with the same name already exists.
+	Create(ctx context.Context, filter *domain.SavedFilter) error
+
+	// GetByName retrieves a saved filter by its name.
+	// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+	GetByName(ctx context.Context, name string) (*domain.SavedFilter, error)
+
+	// GetAll returns all saved filters ordered by name.
+	GetAll(ctx context.Context) ([]*domain.SavedFilter, error)
+
+	// Update replaces a stored saved filter.
+	// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+	Update(ctx context.Context, filter *domain.SavedFilter) error
+
+	// Delete removes a saved filter.
+	// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+	Delete(ctx context.Context, name string) error
+}
+
+// WebhookStore is implemented by task repositories that can also keep the webhook
+// subscriptions, e.g. in the same database.
+type WebhookStore interface {
+	// Webhooks returns the repository of the webhooks.
+	Webhooks() WebhookRepository
+
+	// WebhookDeadLetters returns the repository of the deliveries given up on.
+	WebhookDeadLetters() WebhookDeadLetterRepository
+}
+
+// WebhookRepository defines the contract for persistence of webhook subscriptions.
+type WebhookRepository interface {
+	// Create stores a new webhook.
+	// Returns domain.ErrWebhookExists if a webhook with the same ID already exists.
+	Create(ctx context.Context, webhook *domain.Webhook) error
+
+	// GetByID retrieves a webhook by its unique identifier.
+	// Returns domain.ErrWebhookNotFound if no webhook exists with the given ID.
+	GetByID(ctx context.Context, id string) (*domain.Webhook, error)
+
+	// GetAll returns all webhooks, oldest first.
+	GetAll(ctx context.Context) ([]*domain.Webhook, error)
+
+	// Update modifies an existing webhook.
+	// Returns domain.ErrWebhookNotFound if no webhook exists with the given ID.
+	Update(ctx context.Context, webhook *domain.Webhook) error
+
+	// Delete removes a webhook by its unique identifier.
+	// Returns domain.ErrWebhookNotFound if no webhook exists with the given ID.
+	Delete(ctx context.Context, id string) error
+}
+
+// WebhookDeadLetterRepository defines the contract for persistence of the webhook
+// deliveries given up on.
+type WebhookDeadLetterRepository interface {
+	// Create stores a new dead letter.
+	// Returns domain.ErrDeadLetterExists if a dead letter with the same ID already exists.
+	Create(ctx context.Context, letter *domain.WebhookDeadLetter) error
+
+	// GetByID retrieves a dead letter by its unique identifier.
+	// Returns domain.ErrDeadLetterNotFound if no dead letter exists with the given ID.
+	GetByID(ctx context.Context, id string) (*domain.WebhookDeadLetter, error)
+
+	// GetAll returns all dead letters, oldest first.
+	GetAll(ctx context.Context) ([]*domain.WebhookDeadLetter, error)
+
+	// Delete removes a dead letter by its unique identifier.
+	// Returns domain.ErrDeadLetterNotFound if no dead letter exists with the given ID.
+	Delete(ctx context.Context, id string) error
+}
+
+// HistoryStore is implemented by task repositories that can also keep the change
+// history of their tasks, e.g. in the same database.
+type HistoryStore interface {
+	// History returns the repository of the change history of the stored tasks.
+	History() TaskHistoryRepository
+}
+
+// TaskHistoryRepository defines the contract for persistence of the change history of tasks.
+// Entries are appended as tasks change, and only rewritten to erase or re-encrypt their values.
+type TaskHistoryRepository interface {
+	// Append stores entries after the existing entries of their tasks.
+	Append(ctx context.Context, entries []domain.HistoryEntry) error
+
+	// ListByTask returns the history of a task in the order it was appended, oldest first.
+	// Returns an empty list if the task has no history.
+	ListByTask(ctx context.Context, taskID string) ([]domain.HistoryEntry, error)
+
+	// RewriteByTask replaces the history of a task by the entries returned by rewrite,
+	// which is called with the current history, oldest first. The history is read and
+	// replaced as a whole, so entries appended concurrently are not lost.
+	// Nothing changes if rewrite returns an error; the error is returned as is.
+	// Returns domain.ErrTaskNotFound if the store knows the task does not exist.
+	RewriteByTask(
+		ctx context.Context, taskID string, rewrite func([]domain.HistoryEntry) ([]domain.HistoryEntry, error),
+	) error
+
+	// DeleteByTask removes the history of a task.
+	DeleteByTask(ctx context.Context, taskID string) error
+}
@@ -2,6 +2,8 @@ package ports
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/asp3cto/task-manager/internal/domain"
 )
@@ -10,22 +12,335 @@ import (
 // This interface encapsulates all the use cases and business rules for task management,
 // providing a clean API for the application's core functionality.
 type TaskService interface {
-	// CreateTask creates a new task with the given title and description.
-	// The task is automatically assigned a unique ID and set to pending status.
+	// CreateTask creates a new task with the given title, description, optional due date and priority
+	// in the project with the given ID, or in no project if projectID is empty.
+	// The task is set to pending status and assigned a unique ID unless the client supplies id;
+	// an empty priority defaults to medium. The title and description are trimmed of surrounding whitespace.
+	// Creating a task with a supplied ID again is a safe retry: if the stored task is the same,
+	// it is returned with created set to false.
+	// Returns domain.ErrInvalidTaskID if the supplied ID does not have the format of task IDs.
+	// Returns domain.ErrTaskExists if a different task with the supplied ID already exists.
+	// Returns a *domain.ValidationError if the title or description violates the limits.
 	// Returns domain.ErrEmptyTitle if the title is empty or whitespace.
-	CreateTask(ctx context.Context, title, description string) (*domain.Task, error)
+	// Returns domain.ErrInvalidPriority if the priority is not valid.
+	// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+	// Returns a *domain.DuplicateTaskError, matching domain.ErrDuplicateTask, if duplicate titles
+	// are rejected and an unfinished task already has the title.
+	// Returns a *domain.QuotaExceededError, matching domain.ErrQuotaExceeded, if a task quota is exhausted.
+	CreateTask(
+		ctx context.Context, id, title, description string, dueDate *time.Time, priority domain.Priority,
+		projectID string,
+	) (task *domain.Task, created bool, err error)
 
 	// GetTaskByID retrieves a task by its unique identifier.
 	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
 	GetTaskByID(ctx context.Context, id string) (*domain.Task, error)
 
-	// GetAllTasks retrieves all tasks, optionally filtered by status.
-	// If status is empty, returns all tasks regardless of their status.
-	// The status parameter should match one of the domain.TaskStatus values.
-	GetAllTasks(ctx context.Context, status string) ([]*domain.Task, error)
+	// GetTasksByIDs retrieves the tasks with the given identifiers.
+	// Unknown IDs are skipped; tasks are returned in the order of ids.
+	GetTasksByIDs(ctx context.Context, ids []string) ([]*domain.Task, error)
+
+	// GetAllTasks retrieves the tasks described by the query.
+	// A zero filter matches all tasks, a zero page returns every matching task.
+	// Sorted or paginated results are ordered by the query sort (creation time by default).
+	// Returns domain.ErrInvalidStatus if the filter selects a status that is not configured.
+	// Returns domain.ErrInvalidCursor if the page cursor is malformed.
+	GetAllTasks(ctx context.Context, query ListQuery) (TaskPage, error)
+
+	// GetWorkflow returns the configured task statuses and the transitions allowed between them.
+	GetWorkflow(ctx context.Context) (*domain.Workflow, error)
 
 	// UpdateTaskStatus changes the status of an existing task.
+	// The task must still have the given version; a zero version skips the check.
+	// Returns the updated task on success.
+	// Returns domain.ErrInvalidStatus if the status is not configured.
+	// Returns domain.ErrInvalidTransition if the task cannot move from its current status to status.
+	// Returns a *domain.QuotaExceededError, matching domain.ErrQuotaExceeded, if the quota of the status is exhausted.
+	// Returns domain.ErrVersionConflict if the task has a different version.
+	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+	UpdateTaskStatus(ctx context.Context, id string, status domain.TaskStatus, version int64) (*domain.Task, error)
+
+	// TransitionTasks moves every task matching the filter to status and returns the number
+	// of changed tasks; tasks that already have the status are not changed.
+	// The transition is best-effort, not atomic: all tasks are checked before any is moved,
+	// and the tasks already moved are restored if moving one fails. If a task cannot be
+	// restored, the error is returned together with the number of tasks left moved.
+	// Returns domain.ErrInvalidStatus if the status or a status in the filter is not configured.
+	// Returns domain.ErrInvalidTransition if a matching task cannot move to status.
+	// Returns a *domain.QuotaExceededError, matching domain.ErrQuotaExceeded, if the quota of the status is exhausted.
+	// Returns domain.ErrVersionConflict if a task was modified during the transition and no task was left moved.
+	TransitionTasks(ctx context.Context, filter ListFilter, status domain.TaskStatus) (int, error)
+
+	// UpdateTask applies a partial update of the details of an existing task, see domain.TaskUpdate.
+	// The status, assignee, project and position have their own use cases.
+	// The task must still have the given version; a zero version skips the check.
+	// Returns a *domain.ValidationError if the title, description or tags violate the limits.
+	// Returns domain.ErrEmptyTitle if the title is explicitly set to an empty string.
+	// Returns domain.ErrInvalidPriority if the priority is not valid.
+	// Returns domain.ErrInvalidEstimate if the estimate is negative.
+	// Returns domain.ErrInvalidProgress if the progress is not between 0 and domain.MaxProgress.
+	// Returns domain.ErrInvalidTag or domain.ErrTooManyTags if the tags are not valid.
+	// Returns domain.ErrVersionConflict if the task has a different version.
+	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+	UpdateTask(ctx context.Context, id string, update domain.TaskUpdate, version int64) (*domain.Task, error)
+
+	// AssignTask sets the assignee of an existing task; an empty assignee unassigns the task.
+	// The task must still have the given version; a zero version skips the check.
+	// Returns the updated task on success.
+	// Returns domain.ErrVersionConflict if the task has a different version.
+	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+	AssignTask(ctx context.Context, id, assignee string, version int64) (*domain.Task, error)
+
+	// MoveTaskToProject moves an existing task into the project with the given ID;
+	// an empty projectID removes the task from its project.
+	// The task must still have the given version; a zero version skips the check.
+	// Returns the updated task on success.
+	// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+	// Returns domain.ErrVersionConflict if the task has a different version.
+	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+	MoveTaskToProject(ctx context.Context, id, projectID string, version int64) (*domain.Task, error)
+
+	// MoveTask places an existing task in the manual order right after the task afterID
+	// and right before the task beforeID. An empty afterID moves the task to the start
+	// of the order, an empty beforeID to its end; at least one of them must be given.
+	// The task must still have the given version; a zero version skips the check.
 	// Returns the updated task on success.
+	// Returns domain.ErrInvalidMove if afterID does not sort before beforeID, neither is given
+	// or one of them is the moved task.
+	// Returns domain.ErrMoveTargetNotFound if afterID or beforeID does not exist.
+	// Returns domain.ErrVersionConflict if the task has a different version.
+	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+	MoveTask(ctx context.Context, id, afterID, beforeID string, version int64) (*domain.Task, error)
+
+	// AddSubtask adds a subtask with the given title to an existing task at the zero-based
+	// position, or at the end if position is nil.
+	// The task must still have the given version; a zero version skips the check.
+	// Returns the updated task and the new subtask on success.
+	// Returns domain.ErrEmptyTitle if the title is empty or whitespace.
+	// Returns domain.ErrTooManySubtasks if the task already has domain.MaxSubtasks subtasks.
+	// Returns domain.ErrVersionConflict if the task has a different version.
+	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+	AddSubtask(
+		ctx context.Context, id, title string, position *int, version int64,
+	) (*domain.Task, domain.Subtask, error)
+
+	// UpdateSubtask changes the title, done flag and/or position of a subtask.
+	// Nil arguments leave the corresponding field unchanged.
+	// The task must still have the given version; a zero version skips the check.
+	// Returns the updated task and subtask on success.
+	// Returns domain.ErrEmptyTitle if the title is explicitly set to an empty string.
+	// Returns domain.ErrSubtaskNotFound if the task has no such subtask.
+	// Returns domain.ErrVersionConflict if the task has a different version.
+	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+	UpdateSubtask(
+		ctx context.Context, id, subtaskID string, title *string, done *bool, position *int, version int64,
+	) (*domain.Task, domain.Subtask, error)
+
+	// DeleteSubtask removes a subtask from an existing task.
+	// The task must still have the given version; a zero version skips the check.
+	// Returns the updated task on success.
+	// Returns domain.ErrSubtaskNotFound if the task has no such subtask.
+	// Returns domain.ErrVersionConflict if the task has a different version.
+	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+	DeleteSubtask(ctx context.Context, id, subtaskID string, version int64) (*domain.Task, error)
+
+	// GetTaskHistory returns the changes of an existing task, oldest first:
+	// which field changed, from and to which value, by whom and when.
+	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+	GetTaskHistory(ctx context.Context, id string) ([]domain.HistoryEntry, error)
+
+	// UndoTask reverts the most recent change of an existing task within the undo window,
+	// using the task history, and returns the restored task.
+	// A non-zero version must match the current task version.
+	// Returns domain.ErrNothingToUndo if the task has not changed since it was created.
+	// Returns domain.ErrUndoExpired if the last change is older than the undo window.
+	// Returns domain.ErrCannotUndo if the last change cannot be reverted or restores a status
+	// that is no longer configured.
+	// Returns a *domain.QuotaExceededError if the restored status is full.
+	// Returns domain.ErrVersionConflict if the task has a different version.
+	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+	UndoTask(ctx context.Context, id string, version int64) (*domain.Task, error)
+
+	// AnonymizeTask erases the user-provided content of an existing task, see domain.Task.Anonymize,
+	// together with its comments and attachments, and replaces its history by an entry recording
+	// the anonymization. Returns the anonymized task.
+	// The task must still have the given version; a zero version skips the check.
+	// Returns domain.ErrVersionConflict if the task has a different version.
+	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+	AnonymizeTask(ctx context.Context, id string, version int64) (*domain.Task, error)
+
+	// DeleteTask removes a task by its unique identifier.
+	// A task in progress is deleted only if force is set, see domain.Task.CanDelete.
+	// The task must still have the given version; a zero version skips the check.
+	// Returns domain.ErrTaskInProgress if the task is in progress and force is not set.
+	// Returns domain.ErrVersionConflict if the task has a different version.
+	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+	DeleteTask(ctx context.Context, id string, force bool, version int64) error
+}
+
+// CommentService defines the contract for the discussion on tasks.
+type CommentService interface {
+	// AddComment adds a comment by author to an existing task.
+	// Returns domain.ErrEmptyCommentAuthor if the author is empty.
+	// Returns domain.ErrEmptyCommentBody if the body is empty.
+	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+	AddComment(ctx context.Context, taskID, author, body string) (*domain.Comment, error)
+
+	// ListComments returns the comments of an existing task, oldest first.
 	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
-	UpdateTaskStatus(ctx context.Context, id string, status domain.TaskStatus) (*domain.Task, error)
+	ListComments(ctx context.Context, taskID string) ([]*domain.Comment, error)
+
+	// DeleteComment removes a comment from a task.
+	// Returns domain.ErrCommentNotFound if the task has no comment with the given ID.
+	DeleteComment(ctx context.Context, taskID, id string) error
+}
+
+// AttachmentService defines the contract for files attached to tasks.
+type AttachmentService interface {
+	// AddAttachment stores size bytes of content as a file attached to an existing task.
+	// The content is read twice, to compute its checksum and to store it.
+	// The task must still have the given version; a zero version skips the check.
+	// Returns the updated task and the new attachment on success.
+	// Returns domain.ErrTooManyAttachments if the task already has domain.MaxAttachments files.
+	// Returns domain.ErrVersionConflict if the task has a different version.
+	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+	AddAttachment(
+		ctx context.Context, taskID, filename, contentType string, content io.ReadSeeker, size, version int64,
+	) (*domain.Task, domain.Attachment, error)
+
+	// OpenAttachment returns an attachment of a task and its content. The caller must close the content.
+	// Returns domain.ErrAttachmentNotFound if the task has no such attachment.
+	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+	OpenAttachment(ctx context.Context, taskID, id string) (domain.Attachment, io.ReadCloser, error)
+
+	// DeleteAttachment removes an attachment from a task together with its content.
+	// The task must still have the given version; a zero version skips the check.
+	// Returns the updated task on success.
+	// Returns domain.ErrAttachmentNotFound if the task has no such attachment.
+	// Returns domain.ErrVersionConflict if the task has a different version.
+	// Returns domain.ErrTaskNotFound if no task exists with the given ID.
+	DeleteAttachment(ctx context.Context, taskID, id string, version int64) (*domain.Task, error)
+}
+
+// SavedFilterService defines the contract for saved filters, the task filters
+// stored under a name so clients can list the tasks they select.
+type SavedFilterService interface {
+	// CreateSavedFilter stores filter and sort under name.
+	// Returns domain.ErrInvalidFilterName if the name is not a lowercase identifier.
+	// Returns domain.ErrInvalidFilter if the filter selects an invalid priority or progress.
+	// Returns domain.ErrInvalidStatus if the filter selects a status that is not configured.
+	// Returns domain.ErrSavedFilterExists if a filter with the same name already exists.
+	CreateSavedFilter(
+		ctx context.Context, name, description string, filter domain.TaskFilter, sort Sort,
+	) (*domain.SavedFilter, error)
+
+	// GetSavedFilter retrieves a saved filter by its name.
+	// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+	GetSavedFilter(ctx context.Context, name string) (*domain.SavedFilter, error)
+
+	// ListSavedFilters returns all saved filters ordered by name.
+	ListSavedFilters(ctx context.Context) ([]*domain.SavedFilter, error)
+
+	// UpdateSavedFilter replaces the description, filter and sort of a saved filter.
+	// Returns domain.ErrInvalidFilter if the filter selects an invalid priority or progress.
+	// Returns domain.ErrInvalidStatus if the filter selects a status that is not configured.
+	// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+	UpdateSavedFilter(
+		ctx context.Context, name, description string, filter domain.TaskFilter, sort Sort,
+	) (*domain.SavedFilter, error)
+
+	// DeleteSavedFilter removes a saved filter.
+	// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+	DeleteSavedFilter(ctx context.Context, name string) error
+
+	// ListSavedFilterTasks retrieves a page of the tasks selected by a saved filter,
+	// in the order of its sort.
+	// Returns domain.ErrInvalidStatus if the filter selects a status no longer configured.
+	// Returns domain.ErrInvalidCursor if the page cursor is malformed.
+	// Returns domain.ErrSavedFilterNotFound if no filter has the given name.
+	ListSavedFilterTasks(ctx context.Context, name string, page PageRequest) (TaskPage, error)
+}
+
+// ProjectService defines the contract for projects, the lists that group tasks.
+type ProjectService interface {
+	// CreateProject creates a new project with the given name and description.
+	// Returns domain.ErrEmptyProjectName if the name is empty.
+	CreateProject(ctx context.Context, name, description string) (*domain.Project, error)
+
+	// GetProject retrieves a project by its unique identifier.
+	// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+	GetProject(ctx context.Context, id string) (*domain.Project, error)
+
+	// ListProjects returns all projects, oldest first.
+	ListProjects(ctx context.Context) ([]*domain.Project, error)
+
+	// UpdateProject changes the name and/or description of a project.
+	// Nil arguments leave the corresponding field unchanged.
+	// Returns domain.ErrEmptyProjectName if the name is explicitly set to an empty string.
+	// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+	UpdateProject(ctx context.Context, id string, name, description *string) (*domain.Project, error)
+
+	// DeleteProject removes a project that has no tasks.
+	// Returns domain.ErrProjectNotEmpty if tasks still belong to the project.
+	// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+	DeleteProject(ctx context.Context, id string) error
+
+	// ListProjectTasks retrieves the tasks of a project described by the query,
+	// as GetAllTasks does with the filter restricted to the project.
+	// Returns domain.ErrInvalidStatus if the filter selects a status that is not configured.
+	// Returns domain.ErrInvalidCursor if the page cursor is malformed.
+	// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+	ListProjectTasks(ctx context.Context, id string, query ListQuery) (TaskPage, error)
+
+	// GetProjectProgress rolls up the estimates and the progress of the tasks of a project.
+	// Returns domain.ErrProjectNotFound if no project exists with the given ID.
+	GetProjectProgress(ctx context.Context, id string) (*domain.ProjectProgress, error)
+}
+
+// WebhookService defines the contract for webhooks, the subscriptions of external
+// endpoints to task events.
+type WebhookService interface {
+	// CreateWebhook subscribes url to the events of the given types, signing deliveries with secret.
+	// Returns domain.ErrInvalidWebhookURL if the URL is not an absolute http or https URL.
+	// Returns domain.ErrNoWebhookEvents if no event type is given.
+	// Returns domain.ErrInvalidEventType if an event type is unknown.
+	// Returns domain.ErrEmptyWebhookSecret if the secret is empty.
+	CreateWebhook(ctx context.Context, url string, events []domain.EventType, secret string) (*domain.Webhook, error)
+
+	// GetWebhook retrieves a webhook by its unique identifier.
+	// Returns domain.ErrWebhookNotFound if no webhook exists with the given ID.
+	GetWebhook(ctx context.Context, id string) (*domain.Webhook, error)
+
+	// ListWebhooks returns all webhooks, oldest first.
+	ListWebhooks(ctx context.Context) ([]*domain.Webhook, error)
+
+	// UpdateWebhook changes the URL, event types and/or secret of a webhook.
+	// Nil arguments leave the corresponding field unchanged.
+	// Returns the errors of CreateWebhook for invalid values.
+	// Returns domain.ErrWebhookNotFound if no webhook exists with the given ID.
+	UpdateWebhook(
+		ctx context.Context, id string, url *string, events []domain.EventType, secret *string,
+	) (*domain.Webhook, error)
+
+	// DeleteWebhook removes a webhook.
+	// Returns domain.ErrWebhookNotFound if no webhook exists with the given ID.
+	DeleteWebhook(ctx context.Context, id string) error
+
+	// ListDeadLetters returns the deliveries given up on, oldest first.
+	ListDeadLetters(ctx context.Context) ([]*domain.WebhookDeadLetter, error)
+
+	// GetDeadLetter retrieves a delivery given up on by its unique identifier.
+	// Returns domain.ErrDeadLetterNotFound if no dead letter exists with the given ID.
+	GetDeadLetter(ctx context.Context, id string) (*domain.WebhookDeadLetter, error)
+
+	// ReplayDeadLetter delivers the event of a dead letter to its webhook once more,
+	// with the current URL and secret of the webhook, and removes the dead letter on success.
+	// Returns domain.ErrDeadLetterNotFound if no dead letter exists with the given ID.
+	// Returns domain.ErrWebhookNotFound if the webhook was deleted since.
+	// Returns domain.ErrWebhookDeliveryFailed if the delivery fails again.
+	ReplayDeadLetter(ctx context.Context, id string) error
+
+	// DeleteDeadLetter removes a delivery given up on without replaying it.
+	// Returns domain.ErrDeadLetterNotFound if no dead letter exists with the given ID.
+	DeleteDeadLetter(ctx context.Context, id string) error
 }
@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/asp3cto/task-manager/internal/domain"
+)
+
+// StatusConfig provides the task statuses configured by the operator: the built-in
+// statuses, any additional ones and the transitions allowed between them.
+type StatusConfig interface {
+	// Workflow returns the configured workflow.
+	Workflow(ctx context.Context) (*domain.Workflow, error)
+}
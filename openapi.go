@@ -0,0 +1,10 @@
+// Package taskmanager holds assets shared by the whole application.
+package taskmanager
+
+import _ "embed"
+
+// OpenAPISpec is the OpenAPI 3 specification of the REST API.
+// It is the source of truth for request validation in the HTTP adapter.
+//
+//go:embed openapi.yml
+var OpenAPISpec []byte